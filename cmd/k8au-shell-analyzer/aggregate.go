@@ -0,0 +1,98 @@
+// cmd/k8au-shell-analyzer/aggregate.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+)
+
+// aggregateMinMembers is the team size below which shared statistics may
+// still point at someone, which aggregate warns about
+const aggregateMinMembers = 3
+
+// runAggregate implements `aggregate FILE...`, combining the exports of a
+// team, one per member, into statistics that name no one: the tools most
+// of them run, their combined peak hours and how their tech stacks and
+// roles are spread
+func runAggregate(args []string) int {
+	fs := flag.NewFlagSet("aggregate", flag.ContinueOnError)
+	format := fs.String("format", "text", "output format: text or json")
+	allowUnsigned := fs.Bool("allow-unsigned", false, "accept exports without a signature even though a signing key is set")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer aggregate [--format text|json] [--allow-unsigned] FILE...")
+		fmt.Fprintln(fs.Output(), "Each FILE is one member's `export`.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q, expected text or json\n", *format)
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	key := signingKey(cfg)
+
+	var snaps []snapshot.Snapshot
+	for _, path := range fs.Args() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read %s: %v\n", path, err)
+			return 1
+		}
+		export, err := snapshot.Unmarshal(content)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+			return 1
+		}
+		if key != "" {
+			err := export.Verify(key)
+			if err != nil && !(errors.Is(err, snapshot.ErrUnsigned) && *allowUnsigned) {
+				fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+				return 1
+			}
+		}
+		snap, err := export.Decode()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+			return 1
+		}
+		snaps = append(snaps, snap)
+	}
+
+	team := snapshot.Aggregate(snaps)
+	if *format == "json" {
+		out, err := json.MarshalIndent(team, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to encode the team: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+	render.SetPlain(!isTerminal(os.Stdout))
+	fmt.Println(render.RenderTeam(team))
+	if team.Members < aggregateMinMembers {
+		fmt.Fprintln(os.Stderr, i18n.T("team.small", team.Members))
+	}
+	return 0
+}
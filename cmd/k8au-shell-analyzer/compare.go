@@ -0,0 +1,113 @@
+// cmd/k8au-shell-analyzer/compare.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// runCompare implements `compare`, diffing two stored snapshots or two
+// periods of the history, by default the two newest snapshots
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	backend := fs.String("store", "", "storage backend: json or sqlite (default from config, else json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer compare [--store json|sqlite] [BEFORE AFTER]")
+		fmt.Fprintln(fs.Output(), "BEFORE and AFTER are snapshot keys, latest, previous or periods such as 2024-Q1 or 2024-03.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	sides := fs.Args()
+	switch len(sides) {
+	case 0:
+		sides = []string{"previous", "latest"}
+	case 2:
+	default:
+		fs.Usage()
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if *backend == "" {
+		*backend = cfg.Store
+	}
+	s := store.OpenDefault(*backend)
+	defer s.Close()
+	keys, err := s.List(snapshot.Bucket)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to list snapshots: %v\n", err)
+		return 1
+	}
+	disabled, _ := disabledModules(cfg.Disable, "")
+	opts := analyzer.Options{Shells: cfg.Shells, Disabled: disabled, Casts: cfg.Casts}
+
+	var snaps [2]snapshot.Snapshot
+	var labels [2]string
+	stored := true
+	for i, side := range sides {
+		snap, label, fromStore, err := compareSide(s, keys, side, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		snaps[i], labels[i] = snap, label
+		stored = stored && fromStore
+	}
+
+	render.SetPlain(!isTerminal(os.Stdout))
+	cumulative := stored && snaps[0].Taken.Before(snaps[1].Taken)
+	fmt.Println(render.RenderDiff(snapshot.Compare(snaps[0], snaps[1], labels[0], labels[1], cumulative)))
+	return 0
+}
+
+// compareSide resolves one side of a comparison: latest or previous, a
+// stored snapshot key, or else a period, which is analyzed on the spot
+func compareSide(s store.Store, keys []string, side string, opts analyzer.Options) (snapshot.Snapshot, string, bool, error) {
+	key := side
+	switch side {
+	case "latest", "previous":
+		n := 1
+		if side == "previous" {
+			n = 2
+		}
+		if len(keys) < n {
+			return snapshot.Snapshot{}, "", false, errors.New(i18n.T("diff.need_snapshots", n, len(keys)))
+		}
+		key = keys[len(keys)-n]
+	}
+	for _, k := range keys {
+		if k == key {
+			snap, err := snapshot.Load(s, key)
+			return snap, snap.Taken.Local().Format("2006-01-02 15:04"), true, err
+		}
+	}
+
+	start, end, err := analyzer.ParsePeriod(side, clock.Now())
+	if err != nil {
+		return snapshot.Snapshot{}, "", false, fmt.Errorf("%s is neither a snapshot nor a period: %v", side, err)
+	}
+	if start.Equal(end) {
+		// An age such as 30d runs up to now
+		end = clock.Now()
+	}
+	opts.Since, opts.Until = start, end
+	return snapshot.New(analyzer.Analyze(opts), end), side, false, nil
+}
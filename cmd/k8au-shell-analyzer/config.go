@@ -0,0 +1,143 @@
+// cmd/k8au-shell-analyzer/config.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+)
+
+// runConfig implements `config init [--force]`, writing the commented
+// default config file, and `config path`
+func runConfig(args []string) int {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	force := fs.Bool("force", false, "overwrite an existing config file")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer config init [--force] | config path")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return 2
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	switch args[0] {
+	case "path":
+		fmt.Println(config.Path())
+	case "init":
+		err := config.Init(*force)
+		if errors.Is(err, config.ErrExists) {
+			fmt.Fprintln(os.Stderr, i18n.T("config.exists", config.Path()))
+			return 1
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println(i18n.T("config.written", config.Path()))
+	default:
+		fs.Usage()
+		return 2
+	}
+	return 0
+}
+
+// applyConfig sets up what the config file changes for every command: the
+// history files, ignored commands, categories, redaction rules, model, roast
+// mode and the store of the AI responses and ratings
+func applyConfig(cfg config.Config) {
+	for shell, path := range cfg.History {
+		if err := analyzer.SetHistoryPath(shell, path); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring history path for %s: %v\n", shell, err)
+		}
+	}
+
+	analyzer.SetIgnore(ignorePatterns(cfg.Ignore))
+	analyzer.SetCategories(categoryRules(cfg))
+
+	if err := redact.AddRules(cfg.RedactionRules); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if !cfg.AI.Supported() {
+		fmt.Fprintf(os.Stderr, "Warning: unsupported AI provider %q, only gemini is; the AI stays off\n", cfg.AI.Provider)
+	}
+	gemini.SetModel(cfg.AI.Model)
+	gemini.SetRoast(cfg.Roast)
+	gemini.SetStore(cfg.Store)
+}
+
+// ignorePatterns compiles the ignore patterns of the config file or of
+// --ignore, leaving out the invalid ones
+func ignorePatterns(patterns []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := analyzer.CompileIgnore(pattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring invalid ignore pattern: %v\n", err)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+// listFlag is a flag that may be given several times
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// categoryRules merges the rules of rules.d with those of the config file,
+// which come last, and compiles their patterns
+func categoryRules(cfg config.Config) []analyzer.CategoryRule {
+	merged, err := config.LoadRules()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	for category, rule := range cfg.Categories {
+		merged[category] = merged[category].Merge(rule)
+	}
+
+	categories := make([]string, 0, len(merged))
+	for category := range merged {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	var rules []analyzer.CategoryRule
+	for _, category := range categories {
+		rule := analyzer.CategoryRule{Category: category, Tools: merged[category].Tools}
+		for _, pattern := range merged[category].Patterns {
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring invalid pattern of category %s: %v\n", category, err)
+				continue
+			}
+			rule.Patterns = append(rule.Patterns, compiled)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,80 @@
+// cmd/k8au-shell-analyzer/dedupe.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/rcfile"
+)
+
+// runDedupe implements `dedupe [--apply]`, measuring duplicate history
+// entries and optionally turning on each shell's setting to drop them
+func runDedupe(args []string) int {
+	fs := flag.NewFlagSet("dedupe", flag.ContinueOnError)
+	apply := fs.Bool("apply", false, "add the dedupe setting to the rc file of every shell that lacks it (undo with `undo`)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer dedupe [--apply]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	// Only the history and rc files are needed
+	data := analyzer.Analyze(analyzer.Options{
+		Shells:   cfg.Shells,
+		Disabled: []string{analyzer.ModulePlugins, analyzer.ModuleProbe},
+	})
+	stats := analyzer.AnalyzeDuplicates(data)
+	if len(stats) == 0 {
+		fmt.Println(i18n.T("dedupe.none"))
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("dedupe.header"))
+	for _, d := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t%d\n", d.Shell, d.Entries, d.Duplicates(), d.Share()*100, d.Consecutive)
+	}
+	w.Flush()
+	fmt.Println()
+
+	status := 0
+	for _, d := range stats {
+		switch {
+		case d.Enabled:
+			fmt.Println(i18n.T("dedupe.enabled", d.Shell))
+		case d.Duplicates() == 0:
+			fmt.Println(i18n.T("dedupe.clean", d.Shell))
+		case !*apply:
+			fmt.Println(i18n.T("dedupe.suggest", d.Shell, d.Duplicates(), d.RCFile))
+			for _, line := range d.Setting {
+				fmt.Println("    " + line)
+			}
+		default:
+			lines := append([]string{"", "# Added by k8au-shell-analyzer: do not save duplicate history entries"}, d.Setting...)
+			_, err := rcfile.AppendLines(d.RCFile, i18n.T("dedupe.reason"), lines)
+			if err != nil && !errors.Is(err, rcfile.ErrNoChange) {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				status = 1
+				continue
+			}
+			fmt.Println(i18n.T("dedupe.applied", d.RCFile))
+		}
+	}
+	return status
+}
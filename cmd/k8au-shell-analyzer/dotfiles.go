@@ -0,0 +1,250 @@
+// cmd/k8au-shell-analyzer/dotfiles.go
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// dotfile is a file of a dotfiles export, named relative to its root
+type dotfile struct {
+	name    string
+	content []byte
+}
+
+// dotfilesInventory is inventory.json, what was found per shell
+type dotfilesInventory struct {
+	Generated string                    `json:"generated"`
+	Shells    map[string]shellInventory `json:"shells"`
+}
+
+type shellInventory struct {
+	Files       []string          `json:"files"`
+	Aliases     map[string]string `json:"aliases,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	Plugins     []pluginInventory `json:"plugins,omitempty"`
+}
+
+type pluginInventory struct {
+	Name    string `json:"name"`
+	Manager string `json:"manager,omitempty"`
+	Version string `json:"version,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// runDotfiles implements `dotfiles export`, gathering the startup files,
+// aliases, environment variables and plugin lists of every shell, with
+// secrets redacted, into a directory laid out for GNU stow or a .tar.gz
+func runDotfiles(args []string) int {
+	fs := flag.NewFlagSet("dotfiles", flag.ContinueOnError)
+	output := fs.String("output", "dotfiles", "directory to write, or an archive when it ends in .tar.gz or .tgz")
+	force := fs.Bool("force", false, "write into a directory that is not empty, or overwrite the archive")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer dotfiles export [--output DIR|FILE.tar.gz] [--force]")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 || args[0] != "export" {
+		fs.Usage()
+		return 2
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	// Home directory paths stay, the files would not work without them
+	redact.SetLevel(redact.Secrets)
+
+	opts := analyzer.Options{Shells: cfg.Shells}
+	files, inventory, redacted := collectDotfiles(opts)
+	if len(inventory.Shells) == 0 {
+		fmt.Println(i18n.T("dotfiles.none"))
+		return 0
+	}
+	content, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to encode the inventory: %v\n", err)
+		return 1
+	}
+	files = append(files, dotfile{name: "inventory.json", content: append(content, '\n')})
+
+	path := utils.ExpandPath(*output)
+	archive := strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+	if archive {
+		err = writeDotfilesArchive(path, files, *force)
+	} else {
+		err = writeDotfilesDir(path, files, *force)
+	}
+	if os.IsExist(err) {
+		fmt.Fprintln(os.Stderr, i18n.T("dotfiles.exists", path))
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	for _, shell := range analyzer.SortedKeys(inventory.Shells) {
+		shellInfo := inventory.Shells[shell]
+		fmt.Println(i18n.T("dotfiles.shell", shell, len(shellInfo.Files), len(shellInfo.Aliases), len(shellInfo.Environment), len(shellInfo.Plugins)))
+	}
+	if redacted > 0 {
+		fmt.Println(i18n.T("dotfiles.redacted", redacted))
+	}
+	if archive {
+		fmt.Println(i18n.T("dotfiles.written_archive", path))
+	} else {
+		fmt.Println(i18n.T("dotfiles.written_dir", path, strings.Join(analyzer.SortedKeys(inventory.Shells), " ")))
+	}
+	return 0
+}
+
+// collectDotfiles reads the startup files of every shell into a package
+// per shell, named as they are relative to the home directory, and returns
+// them with the inventory and the number of lines redacted
+func collectDotfiles(opts analyzer.Options) ([]dotfile, dotfilesInventory, int) {
+	inventory := dotfilesInventory{
+		Generated: clock.Now().Format("2006-01-02T15:04:05Z07:00"),
+		Shells:    make(map[string]shellInventory),
+	}
+	var files []dotfile
+	redacted := 0
+	for _, shell := range analyzer.SupportedShells() {
+		if !opts.Includes(shell) {
+			continue
+		}
+		config := analyzer.AnalyzeShellConfig(shell, opts)
+		paths := make(map[string]string)
+		for key, info := range config.ConfigFiles {
+			if stat, err := os.Stat(info.Path); err == nil && !stat.IsDir() && info.Content != "" {
+				paths[key] = info.Content
+			}
+		}
+		// fisher restores its plugins from fish_plugins with fisher update
+		if shell == "fish" {
+			if content, err := os.ReadFile(utils.ExpandPath("~/.config/fish/fish_plugins")); err == nil {
+				paths["~/.config/fish/fish_plugins"] = string(content)
+			}
+		}
+		if len(paths) == 0 {
+			continue
+		}
+
+		shellInfo := shellInventory{Aliases: make(map[string]string), Environment: make(map[string]string)}
+		for _, key := range analyzer.SortedKeys(paths) {
+			lines := strings.Split(paths[key], "\n")
+			for i, line := range lines {
+				if scrubbed := redact.String(line); scrubbed != line {
+					lines[i] = scrubbed
+					redacted++
+				}
+			}
+			name := shell + "/" + strings.TrimPrefix(key, "~/")
+			files = append(files, dotfile{name: name, content: []byte(strings.Join(lines, "\n"))})
+			shellInfo.Files = append(shellInfo.Files, name)
+		}
+		for name, value := range config.Aliases {
+			shellInfo.Aliases[name] = redact.String(value)
+		}
+		// Redacted as an assignment, so a value is masked for its name too
+		for name, value := range config.Environment {
+			shellInfo.Environment[name] = strings.TrimPrefix(redact.String(name+"="+value), name+"=")
+		}
+		for _, plugin := range config.Plugins {
+			entry := pluginInventory{Name: plugin.Name, Manager: plugin.Manager, Version: plugin.Version}
+			if plugin.Source != "" {
+				entry.Source = utils.DisplayPath(plugin.Source)
+			}
+			if !plugin.LastUpdated.IsZero() {
+				entry.Updated = plugin.LastUpdated.Format("2006-01-02")
+			}
+			shellInfo.Plugins = append(shellInfo.Plugins, entry)
+		}
+		sort.Slice(shellInfo.Plugins, func(i, j int) bool {
+			return shellInfo.Plugins[i].Name < shellInfo.Plugins[j].Name
+		})
+		inventory.Shells[shell] = shellInfo
+	}
+	return files, inventory, redacted
+}
+
+// writeDotfilesDir writes the files under dir, which must be missing or
+// empty unless force is set
+func writeDotfilesDir(dir string, files []dotfile, force bool) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 && !force {
+		return os.ErrExist
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file.name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, file.content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// writeDotfilesArchive writes the files as a gzipped tarball under a
+// dotfiles directory, never overwriting path unless force is set
+func writeDotfilesArchive(path string, files []dotfile, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0o600)
+	if err != nil {
+		if os.IsExist(err) {
+			return err
+		}
+		return fmt.Errorf("failed to create %s: %v", path, err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := clock.Now()
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    "dotfiles/" + file.name,
+			Mode:    0o644,
+			Size:    int64(len(file.content)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,162 @@
+// cmd/k8au-shell-analyzer/export.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// signingKey picks the key exports are signed and checked with: the
+// K8AU_SIGNING_KEY environment variable, else signing_key from the config
+func signingKey(cfg config.Config) string {
+	if key := os.Getenv("K8AU_SIGNING_KEY"); key != "" {
+		return key
+	}
+	return cfg.SigningKey
+}
+
+// runExport implements `export`, writing a stored snapshot as a versioned
+// JSON file that `import` accepts on this or another machine
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	key := fs.String("snapshot", "", "key of the snapshot to export (default the newest)")
+	output := fs.String("output", "", "file to write (default standard output)")
+	backend := fs.String("store", "", "storage backend: json or sqlite (default from config, else json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer export [--snapshot KEY] [--output FILE] [--store json|sqlite]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if *backend == "" {
+		*backend = cfg.Store
+	}
+	s := store.OpenDefault(*backend)
+	defer s.Close()
+
+	if *key == "" {
+		if *key, err = snapshot.Latest(s); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if *key == "" {
+			fmt.Fprintln(os.Stderr, i18n.T("export.none"))
+			return 1
+		}
+	}
+	snap, err := snapshot.Load(s, *key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	// Snapshots taken before a command was ignored still count it
+	for command := range snap.CommonCmds {
+		if analyzer.Ignored(command) {
+			delete(snap.CommonCmds, command)
+		}
+	}
+	signWith := signingKey(cfg)
+	out, err := snapshot.Marshal(snap, signWith)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		os.Stdout.Write(out)
+	} else {
+		if err := os.WriteFile(*output, out, 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write %s: %v\n", *output, err)
+			return 1
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("export.written", *key, *output))
+	}
+	if signWith == "" {
+		fmt.Fprintln(os.Stderr, i18n.T("export.unsigned"))
+	}
+	return 0
+}
+
+// runImport implements `import FILE`. When a signing key is set, only
+// exports carrying a valid signature are accepted unless --allow-unsigned
+// is given; older schemas are migrated before the snapshot is saved.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	allowUnsigned := fs.Bool("allow-unsigned", false, "accept exports without a signature even though a signing key is set")
+	backend := fs.String("store", "", "storage backend: json or sqlite (default from config, else json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer import [--allow-unsigned] [--store json|sqlite] FILE")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if *backend == "" {
+		*backend = cfg.Store
+	}
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	export, err := snapshot.Unmarshal(content)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+
+	if key := signingKey(cfg); key != "" {
+		err := export.Verify(key)
+		if err != nil && !(errors.Is(err, snapshot.ErrUnsigned) && *allowUnsigned) {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", fs.Arg(0), err)
+			return 1
+		}
+	} else if export.Signature != "" {
+		fmt.Fprintln(os.Stderr, i18n.T("import.unverified"))
+	}
+
+	snap, err := export.Decode()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	s := store.OpenDefault(*backend)
+	defer s.Close()
+	key, err := snapshot.Save(s, snap)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Println(i18n.T("import.saved", key, export.Schema, store.DefaultDir()))
+	return 0
+}
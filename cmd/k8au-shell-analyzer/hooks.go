@@ -0,0 +1,131 @@
+// cmd/k8au-shell-analyzer/hooks.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/hooks"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// runInit implements `init bash|zsh|fish`, printing the hooks to eval in
+// the shell's rc file
+func runInit(args []string) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: k8au-shell-analyzer init %s\n", strings.Join(hooks.Shells, "|"))
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	// The hooks must keep working when the shell starts in another directory
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to find the executable: %v\n", err)
+		return 1
+	}
+	snippet, err := hooks.Snippet(fs.Arg(0), exe)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	fmt.Print(snippet)
+	return 0
+}
+
+// runRecord implements `record`, which the hooks run after each command.
+// It never prints anything on success, since it runs behind the prompt.
+func runRecord(args []string) int {
+	fs := flag.NewFlagSet("record", flag.ContinueOnError)
+	shell := fs.String("shell", "", "shell that ran the command")
+	exit := fs.Int("exit", 0, "exit status of the command")
+	start := fs.String("start", "", "when the command started, in seconds since the epoch, e.g. $EPOCHREALTIME")
+	duration := fs.Int64("duration", -1, "how long the command ran in milliseconds, when --start is not known")
+	cwd := fs.String("cwd", "", "directory the command ran in")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer record --shell SHELL --exit N (--start SECONDS | --duration MS) [--cwd DIR] -- COMMAND")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	command := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if command == "" {
+		return 0
+	}
+
+	now := time.Now()
+	rec := hooks.Record{Shell: *shell, Command: command, Dir: *cwd, Start: now, Exit: *exit}
+	switch {
+	case *start != "":
+		// $EPOCHREALTIME uses the locale's decimal separator
+		seconds, err := strconv.ParseFloat(strings.Replace(*start, ",", ".", 1), 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --start %q\n", *start)
+			return 2
+		}
+		rec.Start = time.Unix(0, int64(seconds*float64(time.Second)))
+		rec.Duration = max(0, now.Sub(rec.Start))
+	case *duration >= 0:
+		rec.Duration = time.Duration(*duration) * time.Millisecond
+		rec.Start = now.Add(-rec.Duration)
+	}
+
+	if err := hooks.Send(rec); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runDaemon implements `daemon`, collecting what the hooks send until
+// interrupted
+func runDaemon(args []string) int {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer daemon")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	listener, err := hooks.Listen()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Println(i18n.T("daemon.listening", hooks.SocketPath(), hooks.LogPath()))
+	if err := hooks.Serve(listener, stop); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	os.Remove(hooks.SocketPath())
+	return 0
+}
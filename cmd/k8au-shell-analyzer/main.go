@@ -2,20 +2,362 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/logging"
 	"github.com/ksauraj/k8au-shell-analyzer/internal/models"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/telemetry"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
 )
 
 func main() {
-	p := tea.NewProgram(models.InitialModel(),
+	// Logs go to a file, the TUI owns the terminal
+	if err := logging.Setup(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	// The shell hooks run record after every command, so it skips the
+	// rest of the setup and stays quiet
+	if len(os.Args) > 1 && os.Args[1] == "record" {
+		exit(runRecord(os.Args[2:]))
+	}
+
+	// Opt-in OTLP export of the pipeline stages, configured by OTEL_ variables
+	if err := telemetry.Setup(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	// Settings every command honors, such as custom history files. Each
+	// command reports a broken config file itself.
+	if cfg, err := config.Load(); err == nil {
+		applyConfig(cfg)
+	}
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "config":
+			exit(runConfig(os.Args[2:]))
+		case "init":
+			exit(runInit(os.Args[2:]))
+		case "daemon":
+			exit(runDaemon(os.Args[2:]))
+		case "simulate":
+			exit(runSimulate(os.Args[2:]))
+		case "dedupe":
+			exit(runDedupe(os.Args[2:]))
+		case "migrate":
+			exit(runMigrate(os.Args[2:]))
+		case "dotfiles":
+			exit(runDotfiles(os.Args[2:]))
+		case "undo":
+			exit(runUndo(os.Args[2:]))
+		case "scrub":
+			exit(runScrub(os.Args[2:]))
+		case "snapshot":
+			exit(runSnapshot(os.Args[2:]))
+		case "compare":
+			exit(runCompare(os.Args[2:]))
+		case "query":
+			exit(runQuery(os.Args[2:]))
+		case "report":
+			exit(runReport(os.Args[2:]))
+		case "wrapped":
+			exit(runWrapped(os.Args[2:]))
+		case "export":
+			exit(runExport(os.Args[2:]))
+		case "import":
+			exit(runImport(os.Args[2:]))
+		case "aggregate":
+			exit(runAggregate(os.Args[2:]))
+		case "serve":
+			exit(runServe(os.Args[2:]))
+		case "mcp":
+			exit(runMCP(os.Args[2:]))
+		case "install-service":
+			exit(runInstallService(os.Args[2:]))
+		}
+	}
+
+	apiKey := flag.String("api-key", "", "Gemini API key (overrides GEMINI_API_KEY, the config file and the keyring)")
+	lowMemory := flag.Bool("low-memory", false, "stream history and keep only aggregates plus a bounded sample of recent commands")
+	full := flag.Bool("full", false, "parse every history file again and analyze every command, not only the 20000 most recent per shell")
+	watch := flag.Bool("watch", false, "keep watching the history files and refresh the tabs as commands are run")
+	gitReflogs := flag.Bool("git-reflogs", false, "read the reflogs of the git repositories among your projects to count commits (or git_reflogs in the config)")
+	var noAI bool
+	flag.BoolVar(&noAI, "no-ai", false, "never send data to the AI, generate the Wrapped view locally")
+	flag.BoolVar(&noAI, "local-only", false, "alias for --no-ai")
+	roast := flag.Bool("roast", false, "make Wrapped a comedic roast of your shell habits (or roast in the config)")
+	var accessible bool
+	flag.BoolVar(&accessible, "accessible", false, "print the tabs as plain linear text for screen readers instead of starting the TUI")
+	flag.BoolVar(&accessible, "linear", false, "alias for --accessible")
+	deterministic := flag.Bool("deterministic", false, "fix the clock and time zone, skip probing and AI, so the same history gives byte-identical reports")
+	disable := flag.String("disable", "", "comma-separated modules to skip: "+strings.Join(analyzer.Modules, ", ")+", ai")
+	casts := flag.String("cast", "", "comma-separated asciinema recordings or directories of them to read commands from (adds to casts in the config)")
+	since := flag.String("since", "", "only analyze entries from this period on: 2024, 2024-Q1, 2024-03, 2024-03-15 or an age like 30d, 12w, 6m, 1y")
+	until := flag.String("until", "", "only analyze entries before the end of this period, in the same forms as --since")
+	home := flag.String("home", "", "analyze the home directory at this path, e.g. one mounted read-only into a container; implies --no-exec and never writes to it")
+	noExec := flag.Bool("no-exec", false, "never run other programs: skip probing installed tools, the keyring and the editor (default true with --home)")
+	lang := flag.String("lang", "", "language for labels and reports, e.g. en, es, ja (default from config or $LANG)")
+	pprofAddr := flag.String("pprof", "", "serve net/http/pprof on this address, e.g. localhost:6060")
+	tracePrefix := flag.String("trace", "", "write CPU and heap profiles to <prefix>.cpu.pprof and <prefix>.heap.pprof")
+	verbose := flag.Bool("verbose", false, "log what the analyzer does, not only warnings and errors, to "+utils.DisplayPath(logging.Path()))
+	debug := flag.Bool("debug", false, "log everything including the raw AI responses, which may quote your history")
+	var ignore listFlag
+	flag.Var(&ignore, "ignore", "leave commands matching this regular expression, or starting with the words after prefix:, out of the analysis; may be repeated (adds to ignore in the config)")
+	flag.Usage = usage
+	flag.Parse()
+
+	analyzer.AddIgnore(ignorePatterns(ignore))
+
+	switch {
+	case *debug:
+		logging.SetLevel(slog.LevelDebug)
+	case *verbose:
+		logging.SetLevel(slog.LevelInfo)
+	}
+
+	stopProfiling, err := startProfiling(*pprofAddr, *tracePrefix)
+	if err != nil {
+		fmt.Printf("Error starting profiler: %v\n", err)
+		exit(1)
+	}
+
+	if err := i18n.Setup(*lang); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	if *home != "" {
+		if info, err := os.Stat(*home); err != nil || !info.IsDir() {
+			fmt.Printf("Error: --home %s is not a directory\n", *home)
+			exit(2)
+		}
+		utils.SetHome(*home)
+		if !flagSet("no-exec") {
+			*noExec = true
+		}
+	}
+	if *noExec {
+		config.DisableKeyring()
+	}
+
+	key, _ := gemini.ResolveAPIKey(*apiKey)
+	gemini.SetAPIKey(key)
+	if *roast {
+		gemini.SetRoast(true)
+	}
+
+	// Settings saved from the Settings tab; flags still win for this run
+	cfg, err := config.Load()
+	var problems []analyzer.Problem
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		problems = append(problems, analyzer.Problem{ID: models.ProblemSettings, Path: config.Path(), Err: err})
+	}
+	render.SetTheme(cfg.Theme)
+	redact.SetLevel(redact.Level(cfg.Redaction))
+
+	disabled, disableAI := disabledModules(cfg.Disable, *disable)
+	if *deterministic {
+		disabled = makeDeterministic(disabled)
+		disableAI = true
+	}
+	if *noExec {
+		disabled = disableProbing(disabled)
+	}
+	from, to, err := period(*since, *until)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		exit(2)
+	}
+	// Deterministic reports and other people's homes get a throwaway store,
+	// so the Trends tab only shows this run
+	backend := cfg.Store
+	if *deterministic || *home != "" {
+		backend = store.BackendMemory
+	}
+	gemini.SetStore(backend)
+	opts := models.Options{
+		Analyzer: analyzer.Options{LowMemory: *lowMemory, Full: *full, Shells: cfg.Shells, Disabled: disabled, Casts: castPaths(cfg.Casts, *casts),
+			Budgets: budgets(cfg.Budgets), Since: from, Until: to, GitReflogs: *gitReflogs || cfg.GitReflogs},
+		NoAI:  noAI || cfg.NoAI || disableAI || !cfg.AI.Supported(),
+		Store: backend,
+		// A partial period would look like a trimmed history
+		Record:        from.IsZero() && to.IsZero(),
+		Keys:          cfg.Keys,
+		NoExec:        *noExec,
+		ReadOnly:      *home != "",
+		Problems:      problems,
+		Watch:         *watch,
+		SlideInterval: cfg.SlideInterval,
+	}
+
+	if accessible && *watch {
+		fmt.Println("Error: --watch needs the TUI and cannot be combined with --accessible")
+		exit(2)
+	}
+	if accessible {
+		err = models.RunLinear(opts, os.Stdin, os.Stdout, isTerminal(os.Stdin) && isTerminal(os.Stdout))
+		stopProfiling()
+		if err != nil {
+			fmt.Printf("Error reading input: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	p := tea.NewProgram(models.InitialModel(opts),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion())
 
-	if err := p.Start(); err != nil {
+	err = p.Start()
+	stopProfiling()
+	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
-		os.Exit(1)
+		exit(1)
+	}
+	exit(0)
+}
+
+// exit sends the recorded telemetry, if any, before exiting with code
+func exit(code int) {
+	if err := telemetry.Shutdown(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	os.Exit(code)
+}
+
+// disabledModules merges the modules disabled in the config file with those
+// from --disable. "ai" is not an analyzer module and is reported separately.
+func disabledModules(fromConfig []string, fromFlag string) (modules []string, noAI bool) {
+	names := append([]string{}, fromConfig...)
+	if fromFlag != "" {
+		names = append(names, strings.Split(fromFlag, ",")...)
+	}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "":
+		case name == "ai":
+			noAI = true
+		case isModule(name):
+			modules = append(modules, name)
+		default:
+			fmt.Printf("Warning: unknown module %q, expected one of %s, ai\n", name, strings.Join(analyzer.Modules, ", "))
+		}
+	}
+	return modules, noAI
+}
+
+func isModule(name string) bool {
+	for _, module := range analyzer.Modules {
+		if module == name {
+			return true
+		}
+	}
+	return false
+}
+
+// castPaths merges the recordings from the config file with those from --cast
+func castPaths(fromConfig []string, fromFlag string) []string {
+	paths := append([]string{}, fromConfig...)
+	for _, path := range strings.Split(fromFlag, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// budgets compiles the budgets from the config file, skipping with a
+// warning those with an invalid pattern or nothing to match
+func budgets(fromConfig []config.Budget) []analyzer.Budget {
+	var compiled []analyzer.Budget
+	for _, b := range fromConfig {
+		budget := analyzer.Budget{Name: b.Name, Program: b.Program, Category: b.Category, Max: b.Max}
+		if budget.Name == "" {
+			budget.Name = strings.TrimSpace(strings.Join([]string{b.Program, b.Category, b.Pattern}, " "))
+		}
+		if b.Pattern != "" {
+			pattern, err := regexp.Compile(b.Pattern)
+			if err != nil {
+				fmt.Printf("Warning: ignoring budget %q, invalid pattern: %v\n", budget.Name, err)
+				continue
+			}
+			budget.Pattern = pattern
+		}
+		if budget.Program == "" && budget.Category == "" && budget.Pattern == nil {
+			fmt.Printf("Warning: ignoring budget %q, it needs a program, category or pattern\n", budget.Name)
+			continue
+		}
+		compiled = append(compiled, budget)
+	}
+	return compiled
+}
+
+// period turns --since and --until into the range to analyze. It runs after
+// --deterministic so ages count back from the fixed clock.
+func period(since, until string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if since != "" {
+		if from, _, err = analyzer.ParsePeriod(since, clock.Now()); err != nil {
+			return from, to, fmt.Errorf("--since: %v", err)
+		}
+	}
+	if until != "" {
+		if _, to, err = analyzer.ParsePeriod(until, clock.Now()); err != nil {
+			return from, to, fmt.Errorf("--until: %v", err)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return from, to, fmt.Errorf("--since %s is not before --until %s", since, until)
+	}
+	return from, to, nil
+}
+
+// makeDeterministic fixes the clock and seeds the randomness for a
+// reproducible run, and adds probing to the disabled modules since installed
+// tools differ between machines
+func makeDeterministic(disabled []string) []string {
+	clock.Deterministic()
+	gemini.Seed(1)
+	return disableProbing(disabled)
+}
+
+// disableProbing adds probing to the disabled modules, unless it already is
+func disableProbing(disabled []string) []string {
+	if !(analyzer.Options{Disabled: disabled}).Enabled(analyzer.ModuleProbe) {
+		return disabled
 	}
+	return append(disabled, analyzer.ModuleProbe)
+}
+
+// flagSet reports whether the flag called name was given on the command line
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// isTerminal reports whether f is attached to a terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
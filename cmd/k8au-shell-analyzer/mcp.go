@@ -0,0 +1,54 @@
+// cmd/k8au-shell-analyzer/mcp.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/mcp"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/server"
+)
+
+// runMCP implements `mcp`, a Model Context Protocol server on stdin and
+// stdout that AI assistants start to query the analysis with tools
+func runMCP(args []string) int {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	lowMemory := fs.Bool("low-memory", false, "stream history and keep only aggregates plus a bounded sample of recent commands")
+	full := fs.Bool("full", false, "parse every history file again and analyze every command, not only the 20000 most recent per shell")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer mcp [--low-memory] [--full]")
+		fmt.Fprintln(fs.Output(), "Speaks the Model Context Protocol on stdin and stdout; add it to an assistant's MCP servers rather than running it by hand.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	// search_history returns commands, scrubbed like AI requests
+	redact.SetLevel(redact.Level(cfg.Redaction))
+	disabled, _ := disabledModules(cfg.Disable, "")
+	opts := analyzer.Options{LowMemory: *lowMemory, Full: *full, Shells: cfg.Shells, Disabled: disabled, Casts: cfg.Casts, GitReflogs: cfg.GitReflogs}
+	// The dashboard server is only used for its cached analyses
+	srv, err := server.New(opts, period)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := mcp.New(srv.Analyze).Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+// cmd/k8au-shell-analyzer/migrate.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// runMigrate implements `migrate`, listing the aliases, functions and
+// environment variables to port to another shell, with their equivalent
+// syntax, and a starter config for it
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	to := fs.String("to", "", "shell to move to: "+strings.Join(analyzer.SupportedShells(), ", "))
+	from := fs.String("from", "", "shell to move from (default the most used one)")
+	output := fs.String("output", "", "write the starter config to this new file instead of printing it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer migrate --to bash|zsh|fish [--from SHELL] [--output FILE]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	for _, shell := range []string{*to, *from} {
+		if _, ok := analyzer.RCFiles[shell]; !ok && shell != "" {
+			fmt.Fprintf(os.Stderr, "Error: unknown shell %q, expected one of %s\n", shell, strings.Join(analyzer.SupportedShells(), ", "))
+			return 2
+		}
+	}
+	if *to == "" {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	// Only the rc files are needed, and the history to find the main shell
+	opts := analyzer.Options{Shells: cfg.Shells, Disabled: []string{analyzer.ModulePlugins, analyzer.ModuleProbe}}
+	if *from == "" {
+		*from = analyzer.DominantShell(analyzer.Analyze(opts).CommandCounts)
+	}
+	if *from == *to || *from == "" {
+		fmt.Fprintln(os.Stderr, "Error: "+i18n.T("migrate.same", *to))
+		return 2
+	}
+
+	plan := analyzer.PlanMigration(analyzer.AnalyzeShellConfig(*from, opts), analyzer.AnalyzeShellConfig(*to, opts), *from, *to)
+	fmt.Println(i18n.T("migrate.title", *from, *to))
+	total := 0
+	for _, section := range []struct {
+		heading string
+		items   []analyzer.PortedItem
+	}{
+		{"migrate.aliases", plan.Aliases},
+		{"migrate.functions", plan.Functions},
+		{"migrate.environment", plan.Environment},
+	} {
+		if len(section.items) == 0 {
+			continue
+		}
+		total += len(section.items)
+		fmt.Println("\n" + i18n.T(section.heading))
+		for _, item := range section.items {
+			status := "migrate.port"
+			switch {
+			case item.Defined:
+				status = "migrate.defined"
+			case item.Review:
+				status = "migrate.review"
+			}
+			fmt.Printf("• %s (%s)\n", item.Name, i18n.T(status))
+			if !item.Defined {
+				fmt.Print(definition(*from, item.Source) + definition(*to, item.Target))
+			}
+		}
+	}
+	if total == 0 {
+		fmt.Println("\n" + i18n.T("migrate.none", *from))
+		return 0
+	}
+	fmt.Println("\n" + i18n.T("migrate.summary", plan.Pending(), total, *to))
+	if plan.Pending() == 0 {
+		return 0
+	}
+
+	rc := analyzer.RCFiles[*to]
+	if *output == "" {
+		fmt.Println("\n" + i18n.T("migrate.starter", *to, rc) + "\n")
+		fmt.Print(plan.StarterConfig())
+		return 0
+	}
+	path := utils.ExpandPath(*output)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create the starter config: %v\n", err)
+		return 1
+	}
+	_, err = f.WriteString(plan.StarterConfig())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write the starter config: %v\n", err)
+		return 1
+	}
+	fmt.Println(i18n.T("migrate.written", path, rc))
+	return 0
+}
+
+// definition indents a definition under its shell's name
+func definition(shell, text string) string {
+	var content strings.Builder
+	for i, line := range strings.Split(text, "\n") {
+		label := ""
+		if i == 0 {
+			label = shell + ":"
+		}
+		content.WriteString(fmt.Sprintf("    %-6s %s\n", label, line))
+	}
+	return content.String()
+}
@@ -0,0 +1,79 @@
+// cmd/k8au-shell-analyzer/profile.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+)
+
+// hiddenFlags are accepted but left out of --help since they are only
+// useful when diagnosing performance problems
+var hiddenFlags = map[string]bool{
+	"pprof": true,
+	"trace": true,
+}
+
+// usage prints the default flag help without the hidden flags
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] [command]\n", filepath.Base(os.Args[0]))
+	flag.VisitAll(func(f *flag.Flag) {
+		if hiddenFlags[f.Name] {
+			return
+		}
+		name, help := flag.UnquoteUsage(f)
+		if name != "" {
+			name = " " + name
+		}
+		fmt.Fprintf(out, "  --%s%s\n    \t%s\n", f.Name, name, help)
+	})
+}
+
+// startProfiling serves net/http/pprof on pprofAddr and, when tracePrefix
+// is set, records a CPU profile to <prefix>.cpu.pprof. The returned function
+// stops the CPU profile and writes the heap profile to <prefix>.heap.pprof.
+func startProfiling(pprofAddr, tracePrefix string) (func(), error) {
+	if pprofAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(pprofAddr, nil); err != nil {
+				slog.Error("pprof server stopped", "err", err)
+			}
+		}()
+	}
+
+	if tracePrefix == "" {
+		return func() {}, nil
+	}
+
+	cpuFile, err := os.Create(tracePrefix + ".cpu.pprof")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CPU profile: %v", err)
+	}
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		cpuFile.Close()
+		return nil, fmt.Errorf("failed to start CPU profile: %v", err)
+	}
+
+	return func() {
+		pprof.StopCPUProfile()
+		cpuFile.Close()
+
+		heapFile, err := os.Create(tracePrefix + ".heap.pprof")
+		if err != nil {
+			slog.Error("failed to create heap profile", "err", err)
+			return
+		}
+		defer heapFile.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(heapFile); err != nil {
+			slog.Error("failed to write heap profile", "err", err)
+		}
+	}, nil
+}
@@ -0,0 +1,98 @@
+// cmd/k8au-shell-analyzer/query.go
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// runQuery implements `query`, printing stored snapshots sliced by date
+// and grouped by tool, category or shell, as JSON or CSV for dashboards
+func runQuery(args []string) int {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	since := fs.String("since", "", "only snapshots taken from this period on, e.g. 2024, 2024-Q1, 2024-03 or 30d")
+	until := fs.String("until", "", "only snapshots taken before the end of this period")
+	group := fs.String("group", snapshot.GroupTool, "break commands down by tool, category or shell")
+	per := fs.String("per", "", "keep only the newest snapshot of each day or month")
+	only := fs.String("only", "", "comma-separated groups to keep, e.g. git,docker")
+	top := fs.Int("top", 0, "keep the largest N groups of each snapshot")
+	format := fs.String("format", "json", "output format: json or csv")
+	backend := fs.String("store", "", "storage backend: json or sqlite (default from config, else json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer query [--since P] [--until P] [--group tool|category|shell] [--per day|month] [--only LIST] [--top N] [--format json|csv]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "json" && *format != "csv" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q, expected json or csv\n", *format)
+		return 2
+	}
+	from, to, err := period(*since, *until)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	q := snapshot.Query{Since: from, Until: to, GroupBy: *group, Per: *per, Top: *top}
+	for _, name := range strings.Split(*only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			q.Only = append(q.Only, name)
+		}
+	}
+	if err := q.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if *backend == "" {
+		*backend = cfg.Store
+	}
+	s := store.OpenDefault(*backend)
+	defer s.Close()
+	rows, err := snapshot.Run(s, q)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *format == "csv" {
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"snapshot", "taken", "period", "group", "commands", "share"})
+		for _, row := range rows {
+			w.Write([]string{row.Snapshot, row.Taken.Format(time.RFC3339), row.Period, row.Group,
+				strconv.Itoa(row.Commands), strconv.FormatFloat(row.Share, 'f', 2, 64)})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if rows == nil {
+		rows = []snapshot.Row{}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(rows); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
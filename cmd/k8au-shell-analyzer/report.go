@@ -0,0 +1,112 @@
+// cmd/k8au-shell-analyzer/report.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/report"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// runReport implements `report`, printing last week's highlights or
+// posting them to the Slack and Discord webhooks from the config
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	format := fs.String("format", report.FormatText, "print as text, or as the slack or discord webhook payload")
+	post := fs.Bool("post", false, "post to the webhooks in the config file instead of printing")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer report [--format text|slack|discord] [--post]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if *post && !report.Configured(cfg.Report) {
+		fmt.Fprintln(os.Stderr, i18n.T("report.no_webhook", config.Path()))
+		return 1
+	}
+	disabled, _ := disabledModules(cfg.Disable, "")
+	data := analyzer.Analyze(analyzer.Options{Shells: cfg.Shells, Disabled: disabled, Casts: cfg.Casts})
+	w := analyzer.Weekly(data, clock.Now())
+
+	if *post {
+		sent, err := report.Send(cfg.Report, w)
+		if len(sent) > 0 {
+			fmt.Println(i18n.T("report.posted", strings.Join(sent, ", ")))
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	switch *format {
+	case report.FormatText:
+		fmt.Print(report.Text(w))
+	case report.FormatSlack, report.FormatDiscord:
+		build := report.Slack
+		if *format == report.FormatDiscord {
+			build = report.Discord
+		}
+		payload, err := build(w)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(payload))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q, expected text, slack or discord\n", *format)
+		return 2
+	}
+	return 0
+}
+
+// postScheduledReport is the report sink of scheduled runs: on the
+// configured weekday it posts the past week's highlights, once per week
+func postScheduledReport(cfg config.Config, data analyzer.ShellData, s store.Store) {
+	if !report.Configured(cfg.Report) {
+		return
+	}
+	now := clock.Now()
+	due, err := report.Due(cfg.Report, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	w := analyzer.Weekly(data, now)
+	week := w.Start.Format("2006-01-02")
+	if !due || w.Commands == 0 {
+		return
+	}
+	if _, err := s.Get(report.Bucket, week); err == nil {
+		return
+	}
+
+	sent, err := report.Send(cfg.Report, w)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if len(sent) > 0 {
+		// Not retried for the webhooks that failed, so nobody gets it twice
+		if err := s.Put(report.Bucket, week, []byte(strings.Join(sent, ","))); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record the weekly report: %v\n", err)
+		}
+		fmt.Println(i18n.T("report.posted", strings.Join(sent, ", ")))
+	}
+}
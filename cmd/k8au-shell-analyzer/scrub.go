@@ -0,0 +1,108 @@
+// cmd/k8au-shell-analyzer/scrub.go
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/rcfile"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+)
+
+// runScrub implements `scrub [--dry-run] [--yes]`, listing history entries
+// that contain likely secrets and removing them after asking per file.
+// The file is rewritten with rcfile.Rewrite: a backup for `undo` would be a
+// copy of the very secrets being removed.
+func runScrub(args []string) int {
+	fs := flag.NewFlagSet("scrub", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only list the entries, change nothing")
+	yes := fs.Bool("yes", false, "remove the entries without asking")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer scrub [--dry-run] [--yes]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	// Secrets are listed masked even at the secrets-only level
+	redact.SetLevel(redact.Secrets)
+
+	opts := analyzer.Options{Shells: cfg.Shells}
+	in := bufio.NewScanner(os.Stdin)
+	status, total := 0, 0
+	for _, shell := range analyzer.SupportedShells() {
+		if !opts.Includes(shell) {
+			continue
+		}
+		path := analyzer.HistoryFile(shell)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		found := analyzer.FindSecrets(content, shell)
+		if len(found) == 0 {
+			continue
+		}
+		total += len(found)
+
+		fmt.Println(i18n.T("scrub.found", len(found), path))
+		for _, entry := range found {
+			command := strings.ReplaceAll(redact.String(entry.Command), "\n", " ")
+			fmt.Printf("  %d: %s  [%s]\n", entry.Line, command, strings.Join(entry.Kinds, ", "))
+		}
+		if *dryRun {
+			fmt.Println()
+			continue
+		}
+		if !*yes {
+			fmt.Print(i18n.T("scrub.confirm", len(found), path) + " ")
+			if !in.Scan() || !isYes(in.Text()) {
+				fmt.Println(i18n.T("scrub.skipped", path))
+				fmt.Println()
+				continue
+			}
+		}
+
+		removed := 0
+		err = rcfile.Rewrite(path, func(content []byte) ([]byte, error) {
+			var scrubbed []byte
+			scrubbed, removed = analyzer.ScrubSecrets(content, shell)
+			return scrubbed, nil
+		})
+		if err != nil && !errors.Is(err, rcfile.ErrNoChange) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			status = 1
+			continue
+		}
+		fmt.Println(i18n.T("scrub.removed", removed, path))
+		fmt.Println()
+	}
+
+	if total == 0 {
+		fmt.Println(i18n.T("scrub.none"))
+	} else if !*dryRun {
+		fmt.Println(i18n.T("scrub.running_shells"))
+	}
+	return status
+}
+
+// isYes reports whether answer confirms a prompt
+func isYes(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes" || answer == i18n.T("scrub.yes")
+}
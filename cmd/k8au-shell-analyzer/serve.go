@@ -0,0 +1,128 @@
+// cmd/k8au-shell-analyzer/serve.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/server"
+)
+
+// runServe implements `serve`, a local web dashboard of the analysis whose
+// filters live in the URL, so a view can be bookmarked or shared
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:7070", "address to listen on; keep it on localhost unless everyone who can reach it may see your history")
+	metrics := fs.String("metrics", "", "also serve Prometheus metrics at http://ADDRESS/metrics, e.g. localhost:9464")
+	lowMemory := fs.Bool("low-memory", false, "stream history and keep only aggregates plus a bounded sample of recent commands")
+	full := fs.Bool("full", false, "parse every history file again and analyze every command, not only the 20000 most recent per shell")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer serve [--addr HOST:PORT] [--metrics HOST:PORT] [--low-memory] [--full]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	// The timeline endpoint serves commands, scrubbed like AI requests
+	redact.SetLevel(redact.Level(cfg.Redaction))
+	disabled, _ := disabledModules(cfg.Disable, "")
+	opts := analyzer.Options{LowMemory: *lowMemory, Full: *full, Shells: cfg.Shells, Disabled: disabled, Casts: cfg.Casts, GitReflogs: cfg.GitReflogs}
+	srv, err := server.New(opts, period)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to listen on %s: %v\n", *addr, err)
+		return 1
+	}
+	warnExposed(*addr)
+	var metricsListener net.Listener
+	if *metrics != "" {
+		if metricsListener, err = net.Listen("tcp", *metrics); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to listen on %s: %v\n", *metrics, err)
+			return 1
+		}
+		warnExposed(*metrics)
+	}
+
+	// Analyze before the first request, so the page opens quickly
+	if _, err := srv.Analyze(server.Filter{}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	httpServer := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	metricsServer := &http.Server{Handler: srv.MetricsHandler(), ReadHeaderTimeout: 10 * time.Second}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(ctx)
+		metricsServer.Shutdown(ctx)
+	}()
+
+	// The dashboard stops with the metrics, so a failure is noticed
+	failed := make(chan error, 1)
+	if metricsListener != nil {
+		go func() {
+			if err := metricsServer.Serve(metricsListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				failed <- err
+				httpServer.Close()
+			}
+		}()
+		fmt.Println(i18n.T("serve.metrics", "http://"+metricsListener.Addr().String()+"/metrics"))
+	}
+	fmt.Println(i18n.T("serve.listening", "http://"+listener.Addr().String()+"/"))
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	select {
+	case err := <-failed:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	default:
+		return 0
+	}
+}
+
+// warnExposed warns when addr can be reached from other machines
+func warnExposed(addr string) {
+	if host, _, err := net.SplitHostPort(addr); err == nil && !isLoopback(host) {
+		fmt.Fprintln(os.Stderr, i18n.T("serve.exposed", addr))
+	}
+}
+
+// isLoopback reports whether host only accepts connections from this
+// machine
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,79 @@
+// cmd/k8au-shell-analyzer/service.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/service"
+)
+
+// runInstallService implements `install-service [--uninstall] [--print]`,
+// scheduling a daily `snapshot` with systemd or launchd
+func runInstallService(args []string) int {
+	fs := flag.NewFlagSet("install-service", flag.ContinueOnError)
+	uninstall := fs.Bool("uninstall", false, "disable the daily snapshot and remove its service files")
+	printOnly := fs.Bool("print", false, "print the service files instead of installing them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer install-service [--uninstall] [--print]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	if *uninstall {
+		removed, err := service.Uninstall()
+		for _, f := range removed {
+			fmt.Println(i18n.T("service.removed", f.Path))
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(removed) == 0 {
+			fmt.Println(i18n.T("service.not_installed"))
+		}
+		return 0
+	}
+
+	// The service must keep working when started from another directory
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to find the executable: %v\n", err)
+		return 1
+	}
+
+	if *printOnly {
+		files, err := service.Files(runtime.GOOS, exe)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		for _, f := range files {
+			fmt.Printf("# %s\n%s\n", f.Path, f.Content)
+		}
+		return 0
+	}
+
+	files, err := service.Install(exe)
+	for _, f := range files {
+		fmt.Println(i18n.T("service.wrote", f.Path))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Println(i18n.T("service.installed"))
+	return 0
+}
@@ -0,0 +1,60 @@
+// cmd/k8au-shell-analyzer/simulate.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// runSimulate implements `simulate [name=expansion ...]`, replaying the history
+// against proposed aliases. Without arguments it simulates the built-in proposals.
+func runSimulate(args []string) int {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	limit := fs.Int("limit", 10, "number of aliases to propose when none are given")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer simulate [flags] [name=expansion ...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	var proposals []analyzer.AliasProposal
+	for _, arg := range fs.Args() {
+		name, expansion, ok := strings.Cut(arg, "=")
+		if !ok || name == "" || expansion == "" {
+			fmt.Fprintf(os.Stderr, "invalid alias %q, expected name=expansion\n", arg)
+			return 2
+		}
+		proposals = append(proposals, analyzer.AliasProposal{Name: name, Expansion: expansion})
+	}
+
+	data := analyzer.AnalyzeShells().(analyzer.ShellData)
+	if len(proposals) == 0 {
+		proposals = analyzer.ProposeAliases(data, *limit)
+	}
+	if len(proposals) == 0 {
+		fmt.Println(i18n.T("simulate.none"))
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("simulate.header"))
+	for _, sim := range analyzer.SimulateAliases(data, proposals) {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%.1f\t%.1f\n",
+			sim.Proposal.Name, sim.Proposal.Expansion, sim.Matches,
+			sim.KeystrokesSaved, sim.EntriesPerWeek, sim.KeystrokesPerWeek)
+	}
+	w.Flush()
+
+	return 0
+}
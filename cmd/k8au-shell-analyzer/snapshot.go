@@ -0,0 +1,68 @@
+// cmd/k8au-shell-analyzer/snapshot.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// runSnapshot implements `snapshot`, analyzing the history without the TUI
+// and saving the result for trends. It is what install-service schedules.
+func runSnapshot(args []string) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	lowMemory := fs.Bool("low-memory", false, "stream history and keep only aggregates plus a bounded sample of recent commands")
+	full := fs.Bool("full", false, "parse every history file again and analyze every command, not only the 20000 most recent per shell")
+	deterministic := fs.Bool("deterministic", false, "fix the clock and time zone and skip probing, for reproducible snapshots")
+	backend := fs.String("store", "", "storage backend: json or sqlite (default from config, else json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer snapshot [--low-memory] [--full] [--deterministic] [--store json|sqlite]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if *backend == "" {
+		*backend = cfg.Store
+	}
+	disabled, _ := disabledModules(cfg.Disable, "")
+	if *deterministic {
+		disabled = makeDeterministic(disabled)
+	}
+	data := analyzer.Analyze(analyzer.Options{LowMemory: *lowMemory, Full: *full, Shells: cfg.Shells, Disabled: disabled, Casts: cfg.Casts})
+
+	s := store.OpenDefault(*backend)
+	defer s.Close()
+	key, err := snapshot.Save(s, snapshot.New(data, clock.Now()))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	total := 0
+	for _, count := range data.CommandCounts {
+		total += count
+	}
+	fmt.Println(i18n.T("snapshot.saved", key, total, store.DefaultDir()))
+
+	// A fixed clock would post the same week every time
+	if !*deterministic {
+		postScheduledReport(cfg, data, s)
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+// cmd/k8au-shell-analyzer/undo.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/rcfile"
+)
+
+// runUndo implements `undo [--list]`, restoring the file touched by the most
+// recent rc file modification from its backup
+func runUndo(args []string) int {
+	fs := flag.NewFlagSet("undo", flag.ContinueOnError)
+	list := fs.Bool("list", false, "list the changes that can be undone, oldest first")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer undo [--list]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	if *list {
+		changes, err := rcfile.Changes()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(changes) == 0 {
+			fmt.Println(i18n.T("undo.none"))
+			return 0
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, i18n.T("undo.header"))
+		for _, change := range changes {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", change.Time.Format("2006-01-02 15:04:05"), change.Path, change.Reason)
+		}
+		w.Flush()
+		return 0
+	}
+
+	change, err := rcfile.Undo()
+	if errors.Is(err, rcfile.ErrNothingToUndo) {
+		fmt.Println(i18n.T("undo.none"))
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if change.Created {
+		fmt.Println(i18n.T("undo.removed", change.Path, change.Reason))
+	} else {
+		fmt.Println(i18n.T("undo.restored", change.Path, change.Time.Format("2006-01-02 15:04:05"), change.Reason))
+	}
+	return 0
+}
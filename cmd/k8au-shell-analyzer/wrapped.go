@@ -0,0 +1,96 @@
+// cmd/k8au-shell-analyzer/wrapped.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/models"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// runWrapped implements `wrapped`, the year in review slideshow. It is built
+// locally, so nothing is sent to the AI.
+func runWrapped(args []string) int {
+	fs := flag.NewFlagSet("wrapped", flag.ContinueOnError)
+	year := fs.Int("year", 0, "calendar year to look back on (default this year)")
+	roast := fs.Bool("roast", false, "roast your shell habits instead of celebrating them (or roast in the config)")
+	interval := fs.Duration("interval", 6*time.Second, "how long each slide is shown before the next (default slide_interval in the config, else 6s)")
+	var accessible bool
+	fs.BoolVar(&accessible, "accessible", false, "print the slides as plain text instead of playing them")
+	fs.BoolVar(&accessible, "linear", false, "alias for --accessible")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: k8au-shell-analyzer wrapped [--year 2025] [--interval 6s] [--roast] [--accessible]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := i18n.Setup(""); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	now := clock.Now()
+	if *year == 0 {
+		*year = now.Year()
+	}
+	if *year < 1970 || *year > now.Year() {
+		fmt.Fprintln(os.Stderr, "Error: "+i18n.T("wrapped.year.invalid", *year))
+		return 2
+	}
+	if *roast {
+		gemini.SetRoast(true)
+	}
+	if *interval <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: --interval must be positive")
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	render.SetTheme(cfg.Theme)
+	intervalSet := false
+	fs.Visit(func(f *flag.Flag) { intervalSet = intervalSet || f.Name == "interval" })
+	if !intervalSet && cfg.SlideInterval > 0 {
+		*interval = cfg.SlideInterval
+	}
+	disabled, _ := disabledModules(cfg.Disable, "")
+	opts := analyzer.Options{Shells: cfg.Shells, Disabled: disabled, Casts: cfg.Casts, Budgets: budgets(cfg.Budgets)}
+
+	start := time.Date(*year, time.January, 1, 0, 0, 0, 0, time.Local)
+	opts.Since, opts.Until = start, start.AddDate(1, 0, 0)
+	data := analyzer.Analyze(opts)
+	// The years before only tell which tools are new, nothing to probe for
+	before := opts
+	before.Since, before.Until = time.Time{}, start
+	before.Disabled = disableProbing(disabled)
+	review := analyzer.ReviewYear(data, *year, analyzer.Analyze(before).CommonCmds)
+	sections := gemini.ApplyRatings(gemini.GenerateYearWrapped(data, review).Sections, gemini.LoadRatings())
+
+	// Archive the deck for the Wrapped tab, failing to only loses that
+	s := store.OpenDefault(cfg.Store)
+	deck := gemini.Deck{Key: gemini.YearDeckKey(*year), Generated: now, Sections: sections}
+	if err := gemini.SaveDeck(s, deck); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	s.Close()
+
+	if accessible || !isTerminal(os.Stdout) {
+		models.WriteWrapped(os.Stdout, sections)
+		return 0
+	}
+	if err := models.RunWrapped(sections, *interval, cfg.Keys); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		return 1
+	}
+	return 0
+}
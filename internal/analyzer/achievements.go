@@ -0,0 +1,191 @@
+// internal/analyzer/achievements.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Achievements gamifies the history with streaks, milestones and badges
+type Achievements struct {
+	// CurrentStreak counts consecutive active days up to today or yesterday
+	CurrentStreak int
+	LongestStreak int
+	StreakStart   time.Time
+	Milestones    []Milestone
+	Badges        []Badge
+}
+
+// Milestone records when a command was run for the Count-th time; a Count
+// of 1 is the first time
+type Milestone struct {
+	Command string
+	Count   int
+	Reached time.Time
+}
+
+// Badge is earned by a usage pattern. IDs double as message IDs, see
+// internal/i18n.
+type Badge struct {
+	ID     string
+	Earned bool
+}
+
+// milestoneRules lists the commands worth celebrating and at which counts
+var milestoneRules = []struct {
+	command string
+	counts  []int
+}{
+	{"git commit", []int{1, 100, 1000}},
+	{"git push", []int{1, 100, 1000}},
+	{"docker", []int{1, 1000}},
+	{"kubectl", []int{1, 1000}},
+	{"terraform", []int{1, 100}},
+	{"ssh", []int{1, 100}},
+}
+
+// badgeRules decide whether each badge is earned, in display order
+var badgeRules = []struct {
+	id     string
+	earned func(data ShellData, a Achievements) bool
+}{
+	{"night_owl", func(data ShellData, _ Achievements) bool {
+		return hourShare(data.Insights.WorkPatterns, 0, 5) >= 0.2
+	}},
+	{"early_bird", func(data ShellData, _ Achievements) bool {
+		return hourShare(data.Insights.WorkPatterns, 5, 9) >= 0.2
+	}},
+	{"weekend_warrior", func(data ShellData, _ Achievements) bool {
+		return weekendShare(data.Insights.WorkPatterns) >= 0.25
+	}},
+	{"pipe_wizard", func(data ShellData, _ Achievements) bool {
+		return countEntries(data, func(cmd string) bool { return pipelineLength(cmd) >= 3 }) >= 50
+	}},
+	{"marathoner", func(data ShellData, _ Achievements) bool {
+		return data.Insights.WorkPatterns.Sessions.Longest >= 3*time.Hour
+	}},
+	{"streaker", func(_ ShellData, a Achievements) bool {
+		return a.LongestStreak >= 30
+	}},
+	{"shell_hopper", func(data ShellData, _ Achievements) bool {
+		return len(data.Migration.Switches) > 0
+	}},
+}
+
+// ComputeAchievements derives streaks, milestones and badges from the
+// history. now decides whether the latest streak is still running.
+func ComputeAchievements(data ShellData, now time.Time) Achievements {
+	var a Achievements
+
+	_, days := activeDays(data)
+	streak := 0
+	for i, day := range days {
+		if i > 0 && days[i-1].AddDate(0, 0, 1).Equal(day) {
+			streak++
+		} else {
+			streak = 1
+		}
+		if streak > a.LongestStreak {
+			a.LongestStreak = streak
+			a.StreakStart = day.AddDate(0, 0, 1-streak)
+		}
+	}
+	if n := len(days); n > 0 && !days[n-1].Before(dayStart(now).AddDate(0, 0, -1)) {
+		a.CurrentStreak = streak
+	}
+
+	a.Milestones = milestones(data)
+	for _, rule := range badgeRules {
+		a.Badges = append(a.Badges, Badge{ID: rule.id, Earned: rule.earned(data, a)})
+	}
+	return a
+}
+
+// milestones replays the timestamped history in order and records when each
+// milestone count was reached
+func milestones(data ShellData) []Milestone {
+	var entries []CommandEntry
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			if !entry.Timestamp.IsZero() {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	var reached []Milestone
+	counts := make([]int, len(milestoneRules))
+	for _, entry := range entries {
+		for i, rule := range milestoneRules {
+			if !runsCommand(entry.Command, rule.command) {
+				continue
+			}
+			counts[i]++
+			for _, count := range rule.counts {
+				if counts[i] == count {
+					reached = append(reached, Milestone{Command: rule.command, Count: count, Reached: entry.Timestamp})
+				}
+			}
+		}
+	}
+	return reached
+}
+
+// runsCommand reports whether line runs command, e.g. "git commit -m x"
+// runs "git commit", ignoring a leading sudo
+func runsCommand(line, command string) bool {
+	line = strings.TrimPrefix(strings.TrimSpace(line), "sudo ")
+	return line == command || strings.HasPrefix(line, command+" ")
+}
+
+// hourShare returns the share of timestamped commands run from hour from
+// up to, but not including, hour to
+func hourShare(patterns WorkPatterns, from, to int) float64 {
+	hourly := HourlyActivity(patterns)
+	var in, total int
+	for hour, count := range hourly {
+		total += count
+		if hour >= from && hour < to {
+			in += count
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(in) / float64(total)
+}
+
+// weekendShare returns the share of timestamped commands run on Saturday
+// or Sunday
+func weekendShare(patterns WorkPatterns) float64 {
+	var weekend, total int
+	for day, hours := range patterns.Activity {
+		for _, count := range hours {
+			total += count
+			if time.Weekday(day) == time.Saturday || time.Weekday(day) == time.Sunday {
+				weekend += count
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(weekend) / float64(total)
+}
+
+// countEntries counts the entries whose command matches
+func countEntries(data ShellData, match func(string) bool) int {
+	n := 0
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			if match(entry.Command) {
+				n++
+			}
+		}
+	}
+	return n
+}
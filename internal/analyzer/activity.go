@@ -0,0 +1,22 @@
+// internal/analyzer/activity.go
+package analyzer
+
+// addActivity counts a timestamped entry in the weekday × hour grid.
+// Entries without timestamps cannot be placed in time.
+func addActivity(patterns *WorkPatterns, entry CommandEntry) {
+	if entry.Timestamp.IsZero() {
+		return
+	}
+	patterns.Activity[entry.Timestamp.Weekday()][entry.Timestamp.Hour()]++
+}
+
+// HourlyActivity sums the weekday × hour grid into commands per hour of day
+func HourlyActivity(patterns WorkPatterns) [24]int {
+	var hourly [24]int
+	for _, day := range patterns.Activity {
+		for hour, count := range day {
+			hourly[hour] += count
+		}
+	}
+	return hourly
+}
@@ -0,0 +1,134 @@
+// internal/analyzer/alias_audit.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+)
+
+// AliasAudit lists aliases worth cleaning up
+type AliasAudit struct {
+	Unused     []UnusedAliases
+	Shadowing  []AliasShadow
+	Duplicates []DuplicateAlias
+}
+
+// UnusedAliases are the aliases of one shell never run in its history
+type UnusedAliases struct {
+	Shell string
+	Names []string
+}
+
+// AliasShadow is an alias named like a program on $PATH that runs
+// something else instead
+type AliasShadow struct {
+	Shell     string
+	Name      string
+	Expansion string
+}
+
+// DuplicateAlias is an alias defined more than once across the rc files
+type DuplicateAlias struct {
+	Name        string
+	Definitions []AliasDefinition
+	// Conflicting is set when the definitions expand to different commands
+	Conflicting bool
+}
+
+// Empty reports whether the audit found nothing
+func (a AliasAudit) Empty() bool {
+	return len(a.Unused) == 0 && len(a.Shadowing) == 0 && len(a.Duplicates) == 0
+}
+
+// AuditAliases cross-references the parsed aliases with the history.
+// Shadowed programs are only looked up on $PATH when probing is enabled.
+func AuditAliases(data ShellData) AliasAudit {
+	var audit AliasAudit
+	probe := data.Options.Enabled(ModuleProbe)
+
+	for _, shell := range SortedKeys(data.ShellConfigs) {
+		config := data.ShellConfigs[shell]
+
+		if history := data.Histories[shell]; len(history) > 0 {
+			used := make(map[string]bool)
+			for _, entry := range history {
+				for _, program := range segmentPrograms(entry.Command) {
+					used[program] = true
+				}
+			}
+			abbreviations := abbreviationUses(config, history)
+			unused := UnusedAliases{Shell: shell}
+			for _, name := range SortedKeys(config.Aliases) {
+				if !used[name] && abbreviations[name] == 0 {
+					unused.Names = append(unused.Names, name)
+				}
+			}
+			if len(unused.Names) > 0 {
+				audit.Unused = append(audit.Unused, unused)
+			}
+		}
+
+		if probe {
+			for _, name := range SortedKeys(config.Aliases) {
+				// alias grep='grep --color' wraps the program rather than hiding it
+				expansion := config.Aliases[name]
+				if commandProgram(expansion) != name && checkToolInstalled(name) {
+					audit.Shadowing = append(audit.Shadowing, AliasShadow{Shell: shell, Name: name, Expansion: expansion})
+				}
+			}
+		}
+	}
+
+	audit.Duplicates = duplicateAliases(data.ShellConfigs)
+	return audit
+}
+
+// duplicateAliases groups the alias lines of every rc file by name and
+// keeps the names defined more than once
+func duplicateAliases(configs map[string]ShellConfig) []DuplicateAlias {
+	byName := make(map[string][]AliasDefinition)
+	seen := make(map[Location]bool)
+	for _, shell := range SortedKeys(configs) {
+		for _, def := range configs[shell].AliasDefinitions {
+			// A file read for two shells still defines the alias only once
+			if seen[def.Location] {
+				continue
+			}
+			seen[def.Location] = true
+			byName[def.Name] = append(byName[def.Name], def)
+		}
+	}
+
+	var duplicates []DuplicateAlias
+	for _, name := range SortedKeys(byName) {
+		defs := byName[name]
+		if len(defs) < 2 {
+			continue
+		}
+		d := DuplicateAlias{Name: name, Definitions: defs}
+		for _, def := range defs[1:] {
+			if def.Value != defs[0].Value {
+				d.Conflicting = true
+			}
+		}
+		duplicates = append(duplicates, d)
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Conflicting && !duplicates[j].Conflicting
+	})
+	return duplicates
+}
+
+// segmentPrograms returns the program run by each part of a command line
+// split at pipes, ; and &&/||
+func segmentPrograms(command string) []string {
+	var programs []string
+	for _, segment := range strings.FieldsFunc(command, func(r rune) bool {
+		return r == '|' || r == ';' || r == '&'
+	}) {
+		if program := commandProgram(segment); program != "" {
+			programs = append(programs, program)
+		}
+	}
+	return programs
+}
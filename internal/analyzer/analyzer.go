@@ -3,6 +3,8 @@ package analyzer
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,11 +13,33 @@ import (
 
 // ShellData contains all the analyzed shell data
 type ShellData struct {
-	Histories    map[string][]CommandEntry
-	CommonCmds   map[string]int
-	TimePatterns map[string]int
-	Insights     DetailedInsights
-	ShellConfigs map[string]ShellConfig
+	Histories     map[string][]CommandEntry
+	CommandCounts map[string]int
+	// CommonCmds counts runs of each program across all shells, and
+	// ShellCmds the same per shell
+	CommonCmds map[string]int
+	ShellCmds  map[string]map[string]int
+	// CommonPrefixes counts the first two words of multi-word commands
+	CommonPrefixes map[string]int
+	// Subcommands counts the subcommands of tools such as git or kubectl
+	// by tool, and SubcommandFlags their flags by "tool subcommand"
+	Subcommands     map[string]map[string]int
+	SubcommandFlags map[string]map[string]int
+	TimePatterns    map[string]int
+	Insights        DetailedInsights
+	ShellConfigs    map[string]ShellConfig
+	Migration       ShellMigration
+	// Problems lists what could not be read, in the order it was tried
+	Problems []Problem
+	// Options records how the analysis was run, e.g. which modules were disabled
+	Options Options
+
+	// installed, monthly and tails are kept from the analysis for Tail:
+	// the probed languages, the commands per month and shell, and where
+	// each history file was read up to
+	installed map[string]string
+	monthly   map[time.Time]map[string]int
+	tails     map[string]historyTail
 }
 
 // CommandEntry represents a single command entry in the shell history
@@ -24,6 +48,17 @@ type CommandEntry struct {
 	Timestamp  time.Time
 	Count      int
 	Categories []string
+	// Dir is the working directory, when it was recorded (atuin), and
+	// Paths the existing files named on the command line (fish)
+	Dir   string
+	Paths []string
+	// Duration is how long the command ran, known when Timed is set (zsh
+	// EXTENDED_HISTORY, atuin, shell hooks), and Exit its exit status,
+	// known when HasExit is set (atuin, shell hooks)
+	Duration time.Duration
+	Timed    bool
+	Exit     int
+	HasExit  bool
 }
 
 // DetailedInsights contains detailed insights about the user's shell usage
@@ -31,21 +66,51 @@ type DetailedInsights struct {
 	TechnicalProfile TechProfile
 	WorkPatterns     WorkPatterns
 	ToolUsage        ToolUsage
+	Achievements     Achievements
+	Security         []SecurityFinding
+	Suggestions      Suggestions
+	Projects         ProjectUsage
+	Git              GitUsage
+	Containers       ContainerUsage
+	Budgets          []BudgetStatus
 }
 
 // TechProfile contains technical profile information
 type TechProfile struct {
+	// PrimaryRole is the role of PrimarySkill, the most confident skill,
+	// e.g. "Platform Engineer"
 	PrimaryRole     string
-	SecondarySkills []string
+	PrimarySkill    Skill
+	PrimaryLanguage string
+	// SecondarySkills are the other skills found, most confident first
+	SecondarySkills []Skill
 	TechStack       []string
 	Proficiency     map[string]float64
+	// Prompts are the prompt frameworks the shells start, and
+	// PromptsInstalled the ones on $PATH that none does
+	Prompts          []PromptSetup
+	PromptsInstalled []string
 }
 
 // WorkPatterns contains work pattern information
 type WorkPatterns struct {
-	PeakHours       []int
-	CommonWorkflows []string
+	PeakHours []int
+	// CommonWorkflows are the command sequences run again and again
+	CommonWorkflows []Workflow
 	Productivity    map[string]float64
+	// Activity counts timestamped commands by weekday (Sunday first) and hour
+	Activity   [7][24]int
+	Sessions   SessionStats
+	Reuse      HistoryReuse
+	Complexity CommandComplexity
+	// Exploration is the time spent in REPLs rather than running commands
+	Exploration ExplorationTime
+	// Outcomes are the failure rates and durations of commands
+	Outcomes Outcomes
+	// RageRepeats are the commands run again and again within seconds
+	RageRepeats []RageRepeat
+	// WorkLife compares weekdays with weekends and follows late nights
+	WorkLife WorkLife
 }
 
 // ToolUsage contains tool usage statistics
@@ -53,6 +118,13 @@ type ToolUsage struct {
 	Editors    map[string]int
 	Languages  map[string]int
 	BuildTools map[string]int
+	Direnv     DirenvUsage
+	Network    NetworkUsage
+	Packages   PackageUsage
+	// Edits are the languages of the files opened in editors
+	Edits EditUsage
+	// Multiplexers are the tmux, screen and zellij use and tmux config
+	Multiplexers MultiplexerUsage
 }
 
 // ShellConfig contains shell configuration information
@@ -61,6 +133,42 @@ type ShellConfig struct {
 	Plugins     []PluginInfo
 	Aliases     map[string]string
 	Environment map[string]string
+	// RCFile is the main startup file, e.g. ~/.zshrc, if it exists
+	RCFile string
+	// AliasLocations records where each alias is defined
+	AliasLocations map[string]Location
+	// AliasDefinitions lists every alias line in file order, including
+	// the ones a later definition overrides
+	AliasDefinitions []AliasDefinition
+	// GlobalAliases are zsh `alias -g` definitions, expanded anywhere on
+	// the command line
+	GlobalAliases map[string]string
+	// NamedDirs are zsh `hash -d` definitions, used as ~name
+	NamedDirs map[string]string
+	// Abbreviations flags the Aliases that are fish abbreviations, which
+	// are expanded as typed and so show up expanded in the history
+	Abbreviations map[string]bool
+	// Functions maps the functions defined in the rc files to their bodies
+	Functions map[string]string
+	// Problems lists the startup files that exist but could not be read
+	Problems []Problem
+	// Issues are what the Config Health linter found in the startup files
+	Issues []ConfigIssue
+	// Prompt is the prompt framework the startup files start, if any
+	Prompt PromptSetup
+}
+
+// AliasDefinition is one alias line in an rc file
+type AliasDefinition struct {
+	Name  string
+	Value string
+	Location
+}
+
+// Location points at a line in a file, counting from 1
+type Location struct {
+	Path string
+	Line int
 }
 
 // ConfigInfo contains information about a configuration file
@@ -72,17 +180,31 @@ type ConfigInfo struct {
 
 // PluginInfo contains information about a plugin
 type PluginInfo struct {
-	Name        string
-	Source      string
+	Name string
+	// Manager is the plugin manager that loads it, e.g. ManagerZinit
+	Manager string
+	// Source is where the plugin is installed
+	Source string
+	// Version is the tag or short commit of its git checkout, or the
+	// version it is pinned to
+	Version     string
 	LastUpdated time.Time
+	// Defined is the line of the startup file that loads it; Line is 0
+	// for plugins found installed rather than listed
+	Defined Location
 }
 
 // InitShellData initializes an empty ShellData structure
 func InitShellData() ShellData {
 	return ShellData{
-		Histories:    make(map[string][]CommandEntry),
-		CommonCmds:   make(map[string]int),
-		TimePatterns: make(map[string]int),
+		Histories:       make(map[string][]CommandEntry),
+		CommandCounts:   make(map[string]int),
+		CommonCmds:      make(map[string]int),
+		ShellCmds:       make(map[string]map[string]int),
+		CommonPrefixes:  make(map[string]int),
+		Subcommands:     make(map[string]map[string]int),
+		SubcommandFlags: make(map[string]map[string]int),
+		TimePatterns:    make(map[string]int),
 		Insights: DetailedInsights{
 			TechnicalProfile: TechProfile{
 				Proficiency: make(map[string]float64),
@@ -97,6 +219,7 @@ func InitShellData() ShellData {
 			},
 		},
 		ShellConfigs: make(map[string]ShellConfig),
+		tails:        make(map[string]historyTail),
 	}
 }
 
@@ -104,9 +227,29 @@ func InitShellData() ShellData {
 func ShellDataToString(data ShellData) string {
 	var result strings.Builder
 
+	// Add the period the analysis covers
+	if since, until := data.Options.Since, data.Options.Until; !since.IsZero() || !until.IsZero() {
+		result.WriteString("Period:")
+		if !since.IsZero() {
+			result.WriteString(" from " + since.Format("2006-01-02"))
+		}
+		if !until.IsZero() {
+			result.WriteString(" until " + until.Format("2006-01-02"))
+		}
+		result.WriteString("\n")
+	}
+
 	// Add shell usage summary
-	for shell, history := range data.Histories {
-		result.WriteString(fmt.Sprintf("Shell: %s, Commands: %d\n", shell, len(history)))
+	for _, shell := range SortedKeys(data.Histories) {
+		result.WriteString(fmt.Sprintf("Shell: %s, Commands: %d\n", shell, data.CommandCounts[shell]))
+	}
+
+	// Add skills
+	if profile := data.Insights.TechnicalProfile; profile.PrimaryRole != "" {
+		result.WriteString("Primary Role: " + profile.PrimaryRole + "\n")
+		for _, skill := range profile.SecondarySkills {
+			result.WriteString(fmt.Sprintf("Secondary Skill: %s, %.0f%% confidence over %d commands\n", skill.Name, skill.Confidence*100, skill.Runs))
+		}
 	}
 
 	// Add tech stack
@@ -114,6 +257,18 @@ func ShellDataToString(data ShellData) string {
 		result.WriteString("Tech Stack: " + strings.Join(data.Insights.TechnicalProfile.TechStack, ", ") + "\n")
 	}
 
+	// Add prompt setup
+	for _, prompt := range data.Insights.TechnicalProfile.Prompts {
+		line := fmt.Sprintf("Prompt (%s): %s", prompt.Shell, prompt.Framework)
+		if prompt.Theme != "" {
+			line += ", theme " + prompt.Theme
+		}
+		result.WriteString(line + "\n")
+	}
+	if installed := data.Insights.TechnicalProfile.PromptsInstalled; len(installed) > 0 {
+		result.WriteString("Prompt frameworks installed but not started: " + strings.Join(installed, ", ") + "\n")
+	}
+
 	// Add peak hours
 	if len(data.Insights.WorkPatterns.PeakHours) > 0 {
 		result.WriteString("Peak Hours: ")
@@ -123,87 +278,229 @@ func ShellDataToString(data ShellData) string {
 		result.WriteString("\n")
 	}
 
+	// Add work sessions
+	if sessions := data.Insights.WorkPatterns.Sessions; sessions.Count > 0 {
+		result.WriteString(fmt.Sprintf("Sessions: %d, average %s, longest %s, %.1f commands each\n",
+			sessions.Count, sessions.AverageLength.Round(time.Minute), sessions.Longest.Round(time.Minute), sessions.CommandsPerSession))
+	}
+	if reuse := data.Insights.WorkPatterns.Reuse; reuse.Entries > 0 {
+		result.WriteString(fmt.Sprintf("History reuse rate: %.1f%%\n", reuse.Rate()*100))
+	}
+	if exploration := data.Insights.WorkPatterns.Exploration; exploration.Launches > 0 {
+		result.WriteString(fmt.Sprintf("Exploration time in REPLs: %s over %d launches\n", exploration.Time.Round(time.Minute), exploration.Launches))
+	}
+	outcomes := data.Insights.WorkPatterns.Outcomes
+	for _, f := range outcomes.Failures {
+		result.WriteString(fmt.Sprintf("Failure rate of %s: %.1f%% of %d runs\n", f.Program, f.Rate()*100, f.Runs))
+	}
+	for _, slow := range outcomes.Slowest {
+		result.WriteString(fmt.Sprintf("Slow command: %s, %s on average over %d runs\n", slow.Command, slow.Average.Round(time.Second), slow.Runs))
+	}
+	if retried := outcomes.Retried; retried.Retries > 0 {
+		result.WriteString(fmt.Sprintf("Most retried after failing: %s, %d times\n", retried.Command, retried.Retries))
+	}
+	for _, rage := range data.Insights.WorkPatterns.RageRepeats {
+		result.WriteString(fmt.Sprintf("Rage repeat: %s, %d bursts of reruns within seconds, up to %d in a row\n", rage.Command, rage.Bursts, rage.Longest))
+	}
+	if workLife := data.Insights.WorkPatterns.WorkLife; workLife.Total() > 0 {
+		result.WriteString(fmt.Sprintf("Weekdays: %d commands on %d days, weekends: %d commands on %d days\n",
+			workLife.WeekdayCommands, workLife.WeekdayDays, workLife.WeekendCommands, workLife.WeekendDays))
+		result.WriteString(fmt.Sprintf("Average day: first command at %s, last at %s\n", ClockTime(workLife.AverageStart), ClockTime(workLife.AverageEnd)))
+		result.WriteString(fmt.Sprintf("Late night: %d commands after midnight on %d nights, %d of them coding\n",
+			workLife.LateNight, workLife.LateNights, workLife.LateNightCoding))
+		if balance, reasons := workLife.Balance(); len(reasons) > 0 {
+			result.WriteString(fmt.Sprintf("Work-life balance: %s (%s)\n", balance, strings.Join(reasons, ", ")))
+		} else {
+			result.WriteString("Work-life balance: " + balance + "\n")
+		}
+	}
+	if git := data.Insights.Git; git.Commands > 0 {
+		result.WriteString(fmt.Sprintf("Git: %d commands, %d commits, %.1f pushes, %.1f pulls and %.1f rebases per commit\n",
+			git.Commands, git.Subcommands["commit"], git.Ratio("push"), git.Ratio("pull"), git.Ratio("rebase")))
+		if preference := git.Preference(); preference != "" {
+			result.WriteString("Git integrates with: " + preference + "\n")
+		}
+		if git.ForcePushes > 0 || git.LeasePushes > 0 {
+			result.WriteString(fmt.Sprintf("Git force pushes: %d, with lease: %d\n", git.ForcePushes, git.LeasePushes))
+		}
+	}
+	if containers := data.Insights.Containers; containers.Total() > 0 {
+		result.WriteString(fmt.Sprintf("Containers: %d docker, %d compose, %d kubectl and %d helm commands, %d context switches\n",
+			containers.DockerCommands, containers.ComposeCommands, containers.KubectlCommands, containers.HelmCommands, containers.ContextSwitches))
+		if len(containers.Images) > 0 {
+			result.WriteString("Most run image: " + containers.Images[0].Command + "\n")
+		}
+		if verbs := SortedCounts(containers.KubectlVerbs, 3); len(verbs) > 0 {
+			var names []string
+			for _, verb := range verbs {
+				names = append(names, verb.Command)
+			}
+			result.WriteString("Top kubectl verbs: " + strings.Join(names, ", ") + "\n")
+		}
+	}
+	for _, shell := range SortedKeys(data.ShellConfigs) {
+		if issues := data.ShellConfigs[shell].Issues; len(issues) > 0 {
+			kinds := make(map[string]int)
+			for _, issue := range issues {
+				kinds[issue.Kind]++
+			}
+			var counts []string
+			for _, kind := range SortedCounts(kinds, 0) {
+				counts = append(counts, fmt.Sprintf("%s %d", kind.Command, kind.Count))
+			}
+			result.WriteString(fmt.Sprintf("Config health of %s: %s\n", shell, strings.Join(counts, ", ")))
+		}
+	}
+	for _, workflow := range data.Insights.WorkPatterns.CommonWorkflows {
+		result.WriteString(fmt.Sprintf("Workflow: %s, %d times\n", strings.Join(workflow.Steps, " → "), workflow.Count))
+	}
+
 	// Add productivity metrics
 	if len(data.Insights.WorkPatterns.Productivity) > 0 {
 		result.WriteString("Productivity Metrics:\n")
-		for metric, value := range data.Insights.WorkPatterns.Productivity {
-			result.WriteString(fmt.Sprintf("- %s: %.1f%%\n", metric, value*100))
+		productivity := data.Insights.WorkPatterns.Productivity
+		for _, metric := range SortedKeys(productivity) {
+			result.WriteString(fmt.Sprintf("- %s: %.1f%%\n", metric, productivity[metric]*100))
 		}
 	}
 
+	// Add shell migration story
+	for _, sw := range data.Migration.Switches {
+		result.WriteString(fmt.Sprintf("Shell Switch: %s -> %s in %s, aliases carried over: %d, left behind: %d\n",
+			sw.From, sw.To, sw.At.Format("Jan 2006"), len(sw.CarriedAliases), len(sw.MissingAliases)))
+	}
+
 	// Add tool usage
 	if len(data.Insights.ToolUsage.Editors) > 0 {
 		result.WriteString("Editors:\n")
-		for editor, count := range data.Insights.ToolUsage.Editors {
-			result.WriteString(fmt.Sprintf("- %s: %d uses\n", editor, count))
+		for _, editor := range SortedCounts(data.Insights.ToolUsage.Editors, 0) {
+			result.WriteString(fmt.Sprintf("- %s: %d uses\n", editor.Command, editor.Count))
+		}
+	}
+
+	if edits := data.Insights.ToolUsage.Edits; len(edits.Edited) > 0 || len(edits.Run) > 0 {
+		result.WriteString("Languages edited and run:\n")
+		for _, lang := range edits.Languages() {
+			result.WriteString(fmt.Sprintf("- %s: %d files opened in an editor, %d toolchain runs\n", lang, edits.Edited[lang], edits.Run[lang]))
+		}
+	}
+	if mux := data.Insights.ToolUsage.Multiplexers; mux.Total() > 0 || mux.Config != "" {
+		var counts []string
+		for _, program := range SortedCounts(mux.Commands, 0) {
+			counts = append(counts, fmt.Sprintf("%s %d", program.Command, program.Count))
+		}
+		result.WriteString(fmt.Sprintf("Terminal multiplexers: %s\n", strings.Join(counts, ", ")))
+		if len(mux.TmuxCommands) > 0 {
+			var commands []string
+			for _, command := range SortedCounts(mux.TmuxCommands, 5) {
+				commands = append(commands, fmt.Sprintf("%s %d", command.Command, command.Count))
+			}
+			result.WriteString("- tmux commands: " + strings.Join(commands, ", ") + "\n")
+		}
+		if mux.Config != "" {
+			var plugins []string
+			for _, plugin := range mux.Plugins {
+				plugins = append(plugins, plugin.Name)
+			}
+			result.WriteString(fmt.Sprintf("- tmux config: prefix %s, %d key bindings, plugins: %s\n", mux.Prefix, len(mux.Bindings), strings.Join(plugins, ", ")))
+		}
+	}
+	if packages := data.Insights.ToolUsage.Packages; packages.Distinct > 0 {
+		result.WriteString(fmt.Sprintf("Packages installed: %d different, hoarder score %d/100 (%s)\n",
+			packages.Distinct, packages.HoarderScore(), packages.Hoarding()))
+		for _, pkg := range packages.Packages {
+			result.WriteString(fmt.Sprintf("- %s %s: %d installs\n", pkg.Manager, pkg.Name, pkg.Count))
+		}
+		if len(packages.New) > 0 {
+			var names []string
+			for _, pkg := range packages.New {
+				names = append(names, pkg.Name)
+			}
+			result.WriteString("Newly adopted packages: " + strings.Join(names, ", ") + "\n")
+		}
+	}
+
+	// Add remote hosts and domains, hashed so their names stay private
+	if network := data.Insights.ToolUsage.Network; len(network.Hosts) > 0 || len(network.Domains) > 0 {
+		result.WriteString(fmt.Sprintf("Remote hosts: %d, domains fetched: %d\n", len(network.Hosts), len(network.Domains)))
+		for i, host := range network.Hosts {
+			if i == networkSummarized {
+				break
+			}
+			result.WriteString(fmt.Sprintf("- %s via %s: %d connections\n", HashHost(host.Host), strings.Join(host.Tools, ", "), host.Count))
 		}
 	}
 
 	return result.String()
 }
 
+// GenerateTimelineData lists every run of an interesting command over the
+// whole history, newest first. Entries without a timestamp come last, in
+// the order they were recorded, latest first.
 func GenerateTimelineData(data ShellData) []types.TimelineEntry {
 	var timelineData []types.TimelineEntry
 
-	// Track unique commands to avoid duplicates
-	uniqueCommands := make(map[string]bool)
-
-	// Iterate through shell histories
-	for shell, history := range data.Histories {
-		for _, entry := range history {
-			// Skip if we already have this command
-			if uniqueCommands[entry.Command] {
-				continue
-			}
-
-			// Add interesting commands to the timeline
-			if isInterestingCommand(entry.Command) {
+	for _, shell := range SortedKeys(data.Histories) {
+		for _, entry := range data.Histories[shell] {
+			if kind := timelineKind(entry.Command); kind != "" {
 				timelineData = append(timelineData, types.TimelineEntry{
-					Timestamp: entry.Timestamp,
-					Command:   entry.Command,
-					Shell:     shell,
+					Timestamp:  entry.Timestamp,
+					Command:    entry.Command,
+					Shell:      shell,
+					Categories: entry.Categories,
+					Kind:       kind,
 				})
-				uniqueCommands[entry.Command] = true
-			}
-
-			// Stop after collecting 15 commands
-			if len(timelineData) >= 15 {
-				return timelineData
 			}
 		}
 	}
 
+	// Histories are oldest first, so reversing before the stable sort
+	// keeps undated entries latest first
+	slices.Reverse(timelineData)
+	sort.SliceStable(timelineData, func(i, j int) bool {
+		a, b := timelineData[i].Timestamp, timelineData[j].Timestamp
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.After(b)
+	})
 	return timelineData
 }
 
-// isInterestingCommand checks if a command is worth showing in the timeline
-func isInterestingCommand(command string) bool {
+// SortedKeys returns the keys of m in order, so output built from a map
+// does not depend on iteration order
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// timelineKind tells why a command is worth showing in the timeline: a
+// typo, one of the interesting tools, or a pipeline, chain or redirection.
+// It is empty for other commands.
+func timelineKind(command string) string {
 	// List of interesting commands
 	interestingCommands := []string{"git", "docker", "kubectl", "terraform", "ansible", "make", "npm", "go", "python", "java", "ssh", "scp", "curl", "wget", "vim", "nvim", "emacs", "code"}
 
-	// Check if the command contains special characters
-	hasSpecialChars := strings.ContainsAny(command, "|><&;")
-
-	// Check if the command is a typo
-	isTypo := isTypoCommand(command)
-
-	// Check if the command is in the interesting list or has special characters or is a typo
+	if isTypoCommand(command) {
+		return TimelineTypo
+	}
 	for _, interesting := range interestingCommands {
 		if strings.HasPrefix(command, interesting) {
-			return true
+			return TimelineTool
 		}
 	}
-
-	return hasSpecialChars || isTypo
+	if strings.ContainsAny(command, "|><&;") {
+		return TimelinePipeline
+	}
+	return ""
 }
 
-// isTypoCommand checks if a command is a common typo
+// isTypoCommand checks if a command starts with a common typo
 func isTypoCommand(command string) bool {
-	commonTypos := []string{"sl", "cd..", "pythoon", "gti", "vmi", "nivm", "emasc", "clea", "exot"}
-	for _, typo := range commonTypos {
-		if command == typo {
-			return true
-		}
-	}
-	return false
+	_, ok := commonTypos[commandProgram(command)]
+	return ok
 }
@@ -0,0 +1,194 @@
+// internal/analyzer/asciinema.go
+package analyzer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CastSource is the Histories key for commands read from asciinema recordings
+const CastSource = "asciinema"
+
+// castHeader is the first line of an asciinema v2 or v3 recording
+type castHeader struct {
+	Version   int   `json:"version"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// castEvent is one [time, code, data] line. In v2 the time is an offset from
+// the start of the recording, in v3 from the previous event.
+type castEvent struct {
+	at   float64
+	code string
+	data string
+}
+
+var (
+	// ansiSequence matches terminal escape sequences in recorded output
+	ansiSequence = regexp.MustCompile(`\x1b(\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[()][A-Za-z0-9]|[=>])`)
+	// promptLine matches an output line showing a prompt followed by the
+	// command typed at it, e.g. "user@host:~/src$ make"
+	promptLine = regexp.MustCompile(`^(?:\S*[$#%]|[❯➜›λ])\s+(\S.*)$`)
+)
+
+// loadCasts reads the recordings listed in opts.Casts. Only commands run
+// before the shell histories begin are kept, since later ones are already
+// in the history files; without timestamped history every command is kept.
+func loadCasts(opts Options, data *ShellData, monthly map[time.Time]map[string]int) ([]CommandEntry, error) {
+	var before time.Time
+	for month := range monthly {
+		if before.IsZero() || month.Before(before) {
+			before = month
+		}
+	}
+
+	files, problems := castFiles(opts.Casts)
+	data.Problems = append(data.Problems, problems...)
+	return loadEntries(CastSource, func(fn func(CommandEntry)) error {
+		for _, path := range files {
+			// A broken recording should not hide the others
+			err := scanCast(path, func(entry CommandEntry) {
+				if before.IsZero() || entry.Timestamp.IsZero() || entry.Timestamp.Before(before) {
+					fn(entry)
+				}
+			})
+			if problem, ok := problemFor(ProblemCast, path, err); ok {
+				data.Problems = append(data.Problems, problem)
+			}
+		}
+		return nil
+	}, opts, data, monthly)
+}
+
+// castFiles expands the configured paths into .cast files, walking
+// directories, in a stable order. Configured paths that are gone are
+// problems too, unlike missing histories.
+func castFiles(paths []string) ([]string, []Problem) {
+	var files []string
+	var problems []Problem
+	for _, path := range paths {
+		path = expandPath(path)
+		info, err := os.Stat(path)
+		if err != nil {
+			problems = append(problems, Problem{ID: ProblemCast, Path: path, Err: err})
+			continue
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && strings.HasSuffix(d.Name(), ".cast") {
+				files = append(files, p)
+			}
+			return nil
+		})
+	}
+	sort.Strings(files)
+	return files, problems
+}
+
+// scanCast extracts the commands of one recording. Keystrokes are used when
+// the recording captured input (asciinema rec --stdin); otherwise commands
+// are recognized in the output by the prompt in front of them.
+func scanCast(path string, fn func(CommandEntry)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		return fmt.Errorf("failed to read %s: empty recording", path)
+	}
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil || (header.Version != 2 && header.Version != 3) {
+		return fmt.Errorf("failed to read %s: not an asciinema v2 or v3 recording", path)
+	}
+
+	var events []castEvent
+	hasInput := false
+	elapsed := 0.0
+	for scanner.Scan() {
+		var raw []json.RawMessage
+		if json.Unmarshal(scanner.Bytes(), &raw) != nil || len(raw) < 3 {
+			continue
+		}
+		var event castEvent
+		if json.Unmarshal(raw[0], &event.at) != nil || json.Unmarshal(raw[1], &event.code) != nil ||
+			json.Unmarshal(raw[2], &event.data) != nil {
+			continue
+		}
+		if header.Version == 3 {
+			elapsed += event.at
+			event.at = elapsed
+		}
+		hasInput = hasInput || event.code == "i"
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	at := func(offset float64) time.Time {
+		if header.Timestamp == 0 {
+			return time.Time{}
+		}
+		return time.Unix(header.Timestamp, 0).Add(time.Duration(offset * float64(time.Second)))
+	}
+
+	code := "o"
+	if hasInput {
+		code = "i"
+	}
+	var line []rune
+	for _, event := range events {
+		if event.code != code {
+			continue
+		}
+		// Output lines end with \r\n, while a lone \r redraws the line
+		data := strings.ReplaceAll(ansiSequence.ReplaceAllString(event.data, ""), "\r\n", "\n")
+		for _, r := range data {
+			switch r {
+			case '\r', '\n':
+				// Typed lines end with Enter
+				if hasInput || r == '\n' {
+					if cmd := castCommand(string(line), hasInput); cmd != "" {
+						fn(newCommandEntry(cmd, at(event.at)))
+					}
+				}
+				line = line[:0]
+			case '\b', '\x7f':
+				if len(line) > 0 {
+					line = line[:len(line)-1]
+				}
+			default:
+				if r >= ' ' {
+					line = append(line, r)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// castCommand returns the command in a typed or printed line, if any
+func castCommand(line string, typed bool) string {
+	if typed {
+		return strings.TrimSpace(line)
+	}
+	if m := promptLine.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+// internal/analyzer/atuin.go
+package analyzer
+
+import (
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// atuinHistoryPath is where atuin keeps the commands it records next to the
+// shell's own history, with the directory each one ran in
+const atuinHistoryPath = "~/.local/share/atuin/history.db"
+
+// atuinKey identifies a command in both atuin and the shell history
+type atuinKey struct {
+	command string
+	second  int64
+}
+
+// atuinRecord is what atuin knows about a command beyond the history
+type atuinRecord struct {
+	dir      string
+	duration time.Duration
+	exit     int
+}
+
+// atuinRecords reads the working directory, duration and exit status atuin
+// recorded for each command. It returns nil when atuin is not used or the
+// binary was built without SQLite support.
+func atuinRecords() map[atuinKey]atuinRecord {
+	db, err := store.OpenSQLiteReadOnly(expandPath(atuinHistoryPath))
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT command, cwd, timestamp, duration, exit FROM history`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	records := make(map[atuinKey]atuinRecord)
+	for rows.Next() {
+		var command, cwd string
+		var nanos, duration int64
+		var exit int
+		if err := rows.Scan(&command, &cwd, &nanos, &duration, &exit); err != nil {
+			continue
+		}
+		records[atuinKey{command, time.Unix(0, nanos).Unix()}] = atuinRecord{cwd, time.Duration(duration), exit}
+	}
+	return records
+}
+
+// addAtuinRecords fills in the directory, duration and exit status of the
+// entries atuin recorded too. Entries without a timestamp cannot be
+// matched. atuin stores -1 for commands that had not finished.
+func addAtuinRecords(histories map[string][]CommandEntry) {
+	records := atuinRecords()
+	if len(records) == 0 {
+		return
+	}
+	for _, history := range histories {
+		for i, entry := range history {
+			if entry.Timestamp.IsZero() {
+				continue
+			}
+			rec, ok := records[atuinKey{entry.Command, entry.Timestamp.Unix()}]
+			if !ok {
+				continue
+			}
+			history[i].Dir = rec.dir
+			if rec.duration >= 0 {
+				history[i].Duration, history[i].Timed = rec.duration, true
+				history[i].Exit, history[i].HasExit = rec.exit, true
+			}
+		}
+	}
+}
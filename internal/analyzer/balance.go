@@ -0,0 +1,165 @@
+// internal/analyzer/balance.go
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// WorkLife splits the timestamped commands into weekdays and weekends and
+// follows when each day starts and ends. A day runs from lateNightEnd to
+// lateNightEnd the next morning, so a session past midnight ends the day
+// it began in.
+type WorkLife struct {
+	WeekdayCommands, WeekendCommands int
+	// WeekdayDays and WeekendDays count the active days of each
+	WeekdayDays, WeekendDays int
+	// AverageStart and AverageEnd are the mean times of the first and last
+	// command of active days, since midnight; AverageEnd passes 24 hours
+	// when days tend to end after midnight
+	AverageStart, AverageEnd time.Duration
+	// LateNight counts the commands run between midnight and lateNightEnd,
+	// on LateNights different nights; LateNightCoding counts the ones
+	// that were development or build commands
+	LateNight       int
+	LateNights      int
+	LateNightCoding int
+	// Latest is the command run latest into a night
+	Latest time.Time
+}
+
+// Work-life balance levels and the reasons for them, as returned by Balance
+const (
+	BalanceHealthy  = "healthy"
+	BalanceFair     = "fair"
+	BalanceStrained = "strained"
+
+	ReasonWeekends = "weekends"
+	ReasonLate     = "late"
+	ReasonLongDays = "long_days"
+)
+
+const (
+	// balanceWeekendShare is the share of commands on weekends from which
+	// the weekends no longer look free
+	balanceWeekendShare = 0.2
+	// balanceLateShare is the share of commands after midnight from which
+	// nights look cut short
+	balanceLateShare = 0.1
+	// balanceLongDay is the average span of a day from which days look long
+	balanceLongDay = 10 * time.Hour
+)
+
+// Total returns the number of timestamped commands
+func (w WorkLife) Total() int {
+	return w.WeekdayCommands + w.WeekendCommands
+}
+
+// WeekendShare returns the share of commands run on weekends
+func (w WorkLife) WeekendShare() float64 {
+	if w.Total() == 0 {
+		return 0
+	}
+	return float64(w.WeekendCommands) / float64(w.Total())
+}
+
+// LateShare returns the share of commands run after midnight
+func (w WorkLife) LateShare() float64 {
+	if w.Total() == 0 {
+		return 0
+	}
+	return float64(w.LateNight) / float64(w.Total())
+}
+
+// Balance rates the work-life balance: healthy with none of the reasons,
+// fair with one and strained with more. It is "" without timestamps.
+func (w WorkLife) Balance() (string, []string) {
+	if w.Total() == 0 {
+		return "", nil
+	}
+	var reasons []string
+	if w.WeekendShare() >= balanceWeekendShare {
+		reasons = append(reasons, ReasonWeekends)
+	}
+	if w.LateShare() >= balanceLateShare {
+		reasons = append(reasons, ReasonLate)
+	}
+	if w.AverageEnd-w.AverageStart >= balanceLongDay {
+		reasons = append(reasons, ReasonLongDays)
+	}
+	switch len(reasons) {
+	case 0:
+		return BalanceHealthy, nil
+	case 1:
+		return BalanceFair, reasons
+	}
+	return BalanceStrained, reasons
+}
+
+// ClockTime formats a time since midnight as HH:MM, wrapping past midnight
+func ClockTime(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes()) % (24 * 60)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// analyzeWorkLife computes the WorkLife of the timestamped entries
+func analyzeWorkLife(histories map[string][]CommandEntry) WorkLife {
+	type span struct{ first, last time.Duration }
+	days := make(map[time.Time]*span)
+	nights := make(map[time.Time]bool)
+	var w WorkLife
+	var latest time.Duration
+	for _, history := range histories {
+		for _, entry := range history {
+			t := entry.Timestamp
+			if t.IsZero() {
+				continue
+			}
+			// The day the command belongs to, and how long after its
+			// midnight it ran
+			day := dayStart(t.Add(-lateNightEnd * time.Hour))
+			offset := t.Sub(day)
+			if days[day] == nil {
+				days[day] = &span{first: offset, last: offset}
+			}
+			days[day].first = min(days[day].first, offset)
+			days[day].last = max(days[day].last, offset)
+
+			if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+				w.WeekendCommands++
+			} else {
+				w.WeekdayCommands++
+			}
+			if offset >= 24*time.Hour {
+				w.LateNight++
+				nights[day] = true
+				if slices.Contains(entry.Categories, "development") || slices.Contains(entry.Categories, "build") {
+					w.LateNightCoding++
+				}
+				if offset > latest {
+					latest = offset
+					w.Latest = t
+				}
+			}
+		}
+	}
+	if len(days) == 0 {
+		return w
+	}
+
+	var start, end time.Duration
+	for day, s := range days {
+		start += s.first
+		end += s.last
+		if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			w.WeekendDays++
+		} else {
+			w.WeekdayDays++
+		}
+	}
+	w.AverageStart = start / time.Duration(len(days))
+	w.AverageEnd = end / time.Duration(len(days))
+	w.LateNights = len(nights)
+	return w
+}
@@ -0,0 +1,90 @@
+// internal/analyzer/budgets.go
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+)
+
+// Budget is a soft limit on the share of commands matching some criteria,
+// e.g. at most 20% manual kubectl against production. Every criterion that
+// is set must match.
+type Budget struct {
+	Name string
+	// Program is the program run, sudo skipped, e.g. "kubectl"
+	Program string
+	// Category is one of the command categories, e.g. "system"
+	Category string
+	// Pattern is matched against the whole command line
+	Pattern *regexp.Regexp
+	// Max is the highest acceptable share of commands, in percent
+	Max float64
+}
+
+// matches reports whether entry counts against the budget
+func (b Budget) matches(entry CommandEntry) bool {
+	if b.Program != "" && commandProgram(entry.Command) != b.Program {
+		return false
+	}
+	if b.Category != "" {
+		found := false
+		for _, category := range entry.Categories {
+			found = found || category == b.Category
+		}
+		if !found {
+			return false
+		}
+	}
+	return b.Pattern == nil || b.Pattern.MatchString(entry.Command)
+}
+
+// BudgetStatus is how a budget stands against the history
+type BudgetStatus struct {
+	Budget   Budget
+	Commands int
+	// Share is the percentage of all commands matching the budget
+	Share    float64
+	Exceeded bool
+}
+
+// CheckBudgets measures each budget against every entry. In low-memory mode
+// that is the sample, which keeps the shares close.
+func CheckBudgets(histories map[string][]CommandEntry, budgets []Budget) []BudgetStatus {
+	if len(budgets) == 0 {
+		return nil
+	}
+	statuses := make([]BudgetStatus, len(budgets))
+	total := 0
+	for _, history := range histories {
+		for _, entry := range history {
+			total++
+			for i, budget := range budgets {
+				if budget.matches(entry) {
+					statuses[i].Commands++
+				}
+			}
+		}
+	}
+	for i, budget := range budgets {
+		statuses[i].Budget = budget
+		if total > 0 {
+			statuses[i].Share = float64(statuses[i].Commands) / float64(total) * 100
+		}
+		statuses[i].Exceeded = statuses[i].Share > budget.Max
+	}
+	return statuses
+}
+
+// ExceededBudgets returns the budgets over their limit, furthest over first
+func ExceededBudgets(statuses []BudgetStatus) []BudgetStatus {
+	var exceeded []BudgetStatus
+	for _, status := range statuses {
+		if status.Exceeded {
+			exceeded = append(exceeded, status)
+		}
+	}
+	sort.SliceStable(exceeded, func(i, j int) bool {
+		return exceeded[i].Share-exceeded[i].Budget.Max > exceeded[j].Share-exceeded[j].Budget.Max
+	})
+	return exceeded
+}
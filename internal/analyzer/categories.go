@@ -0,0 +1,128 @@
+// internal/analyzer/categories.go
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// CategoryRule puts in Category the commands that run one of Tools, in any
+// part of the command line, or match one of Patterns
+type CategoryRule struct {
+	Category string
+	Tools    []string
+	Patterns []*regexp.Regexp
+}
+
+// builtinCategories maps each category to the programs in it
+var builtinCategories = map[string][]string{
+	"development": {"git", "gh", "docker", "podman", "npm", "npx", "yarn", "pnpm", "node", "deno", "bun", "go",
+		"python", "python3", "cargo", "rustc", "java", "javac", "gcc", "clang", "ruby", "bundle", "make", "cmake",
+		"bazel", "gradle", "mvn", "poetry"},
+	"build": {"make", "cmake", "ninja", "meson", "bazel", "bazelisk", "gradle", "mvn", "just", "task", "nix-build"},
+	"packages": {"apt", "apt-get", "dnf", "yum", "pacman", "yay", "zypper", "apk", "brew", "port", "snap", "flatpak",
+		"nix", "nix-env", "nix-shell", "home-manager", "pip", "pip3", "pipx", "poetry", "uv", "conda"},
+	"containers": {"docker", "docker-compose", "podman", "kubectl", "helm", "k9s", "kind", "minikube", "k3d"},
+	"network":    {"ssh", "scp", "sftp", "rsync", "mosh", "curl", "wget", "ping", "dig", "nslookup", "nc", "traceroute"},
+	"system": {"sudo", "systemctl", "journalctl", "service", "ps", "top", "htop", "btop", "kill", "pkill", "df", "du",
+		"free", "mount", "uname"},
+	"file": {"ls", "cd", "cp", "mv", "rm", "mkdir", "rmdir", "touch", "ln", "chmod", "chown", "find", "fd", "tree",
+		"cat", "less", "bat", "eza", "exa"},
+}
+
+// categoryTools maps each program to its categories, and categoryPatterns
+// holds the rules matched against the whole command line
+var (
+	categoryTools    = toolCategories(nil)
+	categoryPatterns []CategoryRule
+)
+
+// toolCategories indexes the built-in categories and the extra rules by
+// program
+func toolCategories(extra []CategoryRule) map[string][]string {
+	tools := make(map[string][]string)
+	add := func(category string, programs []string) {
+		for _, program := range programs {
+			if !slices.Contains(tools[program], category) {
+				tools[program] = append(tools[program], category)
+			}
+		}
+	}
+	for category, programs := range builtinCategories {
+		add(category, programs)
+	}
+	for _, rule := range extra {
+		add(rule.Category, rule.Tools)
+	}
+	return tools
+}
+
+// SetCategories adds the user's rules to the built-in categories. A rule for
+// a category that exists extends it, any other creates one.
+func SetCategories(rules []CategoryRule) {
+	categoryTools = toolCategories(rules)
+	categoryPatterns = nil
+	for _, rule := range rules {
+		if len(rule.Patterns) > 0 {
+			categoryPatterns = append(categoryPatterns, rule)
+		}
+	}
+}
+
+// categoriesChecksum identifies the categories in use, so histories cached
+// with other ones are categorized again
+func categoriesChecksum() string {
+	patterns := make(map[string][]string)
+	for _, rule := range categoryPatterns {
+		for _, pattern := range rule.Patterns {
+			patterns[rule.Category] = append(patterns[rule.Category], pattern.String())
+		}
+	}
+	// Maps are encoded with sorted keys
+	raw, _ := json.Marshal([]any{categoryTools, patterns})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// CategorizeCommand returns the categories of a command line, e.g.
+// "development" for git, sorted; a command may have none. Every program of
+// a pipeline or chain counts, as does sudo itself.
+func CategorizeCommand(cmd string) []string {
+	found := []string{}
+	add := func(categories []string) {
+		for _, category := range categories {
+			if !slices.Contains(found, category) {
+				found = append(found, category)
+			}
+		}
+	}
+	for _, segment := range strings.FieldsFunc(cmd, func(r rune) bool {
+		return r == '|' || r == ';' || r == '&'
+	}) {
+		fields := strings.Fields(segment)
+		if len(fields) == 0 {
+			continue
+		}
+		add(categoryTools[filepath.Base(fields[0])])
+		if program := commandProgram(segment); program != fields[0] {
+			add(categoryTools[filepath.Base(program)])
+		}
+	}
+	for _, rule := range categoryPatterns {
+		for _, pattern := range rule.Patterns {
+			if pattern.MatchString(cmd) {
+				add([]string{rule.Category})
+				break
+			}
+		}
+	}
+	sort.Strings(found)
+
+	return found
+}
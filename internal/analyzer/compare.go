@@ -0,0 +1,115 @@
+// internal/analyzer/compare.go
+package analyzer
+
+import (
+	"strings"
+	"time"
+)
+
+// ShellSummary is one column of the shell comparison
+type ShellSummary struct {
+	Shell    string
+	Commands int
+	// Recent counts the entries from the last RecentWindow of activity
+	Recent  int
+	Top     []CommandCount
+	Aliases int
+	Plugins int
+	// ConfigFiles and ConfigLines measure the startup files; lines that
+	// are blank or comments are not counted
+	ConfigFiles int
+	ConfigLines int
+	Last        time.Time
+}
+
+// ShellComparison puts the shells side by side
+type ShellComparison struct {
+	Shells []ShellSummary
+	// Recommended is the shell that gets the most real use, and
+	// ByRecent tells whether that was judged on recent activity or, for
+	// histories without timestamps, on all commands
+	Recommended string
+	ByRecent    bool
+}
+
+// RecentWindow is how far back from the newest entry activity counts as
+// recent when recommending a shell
+const RecentWindow = 90 * 24 * time.Hour
+
+// comparedTop is how many top commands are listed per shell
+const comparedTop = 3
+
+// CompareShells summarizes each shell that had a history, in the order they
+// are read. Recordings are not a shell and are left out.
+func CompareShells(data ShellData) ShellComparison {
+	var comparison ShellComparison
+	var newest time.Time
+	for _, shell := range SupportedShells() {
+		history, ok := data.Histories[shell]
+		if !ok {
+			continue
+		}
+		summary := ShellSummary{
+			Shell:    shell,
+			Commands: data.CommandCounts[shell],
+			Top:      SortedCounts(data.ShellCmds[shell], comparedTop),
+		}
+		config := data.ShellConfigs[shell]
+		summary.Aliases = len(config.Aliases)
+		summary.Plugins = len(config.Plugins)
+		summary.ConfigFiles = len(config.ConfigFiles)
+		for _, info := range config.ConfigFiles {
+			summary.ConfigLines += configLines(info.Content)
+		}
+		for _, entry := range history {
+			if entry.Timestamp.After(summary.Last) {
+				summary.Last = entry.Timestamp
+			}
+		}
+		if summary.Last.After(newest) {
+			newest = summary.Last
+		}
+		comparison.Shells = append(comparison.Shells, summary)
+	}
+
+	for i := range comparison.Shells {
+		summary := &comparison.Shells[i]
+		for _, entry := range data.Histories[summary.Shell] {
+			if !entry.Timestamp.IsZero() && newest.Sub(entry.Timestamp) <= RecentWindow {
+				summary.Recent++
+			}
+		}
+		if summary.Recent > 0 {
+			comparison.ByRecent = true
+		}
+	}
+	best := -1
+	for i, summary := range comparison.Shells {
+		if best < 0 || comparison.usage(summary) > comparison.usage(comparison.Shells[best]) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		comparison.Recommended = comparison.Shells[best].Shell
+	}
+	return comparison
+}
+
+// usage is what the recommendation compares shells on
+func (c ShellComparison) usage(summary ShellSummary) int {
+	if c.ByRecent {
+		return summary.Recent
+	}
+	return summary.Commands
+}
+
+// configLines counts the lines of a startup file that do something
+func configLines(content string) int {
+	lines := 0
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			lines++
+		}
+	}
+	return lines
+}
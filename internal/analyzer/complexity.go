@@ -0,0 +1,259 @@
+// internal/analyzer/complexity.go
+package analyzer
+
+import "strings"
+
+// ComplexityFeatures are the shell features the complexity analysis looks
+// for, in display order. They double as i18n keys under "complexity.".
+var ComplexityFeatures = []string{"pipes", "redirections", "substitutions", "subshells", "loops", "conditionals", "xargs", "chains"}
+
+// featureWeights is how much each use of a feature adds to a command's score
+var featureWeights = map[string]int{
+	"pipes":         1,
+	"redirections":  1,
+	"substitutions": 2,
+	"subshells":     2,
+	"loops":         3,
+	"conditionals":  2,
+	"xargs":         2,
+	"chains":        1,
+}
+
+// ComplexityLevels name the score bands, simplest first, and the lowest
+// score of each band
+var (
+	ComplexityLevels = []string{"simple", "moderate", "complex", "elaborate"}
+	levelScores      = []int{0, 1, 3, 6}
+)
+
+// CommandComplexity summarizes how much of the shell language is used
+type CommandComplexity struct {
+	// Levels counts the commands in each of ComplexityLevels
+	Levels []int
+	// Features counts the commands using each of ComplexityFeatures
+	Features map[string]int
+	// LongestPipeline is the most programs piped together in one command
+	LongestPipeline int
+	// MostElaborate is the highest scoring command line
+	MostElaborate string
+	Score         int
+}
+
+// Remarkable reports whether the most elaborate command is at least complex
+func (c CommandComplexity) Remarkable() bool {
+	return c.Score >= levelScores[2]
+}
+
+// analyzeComplexity scores every command line. Ties for the most elaborate
+// command go to the longer, then the earlier one.
+func analyzeComplexity(histories map[string][]CommandEntry) CommandComplexity {
+	complexity := CommandComplexity{
+		Levels:   make([]int, len(ComplexityLevels)),
+		Features: make(map[string]int),
+	}
+
+	for _, shell := range SortedKeys(histories) {
+		for _, entry := range histories[shell] {
+			features, depth := shellFeatures(entry.Command)
+			score := 0
+			for feature, uses := range features {
+				complexity.Features[feature]++
+				score += uses * featureWeights[feature]
+			}
+			complexity.Levels[complexityLevel(score)]++
+			if depth > complexity.LongestPipeline {
+				complexity.LongestPipeline = depth
+			}
+			if score > 0 && (score > complexity.Score ||
+				score == complexity.Score && len(entry.Command) > len(complexity.MostElaborate)) {
+				complexity.Score = score
+				complexity.MostElaborate = entry.Command
+			}
+		}
+	}
+	return complexity
+}
+
+func complexityLevel(score int) int {
+	level := 0
+	for i, lowest := range levelScores {
+		if score >= lowest {
+			level = i
+		}
+	}
+	return level
+}
+
+// shellFeatures counts the uses of each of ComplexityFeatures in a command
+// line, and returns the length of its longest pipeline. Quotes are
+// respected, so a | inside a quoted string is not a pipe.
+func shellFeatures(command string) (map[string]int, int) {
+	features := make(map[string]int)
+	runes := []rune(command)
+	var word strings.Builder
+	var quote rune
+	inBackticks := false
+	atCommand := true
+	pipeline, longest := 1, 1
+
+	endWord := func() {
+		w := word.String()
+		word.Reset()
+		if w == "" || !atCommand {
+			return
+		}
+		switch w {
+		case "for", "while", "until", "select":
+			features["loops"]++
+		case "if", "case", "[[", "[", "test":
+			features["conditionals"]++
+		case "xargs":
+			features["xargs"]++
+		}
+		// Words after which another command follows
+		switch w {
+		case "do", "then", "else", "elif", "while", "until", "if", "!", "{", "sudo", "time", "exec", "nohup":
+		default:
+			atCommand = false
+		}
+	}
+	// newCommand ends the current word at an operator that starts a command
+	newCommand := func() {
+		endWord()
+		atCommand = true
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			}
+			word.WriteRune(r)
+			continue
+		case r == '\\':
+			word.WriteRune(r)
+			if next != 0 {
+				word.WriteRune(next)
+				i++
+			}
+			continue
+		case r == '`':
+			if !inBackticks {
+				features["substitutions"]++
+				newCommand()
+			}
+			inBackticks = !inBackticks
+			continue
+		case r == '$' && next == '(':
+			if i+2 < len(runes) && runes[i+2] == '(' {
+				// $(( arithmetic ))
+				word.WriteString("$((")
+				i += 2
+				continue
+			}
+			features["substitutions"]++
+			newCommand()
+			i++
+			continue
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			}
+			word.WriteRune(r)
+			continue
+		case r == '"' || r == '\'':
+			quote = r
+			word.WriteRune(r)
+			continue
+		}
+
+		switch r {
+		case ' ', '\t', '\n':
+			endWord()
+		case '|':
+			if next == '|' {
+				features["chains"]++
+				pipeline = 1
+				i++
+			} else {
+				features["pipes"]++
+				pipeline++
+				longest = max(longest, pipeline)
+			}
+			newCommand()
+		case '&':
+			switch next {
+			case '&':
+				features["chains"]++
+				pipeline = 1
+				i++
+				newCommand()
+			case '>':
+				// &> redirects both streams
+				endWord()
+				features["redirections"]++
+				i++
+			default:
+				pipeline = 1
+				newCommand()
+			}
+		case ';':
+			if next == ';' {
+				i++
+			} else {
+				features["chains"]++
+			}
+			pipeline = 1
+			newCommand()
+		case '>', '<':
+			if next == '(' {
+				// <(...) process substitution
+				features["substitutions"]++
+				i++
+				newCommand()
+				continue
+			}
+			// A file descriptor such as the 2 in 2> belongs to the redirection
+			if w := word.String(); w != "" && strings.Trim(w, "0123456789") == "" {
+				word.Reset()
+			}
+			endWord()
+			features["redirections"]++
+			for i+1 < len(runes) && strings.ContainsRune("<>&|", runes[i+1]) {
+				i++
+			}
+		case '(':
+			if word.Len() > 0 {
+				// f() or an array assignment
+				word.WriteRune(r)
+				continue
+			}
+			if next == '(' {
+				// (( arithmetic ))
+				i++
+				continue
+			}
+			features["subshells"]++
+			newCommand()
+		case ')':
+			endWord()
+		default:
+			word.WriteRune(r)
+		}
+	}
+	endWord()
+	return features, longest
+}
+
+// pipelineLength returns the most programs piped together in command
+func pipelineLength(command string) int {
+	_, longest := shellFeatures(command)
+	return longest
+}
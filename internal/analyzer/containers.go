@@ -0,0 +1,220 @@
+// internal/analyzer/containers.go
+package analyzer
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ContainerUsage is how containers and Kubernetes are driven from the shell
+type ContainerUsage struct {
+	// DockerCommands counts the docker and podman invocations other than
+	// compose, and ComposeCommands the docker compose and docker-compose
+	// ones
+	DockerCommands  int
+	ComposeCommands int
+	// Images are the images started with docker run, most often first,
+	// without their tag
+	Images []CommandCount
+	// KubectlCommands counts the kubectl invocations and KubectlVerbs each
+	// verb
+	KubectlCommands int
+	KubectlVerbs    map[string]int
+	// HelmCommands counts the helm invocations and Helm each subcommand
+	HelmCommands int
+	Helm         map[string]int
+	// Namespaces are the namespaces passed to kubectl and helm or switched
+	// to with kubens, most often first
+	Namespaces []CommandCount
+	// ContextSwitches counts the switches of kubectl or docker context, and
+	// Contexts are the contexts switched to, most often first
+	ContextSwitches int
+	Contexts        []CommandCount
+}
+
+// Total returns the number of container and Kubernetes commands
+func (c ContainerUsage) Total() int {
+	return c.DockerCommands + c.ComposeCommands + c.KubectlCommands + c.HelmCommands
+}
+
+// ComposeShare returns the share of docker commands that went through
+// compose
+func (c ContainerUsage) ComposeShare() float64 {
+	if c.DockerCommands+c.ComposeCommands == 0 {
+		return 0
+	}
+	return float64(c.ComposeCommands) / float64(c.DockerCommands+c.ComposeCommands)
+}
+
+// containerListShown caps the images, namespaces and contexts
+const containerListShown = 10
+
+var (
+	// dockerRunValueOptions are the options of docker run that take a
+	// value, so the image is the first word after them
+	dockerRunValueOptions = map[string]bool{
+		"-a": true, "--attach": true, "-e": true, "--env": true, "--env-file": true,
+		"-p": true, "--publish": true, "-v": true, "--volume": true, "--mount": true,
+		"--name": true, "-w": true, "--workdir": true, "-u": true, "--user": true,
+		"--network": true, "--net": true, "--entrypoint": true, "-l": true, "--label": true,
+		"--platform": true, "--restart": true, "-m": true, "--memory": true, "--cpus": true,
+		"-h": true, "--hostname": true, "--device": true, "--add-host": true, "--gpus": true,
+		"--pull": true, "--log-driver": true, "--log-opt": true, "--cap-add": true,
+		"--cap-drop": true, "--runtime": true, "--ipc": true, "--pid": true, "--tmpfs": true,
+		"--security-opt": true, "--ulimit": true, "--dns": true, "--link": true,
+		"--volumes-from": true, "--expose": true, "--shm-size": true, "--cidfile": true,
+		"--stop-signal": true, "--stop-timeout": true, "--health-cmd": true, "--userns": true,
+	}
+	// dockerGlobalSwitches are the global options of docker that take no
+	// value
+	dockerGlobalSwitches = map[string]bool{"-D": true, "--debug": true, "--tls": true, "--tlsverify": true}
+	// kubeValueOptions are the options of kubectl and helm that take a
+	// value, so the verb is the first word after them
+	kubeValueOptions = map[string]bool{
+		"-n": true, "--namespace": true, "--context": true, "--kube-context": true,
+		"--kubeconfig": true, "--cluster": true, "--user": true, "-l": true, "--selector": true,
+		"-o": true, "--output": true, "-f": true, "--filename": true, "-c": true,
+		"--container": true, "-L": true, "--field-selector": true, "--sort-by": true,
+		"--values": true, "--set": true, "--version": true, "--repo": true,
+	}
+)
+
+// AnalyzeContainers reads the docker, podman, kubectl and helm commands of
+// every part of each command line
+func AnalyzeContainers(histories map[string][]CommandEntry) ContainerUsage {
+	usage := ContainerUsage{KubectlVerbs: make(map[string]int), Helm: make(map[string]int)}
+	images := make(map[string]int)
+	namespaces := make(map[string]int)
+	contexts := make(map[string]int)
+	for _, history := range histories {
+		for _, entry := range history {
+			for _, fields := range commandSegments(entry.Command) {
+				args := fields[1:]
+				switch filepath.Base(fields[0]) {
+				case "docker", "podman":
+					addDockerCommand(&usage, images, contexts, args)
+				case "docker-compose", "podman-compose":
+					usage.ComposeCommands++
+				case "kubectl", "kubecolor":
+					usage.KubectlCommands++
+					verb, positional := kubeArgs(args, namespaces)
+					if verb == "" {
+						break
+					}
+					usage.KubectlVerbs[verb]++
+					if verb == "config" && len(positional) > 1 && positional[0] == "use-context" {
+						usage.ContextSwitches++
+						contexts[positional[1]]++
+					}
+				case "helm":
+					usage.HelmCommands++
+					if verb, _ := kubeArgs(args, namespaces); verb != "" {
+						usage.Helm[verb]++
+					}
+				case "kubectx":
+					if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+						usage.ContextSwitches++
+						contexts[args[0]]++
+					}
+				case "kubens":
+					if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+						namespaces[args[0]]++
+					}
+				}
+			}
+		}
+	}
+	usage.Images = SortedCounts(images, containerListShown)
+	usage.Namespaces = SortedCounts(namespaces, containerListShown)
+	usage.Contexts = SortedCounts(contexts, containerListShown)
+	return usage
+}
+
+// addDockerCommand counts one docker or podman invocation given its
+// arguments
+func addDockerCommand(usage *ContainerUsage, images, contexts map[string]int, args []string) {
+	// Skip the global options before the subcommand; all but a few take a
+	// value
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		if !strings.Contains(args[i], "=") && !dockerGlobalSwitches[args[i]] {
+			i++
+		}
+		i++
+	}
+	if i >= len(args) {
+		usage.DockerCommands++
+		return
+	}
+	subcommand, rest := args[i], args[i+1:]
+	if subcommand == "compose" {
+		usage.ComposeCommands++
+		return
+	}
+	usage.DockerCommands++
+
+	// docker container run is docker run
+	if subcommand == "container" && len(rest) > 0 {
+		subcommand, rest = rest[0], rest[1:]
+	}
+	switch subcommand {
+	case "run":
+		if image := dockerRunImage(rest); image != "" {
+			images[image]++
+		}
+	case "context":
+		if len(rest) > 1 && rest[0] == "use" {
+			usage.ContextSwitches++
+			contexts[rest[1]]++
+		}
+	}
+}
+
+// dockerRunImage returns the image the arguments of docker run start,
+// without its tag or digest, or "" when there is none
+func dockerRunImage(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			image, _, _ := strings.Cut(arg, "@")
+			// A colon after the last slash starts the tag; one before it
+			// is a registry port
+			if colon := strings.LastIndex(image, ":"); colon > strings.LastIndex(image, "/") {
+				image = image[:colon]
+			}
+			return image
+		}
+		if dockerRunValueOptions[arg] {
+			i++
+		}
+	}
+	return ""
+}
+
+// kubeArgs returns the verb of a kubectl or helm invocation and the
+// positional arguments after it, counting the namespace it targets
+func kubeArgs(args []string, namespaces map[string]int) (string, []string) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		name, value, inline := strings.Cut(arg, "=")
+		if !inline && kubeValueOptions[name] && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		if (name == "-n" || name == "--namespace") && value != "" {
+			namespaces[value]++
+		}
+	}
+	if len(positional) == 0 {
+		return "", nil
+	}
+	return positional[0], positional[1:]
+}
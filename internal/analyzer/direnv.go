@@ -0,0 +1,174 @@
+// internal/analyzer/direnv.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DirenvUsage describes how per-project environments are handled
+type DirenvUsage struct {
+	// Hooked is set when an rc file runs `direnv hook`
+	Hooked bool
+	// Projects are the directories with an .envrc, most active first
+	Projects []EnvProject
+	// ExportSequences are exports typed by hand again and again, most
+	// repeated first
+	ExportSequences []ExportSequence
+}
+
+// EnvProject is a directory whose environment direnv manages
+type EnvProject struct {
+	Dir string
+	// Commands counts direnv commands and .envrc edits for the directory
+	Commands int
+	Last     time.Time
+}
+
+// ExportSequence is a run of consecutive exports typed in one directory
+type ExportSequence struct {
+	Variables []string
+	Dir       string
+	Count     int
+}
+
+// minExportRepeats is how often the same exports must be typed before
+// direnv is recommended for them
+const minExportRepeats = 3
+
+// AnalyzeDirenv looks for direnv and .envrc use in the histories, and for
+// exports typed by hand that an .envrc would set. Directories are inferred
+// from cd commands, see walkDirs.
+func AnalyzeDirenv(data ShellData) DirenvUsage {
+	var usage DirenvUsage
+	for _, config := range data.ShellConfigs {
+		for _, info := range config.ConfigFiles {
+			if strings.Contains(info.Content, "direnv hook") || strings.Contains(info.Content, "direnv export") {
+				usage.Hooked = true
+			}
+		}
+	}
+
+	projects := make(map[string]*EnvProject)
+	sequences := make(map[string]*ExportSequence)
+	var run []string
+	runDir := ""
+	endRun := func() {
+		if len(run) > 0 {
+			sort.Strings(run)
+			key := runDir + "\x00" + strings.Join(run, " ")
+			if sequences[key] == nil {
+				sequences[key] = &ExportSequence{Variables: run, Dir: runDir}
+			}
+			sequences[key].Count++
+		}
+		run = nil
+	}
+	walkDirs(data.Histories, func(entry CommandEntry, cwd string, newSession bool) {
+		if newSession {
+			endRun()
+		}
+		words := strings.Fields(entry.Command)
+		if vars := exportedVariables(words); len(vars) > 0 {
+			run, runDir = append(run, vars...), cwd
+			return
+		}
+		endRun()
+
+		for _, dir := range envrcDirs(words, cwd) {
+			if projects[dir] == nil {
+				projects[dir] = &EnvProject{Dir: dir}
+			}
+			projects[dir].Commands++
+			if entry.Timestamp.After(projects[dir].Last) {
+				projects[dir].Last = entry.Timestamp
+			}
+		}
+	})
+	endRun()
+
+	for _, project := range projects {
+		usage.Projects = append(usage.Projects, *project)
+	}
+	sort.Slice(usage.Projects, func(i, j int) bool {
+		if usage.Projects[i].Commands != usage.Projects[j].Commands {
+			return usage.Projects[i].Commands > usage.Projects[j].Commands
+		}
+		return usage.Projects[i].Dir < usage.Projects[j].Dir
+	})
+	for _, sequence := range sequences {
+		// Exports in a direnv project are probably being tried out for its .envrc
+		if sequence.Count >= minExportRepeats && projects[sequence.Dir] == nil {
+			usage.ExportSequences = append(usage.ExportSequences, *sequence)
+		}
+	}
+	sort.Slice(usage.ExportSequences, func(i, j int) bool {
+		a, b := usage.ExportSequences[i], usage.ExportSequences[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Dir != b.Dir {
+			return a.Dir < b.Dir
+		}
+		return strings.Join(a.Variables, " ") < strings.Join(b.Variables, " ")
+	})
+	return usage
+}
+
+// exportedVariables returns the names set by `export A=1 B=2` or fish's
+// `set -x A 1`, without their values
+func exportedVariables(words []string) []string {
+	if len(words) < 2 {
+		return nil
+	}
+	var names []string
+	switch words[0] {
+	case "export":
+		for _, word := range words[1:] {
+			if name, _, ok := strings.Cut(word, "="); ok && name != "" {
+				names = append(names, name)
+			}
+		}
+	case "set":
+		exported := false
+		for i, word := range words[1:] {
+			if strings.HasPrefix(word, "-") && !strings.HasPrefix(word, "--") {
+				exported = exported || strings.Contains(word, "x")
+				continue
+			}
+			if word == "--export" {
+				exported = true
+				continue
+			}
+			if exported && i+2 < len(words) {
+				names = append(names, word)
+			}
+			break
+		}
+	}
+	return names
+}
+
+// envrcDirs returns the directories a direnv command or a reference to an
+// .envrc file is about
+func envrcDirs(words []string, cwd string) []string {
+	var dirs []string
+	if len(words) > 1 && words[0] == "direnv" {
+		switch words[1] {
+		case "allow", "permit", "deny", "block", "revoke", "edit", "reload":
+			target := "."
+			if len(words) > 2 {
+				target = strings.TrimSuffix(words[2], ".envrc")
+			}
+			return []string{resolveDir(cwd, target)}
+		}
+		return nil
+	}
+	for _, word := range words {
+		if word == ".envrc" || strings.HasSuffix(word, "/.envrc") {
+			dirs = append(dirs, resolveDir(cwd, strings.TrimSuffix(word, ".envrc")))
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,85 @@
+// internal/analyzer/duplicates.go
+package analyzer
+
+import "strings"
+
+// DuplicateStats measures the exact duplicate entries in one shell's history
+type DuplicateStats struct {
+	Shell   string
+	Entries int
+	Unique  int
+	// Consecutive counts entries repeating the one right before them, which
+	// is what ignoredups alone would have dropped
+	Consecutive int
+	// Setting is the rc file snippet that stops duplicates from being saved
+	Setting []string
+	// Enabled is set when the rc files already contain the setting
+	Enabled bool
+	// RCFile is where the setting belongs
+	RCFile string
+}
+
+// Duplicates returns how many entries keeping only the last copy of each
+// command would save
+func (d DuplicateStats) Duplicates() int {
+	return d.Entries - d.Unique
+}
+
+// Share returns the fraction of the history that is duplicates
+func (d DuplicateStats) Share() float64 {
+	if d.Entries == 0 {
+		return 0
+	}
+	return float64(d.Duplicates()) / float64(d.Entries)
+}
+
+// dedupeSettings are the rc file lines that make each shell skip duplicates,
+// along with a marker that shows the setting is already on. Fish never
+// saves duplicates, so it is not listed.
+var dedupeSettings = map[string]struct {
+	lines  []string
+	marker string
+	rcFile string
+}{
+	"bash": {[]string{"HISTCONTROL=ignoredups:erasedups"}, "erasedups", "~/.bashrc"},
+	"zsh":  {[]string{"setopt HIST_IGNORE_ALL_DUPS", "setopt HIST_SAVE_NO_DUPS"}, "hist_ignore_all_dups", "~/.zshrc"},
+}
+
+// AnalyzeDuplicates measures duplicates in every analyzed history that has
+// a dedupe setting. It needs the full history, not a low-memory sample.
+func AnalyzeDuplicates(data ShellData) []DuplicateStats {
+	var stats []DuplicateStats
+	for _, shell := range SortedKeys(data.Histories) {
+		setting, ok := dedupeSettings[shell]
+		if !ok {
+			continue
+		}
+
+		d := DuplicateStats{Shell: shell, Setting: setting.lines, RCFile: data.ShellConfigs[shell].RCFile}
+		if d.RCFile == "" {
+			d.RCFile = expandPath(setting.rcFile)
+		}
+		seen := make(map[string]bool)
+		previous := ""
+		for _, entry := range data.Histories[shell] {
+			d.Entries++
+			if !seen[entry.Command] {
+				seen[entry.Command] = true
+				d.Unique++
+			}
+			if entry.Command == previous {
+				d.Consecutive++
+			}
+			previous = entry.Command
+		}
+
+		for _, info := range data.ShellConfigs[shell].ConfigFiles {
+			if strings.Contains(strings.ReplaceAll(strings.ToLower(info.Content), "_", ""),
+				strings.ReplaceAll(setting.marker, "_", "")) {
+				d.Enabled = true
+			}
+		}
+		stats = append(stats, d)
+	}
+	return stats
+}
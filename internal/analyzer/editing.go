@@ -0,0 +1,197 @@
+// internal/analyzer/editing.go
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// EditUsage compares the languages of the files opened in editors with the
+// languages whose toolchains are run
+type EditUsage struct {
+	// Edited counts the files opened per language, and Run the commands
+	// run with each language's toolchain, as in skillClusters
+	Edited map[string]int
+	Run    map[string]int
+	// Files are the files opened most often
+	Files []CommandCount
+}
+
+// Languages returns the languages edited or run, most used first
+func (e EditUsage) Languages() []string {
+	uses := make(map[string]int)
+	for lang, count := range e.Edited {
+		uses[lang] += count
+	}
+	for lang, count := range e.Run {
+		uses[lang] += count
+	}
+	var languages []string
+	for _, lang := range SortedCounts(uses, 0) {
+		languages = append(languages, lang.Command)
+	}
+	return languages
+}
+
+const (
+	// editedFilesShown caps the files opened most often
+	editedFilesShown = 10
+	// stackMinUses is how many edits or runs put a language or tool in
+	// the tech stack
+	stackMinUses = 3
+)
+
+// editorPrograms are the editors whose file arguments are read
+var editorPrograms = map[string]bool{
+	"vim": true, "vi": true, "nvim": true, "emacs": true, "emacsclient": true, "code": true,
+	"code-insiders": true, "nano": true, "hx": true, "micro": true, "subl": true,
+}
+
+// editorValueOptions are the editor options that take the next word as
+// their value, which is no file to edit
+var editorValueOptions = flagSet("-c --cmd -u -U -S -s -w -W -i -T --eval --load -l -e --user-data-dir --extensions-dir --profile")
+
+// extensionLanguages maps file extensions to languages, named as in
+// skillClusters where the language has one
+var extensionLanguages = map[string]string{
+	".py": "python", ".pyi": "python", ".ipynb": "python",
+	".go": "go", ".rs": "rust", ".rb": "ruby", ".php": "php",
+	".js": "javascript", ".mjs": "javascript", ".cjs": "javascript", ".jsx": "javascript",
+	".ts": "javascript", ".tsx": "javascript", ".vue": "javascript", ".svelte": "javascript",
+	".java": "java", ".kt": "kotlin", ".kts": "kotlin", ".scala": "scala",
+	".c": "c", ".h": "c", ".cc": "c", ".cpp": "c", ".cxx": "c", ".hpp": "c",
+	".cs": "csharp", ".swift": "swift", ".lua": "lua", ".hs": "haskell",
+	".ex": "elixir", ".exs": "elixir",
+	".sh": "shell", ".bash": "shell", ".zsh": "shell", ".fish": "shell",
+	".tf": "terraform", ".sql": "sql",
+}
+
+// analyzeEditing reads the files opened with an editor on every part of
+// every command line, and counts the runs of each language's toolchain
+func analyzeEditing(histories map[string][]CommandEntry) EditUsage {
+	toolchains := make(map[string]string)
+	for name, cluster := range skillClusters {
+		if cluster.language {
+			for _, program := range cluster.programs {
+				toolchains[program] = name
+			}
+		}
+	}
+
+	usage := EditUsage{Edited: make(map[string]int), Run: make(map[string]int)}
+	files := make(map[string]int)
+	for _, shell := range SortedKeys(histories) {
+		for _, entry := range histories[shell] {
+			for _, segment := range strings.FieldsFunc(entry.Command, func(r rune) bool {
+				return r == '|' || r == ';' || r == '&'
+			}) {
+				words := splitWords(segment)
+				if len(words) > 1 && words[0] == "sudo" {
+					words = words[1:]
+				}
+				if len(words) == 0 {
+					continue
+				}
+				program := path.Base(words[0])
+				if lang, ok := toolchains[program]; ok {
+					usage.Run[lang]++
+					continue
+				}
+				if !editorPrograms[program] {
+					continue
+				}
+				for _, file := range editedFiles(words[1:]) {
+					files[file]++
+					if lang, ok := extensionLanguages[strings.ToLower(path.Ext(file))]; ok {
+						usage.Edited[lang]++
+					}
+				}
+			}
+		}
+	}
+	usage.Files = SortedCounts(files, editedFilesShown)
+	return usage
+}
+
+// editedFiles returns the files among an editor's arguments, without the
+// :line:column suffix of code --goto
+func editedFiles(args []string) []string {
+	var files []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--", arg == ".", arg == "..":
+			continue
+		case strings.HasPrefix(arg, "+"):
+			// vim +42 file opens at a line
+			continue
+		case strings.HasPrefix(arg, "-"):
+			if editorValueOptions[arg] {
+				i++
+			}
+			continue
+		case strings.ContainsAny(arg, "$*"):
+			continue
+		}
+		if file, _, found := strings.Cut(arg, ":"); found && path.Ext(file) != "" {
+			arg = file
+		}
+		files = append(files, arg)
+	}
+	return files
+}
+
+// techStack lists the languages edited or run at least stackMinUses times,
+// and the installed tools, from probing, run that often. Tools that are a
+// language's toolchain count toward the language.
+func techStack(histories map[string][]CommandEntry, installed map[string]string, edits EditUsage) []string {
+	stack := make(map[string]bool)
+	for lang, count := range edits.Edited {
+		if count+edits.Run[lang] >= stackMinUses {
+			stack[lang] = true
+		}
+	}
+	for lang, count := range edits.Run {
+		if count+edits.Edited[lang] >= stackMinUses {
+			stack[lang] = true
+		}
+	}
+
+	toolchains := make(map[string]bool)
+	for _, cluster := range skillClusters {
+		if cluster.language {
+			for _, program := range cluster.programs {
+				toolchains[program] = true
+			}
+		}
+	}
+	tools := make(map[string]string)
+	for name := range installed {
+		if binary := probeBinary(name); !toolchains[binary] && !editorPrograms[binary] {
+			tools[binary] = name
+		}
+	}
+	runs := make(map[string]int)
+	for _, history := range histories {
+		for _, entry := range history {
+			for _, program := range commandPrograms(entry.Command) {
+				if name, ok := tools[program]; ok {
+					runs[name]++
+				}
+			}
+		}
+	}
+	for name, count := range runs {
+		if count >= stackMinUses {
+			stack[name] = true
+		}
+	}
+
+	result := make([]string, 0, len(stack))
+	for name := range stack {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,141 @@
+// internal/analyzer/entry_points.go
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// EntryPoint is a project task: a make, just or task target, or a script
+type EntryPoint struct {
+	// Runner is make, just or task, the interpreter a script was passed
+	// to, or "script" for a script run directly
+	Runner string
+	// Target is the target name, or the script path as typed
+	Target string
+	// Dir is the directory it was run in, see walkDirs
+	Dir   string
+	Count int
+}
+
+// Command returns the command line that runs the entry point
+func (e EntryPoint) Command() string {
+	if e.Runner == "script" {
+		return e.Target
+	}
+	return e.Runner + " " + e.Target
+}
+
+// defaultTarget stands for a runner invoked without a target
+const defaultTarget = "(default)"
+
+// runnerValueFlags are the options of each runner that take a value
+var runnerValueFlags = map[string]map[string]bool{
+	"make": {"-C": true, "-f": true, "-I": true, "-o": true, "-W": true, "--directory": true, "--file": true, "--makefile": true},
+	"just": {"-f": true, "-d": true, "--justfile": true, "--working-directory": true, "--set": true, "--shell": true, "--dotenv-path": true},
+	"task": {"-d": true, "-t": true, "--dir": true, "--taskfile": true, "-o": true, "--output": true, "-c": true, "--color": true},
+}
+
+// scriptExtensions are the file types counted as project scripts
+var scriptExtensions = map[string]bool{".sh": true, ".bash": true, ".zsh": true, ".py": true, ".rb": true, ".pl": true}
+
+// EntryPoints returns the n most run project entry points. Each target run
+// counts once per command line, so `make clean build` counts both.
+func EntryPoints(data ShellData, n int) []EntryPoint {
+	counts := make(map[EntryPoint]int)
+	walkDirs(data.Histories, func(entry CommandEntry, cwd string, _ bool) {
+		for _, point := range entryPoints(strings.Fields(entry.Command)) {
+			point.Dir = cwd
+			counts[point]++
+		}
+	})
+
+	points := make([]EntryPoint, 0, len(counts))
+	for point, count := range counts {
+		point.Count = count
+		points = append(points, point)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Count != points[j].Count {
+			return points[i].Count > points[j].Count
+		}
+		if points[i].Dir != points[j].Dir {
+			return points[i].Dir < points[j].Dir
+		}
+		return points[i].Command() < points[j].Command()
+	})
+	if n > 0 && len(points) > n {
+		points = points[:n]
+	}
+	return points
+}
+
+// entryPoints parses the targets or script a command line runs
+func entryPoints(words []string) []EntryPoint {
+	if len(words) > 0 && words[0] == "sudo" {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	runner := words[0]
+	if flags, ok := runnerValueFlags[runner]; ok {
+		var targets []string
+		for i := 1; i < len(words); i++ {
+			word := words[i]
+			switch {
+			case strings.HasPrefix(word, "-"):
+				if flags[word] {
+					i++
+				}
+			case strings.Contains(word, "="):
+				// make VAR=value, or just/task variable assignments
+			default:
+				targets = append(targets, word)
+			}
+			// just passes everything after the recipe to it as arguments
+			if runner == "just" && len(targets) == 1 {
+				break
+			}
+		}
+		if len(targets) == 0 {
+			targets = []string{defaultTarget}
+		}
+		points := make([]EntryPoint, len(targets))
+		for i, target := range targets {
+			points[i] = EntryPoint{Runner: runner, Target: target}
+		}
+		return points
+	}
+
+	// ./scripts/deploy.sh, or bash scripts/deploy.sh
+	script := runner
+	switch runner {
+	case "sh", "bash", "zsh", "python", "python3", "ruby", "perl":
+		if len(words) < 2 {
+			return nil
+		}
+		script = words[1]
+	default:
+		if !strings.Contains(script, "/") {
+			return nil
+		}
+		runner = "script"
+	}
+	if !scriptExtensions[path.Ext(script)] {
+		return nil
+	}
+	return []EntryPoint{{Runner: runner, Target: script}}
+}
+
+// projectEntryCommands counts the command lines that run entry points,
+// for alias proposals
+func projectEntryCommands(data ShellData) map[string]int {
+	counts := make(map[string]int)
+	for _, point := range EntryPoints(data, 0) {
+		counts[point.Command()] += point.Count
+	}
+	return counts
+}
@@ -0,0 +1,150 @@
+// internal/analyzer/fish_abbr.go
+package analyzer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// abbrValueFlags are the abbr options that take a value
+var abbrValueFlags = map[string]bool{
+	"-p": true, "--position": true, "-c": true, "--command": true,
+}
+
+// parseAbbr reads an `abbr -a name expansion` line, or a _fish_abbr_name
+// universal variable from fish_variables. Abbreviations expanded by a
+// function or matched by a regex have no fixed expansion and are skipped.
+func parseAbbr(line string) (name, expansion string, ok bool) {
+	line = strings.TrimSpace(line)
+	if rest, found := strings.CutPrefix(line, "SETUVAR _fish_abbr_"); found {
+		name, value, found := strings.Cut(rest, ":")
+		if !found || name == "" {
+			return "", "", false
+		}
+		return name, unescapeFishVariable(value), true
+	}
+
+	words := splitWords(line)
+	if len(words) < 3 || words[0] != "abbr" {
+		return "", "", false
+	}
+	var args []string
+	for i := 1; i < len(words); i++ {
+		word := words[i]
+		switch {
+		case word == "--":
+			args = append(args, words[i+1:]...)
+			i = len(words)
+		case word == "-a" || word == "--add" || word == "-g" || word == "--global" ||
+			word == "-U" || word == "--universal" || strings.HasPrefix(word, "--set-cursor") ||
+			strings.HasPrefix(word, "--position=") || strings.HasPrefix(word, "--command="):
+		case abbrValueFlags[word]:
+			i++
+		case strings.HasPrefix(word, "-") && len(args) == 0:
+			// --erase, --list, --function, --regex and the like
+			return "", "", false
+		default:
+			args = append(args, word)
+		}
+	}
+	if len(args) < 2 {
+		return "", "", false
+	}
+	return args[0], strings.Join(args[1:], " "), true
+}
+
+// splitWords splits a line into words the way fish would, honoring quotes
+// and backslash escapes, and stops at a comment
+func splitWords(line string) []string {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case r == '#' && !inWord:
+			return words
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// unescapeFishVariable decodes a fish_variables value, where spaces and
+// other special characters are written as \xHH
+func unescapeFishVariable(value string) string {
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			if value[i+1] == 'x' && i+3 < len(value) {
+				if b, err := strconv.ParseUint(value[i+2:i+4], 16, 8); err == nil {
+					out.WriteByte(byte(b))
+					i += 3
+					continue
+				}
+			}
+			out.WriteByte(value[i+1])
+			i++
+			continue
+		}
+		out.WriteByte(value[i])
+	}
+	return out.String()
+}
+
+// FishAbbreviations counts how often each fish abbreviation was used, most
+// used first. Fish saves the expanded command, so a use is an entry that
+// contains the expansion as whole words, which also covers abbreviations
+// expanded anywhere on the line, or that starts with the unexpanded name.
+func FishAbbreviations(data ShellData) []ShortcutUsage {
+	config := data.ShellConfigs["fish"]
+	uses := abbreviationUses(config, data.Histories["fish"])
+	var usage []ShortcutUsage
+	for name := range config.Abbreviations {
+		usage = append(usage, ShortcutUsage{Name: name, Expansion: config.Aliases[name], Uses: uses[name]})
+	}
+	sortShortcuts(usage)
+	return usage
+}
+
+// abbreviationUses counts the history entries using each abbreviation
+func abbreviationUses(config ShellConfig, history []CommandEntry) map[string]int {
+	uses := make(map[string]int)
+	if len(config.Abbreviations) == 0 {
+		return uses
+	}
+	for _, entry := range history {
+		padded := " " + strings.Join(strings.Fields(entry.Command), " ") + " "
+		for name := range config.Abbreviations {
+			if strings.Contains(padded, " "+config.Aliases[name]+" ") || runsCommand(entry.Command, name) {
+				uses[name]++
+			}
+		}
+	}
+	return uses
+}
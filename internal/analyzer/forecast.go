@@ -0,0 +1,97 @@
+// internal/analyzer/forecast.go
+package analyzer
+
+import "time"
+
+const (
+	// forecastWeeks is how many recent weeks the trend is fitted to
+	forecastWeeks = 12
+	// forecastHorizon drops milestones that are too far out to be meaningful
+	forecastHorizon = 2 * 365 * 24 * time.Hour
+)
+
+// Forecast projects when a program will reach its next milestone if it
+// keeps being used at the recent rate
+type Forecast struct {
+	Command    string
+	Total      int
+	WeeklyRate float64
+	Milestone  int
+	ETA        time.Time
+}
+
+// Forecasts fits a line to the cumulative weekly runs of the n most run
+// programs over the forecastWeeks before now. Programs that were not used
+// recently, or whose next milestone lies beyond forecastHorizon, are left out.
+func Forecasts(data ShellData, n int, now time.Time) []Forecast {
+	top := SortedCounts(data.CommonCmds, n)
+	start := now.Add(-forecastWeeks * 7 * 24 * time.Hour)
+
+	weekly := make(map[string][]int, len(top))
+	for _, cc := range top {
+		weekly[cc.Command] = make([]int, forecastWeeks)
+	}
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			if entry.Timestamp.Before(start) || !entry.Timestamp.Before(now) {
+				continue
+			}
+			if weeks, ok := weekly[commandProgram(entry.Command)]; ok {
+				weeks[int(entry.Timestamp.Sub(start)/(7*24*time.Hour))]++
+			}
+		}
+	}
+
+	var forecasts []Forecast
+	for _, cc := range top {
+		rate := cumulativeSlope(weekly[cc.Command])
+		if rate <= 0 {
+			continue
+		}
+		milestone := nextMilestone(cc.Count)
+		wait := time.Duration(float64(milestone-cc.Count) / rate * float64(7*24*time.Hour))
+		if wait > forecastHorizon {
+			continue
+		}
+		forecasts = append(forecasts, Forecast{
+			Command:    cc.Command,
+			Total:      cc.Count,
+			WeeklyRate: rate,
+			Milestone:  milestone,
+			ETA:        now.Add(wait),
+		})
+	}
+	return forecasts
+}
+
+// cumulativeSlope returns the least-squares slope of the running total of
+// counts, i.e. the trend in runs per week
+func cumulativeSlope(counts []int) float64 {
+	n := float64(len(counts))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	total := 0
+	for i, count := range counts {
+		total += count
+		x, y := float64(i), float64(total)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
+
+// nextMilestone returns the first round number above total: 100, 250, 500,
+// 1000, 2500, 5000, ...
+func nextMilestone(total int) int {
+	for base := 100; ; base *= 10 {
+		for _, milestone := range []int{base, base * 5 / 2, base * 5} {
+			if milestone > total {
+				return milestone
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// internal/analyzer/functions.go
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// shFunction matches the first line of a bash or zsh function, in either
+// the `name() {` or the `function name {` form
+var shFunction = regexp.MustCompile(`^(\s*)(?:function\s+([\w.:-]+)\s*(?:\(\)\s*)?|([\w.:-]+)\s*\(\)\s*)(\{.*)?$`)
+
+// parseFunctions records the functions defined in an rc file with their
+// bodies. Fish files hold `function name ... end` blocks, the others
+// `name() { ... }`; a block that never closes is dropped.
+func parseFunctions(path, content string, config *ShellConfig) {
+	fish := strings.HasSuffix(path, ".fish")
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		var name, indent, end string
+		if fish {
+			words := splitWords(lines[i])
+			if len(words) < 2 || words[0] != "function" {
+				continue
+			}
+			name = words[1]
+			indent = lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+			end = "end"
+		} else {
+			m := shFunction.FindStringSubmatch(lines[i])
+			if m == nil {
+				continue
+			}
+			name, indent, end = m[2]+m[3], m[1], "}"
+			brace := strings.TrimSpace(m[4])
+			if brace == "" {
+				// The brace on a line of its own
+				if i+1 == len(lines) || strings.TrimSpace(lines[i+1]) != "{" {
+					continue
+				}
+				i++
+			} else if inner, ok := strings.CutSuffix(brace, "}"); ok {
+				config.Functions[name] = indent + "  " + strings.TrimSuffix(strings.TrimSpace(inner[1:]), ";")
+				continue
+			} else if rest := strings.TrimSpace(brace[1:]); rest != "" {
+				lines[i] = indent + "  " + rest
+				i--
+			}
+		}
+
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == end && strings.HasPrefix(lines[j], indent) &&
+				len(lines[j])-len(strings.TrimLeft(lines[j], " \t")) == len(indent) {
+				config.Functions[name] = strings.Join(lines[i+1:j], "\n")
+				i = j
+				break
+			}
+		}
+	}
+}
+
+// parseFishSet reads an exported variable from a fish `set -gx NAME value`
+// line, or a SETUVAR --export line of fish_variables
+func parseFishSet(line string) (name, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if rest, found := strings.CutPrefix(line, "SETUVAR --export "); found {
+		name, value, found := strings.Cut(rest, ":")
+		// Lists are separated by \x1e
+		return name, strings.ReplaceAll(unescapeFishVariable(value), "\x1e", " "), found && name != ""
+	}
+
+	words := splitWords(line)
+	if len(words) < 3 || words[0] != "set" {
+		return "", "", false
+	}
+	exported := false
+	for i, word := range words[1:] {
+		switch {
+		case word == "--export" || (strings.HasPrefix(word, "-") && !strings.HasPrefix(word, "--") && strings.Contains(word, "x")):
+			exported = true
+		case strings.HasPrefix(word, "-"):
+		default:
+			return word, strings.Join(words[i+2:], " "), exported
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,320 @@
+// internal/analyzer/git.go
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// GitUsage is how git is used from the shell
+type GitUsage struct {
+	// Commands counts the git invocations and Subcommands each subcommand
+	Commands    int
+	Subcommands map[string]int
+	// PullRebases counts the pulls with --rebase, which rebase rather than
+	// merge like the others
+	PullRebases int
+	// Branches are the branch names typed, most often first
+	Branches []CommandCount
+	// ForcePushes counts pushes with --force or -f, and LeasePushes the
+	// safer --force-with-lease
+	ForcePushes int
+	LeasePushes int
+	// Stash counts the stash subcommands, "push" for a plain git stash
+	Stash map[string]int
+	// Reflogs are the local repositories' reflogs, read when
+	// Options.GitReflogs is set
+	Reflogs []RepoReflog
+}
+
+// RepoReflog counts what the reflog of a repository recorded
+type RepoReflog struct {
+	Dir       string
+	Commits   int
+	Amends    int
+	Checkouts int
+	Rebases   int
+	Merges    int
+	Resets    int
+	Last      time.Time
+}
+
+// Ratio returns the runs of subcommand per commit, or 0 without commits
+func (g GitUsage) Ratio(subcommand string) float64 {
+	if g.Subcommands["commit"] == 0 {
+		return 0
+	}
+	return float64(g.Subcommands[subcommand]) / float64(g.Subcommands["commit"])
+}
+
+// Merges counts the merges: git merge and the pulls without --rebase
+func (g GitUsage) Merges() int {
+	return g.Subcommands["merge"] + g.Subcommands["pull"] - g.PullRebases
+}
+
+// Rebases counts the rebases: git rebase and git pull --rebase
+func (g GitUsage) Rebases() int {
+	return g.Subcommands["rebase"] + g.PullRebases
+}
+
+// Preference returns "merge" or "rebase", whichever is used at least twice
+// as often as the other, or "" when neither stands out
+func (g GitUsage) Preference() string {
+	merges, rebases := g.Merges(), g.Rebases()
+	switch {
+	case merges == 0 && rebases == 0:
+		return ""
+	case merges >= 2*rebases:
+		return "merge"
+	case rebases >= 2*merges:
+		return "rebase"
+	}
+	return ""
+}
+
+const (
+	// gitBranchesShown caps the branch names
+	gitBranchesShown = 10
+	// gitReflogsShown caps the repositories whose reflog is read
+	gitReflogsShown = 20
+)
+
+// gitValueOptions are the global options of git that take a value
+var gitValueOptions = map[string]bool{"-C": true, "-c": true, "--git-dir": true, "--work-tree": true, "--namespace": true}
+
+// gitBranchWord matches what can be a branch name rather than a path,
+// revision expression or commit hash
+var (
+	gitBranchWord = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+	gitHash       = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+// gitBranchArgs is which positional argument of a subcommand is a branch:
+// the first, or for push the second after the remote
+var gitBranchArgs = map[string]int{"checkout": 0, "switch": 0, "merge": 0, "rebase": 0, "branch": 0, "push": 1}
+
+// AnalyzeGit reads the git commands of every part of each command line,
+// and the reflogs of the projects when opts.GitReflogs is set
+func AnalyzeGit(data ShellData) GitUsage {
+	usage := GitUsage{Subcommands: make(map[string]int), Stash: make(map[string]int)}
+	branches := make(map[string]int)
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			for _, fields := range commandSegments(entry.Command) {
+				if filepath.Base(fields[0]) == "git" {
+					addGitCommand(&usage, branches, fields[1:])
+				}
+			}
+		}
+	}
+	usage.Branches = SortedCounts(branches, gitBranchesShown)
+
+	if data.Options.GitReflogs {
+		usage.Reflogs = readReflogs(data.Insights.Projects, data.Options)
+	}
+	return usage
+}
+
+// commandSegments returns the words of every part of a pipeline or chain
+// that has any, without a leading sudo
+func commandSegments(command string) [][]string {
+	var segments [][]string
+	for _, segment := range strings.FieldsFunc(command, func(r rune) bool {
+		return r == '|' || r == ';' || r == '&'
+	}) {
+		fields := strings.Fields(segment)
+		if len(fields) > 1 && fields[0] == "sudo" {
+			fields = fields[1:]
+		}
+		if len(fields) > 0 {
+			segments = append(segments, fields)
+		}
+	}
+	return segments
+}
+
+// addGitCommand counts one git invocation given its arguments
+func addGitCommand(usage *GitUsage, branches map[string]int, args []string) {
+	// Skip the global options before the subcommand
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		if gitValueOptions[args[i]] {
+			i++
+		}
+		i++
+	}
+	if i >= len(args) {
+		return
+	}
+	subcommand := args[i]
+	usage.Commands++
+	usage.Subcommands[subcommand]++
+
+	var positional, flags []string
+	for _, arg := range args[i+1:] {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			flags = append(flags, arg)
+		} else {
+			positional = append(positional, arg)
+		}
+	}
+	hasFlag := func(names ...string) bool {
+		for _, flag := range flags {
+			name, _, _ := strings.Cut(flag, "=")
+			for _, n := range names {
+				if name == n {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	switch subcommand {
+	case "push":
+		if hasFlag("--force-with-lease") {
+			usage.LeasePushes++
+		} else if hasFlag("--force", "-f") || (len(positional) > 1 && strings.HasPrefix(positional[1], "+")) {
+			usage.ForcePushes++
+		}
+	case "pull":
+		if hasFlag("--rebase", "-r") {
+			usage.PullRebases++
+		}
+	case "stash":
+		action := "push"
+		if len(positional) > 0 {
+			action = positional[0]
+		}
+		usage.Stash[action]++
+	}
+
+	if n, ok := gitBranchArgs[subcommand]; ok && n < len(positional) {
+		branch := strings.TrimPrefix(positional[n], "+")
+		if branch == "HEAD" || branch == "." || !gitBranchWord.MatchString(branch) || gitHash.MatchString(branch) {
+			return
+		}
+		// A file checked out from the index is not a branch
+		if subcommand == "checkout" && strings.Contains(path.Base(branch), ".") {
+			return
+		}
+		branches[branch]++
+	}
+}
+
+// readReflogs reads the HEAD reflog of the repositories the projects are
+// in, most commits first
+func readReflogs(projects ProjectUsage, opts Options) []RepoReflog {
+	home, err := utils.HomeDir()
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var reflogs []RepoReflog
+	for _, project := range projects.Projects {
+		if len(seen) == gitReflogsShown {
+			break
+		}
+		dir, gitDir, ok := findGitDir(home, project.Dir)
+		if !ok || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if reflog, ok := readReflog(filepath.Join(gitDir, "logs", "HEAD"), opts); ok {
+			reflog.Dir = dir
+			reflogs = append(reflogs, reflog)
+		}
+	}
+	sort.SliceStable(reflogs, func(i, j int) bool {
+		return reflogs[i].Commits > reflogs[j].Commits
+	})
+	return reflogs
+}
+
+// findGitDir returns the repository a directory in ~/ form is in, in ~/
+// form, and its git directory. A .git file, as in worktrees and
+// submodules, points at the git directory.
+func findGitDir(home, dir string) (string, string, bool) {
+	for strings.HasPrefix(dir, "~/") {
+		full := filepath.Join(home, filepath.FromSlash(strings.TrimPrefix(dir, "~/")))
+		dotGit := filepath.Join(full, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			if info.IsDir() {
+				return dir, dotGit, true
+			}
+			if content, err := os.ReadFile(dotGit); err == nil {
+				if target, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: "); ok {
+					if !filepath.IsAbs(target) {
+						target = filepath.Join(full, target)
+					}
+					return dir, target, true
+				}
+			}
+		}
+		dir = path.Dir(dir)
+	}
+	return "", "", false
+}
+
+// readReflog counts the entries of a reflog within the analyzed period.
+// Each line is "<old> <new> <name> <email> <seconds> <zone>\t<message>".
+func readReflog(file string, opts Options) (RepoReflog, bool) {
+	f, err := os.Open(file)
+	if err != nil {
+		return RepoReflog{}, false
+	}
+	defer f.Close()
+
+	var reflog RepoReflog
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		header, message, ok := strings.Cut(scanner.Text(), "\t")
+		fields := strings.Fields(header)
+		if !ok || len(fields) < 2 {
+			continue
+		}
+		seconds, err := strconv.ParseInt(fields[len(fields)-2], 10, 64)
+		if err != nil {
+			continue
+		}
+		when := time.Unix(seconds, 0)
+		if !opts.InRange(when) {
+			continue
+		}
+		action, _, _ := strings.Cut(message, ":")
+		switch {
+		case action == "commit (amend)":
+			reflog.Amends++
+		case strings.HasPrefix(action, "commit"):
+			reflog.Commits++
+		case action == "checkout":
+			reflog.Checkouts++
+		case strings.HasSuffix(action, "(start)"):
+			// A rebase, also one by pull --rebase, logs every picked
+			// commit; count where it started
+			reflog.Rebases++
+		case strings.Contains(action, "rebase"):
+		case strings.HasPrefix(action, "merge"), strings.HasPrefix(action, "pull"):
+			reflog.Merges++
+		case action == "reset":
+			reflog.Resets++
+		}
+		if when.After(reflog.Last) {
+			reflog.Last = when
+		}
+	}
+	return reflog, true
+}
@@ -0,0 +1,97 @@
+// internal/analyzer/growth.go
+package analyzer
+
+const (
+	// growthNext caps the skills recommended and growthTools the tools
+	growthNext  = 3
+	growthTools = 5
+)
+
+// growthPath lists the skills usually picked up after a skill and the
+// tools worth knowing for it
+type growthPath struct {
+	next  []string
+	tools []string
+}
+
+// growthPaths maps each skill of skillClusters to its path
+var growthPaths = map[string]growthPath{
+	"kubernetes":     {next: []string{"infrastructure", "cloud", "networking"}, tools: []string{"k9s", "stern", "kustomize", "helm", "kubectx"}},
+	"containers":     {next: []string{"kubernetes", "ci", "security"}, tools: []string{"dive", "lazydocker", "trivy", "skopeo"}},
+	"ci":             {next: []string{"infrastructure", "containers", "security"}, tools: []string{"act", "gh", "glab"}},
+	"infrastructure": {next: []string{"cloud", "kubernetes", "security"}, tools: []string{"terragrunt", "tflint", "infracost", "vault"}},
+	"cloud":          {next: []string{"infrastructure", "kubernetes", "networking"}, tools: []string{"terraform", "steampipe", "granted"}},
+	"databases":      {next: []string{"data", "go", "debugging"}, tools: []string{"pgcli", "litecli", "duckdb", "usql"}},
+	"debugging":      {next: []string{"networking", "c", "rust"}, tools: []string{"bpftrace", "rr", "perf", "hyperfine"}},
+	"networking":     {next: []string{"security", "kubernetes", "debugging"}, tools: []string{"mtr", "tshark", "iperf3", "dog"}},
+	"security":       {next: []string{"networking", "ci", "infrastructure"}, tools: []string{"semgrep", "trivy", "age", "gitleaks"}},
+	"data":           {next: []string{"databases", "python", "cloud"}, tools: []string{"duckdb", "dbt", "qsv", "visidata"}},
+
+	"python":     {next: []string{"data", "containers", "rust"}, tools: []string{"uv", "ruff", "pytest", "mypy", "ipython"}},
+	"go":         {next: []string{"containers", "kubernetes", "debugging"}, tools: []string{"golangci-lint", "dlv", "goreleaser", "air"}},
+	"rust":       {next: []string{"debugging", "containers", "c"}, tools: []string{"rust-analyzer", "cargo-nextest", "bacon", "hyperfine"}},
+	"javascript": {next: []string{"containers", "ci", "databases"}, tools: []string{"pnpm", "bun", "tsc", "eslint", "vitest"}},
+	"java":       {next: []string{"containers", "ci", "kubernetes"}, tools: []string{"gradle", "jshell", "jbang", "sdk"}},
+	"ruby":       {next: []string{"databases", "containers", "ci"}, tools: []string{"rubocop", "rspec", "solargraph"}},
+	"php":        {next: []string{"databases", "containers", "javascript"}, tools: []string{"composer", "phpunit", "phpstan"}},
+	"c":          {next: []string{"debugging", "rust", "security"}, tools: []string{"gdb", "valgrind", "cmake", "clang-tidy"}},
+}
+
+// Growth recommends what to learn next from the skills the history shows
+type Growth struct {
+	// Skills are the skills the recommendations build on, most confident
+	// first
+	Skills []Skill
+	// Next are skills that usually follow them and the history does not
+	// show yet
+	Next []NextSkill
+	// Tools are programs that go with the skills and were never run
+	Tools []NextTool
+}
+
+// NextSkill is a skill to pick up, following from one the user has
+type NextSkill struct {
+	Skill Skill
+	After Skill
+}
+
+// NextTool is a program worth trying for a skill the user has
+type NextTool struct {
+	Program string
+	For     Skill
+}
+
+// GrowthPlan maps the skills of the technical profile to the skills and
+// tools to learn next. It is empty when the history shows no skill.
+func GrowthPlan(data ShellData) Growth {
+	profile := data.Insights.TechnicalProfile
+	var growth Growth
+	if profile.PrimarySkill.Name != "" {
+		growth.Skills = append(growth.Skills, profile.PrimarySkill)
+	}
+	growth.Skills = append(growth.Skills, profile.SecondarySkills...)
+
+	has := make(map[string]bool)
+	for _, skill := range growth.Skills {
+		has[skill.Name] = true
+	}
+	suggested, listed := make(map[string]bool), make(map[string]bool)
+	for _, skill := range growth.Skills {
+		for _, name := range growthPaths[skill.Name].next {
+			if len(growth.Next) < growthNext && !has[name] && !suggested[name] {
+				suggested[name] = true
+				growth.Next = append(growth.Next, NextSkill{
+					Skill: Skill{Name: name, Language: skillClusters[name].language},
+					After: skill,
+				})
+			}
+		}
+		for _, program := range growthPaths[skill.Name].tools {
+			if len(growth.Tools) < growthTools && data.CommonCmds[program] == 0 && !listed[program] {
+				listed[program] = true
+				growth.Tools = append(growth.Tools, NextTool{Program: program, For: skill})
+			}
+		}
+	}
+	return growth
+}
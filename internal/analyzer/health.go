@@ -0,0 +1,223 @@
+// internal/analyzer/health.go
+package analyzer
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigIssue is a problem found in a startup file, and what to do about it
+type ConfigIssue struct {
+	Kind string
+	// Location is the offending line; Line is 0 for a whole file
+	Location
+	// Subject is what the issue is about: the directory, the deprecated
+	// syntax, the command run without a guard, the number of lines or the
+	// plugin
+	Subject string
+	// Since is when a stale plugin was last updated
+	Since time.Time
+	// Previous points at the earlier line a duplicate repeats
+	Previous Location
+}
+
+// Kinds of ConfigIssue
+const (
+	IssueDuplicatePath    = "duplicate_path"
+	IssueInteractiveGuard = "interactive_guard"
+	IssueDeprecated       = "deprecated"
+	IssueEnormous         = "enormous"
+	IssueUnusedPlugin     = "unused_plugin"
+	IssueStalePlugin      = "stale_plugin"
+)
+
+// enormousConfigLines is the size, in lines of code, from which a startup
+// file is worth splitting up
+const enormousConfigLines = 300
+
+// deprecatedSyntax lists old syntax per shell, by Subject
+var deprecatedSyntax = map[string][]struct {
+	subject string
+	pattern *regexp.Regexp
+}{
+	"bash": {
+		{"backticks", regexp.MustCompile("`[^`]+`")},
+		{"dollar_bracket", regexp.MustCompile(`\$\[`)},
+		{"egrep", regexp.MustCompile(`(^|[\s|;(])egrep\b`)},
+		{"fgrep", regexp.MustCompile(`(^|[\s|;(])fgrep\b`)},
+	},
+	"zsh": {
+		{"backticks", regexp.MustCompile("`[^`]+`")},
+		{"dollar_bracket", regexp.MustCompile(`\$\[`)},
+		{"egrep", regexp.MustCompile(`(^|[\s|;(])egrep\b`)},
+		{"fgrep", regexp.MustCompile(`(^|[\s|;(])fgrep\b`)},
+	},
+	"fish": {
+		{"caret", regexp.MustCompile(`\s\^(/|&|>)`)},
+		{"dot", regexp.MustCompile(`^\.\s`)},
+		{"egrep", regexp.MustCompile(`(^|[\s|;(])egrep\b`)},
+		{"fgrep", regexp.MustCompile(`(^|[\s|;(])fgrep\b`)},
+	},
+}
+
+var (
+	// pathAssignment matches the lines that set PATH in bash and zsh, and
+	// fishPathAssignment those that do in fish
+	pathAssignment     = regexp.MustCompile(`^(?:export\s+|typeset\s+-x\s+|declare\s+-x\s+)?PATH=(.*)$`)
+	fishPathAssignment = regexp.MustCompile(`^(?:set\s+(?:-[a-zA-Z]+\s+)*PATH|fish_add_path(?:\s+-[a-zA-Z]+)*)\s+(.*)$`)
+)
+
+// interactiveGuards are the ways a bashrc or config.fish stops when the
+// shell is not interactive
+var interactiveGuards = []string{"$-", "$PS1", "status is-interactive", "status --is-interactive", "status -i"}
+
+// interactiveOnly are the commands that print or expect a terminal, out of
+// place in a shell that runs a script or an scp
+var interactiveOnly = map[string]bool{
+	"echo": true, "printf": true, "bind": true, "stty": true, "tput": true, "fortune": true,
+	"neofetch": true, "fastfetch": true, "cowsay": true, "figlet": true, "abbr": true,
+}
+
+// pluginPrograms are the programs a plugin is of use with; plugins not
+// listed cannot be told unused
+var pluginPrograms = map[string][]string{
+	"git": {"git"}, "docker": {"docker"}, "docker-compose": {"docker-compose", "docker"},
+	"kubectl": {"kubectl"}, "helm": {"helm"}, "terraform": {"terraform"}, "aws": {"aws"},
+	"gcloud": {"gcloud"}, "azure": {"az"}, "npm": {"npm"}, "yarn": {"yarn"}, "node": {"node"},
+	"python": {"python", "python3"}, "pip": {"pip", "pip3"}, "golang": {"go"}, "rust": {"cargo", "rustc"},
+	"ruby": {"ruby"}, "rails": {"rails"}, "bundler": {"bundle"}, "composer": {"composer"},
+	"brew": {"brew"}, "tmux": {"tmux"}, "fzf": {"fzf"}, "ansible": {"ansible", "ansible-playbook"},
+	"vagrant": {"vagrant"}, "minikube": {"minikube"}, "gradle": {"gradle", "gradlew"},
+	"mvn": {"mvn"}, "heroku": {"heroku"}, "systemd": {"systemctl", "journalctl"},
+}
+
+// lintConfig checks the startup files and plugins of a shell. history
+// tells the plugins in use from the unused ones.
+func lintConfig(shell string, config ShellConfig, history []CommandEntry, now time.Time) []ConfigIssue {
+	var issues []ConfigIssue
+	paths := make(map[string]Location)
+	for _, key := range SortedKeys(config.ConfigFiles) {
+		info := config.ConfigFiles[key]
+		if info.Content == "" {
+			continue
+		}
+		guarded := false
+		var unguarded Location
+		var command string
+		for i, line := range strings.Split(info.Content, "\n") {
+			here := Location{Path: info.Path, Line: i + 1}
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			// PATH entries added more than once
+			for _, dir := range pathEntries(shell, line) {
+				if previous, ok := paths[dir]; ok {
+					issues = append(issues, ConfigIssue{Kind: IssueDuplicatePath, Location: here, Subject: dir, Previous: previous})
+				} else {
+					paths[dir] = here
+				}
+			}
+
+			for _, syntax := range deprecatedSyntax[shell] {
+				if syntax.pattern.MatchString(line) {
+					issues = append(issues, ConfigIssue{Kind: IssueDeprecated, Location: here, Subject: syntax.subject})
+				}
+			}
+
+			for _, guard := range interactiveGuards {
+				if strings.Contains(line, guard) {
+					guarded = true
+				}
+			}
+			if fields := strings.Fields(line); !guarded && unguarded.Line == 0 && interactiveOnly[fields[0]] {
+				unguarded, command = here, fields[0]
+			}
+		}
+
+		// Only the bashrc and config.fish run for shells that are not
+		// interactive; a zshrc never does
+		if base := filepath.Base(info.Path); unguarded.Line != 0 && (base == ".bashrc" || base == "config.fish") {
+			issues = append(issues, ConfigIssue{Kind: IssueInteractiveGuard, Location: unguarded, Subject: command})
+		}
+		if lines := configLines(info.Content); lines >= enormousConfigLines {
+			issues = append(issues, ConfigIssue{Kind: IssueEnormous, Location: Location{Path: info.Path}, Subject: strconv.Itoa(lines)})
+		}
+	}
+
+	// Listed plugins for programs that are never run, and listed plugins
+	// not updated in a long time
+	run := make(map[string]bool)
+	for _, entry := range history {
+		for _, program := range commandPrograms(entry.Command) {
+			run[program] = true
+		}
+	}
+	for _, plugin := range config.Plugins {
+		if plugin.Defined.Line == 0 {
+			continue
+		}
+		if plugin.Stale(now) {
+			issues = append(issues, ConfigIssue{Kind: IssueStalePlugin, Location: plugin.Defined, Subject: plugin.Name, Since: plugin.LastUpdated})
+		}
+		programs, ok := pluginPrograms[filepath.Base(plugin.Name)]
+		if !ok || len(history) == 0 {
+			continue
+		}
+		used := false
+		for _, program := range programs {
+			used = used || run[program]
+		}
+		if !used {
+			issues = append(issues, ConfigIssue{Kind: IssueUnusedPlugin, Location: plugin.Defined, Subject: plugin.Name})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Path != issues[j].Path {
+			return issues[i].Path < issues[j].Path
+		}
+		return issues[i].Line < issues[j].Line
+	})
+	return issues
+}
+
+// pathEntries returns the directories a line adds to PATH, with $HOME
+// written as ~, leaving out PATH itself
+func pathEntries(shell, line string) []string {
+	var entries []string
+	if shell == "fish" {
+		m := fishPathAssignment.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		entries = strings.Fields(m[1])
+	} else {
+		m := pathAssignment.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		entries = strings.Split(strings.Trim(m[1], `"'`), ":")
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		entry = strings.Trim(entry, `"'`)
+		switch entry {
+		case "", "$PATH", "${PATH}", "$path":
+			continue
+		}
+		for _, home := range []string{"$HOME", "${HOME}"} {
+			if rest, ok := strings.CutPrefix(entry, home); ok {
+				entry = "~" + rest
+			}
+		}
+		dirs = append(dirs, strings.TrimSuffix(entry, "/"))
+	}
+	return dirs
+}
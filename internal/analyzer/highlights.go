@@ -0,0 +1,138 @@
+// internal/analyzer/highlights.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Highlights contains headline statistics used for the Wrapped slides
+type Highlights struct {
+	TotalCommands  int
+	TopCommands    []CommandCount
+	LongestStreak  int
+	StreakStart    time.Time
+	ActiveDays     int
+	Typos          []Typo
+	BusiestDay     time.Time
+	BusiestDayRuns int
+	// LongestRun is the command line run the most times in a row
+	LongestRun CommandCount
+}
+
+// CommandCount pairs a command (or program name) with how often it was run
+type CommandCount struct {
+	Command string
+	Count   int
+}
+
+// ComputeHighlights derives deterministic headline statistics from the history.
+// Ties are broken alphabetically so the same input always gives the same output.
+func ComputeHighlights(data ShellData) Highlights {
+	var highlights Highlights
+
+	for _, count := range data.CommandCounts {
+		highlights.TotalCommands += count
+	}
+
+	highlights.TopCommands = SortedCounts(data.CommonCmds, 5)
+	highlights.Typos = DetectTypos(data)
+	if len(highlights.Typos) > 3 {
+		highlights.Typos = highlights.Typos[:3]
+	}
+
+	for _, shell := range SortedKeys(data.Histories) {
+		history := data.Histories[shell]
+		run := 0
+		for i, entry := range history {
+			if i > 0 && entry.Command == history[i-1].Command {
+				run++
+			} else {
+				run = 1
+			}
+			longest := highlights.LongestRun
+			if run > longest.Count || run == longest.Count && entry.Command < longest.Command {
+				highlights.LongestRun = CommandCount{Command: entry.Command, Count: run}
+			}
+		}
+	}
+
+	days, sortedDays := activeDays(data)
+	highlights.ActiveDays = len(days)
+
+	streak := 0
+	for i, day := range sortedDays {
+		if i > 0 && sortedDays[i-1].AddDate(0, 0, 1).Equal(day) {
+			streak++
+		} else {
+			streak = 1
+		}
+		if streak > highlights.LongestStreak {
+			highlights.LongestStreak = streak
+			highlights.StreakStart = day.AddDate(0, 0, 1-streak)
+		}
+		if days[day] > highlights.BusiestDayRuns {
+			highlights.BusiestDay = day
+			highlights.BusiestDayRuns = days[day]
+		}
+	}
+
+	return highlights
+}
+
+// activeDays counts the timestamped commands of each day and returns the
+// days in chronological order
+func activeDays(data ShellData) (map[time.Time]int, []time.Time) {
+	days := make(map[time.Time]int)
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			if !entry.Timestamp.IsZero() {
+				days[dayStart(entry.Timestamp)]++
+			}
+		}
+	}
+
+	sorted := make([]time.Time, 0, len(days))
+	for day := range days {
+		sorted = append(sorted, day)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+	return days, sorted
+}
+
+// commandProgram returns the program name of a command line, skipping sudo
+func commandProgram(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) > 1 && fields[0] == "sudo" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// SortedCounts returns up to limit entries ordered by count, then name
+func SortedCounts(counts map[string]int, limit int) []CommandCount {
+	result := make([]CommandCount, 0, len(counts))
+	for command, count := range counts {
+		result = append(result, CommandCount{Command: command, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Command < result[j].Command
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,70 @@
+// internal/analyzer/hooks.go
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/hooks"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// hookLogPath is where the hooks of an analyzed home, see utils.SetHome,
+// wrote their records
+const hookLogPath = "~/.local/share/k8au-shell-analyzer/hooks.jsonl"
+
+// hookKey identifies a command in both the hook log and the shell history
+type hookKey struct {
+	shell   string
+	command string
+	second  int64
+}
+
+// hookRecords reads the records of the shell hooks by command and second
+func hookRecords() map[hookKey]hooks.Record {
+	path := hooks.LogPath()
+	if home, err := utils.HomeDir(); err == nil {
+		if own, err := os.UserHomeDir(); err == nil && filepath.Clean(own) != home {
+			path = expandPath(hookLogPath)
+		}
+	}
+
+	records := make(map[hookKey]hooks.Record)
+	hooks.Read(path, func(rec hooks.Record) {
+		records[hookKey{rec.Shell, rec.Command, rec.Start.Unix()}] = rec
+	})
+	return records
+}
+
+// addHookRecords fills in the directory, duration and exit status of the
+// entries the shell hooks recorded too. Shells stamp their history at about
+// the time the hooks take the start, so a second either way still matches;
+// entries without a timestamp cannot be matched.
+func addHookRecords(histories map[string][]CommandEntry) {
+	records := hookRecords()
+	if len(records) == 0 {
+		return
+	}
+	for shell, history := range histories {
+		for i, entry := range history {
+			if entry.Timestamp.IsZero() {
+				continue
+			}
+			second := entry.Timestamp.Unix()
+			for _, s := range []int64{second, second + 1, second - 1} {
+				rec, ok := records[hookKey{shell, entry.Command, s}]
+				if !ok {
+					continue
+				}
+				if rec.Dir != "" {
+					history[i].Dir = rec.Dir
+				}
+				history[i].Duration = rec.Duration
+				history[i].Timed = true
+				history[i].Exit = rec.Exit
+				history[i].HasExit = true
+				break
+			}
+		}
+	}
+}
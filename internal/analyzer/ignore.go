@@ -0,0 +1,58 @@
+// internal/analyzer/ignore.go
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// ignorePatterns are the commands left out of every statistic
+var ignorePatterns []*regexp.Regexp
+
+// ignorePrefix marks an ignore pattern that is a command prefix rather than
+// a regular expression
+const ignorePrefix = "prefix:"
+
+// CompileIgnore turns an ignore pattern into a regular expression. A
+// "prefix:" pattern matches commands starting with its words, e.g.
+// "prefix:git status" matches "git status -s" but not "git stash"; any
+// other is a regular expression.
+func CompileIgnore(pattern string) (*regexp.Regexp, error) {
+	if prefix, ok := strings.CutPrefix(pattern, ignorePrefix); ok {
+		return regexp.Compile(`^\s*` + regexp.QuoteMeta(strings.TrimSpace(prefix)) + `(\s|$)`)
+	}
+	return regexp.Compile(pattern)
+}
+
+// SetIgnore leaves the commands matching any of patterns out of the
+// analysis, as if they were never run
+func SetIgnore(patterns []*regexp.Regexp) {
+	ignorePatterns = patterns
+}
+
+// AddIgnore leaves the commands matching patterns out too
+func AddIgnore(patterns []*regexp.Regexp) {
+	ignorePatterns = append(ignorePatterns[:len(ignorePatterns):len(ignorePatterns)], patterns...)
+}
+
+// ignoreChecksum identifies the ignore patterns in use, so histories counted
+// with other ones are counted again
+func ignoreChecksum() string {
+	hash := sha256.New()
+	for _, pattern := range ignorePatterns {
+		hash.Write([]byte(pattern.String() + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+// Ignored reports whether command matches an ignore pattern
+func Ignored(command string) bool {
+	for _, pattern := range ignorePatterns {
+		if pattern.MatchString(command) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+// internal/analyzer/incremental.go
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// historyCacheVersion changes whenever parsing, categorizing or counting
+// does, so aggregates cached by an older version are computed again
+const historyCacheVersion = 3
+
+// historyBucket holds the aggregates of the histories in the cache directory
+const historyBucket = "history"
+
+// checksumWindow is how much of the start and of the end of the parsed
+// part of a history file is hashed to notice it was rewritten
+const checksumWindow = 4096
+
+// monthLayout keys the monthly counts of historyAggregates
+const monthLayout = "2006-01"
+
+// historyFingerprint identifies how far a history file was counted
+type historyFingerprint struct {
+	Version int       `json:"version"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	// Offset is where the next run resumes, Checksum hashes the bytes
+	// around it and at the start of the file
+	Offset   int64  `json:"offset"`
+	Checksum string `json:"checksum"`
+	// Categories and Ignore identify the categories the entries were given
+	// and the commands left out; SampleSize how many entries were kept
+	Categories string `json:"categories"`
+	Ignore     string `json:"ignore"`
+	SampleSize int    `json:"sample_size"`
+}
+
+// historyAggregates are the counts a history file adds to ShellData, the
+// same loadEntries makes, and its most recent entries. They take the same
+// space whatever the length of the file.
+type historyAggregates struct {
+	Commands        int                       `json:"commands"`
+	Programs        map[string]int            `json:"programs,omitempty"`
+	Prefixes        map[string]int            `json:"prefixes,omitempty"`
+	Subcommands     map[string]map[string]int `json:"subcommands,omitempty"`
+	SubcommandFlags map[string]map[string]int `json:"subcommand_flags,omitempty"`
+	// Monthly counts the timestamped commands by month, see monthLayout
+	Monthly  map[string]int `json:"monthly,omitempty"`
+	Activity [7][24]int     `json:"activity"`
+	Recent   []CommandEntry `json:"recent,omitempty"`
+}
+
+// cachedHistory is a history file counted up to its fingerprint's offset
+type cachedHistory struct {
+	Fingerprint historyFingerprint `json:"fingerprint"`
+	Counts      historyAggregates  `json:"counts"`
+}
+
+// aggregateEntries aggregates the entries of shell as loadEntries does
+func aggregateEntries(shell string, entries []CommandEntry, opts Options) historyAggregates {
+	scratch := InitShellData()
+	monthly := make(map[time.Time]map[string]int)
+	// Low-memory mode makes loadEntries keep the sample alone
+	opts.LowMemory = true
+	recent, _ := loadEntries(shell, func(fn func(CommandEntry)) error {
+		for _, entry := range entries {
+			fn(entry)
+		}
+		return nil
+	}, opts, &scratch, monthly)
+
+	counts := historyAggregates{
+		Commands:        scratch.CommandCounts[shell],
+		Programs:        scratch.ShellCmds[shell],
+		Prefixes:        scratch.CommonPrefixes,
+		Subcommands:     scratch.Subcommands,
+		SubcommandFlags: scratch.SubcommandFlags,
+		Monthly:         make(map[string]int, len(monthly)),
+		Activity:        scratch.Insights.WorkPatterns.Activity,
+		Recent:          recent,
+	}
+	for month, shells := range monthly {
+		counts.Monthly[month.Format(monthLayout)] = shells[shell]
+	}
+	return counts
+}
+
+// add adds other to the aggregates, keeping the limit most recent entries
+func (a *historyAggregates) add(other historyAggregates, limit int) {
+	a.Commands += other.Commands
+	a.Programs = addCounts(a.Programs, other.Programs)
+	a.Prefixes = addCounts(a.Prefixes, other.Prefixes)
+	a.Subcommands = addNestedCounts(a.Subcommands, other.Subcommands)
+	a.SubcommandFlags = addNestedCounts(a.SubcommandFlags, other.SubcommandFlags)
+	a.Monthly = addCounts(a.Monthly, other.Monthly)
+	for day := range a.Activity {
+		for hour := range a.Activity[day] {
+			a.Activity[day][hour] += other.Activity[day][hour]
+		}
+	}
+	a.Recent = append(a.Recent, other.Recent...)
+	a.Recent = a.Recent[max(0, len(a.Recent)-limit):]
+}
+
+// addTo counts the aggregates of shell into data and monthly
+func (a *historyAggregates) addTo(shell string, data *ShellData, monthly map[time.Time]map[string]int) {
+	data.CommandCounts[shell] += a.Commands
+	data.CommonCmds = addCounts(data.CommonCmds, a.Programs)
+	data.ShellCmds[shell] = addCounts(data.ShellCmds[shell], a.Programs)
+	data.CommonPrefixes = addCounts(data.CommonPrefixes, a.Prefixes)
+	data.Subcommands = addNestedCounts(data.Subcommands, a.Subcommands)
+	data.SubcommandFlags = addNestedCounts(data.SubcommandFlags, a.SubcommandFlags)
+	for key, count := range a.Monthly {
+		month, err := time.ParseInLocation(monthLayout, key, time.Local)
+		if err != nil {
+			continue
+		}
+		if monthly[month] == nil {
+			monthly[month] = make(map[string]int)
+		}
+		monthly[month][shell] += count
+	}
+	activity := &data.Insights.WorkPatterns.Activity
+	for day := range activity {
+		for hour := range activity[day] {
+			activity[day][hour] += a.Activity[day][hour]
+		}
+	}
+}
+
+// addCounts adds the counts of src to dst, which is made when nil
+func addCounts(dst, src map[string]int) map[string]int {
+	if dst == nil {
+		dst = make(map[string]int, len(src))
+	}
+	for key, count := range src {
+		dst[key] += count
+	}
+	return dst
+}
+
+// addNestedCounts is addCounts for counts grouped by a key
+func addNestedCounts(dst, src map[string]map[string]int) map[string]map[string]int {
+	if dst == nil {
+		dst = make(map[string]map[string]int, len(src))
+	}
+	for key, counts := range src {
+		dst[key] = addCounts(dst[key], counts)
+	}
+	return dst
+}
+
+// historyCacheKey names the cache entry of a history file
+func historyCacheKey(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(sum[:8])
+}
+
+// checksum hashes the first and the last checksumWindow bytes before offset
+func checksum(file *os.File, offset int64) (string, error) {
+	hash := sha256.New()
+	head := min(offset, checksumWindow)
+	if _, err := io.Copy(hash, io.NewSectionReader(file, 0, head)); err != nil {
+		return "", err
+	}
+	tail := max(head, offset-checksumWindow)
+	if _, err := io.Copy(hash, io.NewSectionReader(file, tail, offset-tail)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16]), nil
+}
+
+// readIncrementally counts only what was added to a history file since the
+// last run into the aggregates saved then, which hold the most recent
+// entries too. A file that shrank or was rewritten, and every file with
+// opts.Full, is parsed again; opts.Full also returns all of its entries.
+// A period counts only some entries, so the whole file is read without the
+// cache.
+func readIncrementally(path, shell string, opts Options) *parsedHistory {
+	parsed := &parsedHistory{}
+	if opts.bounded() {
+		resume, kept, err := scanHistoryFrom(path, shell, 0, func(entry CommandEntry) {
+			parsed.entries = append(parsed.entries, entry)
+		}, opts.reportHistory(shell))
+		parsed.tail, parsed.err = historyTail{offset: resume, pending: len(parsed.entries) - kept}, err
+		return parsed
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		parsed.err = err
+		return parsed
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		parsed.err = err
+		return parsed
+	}
+
+	// Without a cache the file is counted from the start every time
+	cache, err := store.NewJSONStore(store.CacheDir())
+	if err == nil {
+		defer cache.Close()
+	}
+	key := historyCacheKey(path)
+	var cached cachedHistory
+	if err == nil && !opts.Full {
+		cached, _ = loadCachedHistory(cache, key, file, info, opts)
+	}
+	previous := cached.Fingerprint
+	slog.Debug("parsing history", "shell", shell, "cached", cached.Counts.Commands, "from", previous.Offset, "size", info.Size())
+	if previous.Size == info.Size() && previous.ModTime.Equal(info.ModTime()) && previous.Offset == info.Size() {
+		opts.reportHistory(shell)(info.Size(), info.Size())
+		parsed.counts = &cached.Counts
+		parsed.tail = historyTail{offset: previous.Offset}
+		return parsed
+	}
+
+	var appended []CommandEntry
+	resume, kept, err := scanHistoryFrom(path, shell, previous.Offset, func(entry CommandEntry) {
+		appended = append(appended, entry)
+	}, opts.reportHistory(shell))
+	if err != nil {
+		parsed.err = err
+		return parsed
+	}
+
+	// The entries after resume may be incomplete: they are counted in this
+	// run, not saved, and parsed again by the next
+	limit := sampleSize(opts)
+	saved := cached.Counts
+	saved.add(aggregateEntries(shell, appended[:kept], opts), limit)
+	parsed.tail = historyTail{offset: resume, pending: len(appended) - kept}
+	switch {
+	case opts.Full:
+		parsed.entries = appended
+	case kept == len(appended):
+		parsed.counts = &saved
+	default:
+		var counts historyAggregates
+		counts.add(saved, limit)
+		counts.add(aggregateEntries(shell, appended[kept:], opts), limit)
+		parsed.counts = &counts
+	}
+
+	if cache == nil {
+		return parsed
+	}
+	sum, err := checksum(file, resume)
+	if err != nil {
+		return parsed
+	}
+	fingerprint := historyFingerprint{Version: historyCacheVersion, Size: info.Size(), ModTime: info.ModTime(),
+		Offset: resume, Checksum: sum, Categories: categoriesChecksum(), Ignore: ignoreChecksum(), SampleSize: limit}
+	raw, err := json.Marshal(cachedHistory{Fingerprint: fingerprint, Counts: saved})
+	if err == nil {
+		// Failing to only costs the next run a full parse
+		cache.Put(historyBucket, key, raw)
+	}
+	return parsed
+}
+
+// loadCachedHistory returns the aggregates counted by an earlier run,
+// provided the part of the file they came from is unchanged and they were
+// counted the same way
+func loadCachedHistory(cache store.Store, key string, file *os.File, info os.FileInfo, opts Options) (cachedHistory, bool) {
+	raw, err := cache.Get(historyBucket, key)
+	if err != nil {
+		return cachedHistory{}, false
+	}
+	var cached cachedHistory
+	if json.Unmarshal(raw, &cached) != nil {
+		return cachedHistory{}, false
+	}
+	fingerprint := cached.Fingerprint
+	if fingerprint.Version != historyCacheVersion || fingerprint.Categories != categoriesChecksum() ||
+		fingerprint.Ignore != ignoreChecksum() || fingerprint.SampleSize != sampleSize(opts) || fingerprint.Offset > info.Size() {
+		return cachedHistory{}, false
+	}
+	if sum, err := checksum(file, fingerprint.Offset); err != nil || sum != fingerprint.Checksum {
+		return cachedHistory{}, false
+	}
+	return cached, true
+}
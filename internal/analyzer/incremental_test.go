@@ -0,0 +1,120 @@
+// internal/analyzer/incremental_test.go
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// appendHistory adds lines zsh commands to the history at path
+func appendHistory(t *testing.T, path string, lines int) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(file, ": %d:0;git push origin feature-%d\n", 1700000000+i*60, i)
+	}
+}
+
+// cachedCounts reads what the cache holds for the history at path
+func cachedCounts(t *testing.T, path string) historyAggregates {
+	t.Helper()
+	cache, err := store.NewJSONStore(store.CacheDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+	raw, err := cache.Get(historyBucket, historyCacheKey(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cached cachedHistory
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		t.Fatal(err)
+	}
+	return cached.Counts
+}
+
+func TestIncrementalMatchesFull(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	path := writeHistory(t, 1000)
+	useHistory(t, "zsh", path)
+	opts := Options{Shells: []string{"zsh"}, SampleSize: 100, Disabled: []string{ModuleProbe, ModuleConfig, ModulePlugins}}
+
+	// The first run fills the cache, the second counts only what was appended
+	Analyze(opts)
+	appendHistory(t, path, 500)
+	cached := Analyze(opts)
+	if counts := cachedCounts(t, path); counts.Commands != 1500 || len(counts.Recent) != 100 {
+		t.Fatalf("cached %d commands and %d entries, want 1500 and 100", counts.Commands, len(counts.Recent))
+	}
+	full := opts
+	full.Full = true
+	parsed := Analyze(full)
+
+	if cached.CommandCounts["zsh"] != 1500 || parsed.CommandCounts["zsh"] != 1500 {
+		t.Fatalf("counted %d and %d commands, want 1500", cached.CommandCounts["zsh"], parsed.CommandCounts["zsh"])
+	}
+	for name, pair := range map[string][2]any{
+		"CommonCmds":      {cached.CommonCmds, parsed.CommonCmds},
+		"ShellCmds":       {cached.ShellCmds, parsed.ShellCmds},
+		"CommonPrefixes":  {cached.CommonPrefixes, parsed.CommonPrefixes},
+		"Subcommands":     {cached.Subcommands, parsed.Subcommands},
+		"SubcommandFlags": {cached.SubcommandFlags, parsed.SubcommandFlags},
+		"Activity":        {cached.Insights.WorkPatterns.Activity, parsed.Insights.WorkPatterns.Activity},
+		"Migration":       {cached.Migration, parsed.Migration},
+	} {
+		if !reflect.DeepEqual(pair[0], pair[1]) {
+			t.Errorf("%s differs from a full parse:\n%v\n%v", name, pair[0], pair[1])
+		}
+	}
+
+	// The cached run keeps the sample, --full every entry
+	history := cached.Histories["zsh"]
+	if len(history) != 100 || len(parsed.Histories["zsh"]) != 1500 {
+		t.Fatalf("kept %d and %d entries, want 100 and 1500", len(history), len(parsed.Histories["zsh"]))
+	}
+	if last := history[len(history)-1].Command; last != "git push origin feature-499" {
+		t.Errorf("newest kept entry is %q, want the last command", last)
+	}
+}
+
+// BenchmarkReadHistory compares parsing a 200k-line history with counting
+// it from the cache after a command was appended
+func BenchmarkReadHistory(b *testing.B) {
+	b.Setenv("HOME", b.TempDir())
+	b.Setenv("XDG_CACHE_HOME", b.TempDir())
+	path := writeHistory(b, 200_000)
+	useHistory(b, "zsh", path)
+	opts := Options{Shells: []string{"zsh"}}
+
+	b.Run("full", func(b *testing.B) {
+		full := opts
+		full.Full = true
+		for i := 0; i < b.N; i++ {
+			readIncrementally(path, "zsh", full)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		readIncrementally(path, "zsh", opts)
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer file.Close()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			fmt.Fprintf(file, ": %d:0;git status\n", 1700000000+i)
+			readIncrementally(path, "zsh", opts)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+// internal/analyzer/live.go
+package analyzer
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"os"
+)
+
+// ErrHistoryRewritten is returned by Tail when a history file shrank or
+// changed before where it was read up to, as shells do when they trim or
+// deduplicate it. Only a new analysis can follow that.
+var ErrHistoryRewritten = errors.New("a history file was rewritten")
+
+// historyTail is where a history file was read up to. offset is where the
+// next read resumes and pending how many entries after it were already
+// counted, since the last entry may still have been incomplete; checksum
+// hashes the bytes before offset, see checksum.
+type historyTail struct {
+	offset   int64
+	pending  int
+	checksum string
+}
+
+// checksummed returns the tail with the checksum of path up to its offset.
+// Bytes appended since do not change it.
+func (tail historyTail) checksummed(path string) (historyTail, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return tail, err
+	}
+	defer file.Close()
+	tail.checksum, err = checksum(file, tail.offset)
+	return tail, err
+}
+
+// Tail reads what the shells appended to their history files since data
+// was analyzed and returns data with it, reporting whether anything was
+// added. Only the new bytes are parsed: they are counted into copies of the
+// aggregates, so data itself is never written to, and the insights drawn
+// from the histories are computed again. Tools are not probed and startup
+// files not read again.
+func Tail(data ShellData) (ShellData, bool, error) {
+	opts := data.Options
+	live := data.clone()
+	added := false
+	for _, shell := range SupportedShells() {
+		tail, ok := data.tails[shell]
+		if !ok {
+			continue
+		}
+		path := expandPath(historyPaths[shell])
+		info, err := os.Stat(path)
+		if err != nil {
+			slog.Warn("failed to check history", "shell", shell, "err", err)
+			continue
+		}
+		if info.Size() == tail.offset && tail.pending == 0 {
+			continue
+		}
+		if current, err := (historyTail{offset: tail.offset}).checksummed(path); info.Size() < tail.offset ||
+			err != nil || current.checksum != tail.checksum {
+			return data, false, ErrHistoryRewritten
+		}
+
+		// The entries counted before are read again and skipped
+		var appended []CommandEntry
+		emitted := 0
+		resume, kept, err := scanHistoryFrom(path, shell, tail.offset, func(entry CommandEntry) {
+			emitted++
+			if emitted > tail.pending {
+				appended = append(appended, entry)
+			}
+		}, nil)
+		if err != nil {
+			slog.Warn("failed to read history", "shell", shell, "err", err)
+			continue
+		}
+		if tail, err = (historyTail{offset: resume, pending: emitted - kept}).checksummed(path); err == nil {
+			live.tails[shell] = tail
+		}
+		if len(appended) == 0 {
+			continue
+		}
+
+		addAtuinRecords(map[string][]CommandEntry{shell: appended})
+		addHookRecords(map[string][]CommandEntry{shell: appended})
+		counted, err := loadEntries(shell, func(fn func(CommandEntry)) error {
+			for _, entry := range appended {
+				fn(entry)
+			}
+			return nil
+		}, opts, &live, live.monthly)
+		if err != nil || len(counted) == 0 {
+			continue
+		}
+		history := append(live.Histories[shell], counted...)
+		if opts.sampled() {
+			history = history[max(0, len(history)-sampleSize(opts)):]
+		}
+		live.Histories[shell] = history
+		added = true
+	}
+	if !added {
+		return live, false, nil
+	}
+
+	for _, shell := range append(SupportedShells(), CastSource) {
+		if history, ok := live.Histories[shell]; ok {
+			analyzeCommands(history, live.installed, opts, &live)
+		}
+	}
+	deriveInsights(&live, live.installed, opts, live.monthly)
+	return live, true, nil
+}
+
+// clone copies the aggregates Tail counts new entries into. The histories
+// are only appended to, which leaves the entries data holds untouched.
+func (data ShellData) clone() ShellData {
+	data.Histories = maps.Clone(data.Histories)
+	data.CommandCounts = maps.Clone(data.CommandCounts)
+	data.CommonCmds = maps.Clone(data.CommonCmds)
+	data.ShellCmds = cloneCounts(data.ShellCmds)
+	data.CommonPrefixes = maps.Clone(data.CommonPrefixes)
+	data.Subcommands = cloneCounts(data.Subcommands)
+	data.SubcommandFlags = cloneCounts(data.SubcommandFlags)
+	data.monthly = cloneCounts(data.monthly)
+	data.tails = maps.Clone(data.tails)
+	return data
+}
+
+// cloneCounts copies a map of counts and the counts in it
+func cloneCounts[K comparable](counts map[K]map[string]int) map[K]map[string]int {
+	cloned := make(map[K]map[string]int, len(counts))
+	for key, inner := range counts {
+		cloned[key] = maps.Clone(inner)
+	}
+	return cloned
+}
+
+// sampleSize is how many entries per shell low-memory mode keeps
+func sampleSize(opts Options) int {
+	if opts.SampleSize > 0 {
+		return opts.SampleSize
+	}
+	return defaultSampleSize
+}
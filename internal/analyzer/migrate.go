@@ -0,0 +1,258 @@
+// internal/analyzer/migrate.go
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MigrationPlan lists what moving from one shell to another takes: the
+// aliases, functions and environment variables to port, each written in
+// the target shell's syntax
+type MigrationPlan struct {
+	From, To    string
+	Aliases     []PortedItem
+	Functions   []PortedItem
+	Environment []PortedItem
+}
+
+// PortedItem is one definition of the old shell and its equivalent in the
+// target shell
+type PortedItem struct {
+	Name   string
+	Source string
+	Target string
+	// Defined is set when the target shell already defines the name
+	Defined bool
+	// Review is set when the translation is a best effort, e.g. of a
+	// function body, and has to be checked by hand
+	Review bool
+}
+
+// shellOnlyVars are the environment variables that configure the shell
+// itself and mean nothing to another one
+var shellOnlyVars = map[string]bool{
+	"HISTSIZE": true, "HISTFILESIZE": true, "HISTFILE": true, "HISTCONTROL": true, "HISTIGNORE": true,
+	"HISTTIMEFORMAT": true, "SAVEHIST": true, "PS1": true, "PS2": true, "PS3": true, "PS4": true,
+	"PROMPT": true, "RPROMPT": true, "PROMPT_COMMAND": true, "ZSH": true, "ZSH_THEME": true, "ZSH_CUSTOM": true,
+}
+
+// RCFiles maps each supported shell to the file a starter config belongs in
+var RCFiles = map[string]string{
+	"bash": "~/.bashrc",
+	"zsh":  "~/.zshrc",
+	"fish": "~/.config/fish/config.fish",
+}
+
+// PlanMigration compares the rc files of the shells from and to, read into
+// source and target, and translates what the target does not define yet
+func PlanMigration(source, target ShellConfig, from, to string) MigrationPlan {
+	plan := MigrationPlan{From: from, To: to}
+
+	for _, name := range SortedKeys(source.Aliases) {
+		value := source.Aliases[name]
+		_, defined := target.Aliases[name]
+		item := PortedItem{Name: name, Source: aliasSource(from, name, value, source.Abbreviations[name]), Defined: defined}
+		item.Target = aliasTarget(to, name, value)
+		plan.Aliases = append(plan.Aliases, item)
+	}
+	for _, name := range SortedKeys(source.GlobalAliases) {
+		value := source.GlobalAliases[name]
+		_, defined := target.GlobalAliases[name]
+		item := PortedItem{Name: name, Source: "alias -g " + name + "=" + shQuote(value), Defined: defined}
+		switch to {
+		case "zsh":
+			item.Target = item.Source
+		case "fish":
+			// Needs fish 3.6
+			item.Target = "abbr -a --position anywhere " + name + " " + fishQuote(value)
+		default:
+			item.Target = fmt.Sprintf("# %s has no global aliases: %s", to, item.Source)
+			item.Review = true
+		}
+		plan.Aliases = append(plan.Aliases, item)
+	}
+
+	for _, name := range SortedKeys(source.Functions) {
+		body := source.Functions[name]
+		_, defined := target.Functions[name]
+		item := PortedItem{Name: name, Source: functionDefinition(from, name, body), Defined: defined}
+		switch {
+		case isFish(from) == isFish(to):
+			item.Target = functionDefinition(to, name, body)
+		case isFish(to):
+			item.Target = functionDefinition(to, name, shToFish(body))
+			item.Review = true
+		default:
+			item.Target = functionDefinition(to, name, fishToSh(body))
+			item.Review = true
+		}
+		plan.Functions = append(plan.Functions, item)
+	}
+
+	for _, name := range SortedKeys(source.Environment) {
+		if shellOnlyVars[name] || strings.HasPrefix(name, "fish_") {
+			continue
+		}
+		value := source.Environment[name]
+		_, defined := target.Environment[name]
+		item := PortedItem{Name: name, Source: envDefinition(from, name, value), Defined: defined}
+		if isFish(from) && name == "PATH" {
+			value = strings.Join(strings.Fields(value), ":")
+		}
+		if isFish(to) && !isFish(from) {
+			item.Review = strings.Contains(value, "$(") || strings.Contains(value, "`")
+			value = shToFish(value)
+		}
+		// A PATH of just $PATH is left out
+		if item.Target = envDefinition(to, name, value); item.Target != "" {
+			plan.Environment = append(plan.Environment, item)
+		}
+	}
+	return plan
+}
+
+// Pending counts the items the target shell does not define yet
+func (p MigrationPlan) Pending() int {
+	pending := 0
+	for _, items := range [][]PortedItem{p.Aliases, p.Functions, p.Environment} {
+		for _, item := range items {
+			if !item.Defined {
+				pending++
+			}
+		}
+	}
+	return pending
+}
+
+// StarterConfig writes the pending items as a config file for the target
+// shell, marking the translations that need checking
+func (p MigrationPlan) StarterConfig() string {
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# Starter %s config generated by k8au-shell-analyzer from your %s setup.\n", p.To, p.From))
+	content.WriteString("# Definitions marked \"review\" were translated automatically; check them before use.\n")
+	for _, section := range []struct {
+		heading string
+		items   []PortedItem
+	}{
+		{"Aliases", p.Aliases},
+		{"Environment", p.Environment},
+		{"Functions", p.Functions},
+	} {
+		first := true
+		for _, item := range section.items {
+			if item.Defined {
+				continue
+			}
+			if first {
+				content.WriteString("\n# " + section.heading + "\n")
+				first = false
+			}
+			if item.Review {
+				content.WriteString("# review: translated from " + p.From + "\n")
+			}
+			content.WriteString(item.Target + "\n")
+		}
+	}
+	return content.String()
+}
+
+func isFish(shell string) bool {
+	return shell == "fish"
+}
+
+// shQuote quotes s for bash and zsh
+func shQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes s for fish, where a backslash escapes a single quote
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// doubleQuote quotes s so variables in it still expand
+func doubleQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func aliasSource(shell, name, value string, abbreviation bool) string {
+	if abbreviation {
+		return "abbr -a " + name + " " + fishQuote(value)
+	}
+	if isFish(shell) {
+		return "alias " + name + " " + fishQuote(value)
+	}
+	return "alias " + name + "=" + shQuote(value)
+}
+
+// aliasTarget defines an alias in shell, as an abbreviation in fish so the
+// history keeps the expanded command
+func aliasTarget(shell, name, value string) string {
+	if isFish(shell) {
+		return "abbr -a " + name + " " + fishQuote(value)
+	}
+	return "alias " + name + "=" + shQuote(value)
+}
+
+func envDefinition(shell, name, value string) string {
+	if !isFish(shell) {
+		return "export " + name + "=" + doubleQuote(value)
+	}
+	if name != "PATH" {
+		return "set -gx " + name + " " + doubleQuote(value)
+	}
+	// fish_add_path keeps PATH free of duplicates
+	var lines []string
+	for _, dir := range strings.Split(value, ":") {
+		if dir != "" && dir != "$PATH" && dir != "${PATH}" {
+			lines = append(lines, "fish_add_path "+doubleQuote(dir))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func functionDefinition(shell, name, body string) string {
+	if isFish(shell) {
+		return "function " + name + "\n" + body + "\nend"
+	}
+	return name + "() {\n" + body + "\n}"
+}
+
+var (
+	shPositional  = regexp.MustCompile(`\$([1-9])\b|\$\{([1-9])\}`)
+	shAssignment  = regexp.MustCompile(`(?m)^(\s*)(local|export)\s+(\w+)=(.*)$`)
+	shBraceVar    = regexp.MustCompile(`\$\{(\w+)\}`)
+	fishArgv      = regexp.MustCompile(`\$argv\[([1-9])\]`)
+	fishSet       = regexp.MustCompile(`(?m)^(\s*)set\s+(-l|-gx|-x)\s+(\w+)\s+(.*)$`)
+	fishSetScopes = map[string]string{"-l": "local", "-gx": "export", "-x": "export"}
+)
+
+// shToFish rewrites the bash and zsh syntax fish does not share: arguments,
+// the exit status, command substitution and assignments. Control flow
+// such as if/then/fi is left alone, hence the review.
+func shToFish(body string) string {
+	body = shAssignment.ReplaceAllStringFunc(body, func(line string) string {
+		m := shAssignment.FindStringSubmatch(line)
+		scope := "-l"
+		if m[2] == "export" {
+			scope = "-gx"
+		}
+		return m[1] + "set " + scope + " " + m[3] + " " + m[4]
+	})
+	body = strings.NewReplacer(`"$@"`, "$argv", "$@", "$argv", "$*", "$argv", "$#", "(count $argv)",
+		"$?", "$status", "$(", "(").Replace(body)
+	body = shPositional.ReplaceAllString(body, "$$argv[$1$2]")
+	return shBraceVar.ReplaceAllString(body, "{$$$1}")
+}
+
+// fishToSh is the reverse of shToFish
+func fishToSh(body string) string {
+	body = fishSet.ReplaceAllStringFunc(body, func(line string) string {
+		m := fishSet.FindStringSubmatch(line)
+		return m[1] + fishSetScopes[m[2]] + " " + m[3] + "=" + m[4]
+	})
+	body = fishArgv.ReplaceAllString(body, `"$$$1"`)
+	return strings.NewReplacer("(count $argv)", "$#", "$argv", `"$@"`, "$status", "$?").Replace(body)
+}
@@ -0,0 +1,69 @@
+// internal/analyzer/modern_tools.go
+package analyzer
+
+import "sort"
+
+// ToolAlternative is a modern replacement for a classic command the user
+// runs often
+type ToolAlternative struct {
+	Classic string
+	Modern  string
+	// Binary is the installed program, or the usual one when not installed
+	Binary string
+	// Runs counts the classic command, ModernRuns the replacement
+	Runs       int
+	ModernRuns int
+	// Installed is only known when probing is enabled
+	Installed bool
+}
+
+// modernTools maps classic commands to their replacements. Some are
+// packaged under a second binary name, e.g. batcat on Debian, and zoxide
+// is run through the z and zi shell functions.
+var modernTools = []struct {
+	classic  string
+	modern   string
+	binaries []string
+	commands []string
+}{
+	{"ls", "eza", []string{"eza", "exa"}, []string{"eza", "exa"}},
+	{"grep", "ripgrep", []string{"rg"}, []string{"rg"}},
+	{"cat", "bat", []string{"bat", "batcat"}, []string{"bat", "batcat"}},
+	{"find", "fd", []string{"fd", "fdfind"}, []string{"fd", "fdfind"}},
+	{"cd", "zoxide", []string{"zoxide"}, []string{"z", "zi", "zoxide"}},
+}
+
+// minClassicRuns is how often a classic command must be run before its
+// replacement is suggested
+const minClassicRuns = 20
+
+// ModernAlternatives suggests replacements for the classic commands run at
+// least minClassicRuns times and more often than their replacement, most
+// run first. Whether the replacement is installed is looked up on $PATH
+// when probing is enabled.
+func ModernAlternatives(data ShellData) []ToolAlternative {
+	probe := data.Options.Enabled(ModuleProbe)
+	var alternatives []ToolAlternative
+	for _, tool := range modernTools {
+		alt := ToolAlternative{Classic: tool.classic, Modern: tool.modern, Binary: tool.binaries[0], Runs: data.CommonCmds[tool.classic]}
+		for _, command := range tool.commands {
+			alt.ModernRuns += data.CommonCmds[command]
+		}
+		if alt.Runs < minClassicRuns || alt.ModernRuns >= alt.Runs {
+			continue
+		}
+		if probe {
+			for _, binary := range tool.binaries {
+				if checkToolInstalled(binary) {
+					alt.Installed, alt.Binary = true, binary
+					break
+				}
+			}
+		}
+		alternatives = append(alternatives, alt)
+	}
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		return alternatives[i].Runs > alternatives[j].Runs
+	})
+	return alternatives
+}
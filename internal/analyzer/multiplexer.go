@@ -0,0 +1,225 @@
+// internal/analyzer/multiplexer.go
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MultiplexerUsage is how tmux, screen and zellij are used, and how tmux is
+// configured
+type MultiplexerUsage struct {
+	// Commands counts the invocations of each multiplexer
+	Commands map[string]int
+	// TmuxCommands counts the tmux commands run from the shell, by their
+	// full name, e.g. attach-session for tmux a
+	TmuxCommands map[string]int
+	// Sessions are the sessions created or attached to by name, most often
+	// first
+	Sessions []CommandCount
+	// Config is the tmux config file read, "" without one
+	Config string
+	// Prefix is the tmux prefix key, C-b unless the config changes it
+	Prefix string
+	// Bindings are the keys bound in the tmux config, in file order
+	Bindings []KeyBinding
+	// Plugins are the tmux plugins listed for tpm
+	Plugins []PluginInfo
+}
+
+// KeyBinding is a key bound in the tmux config
+type KeyBinding struct {
+	Key     string
+	Command string
+	// Root is set for bind -n, which needs no prefix
+	Root bool
+	Location
+}
+
+// Total returns the number of multiplexer invocations
+func (m MultiplexerUsage) Total() int {
+	total := 0
+	for _, count := range m.Commands {
+		total += count
+	}
+	return total
+}
+
+// ManagerTPM is the tmux plugin manager, as in PluginInfo.Manager
+const ManagerTPM = "tpm"
+
+// multiplexerSessionsShown caps the sessions listed
+const multiplexerSessionsShown = 10
+
+// tmuxConfigPaths are where tmux reads its config from, the first that
+// exists being used
+var tmuxConfigPaths = []string{"~/.tmux.conf", "~/.config/tmux/tmux.conf"}
+
+// tmuxAliases maps the short tmux commands to their full names
+var tmuxAliases = map[string]string{
+	"a": "attach-session", "at": "attach-session", "attach": "attach-session",
+	"new": "new-session", "ls": "list-sessions", "kill-ses": "kill-session",
+	"switchc": "switch-client", "detach": "detach-client", "source": "source-file",
+	"neww": "new-window", "splitw": "split-window", "lsw": "list-windows",
+	"rename": "rename-session", "renamew": "rename-window",
+}
+
+// AnalyzeMultiplexers counts the tmux, screen and zellij commands of every
+// part of each command line and, when the config module is enabled, reads
+// the tmux config
+func AnalyzeMultiplexers(histories map[string][]CommandEntry, opts Options) MultiplexerUsage {
+	usage := MultiplexerUsage{Commands: make(map[string]int), TmuxCommands: make(map[string]int), Prefix: "C-b"}
+	sessions := make(map[string]int)
+	for _, history := range histories {
+		for _, entry := range history {
+			for _, fields := range commandSegments(entry.Command) {
+				program, args := filepath.Base(fields[0]), fields[1:]
+				switch program {
+				case "tmux":
+					command, session := tmuxArgs(args)
+					usage.TmuxCommands[command]++
+					if session != "" {
+						sessions[session]++
+					}
+				case "screen":
+					// screen -S name starts a session, -r and -x attach to one
+					for i, arg := range args {
+						if (arg == "-S" || arg == "-r" || arg == "-x") && i+1 < len(args) {
+							sessions[args[i+1]]++
+							break
+						}
+					}
+				case "zellij":
+					if len(args) > 1 && (args[0] == "attach" || args[0] == "a" || args[0] == "-s" || args[0] == "--session") {
+						sessions[args[1]]++
+					}
+				default:
+					continue
+				}
+				usage.Commands[program]++
+			}
+		}
+	}
+	usage.Sessions = SortedCounts(sessions, multiplexerSessionsShown)
+
+	if opts.Enabled(ModuleConfig) {
+		readTmuxConfig(&usage, opts.Enabled(ModulePlugins))
+	}
+	return usage
+}
+
+// tmuxArgs returns the full name of the command a tmux invocation runs,
+// new-session for a bare tmux, and the session it names with -t or -s
+func tmuxArgs(args []string) (string, string) {
+	// Global options before the command; -L, -S and -f take a value
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		if args[i] == "-L" || args[i] == "-S" || args[i] == "-f" || args[i] == "-c" {
+			i++
+		}
+		i++
+	}
+	if i >= len(args) {
+		return "new-session", ""
+	}
+	command := args[i]
+	if full, ok := tmuxAliases[command]; ok {
+		command = full
+	}
+	session := ""
+	rest := args[i+1:]
+	for j, arg := range rest {
+		if (arg == "-t" || arg == "-s") && j+1 < len(rest) {
+			// -t session:window.pane names a session with a target
+			session, _, _ = strings.Cut(rest[j+1], ":")
+			break
+		}
+	}
+	return command, session
+}
+
+// readTmuxConfig reads the prefix, key bindings and tpm plugins of the
+// first tmux config that exists
+func readTmuxConfig(usage *MultiplexerUsage, plugins bool) {
+	for _, candidate := range tmuxConfigPaths {
+		path := expandPath(candidate)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		usage.Config = path
+		for i, line := range strings.Split(string(content), "\n") {
+			here := Location{Path: path, Line: i + 1}
+			fields := splitWords(strings.TrimSpace(line))
+			if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			switch fields[0] {
+			case "set", "set-option", "setw", "set-window-option":
+				name, value := tmuxOption(fields[1:])
+				switch {
+				case name == "prefix" && value != "":
+					usage.Prefix = value
+				case name == "@plugin" && value != "" && plugins:
+					dir := expandPath("~/.tmux/plugins/" + filepath.Base(value))
+					usage.Plugins = append(usage.Plugins, pluginAt(value, ManagerTPM, dir, here))
+				}
+			case "bind", "bind-key":
+				// Unsplit, so the command reads as written
+				if binding, ok := tmuxBinding(strings.Fields(line)[1:]); ok {
+					binding.Location = here
+					usage.Bindings = append(usage.Bindings, binding)
+				}
+			}
+		}
+		return
+	}
+}
+
+// tmuxOption returns the name and value of a set-option line, given its
+// arguments after the command
+func tmuxOption(args []string) (string, string) {
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			// -t takes a target; the other flags stand alone
+			if args[i] == "-t" {
+				i++
+			}
+			continue
+		}
+		rest = append(rest, args[i])
+	}
+	if len(rest) < 2 {
+		return "", ""
+	}
+	return rest[0], strings.Join(rest[1:], " ")
+}
+
+// tmuxBinding parses the arguments of bind-key into the key and the
+// command it runs
+func tmuxBinding(args []string) (KeyBinding, bool) {
+	var binding KeyBinding
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || len(arg) == 1 {
+			binding.Key = arg
+			binding.Command = strings.Join(args[i+1:], " ")
+			return binding, binding.Command != ""
+		}
+		switch arg {
+		case "-n":
+			binding.Root = true
+		case "-T":
+			if i+1 < len(args) && args[i+1] == "root" {
+				binding.Root = true
+			}
+			i++
+		case "-N":
+			// a note describing the binding
+			i++
+		}
+	}
+	return binding, false
+}
@@ -0,0 +1,209 @@
+// internal/analyzer/network.go
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NetworkUsage maps the machines reached from the shell
+type NetworkUsage struct {
+	// Hosts are the ssh, scp, rsync and mosh destinations, most used first
+	Hosts []RemoteHost
+	// Domains are the hosts of the URLs fetched with curl and wget, most
+	// fetched first
+	Domains []CommandCount
+}
+
+// RemoteHost is a destination of remote shells and copies
+type RemoteHost struct {
+	Host  string
+	Count int
+	// Tools lists the programs used to reach the host, alphabetically
+	Tools []string
+	Last  time.Time
+}
+
+// networkSummarized is how many hosts the AI summary lists
+const networkSummarized = 5
+
+// remoteValueFlags are the options of each remote tool that take the next
+// word as their value
+var remoteValueFlags = map[string]map[string]bool{
+	"ssh":   flagSet("-B -b -c -D -E -e -F -I -i -J -L -l -m -O -o -p -P -Q -R -S -W -w"),
+	"scp":   flagSet("-c -D -F -i -J -l -o -P -S -X"),
+	"rsync": flagSet("-e --rsh -f --filter --exclude --include -T --temp-dir --port --log-file --password-file"),
+	"mosh":  flagSet("-p --port --ssh --predict --family --server --client"),
+}
+
+func flagSet(flags string) map[string]bool {
+	set := make(map[string]bool)
+	for _, flag := range strings.Fields(flags) {
+		set[flag] = true
+	}
+	return set
+}
+
+// AnalyzeNetwork collects the remote hosts and fetched domains from every
+// part of every command line
+func AnalyzeNetwork(histories map[string][]CommandEntry) NetworkUsage {
+	hosts := make(map[string]*RemoteHost)
+	tools := make(map[string]map[string]bool)
+	domains := make(map[string]int)
+
+	for _, shell := range SortedKeys(histories) {
+		for _, entry := range histories[shell] {
+			for _, segment := range strings.FieldsFunc(entry.Command, func(r rune) bool {
+				return r == '|' || r == ';' || r == '&'
+			}) {
+				words := splitWords(segment)
+				if len(words) > 1 && words[0] == "sudo" {
+					words = words[1:]
+				}
+				if len(words) == 0 {
+					continue
+				}
+				program := path.Base(words[0])
+				switch program {
+				case "curl", "wget":
+					for _, domain := range fetchedDomains(words[1:]) {
+						domains[domain]++
+					}
+					continue
+				}
+				if _, ok := remoteValueFlags[program]; !ok {
+					continue
+				}
+				for _, host := range remoteHosts(program, words[1:]) {
+					if hosts[host] == nil {
+						hosts[host] = &RemoteHost{Host: host}
+						tools[host] = make(map[string]bool)
+					}
+					hosts[host].Count++
+					tools[host][program] = true
+					if entry.Timestamp.After(hosts[host].Last) {
+						hosts[host].Last = entry.Timestamp
+					}
+				}
+			}
+		}
+	}
+
+	var usage NetworkUsage
+	for host, remote := range hosts {
+		remote.Tools = SortedKeys(tools[host])
+		usage.Hosts = append(usage.Hosts, *remote)
+	}
+	sort.Slice(usage.Hosts, func(i, j int) bool {
+		a, b := usage.Hosts[i], usage.Hosts[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Host < b.Host
+	})
+	usage.Domains = SortedCounts(domains, 0)
+	return usage
+}
+
+// remoteHosts returns the hosts named by the arguments of a remote tool,
+// each once. ssh and mosh connect to their first operand; scp and rsync
+// copy from or to every operand of the form [user@]host:path.
+func remoteHosts(program string, args []string) []string {
+	valueFlags := remoteValueFlags[program]
+	seen := make(map[string]bool)
+	var hosts []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			continue
+		}
+		if strings.HasPrefix(arg, "-") {
+			if valueFlags[arg] {
+				i++
+			}
+			continue
+		}
+
+		var host string
+		switch program {
+		case "ssh", "mosh":
+			host = destinationHost(arg)
+		default:
+			host = copyHost(arg)
+		}
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+		if program == "ssh" || program == "mosh" {
+			// The rest is the remote command
+			break
+		}
+	}
+	return hosts
+}
+
+// destinationHost reads [user@]host or ssh://[user@]host[:port]
+func destinationHost(arg string) string {
+	if strings.Contains(arg, "://") {
+		return urlHost(arg)
+	}
+	return normalizeHost(arg[strings.LastIndex(arg, "@")+1:])
+}
+
+// copyHost reads the host of an scp or rsync operand: [user@]host:path,
+// host::module or a URL. Local paths, including ./a:b, have none.
+func copyHost(arg string) string {
+	if strings.Contains(arg, "://") {
+		return urlHost(arg)
+	}
+	colon := strings.Index(arg, ":")
+	if colon <= 0 || strings.Contains(arg[:colon], "/") {
+		return ""
+	}
+	return destinationHost(arg[:colon])
+}
+
+// fetchedDomains returns the hosts of the URLs among curl or wget arguments
+func fetchedDomains(args []string) []string {
+	var domains []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "http://") && !strings.HasPrefix(arg, "https://") && !strings.HasPrefix(arg, "ftp://") {
+			continue
+		}
+		if host := urlHost(arg); host != "" {
+			domains = append(domains, host)
+		}
+	}
+	return domains
+}
+
+func urlHost(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return normalizeHost(u.Hostname())
+}
+
+// normalizeHost lowercases a host name and drops anything that cannot be
+// one, such as variables or globs
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.Trim(host, "[]"))
+	if host == "" || strings.ContainsAny(host, "$*?{}()<>'\"` ") {
+		return ""
+	}
+	return host
+}
+
+// HashHost replaces a host name with a stable pseudonym, so the AI can
+// tell hosts apart without learning their names
+func HashHost(host string) string {
+	sum := sha256.Sum256([]byte(host))
+	return "host-" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,167 @@
+// internal/analyzer/outcomes.go
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"time"
+)
+
+// Outcomes is how commands ended and how long they ran, from the sources
+// that record it: zsh EXTENDED_HISTORY durations, atuin and the shell hooks
+type Outcomes struct {
+	// Timed and Exited count the entries with a known duration or exit
+	// status
+	Timed  int
+	Exited int
+	// Failures are the programs that fail most often, by failure rate
+	Failures []ProgramFailures
+	// Slowest are the command lines that take longest on average, leaving
+	// out interactive programs such as editors, which run as long as the
+	// user wants
+	Slowest []SlowCommand
+	// Retried is the command most often run again right after failing
+	Retried RetriedCommand
+}
+
+// ProgramFailures counts the runs of a program with a known exit status and
+// how many of them failed
+type ProgramFailures struct {
+	Program  string
+	Runs     int
+	Failures int
+}
+
+// Rate is the share of runs that failed
+func (p ProgramFailures) Rate() float64 {
+	if p.Runs == 0 {
+		return 0
+	}
+	return float64(p.Failures) / float64(p.Runs)
+}
+
+// SlowCommand is a command line and how long its runs took
+type SlowCommand struct {
+	Command string
+	Runs    int
+	Average time.Duration
+	Longest time.Duration
+}
+
+// RetriedCommand is a command and how often it was run again right after
+// it failed
+type RetriedCommand struct {
+	Command string
+	Retries int
+}
+
+const (
+	// outcomesShown caps the failure and slowest lists
+	outcomesShown = 5
+	// failureMinRuns is how often a program must have run for its failure
+	// rate to mean something
+	failureMinRuns = 5
+	// interruptedExit is the status of a command stopped with Ctrl+C, which
+	// is how long-running commands usually end rather than a failure
+	interruptedExit = 130
+)
+
+// interactivePrograms run until the user leaves them, so their duration
+// says nothing about speed
+var interactivePrograms = map[string]bool{
+	"vim": true, "nvim": true, "vi": true, "emacs": true, "nano": true, "hx": true, "micro": true,
+	"ssh": true, "mosh": true, "tmux": true, "screen": true, "zellij": true,
+	"less": true, "more": true, "man": true, "top": true, "htop": true, "btop": true, "watch": true,
+	"k9s": true, "lazygit": true, "tig": true, "ranger": true, "nnn": true, "yazi": true,
+}
+
+// failed reports whether entry ended with an error; interrupted commands
+// do not count
+func (e CommandEntry) failed() bool {
+	return e.HasExit && e.Exit != 0 && e.Exit != interruptedExit
+}
+
+// analyzeOutcomes ranks programs by failure rate and command lines by
+// duration, and finds the command retried most after failing
+func analyzeOutcomes(histories map[string][]CommandEntry) Outcomes {
+	var outcomes Outcomes
+	failures := make(map[string]*ProgramFailures)
+	type timing struct {
+		runs           int
+		total, longest time.Duration
+	}
+	timings := make(map[string]*timing)
+	retries := make(map[string]int)
+
+	for _, history := range histories {
+		for i, entry := range history {
+			program := path.Base(commandProgram(entry.Command))
+			if entry.HasExit {
+				outcomes.Exited++
+				if failures[program] == nil {
+					failures[program] = &ProgramFailures{Program: program}
+				}
+				failures[program].Runs++
+				if entry.failed() {
+					failures[program].Failures++
+				}
+				if i > 0 && history[i-1].failed() && history[i-1].Command == entry.Command {
+					retries[entry.Command]++
+				}
+			}
+			if entry.Timed {
+				outcomes.Timed++
+				if _, repl := replLaunch(entry.Command); repl || interactivePrograms[program] {
+					continue
+				}
+				t := timings[entry.Command]
+				if t == nil {
+					t = &timing{}
+					timings[entry.Command] = t
+				}
+				t.runs++
+				t.total += entry.Duration
+				t.longest = max(t.longest, entry.Duration)
+			}
+		}
+	}
+
+	for _, f := range failures {
+		if f.Runs >= failureMinRuns && f.Failures > 0 {
+			outcomes.Failures = append(outcomes.Failures, *f)
+		}
+	}
+	sort.Slice(outcomes.Failures, func(i, j int) bool {
+		a, b := outcomes.Failures[i], outcomes.Failures[j]
+		if a.Rate() != b.Rate() {
+			return a.Rate() > b.Rate()
+		}
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return a.Program < b.Program
+	})
+	outcomes.Failures = outcomes.Failures[:min(len(outcomes.Failures), outcomesShown)]
+
+	for command, t := range timings {
+		if t.total > 0 {
+			outcomes.Slowest = append(outcomes.Slowest, SlowCommand{Command: command, Runs: t.runs,
+				Average: t.total / time.Duration(t.runs), Longest: t.longest})
+		}
+	}
+	sort.Slice(outcomes.Slowest, func(i, j int) bool {
+		a, b := outcomes.Slowest[i], outcomes.Slowest[j]
+		if a.Average != b.Average {
+			return a.Average > b.Average
+		}
+		return a.Command < b.Command
+	})
+	outcomes.Slowest = outcomes.Slowest[:min(len(outcomes.Slowest), outcomesShown)]
+
+	for command, count := range retries {
+		if count > outcomes.Retried.Retries || count == outcomes.Retried.Retries && command < outcomes.Retried.Command {
+			outcomes.Retried = RetriedCommand{Command: command, Retries: count}
+		}
+	}
+	return outcomes
+}
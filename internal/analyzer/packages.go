@@ -0,0 +1,252 @@
+// internal/analyzer/packages.go
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PackageUsage lists the packages installed from the shell
+type PackageUsage struct {
+	// Installs counts the install commands per package manager
+	Installs map[string]int
+	// Packages are the packages installed most often, and New the ones
+	// first installed in the last RecentWindow of the history, newest
+	// first
+	Packages []PackageCount
+	New      []PackageCount
+	// Distinct counts the different packages installed, and Months the
+	// span of the history they were installed over
+	Distinct int
+	Months   float64
+}
+
+// PackageCount is a package with the times it was installed
+type PackageCount struct {
+	Manager string
+	Name    string
+	Count   int
+	First   time.Time
+}
+
+// Dependency hoarding levels, as returned by Hoarding
+const (
+	HoardingMinimalist = "minimalist"
+	HoardingCollector  = "collector"
+	HoardingHoarder    = "hoarder"
+)
+
+const (
+	// packagesShown caps the packages installed most and the new ones
+	packagesShown = 10
+	// hoarderFullPerMonth is how many different packages a month score a
+	// full 100 on the hoarder scale
+	hoarderFullPerMonth = 20.0
+	// hoardingCollector and hoardingHoarder are the scores from which one
+	// collects or hoards packages
+	hoardingCollector = 25
+	hoardingHoarder   = 60
+)
+
+// HoarderScore rates from 0 to 100 how many different packages are
+// installed a month, 100 from hoarderFullPerMonth on
+func (p PackageUsage) HoarderScore() int {
+	if p.Distinct == 0 {
+		return 0
+	}
+	perMonth := float64(p.Distinct) / max(p.Months, 1)
+	return int(min(100, perMonth/hoarderFullPerMonth*100) + 0.5)
+}
+
+// Hoarding labels the hoarder score, or returns "" without installs
+func (p PackageUsage) Hoarding() string {
+	switch score := p.HoarderScore(); {
+	case p.Distinct == 0:
+		return ""
+	case score >= hoardingHoarder:
+		return HoardingHoarder
+	case score >= hoardingCollector:
+		return HoardingCollector
+	}
+	return HoardingMinimalist
+}
+
+// packageValueOptions are the options of each package manager's install
+// command that take the next word as their value
+var packageValueOptions = map[string]map[string]bool{
+	"pip":   flagSet("-r --requirement -c --constraint -e --editable -i --index-url --extra-index-url -t --target --prefix --root -f --find-links"),
+	"npm":   flagSet("--prefix --registry --tag"),
+	"cargo": flagSet("--version --vers --git --branch --tag --rev --path --root --features -F --package -p"),
+	"apt":   flagSet("-t --target-release -o --option"),
+	"go":    flagSet("-C -tags -ldflags -gcflags -modfile"),
+}
+
+// AnalyzeInstalls collects the packages installed with npm, yarn, pnpm,
+// pip, go, cargo, brew and apt from every part of every command line
+func AnalyzeInstalls(histories map[string][]CommandEntry) PackageUsage {
+	usage := PackageUsage{Installs: make(map[string]int)}
+	packages := make(map[[2]string]*PackageCount)
+	var oldest, newest time.Time
+	for _, shell := range SortedKeys(histories) {
+		for _, entry := range histories[shell] {
+			if t := entry.Timestamp; !t.IsZero() {
+				if oldest.IsZero() || t.Before(oldest) {
+					oldest = t
+				}
+				if t.After(newest) {
+					newest = t
+				}
+			}
+			for _, segment := range strings.FieldsFunc(entry.Command, func(r rune) bool {
+				return r == '|' || r == ';' || r == '&'
+			}) {
+				words := splitWords(segment)
+				if len(words) > 1 && words[0] == "sudo" {
+					words = words[1:]
+				}
+				manager, names := installedPackages(words)
+				if manager == "" {
+					continue
+				}
+				usage.Installs[manager]++
+				for _, name := range names {
+					key := [2]string{manager, name}
+					if packages[key] == nil {
+						packages[key] = &PackageCount{Manager: manager, Name: name}
+					}
+					pkg := packages[key]
+					pkg.Count++
+					if t := entry.Timestamp; !t.IsZero() && (pkg.First.IsZero() || t.Before(pkg.First)) {
+						pkg.First = t
+					}
+				}
+			}
+		}
+	}
+
+	usage.Distinct = len(packages)
+	usage.Months = 1
+	if !oldest.IsZero() {
+		usage.Months = max(1, newest.Sub(oldest).Hours()/24/30)
+	}
+
+	all := make([]PackageCount, 0, len(packages))
+	for _, pkg := range packages {
+		all = append(all, *pkg)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Count != all[j].Count {
+			return all[i].Count > all[j].Count
+		}
+		return all[i].Manager+" "+all[i].Name < all[j].Manager+" "+all[j].Name
+	})
+	usage.Packages = all[:min(len(all), packagesShown)]
+
+	for _, pkg := range all {
+		if !pkg.First.IsZero() && newest.Sub(pkg.First) < RecentWindow {
+			usage.New = append(usage.New, pkg)
+		}
+	}
+	sort.SliceStable(usage.New, func(i, j int) bool {
+		return usage.New[i].First.After(usage.New[j].First)
+	})
+	usage.New = usage.New[:min(len(usage.New), packagesShown)]
+	return usage
+}
+
+// installedPackages returns the package manager and the packages a command
+// installs, or "" when it installs none. Installing a project's declared
+// dependencies, as a bare npm install does, installs no package by name.
+func installedPackages(words []string) (string, []string) {
+	if len(words) < 2 {
+		return "", nil
+	}
+	program := path.Base(words[0])
+	args := words[1:]
+	var manager string
+	switch program {
+	case "npm", "pnpm", "yarn", "bun":
+		if args[0] != "install" && args[0] != "i" && args[0] != "add" {
+			return "", nil
+		}
+		manager = "npm"
+	case "pip", "pip3", "pipx":
+		if args[0] != "install" {
+			return "", nil
+		}
+		manager = "pip"
+	case "python", "python3":
+		// python -m pip install
+		if len(args) < 3 || args[0] != "-m" || (args[1] != "pip" && args[1] != "pip3") || args[2] != "install" {
+			return "", nil
+		}
+		args = args[2:]
+		manager = "pip"
+	case "go":
+		if args[0] != "get" && args[0] != "install" {
+			return "", nil
+		}
+		manager = "go"
+	case "cargo":
+		if args[0] != "add" && args[0] != "install" {
+			return "", nil
+		}
+		manager = "cargo"
+	case "brew":
+		if args[0] != "install" {
+			return "", nil
+		}
+		manager = "brew"
+	case "apt", "apt-get":
+		if args[0] != "install" {
+			return "", nil
+		}
+		manager = "apt"
+	default:
+		return "", nil
+	}
+
+	var names []string
+	options := packageValueOptions[manager]
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "-") {
+			if options[arg] {
+				i++
+			}
+			continue
+		}
+		if name := packageName(manager, arg); name != "" {
+			names = append(names, name)
+		}
+	}
+	return manager, names
+}
+
+// packageName strips the version from a package argument, or returns ""
+// for local paths, URLs and variables, which name no package
+func packageName(manager, arg string) string {
+	if strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, "~") ||
+		strings.Contains(arg, "://") || strings.ContainsAny(arg, "$*") {
+		return ""
+	}
+	switch manager {
+	case "npm":
+		// @scope/name@version keeps its leading @
+		if at := strings.LastIndex(arg, "@"); at > 0 {
+			arg = arg[:at]
+		}
+	case "pip":
+		if end := strings.IndexAny(arg, "=<>!~[;"); end >= 0 {
+			arg = arg[:end]
+		}
+		arg = strings.ToLower(arg)
+	case "go", "cargo":
+		arg, _, _ = strings.Cut(arg, "@")
+	case "apt":
+		arg, _, _ = strings.Cut(arg, "=")
+	}
+	return arg
+}
@@ -0,0 +1,78 @@
+// internal/analyzer/period.go
+package analyzer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InRange reports whether an entry at t falls within Since and Until.
+// Without a range every entry does; with one, entries without a timestamp
+// cannot be placed and are left out.
+func (o Options) InRange(t time.Time) bool {
+	if o.Since.IsZero() && o.Until.IsZero() {
+		return true
+	}
+	if t.IsZero() {
+		return false
+	}
+	return !t.Before(o.Since) && (o.Until.IsZero() || t.Before(o.Until))
+}
+
+// bounded reports whether the analysis is limited to a period
+func (o Options) bounded() bool {
+	return !o.Since.IsZero() || !o.Until.IsZero()
+}
+
+// ParsePeriod reads a --since or --until value: a year (2024), a quarter
+// (2024-Q1), a month (2024-03), a day (2024-03-15) or an age such as 30d,
+// 12w, 6m or 1y. It
+// returns the start and the end of the period; an age is a single moment,
+// so both are the same.
+func ParsePeriod(value string, now time.Time) (time.Time, time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []struct {
+		layout string
+		years  int
+		months int
+		days   int
+	}{
+		{"2006", 1, 0, 0},
+		{"2006-01", 0, 1, 0},
+		{"2006-01-02", 0, 0, 1},
+	} {
+		if start, err := time.ParseInLocation(layout.layout, value, time.Local); err == nil {
+			return start, start.AddDate(layout.years, layout.months, layout.days), nil
+		}
+	}
+
+	if year, quarter, ok := strings.Cut(strings.ToUpper(value), "-Q"); ok {
+		if start, err := time.ParseInLocation("2006", year, time.Local); err == nil && len(quarter) == 1 && quarter >= "1" && quarter <= "4" {
+			start = start.AddDate(0, 3*int(quarter[0]-'1'), 0)
+			return start, start.AddDate(0, 3, 0), nil
+		}
+	}
+
+	if len(value) >= 2 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n >= 0 {
+			var at time.Time
+			switch value[len(value)-1] {
+			case 'd':
+				at = now.AddDate(0, 0, -n)
+			case 'w':
+				at = now.AddDate(0, 0, -7*n)
+			case 'm':
+				at = now.AddDate(0, -n, 0)
+			case 'y':
+				at = now.AddDate(-n, 0, 0)
+			}
+			if !at.IsZero() {
+				return at, at, nil
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q, expected e.g. 2024, 2024-Q1, 2024-03, 2024-03-15 or 30d", value)
+}
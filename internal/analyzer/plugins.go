@@ -0,0 +1,310 @@
+// internal/analyzer/plugins.go
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// PluginStaleAfter is how long without an update makes a plugin stale
+const PluginStaleAfter = 365 * 24 * time.Hour
+
+// Plugin managers, as in PluginInfo.Manager
+const (
+	ManagerOhMyZsh  = "oh-my-zsh"
+	ManagerZinit    = "zinit"
+	ManagerAntigen  = "antigen"
+	ManagerZplug    = "zplug"
+	ManagerFisher   = "fisher"
+	ManagerOhMyPosh = "oh-my-posh"
+	ManagerBashIt   = "bash-it"
+)
+
+// Stale reports whether the plugin was last updated more than
+// PluginStaleAfter before now; plugins of unknown age are not
+func (p PluginInfo) Stale(now time.Time) bool {
+	return !p.LastUpdated.IsZero() && now.Sub(p.LastUpdated) > PluginStaleAfter
+}
+
+// configLine is a line of code in a startup file
+type configLine struct {
+	text string
+	Location
+}
+
+// codeLines returns the lines of the startup files that are not blank or
+// comments, trimmed, file by file
+func codeLines(config *ShellConfig) []configLine {
+	var lines []configLine
+	for _, key := range SortedKeys(config.ConfigFiles) {
+		info := config.ConfigFiles[key]
+		for i, line := range strings.Split(info.Content, "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				lines = append(lines, configLine{text: line, Location: Location{Path: info.Path, Line: i + 1}})
+			}
+		}
+	}
+	return lines
+}
+
+// pluginAt describes a plugin installed in dir, which may not exist when
+// the plugin was listed but never installed
+func pluginAt(name, manager, dir string, defined Location) PluginInfo {
+	plugin := PluginInfo{Name: name, Manager: manager, Defined: defined}
+	info, err := os.Stat(dir)
+	if dir == "" || err != nil {
+		return plugin
+	}
+	plugin.Source = dir
+	plugin.LastUpdated = info.ModTime()
+	if version, updated, ok := checkoutInfo(dir); ok {
+		plugin.Version = version
+		if !updated.IsZero() {
+			plugin.LastUpdated = updated
+		}
+	}
+	return plugin
+}
+
+// firstDir returns the first of dirs that exists, or the first one
+func firstDir(dirs ...string) string {
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return dirs[0]
+}
+
+// checkoutInfo returns the version of the git checkout dir is in, a tag
+// or a short commit, and when it was last cloned or pulled according to
+// its reflog
+func checkoutInfo(dir string) (string, time.Time, bool) {
+	home, err := utils.HomeDir()
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	rel, err := filepath.Rel(home, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", time.Time{}, false
+	}
+	_, gitDir, ok := findGitDir(home, "~/"+filepath.ToSlash(rel))
+	if !ok {
+		return "", time.Time{}, false
+	}
+	reflog, _ := readReflog(filepath.Join(gitDir, "logs", "HEAD"), Options{})
+	return gitVersion(gitDir), reflog.Last, true
+}
+
+// gitVersion returns the tag HEAD is at, or its short commit hash
+func gitVersion(gitDir string) string {
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	commit := strings.TrimSpace(string(head))
+	packed, _ := os.ReadFile(filepath.Join(gitDir, "packed-refs"))
+	if ref, ok := strings.CutPrefix(commit, "ref: "); ok {
+		commit = ""
+		if loose, err := os.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref))); err == nil {
+			commit = strings.TrimSpace(string(loose))
+		}
+		for _, line := range strings.Split(string(packed), "\n") {
+			if hash, name, ok := strings.Cut(line, " "); ok && commit == "" && name == ref {
+				commit = hash
+			}
+		}
+	}
+	if len(commit) < 7 {
+		return ""
+	}
+
+	// A tag on the commit, loose or packed; the ^ lines of packed-refs
+	// peel annotated tags to their commit
+	if tags, err := os.ReadDir(filepath.Join(gitDir, "refs", "tags")); err == nil {
+		for _, tag := range tags {
+			if hash, err := os.ReadFile(filepath.Join(gitDir, "refs", "tags", tag.Name())); err == nil && strings.TrimSpace(string(hash)) == commit {
+				return tag.Name()
+			}
+		}
+	}
+	var tag string
+	for _, line := range strings.Split(string(packed), "\n") {
+		if peeled, ok := strings.CutPrefix(line, "^"); ok {
+			if peeled == commit && tag != "" {
+				return tag
+			}
+			continue
+		}
+		tag = ""
+		if hash, name, ok := strings.Cut(line, " "); ok {
+			if name, ok = strings.CutPrefix(name, "refs/tags/"); ok {
+				if hash == commit {
+					return name
+				}
+				tag = name
+			}
+		}
+	}
+	return commit[:7]
+}
+
+// listedZshPlugins reads the plugins the startup files load with
+// oh-my-zsh, zinit, antigen and zplug, and which of those managers list
+// any
+func listedZshPlugins(config *ShellConfig) (plugins []PluginInfo, managers map[string]bool) {
+	managers = make(map[string]bool)
+	omz := expandPath("~/.oh-my-zsh")
+	inList := false
+	for _, line := range codeLines(config) {
+		fields := strings.Fields(line.text)
+
+		// The oh-my-zsh list, which may span lines
+		if rest, ok := strings.CutPrefix(line.text, "plugins=("); ok || inList {
+			if !ok {
+				rest = line.text
+			}
+			list, _, closed := strings.Cut(rest, ")")
+			for _, name := range strings.Fields(list) {
+				dir := firstDir(filepath.Join(omz, "custom", "plugins", name), filepath.Join(omz, "plugins", name))
+				plugins = append(plugins, pluginAt(name, ManagerOhMyZsh, dir, line.Location))
+				managers[ManagerOhMyZsh] = true
+			}
+			inList = !closed
+			continue
+		}
+
+		if len(fields) < 2 {
+			continue
+		}
+		switch {
+		case (fields[0] == "zinit" || fields[0] == "zi") && len(fields) > 2 &&
+			(fields[1] == "light" || fields[1] == "load" || fields[1] == "snippet"):
+			spec := strings.Trim(fields[2], `"'`)
+			dir := ""
+			if fields[1] != "snippet" {
+				name := strings.ReplaceAll(spec, "/", "---")
+				dir = firstDir(expandPath("~/.local/share/zinit/plugins/"+name), expandPath("~/.zinit/plugins/"+name))
+			}
+			plugins = append(plugins, pluginAt(spec, ManagerZinit, dir, line.Location))
+			managers[ManagerZinit] = true
+		case fields[0] == "antigen" && fields[1] == "bundle" && len(fields) > 2:
+			spec := strings.Trim(fields[2], `"'`)
+			dir := expandPath("~/.antigen/bundles/" + spec)
+			if !strings.Contains(spec, "/") {
+				// A bare name is an oh-my-zsh plugin
+				dir = expandPath("~/.antigen/bundles/robbyrussell/oh-my-zsh/plugins/" + spec)
+			}
+			plugins = append(plugins, pluginAt(spec, ManagerAntigen, dir, line.Location))
+			managers[ManagerAntigen] = true
+		case fields[0] == "zplug" && strings.Contains(fields[1], "/"):
+			spec := strings.Trim(strings.TrimSuffix(fields[1], ","), `"'`)
+			plugins = append(plugins, pluginAt(spec, ManagerZplug, expandPath("~/.zplug/repos/"+spec), line.Location))
+			managers[ManagerZplug] = true
+		}
+	}
+	return plugins, managers
+}
+
+// promptTheme returns the oh-my-posh theme the startup files initialize,
+// as a plugin, if any
+func promptTheme(config *ShellConfig) (PluginInfo, bool) {
+	for _, line := range codeLines(config) {
+		if _, args, ok := strings.Cut(line.text, "oh-my-posh init"); ok {
+			return ohMyPoshTheme(args, line.Location), true
+		}
+	}
+	return PluginInfo{}, false
+}
+
+// ohMyPoshTheme returns the theme given to oh-my-posh init by the rest of
+// its line, named oh-my-posh when it uses the default one
+func ohMyPoshTheme(args string, defined Location) PluginInfo {
+	theme := ""
+	fields := strings.Fields(args)
+	for i, field := range fields {
+		if value, ok := strings.CutPrefix(field, "--config="); ok {
+			theme = value
+		} else if (field == "--config" || field == "-c") && i+1 < len(fields) {
+			theme = fields[i+1]
+		}
+	}
+	theme = strings.Trim(strings.TrimRight(theme, `)"'`), `"'`)
+	if theme == "" {
+		return PluginInfo{Name: ManagerOhMyPosh, Manager: ManagerOhMyPosh, Defined: defined}
+	}
+	name := filepath.Base(theme)
+	for _, ext := range []string{".omp.json", ".omp.yaml", ".omp.toml", ".json", ".yaml", ".toml"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	dir := ""
+	if !strings.Contains(theme, "$(") {
+		dir = expandPath(strings.Replace(strings.Replace(theme, "$HOME/", "~/", 1), "${HOME}/", "~/", 1))
+	}
+	return pluginAt(name, ManagerOhMyPosh, dir, defined)
+}
+
+// fisherPlugins reads the plugins fisher keeps in fish_plugins, one
+// owner/repo[@version] per line. fisher rewrites the file on every install
+// and update, so its time stands in for their last update.
+func fisherPlugins() []PluginInfo {
+	path := expandPath("~/.config/fish/fish_plugins")
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var plugins []PluginInfo
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, version, _ := strings.Cut(line, "@")
+		plugins = append(plugins, PluginInfo{
+			Name:        name,
+			Manager:     ManagerFisher,
+			Source:      path,
+			Version:     version,
+			LastUpdated: info.ModTime(),
+			Defined:     Location{Path: path, Line: i + 1},
+		})
+	}
+	return plugins
+}
+
+// bashItPlugins lists the plugins enabled in bash-it, linked in its
+// enabled directory as <priority>---<name>.plugin.bash
+func bashItPlugins() []PluginInfo {
+	root := expandPath("~/.bash_it")
+	entries, err := os.ReadDir(filepath.Join(root, "enabled"))
+	if err != nil {
+		return nil
+	}
+	version, updated, _ := checkoutInfo(root)
+	var plugins []PluginInfo
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".plugin.bash")
+		if !ok {
+			continue
+		}
+		if _, rest, found := strings.Cut(name, "---"); found {
+			name = rest
+		}
+		plugins = append(plugins, PluginInfo{
+			Name:        name,
+			Manager:     ManagerBashIt,
+			Source:      filepath.Join(root, "plugins", "available", name+".plugin.bash"),
+			Version:     version,
+			LastUpdated: updated,
+		})
+	}
+	return plugins
+}
@@ -0,0 +1,205 @@
+// internal/analyzer/probe.go
+package analyzer
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// probeCommands maps each language and tool to the command that prints
+// its version
+var probeCommands = map[string]string{
+	// Programming Languages
+	"python":  "python --version",
+	"python3": "python3 --version",
+	"node":    "node --version",
+	"go":      "go version",
+	"java":    "java -version",
+	"ruby":    "ruby --version",
+	"php":     "php --version",
+	"rust":    "rustc --version",
+	"perl":    "perl --version",
+	"scala":   "scala -version",
+	"kotlin":  "kotlin -version",
+	"swift":   "swift --version",
+	"r":       "R --version",
+	"julia":   "julia --version",
+	"haskell": "ghc --version",
+	"elixir":  "elixir --version",
+	"erlang":  "erl -version",
+	"clang":   "clang --version",
+	"gcc":     "gcc --version",
+	"dotnet":  "dotnet --version",
+	"lua":     "lua -v",
+	"ocaml":   "ocaml -version",
+	"dart":    "dart --version",
+	"zig":     "zig version",
+	"nim":     "nim --version",
+
+	// Build Tools & Package Managers
+	"maven":    "mvn --version",
+	"gradle":   "gradle --version",
+	"npm":      "npm --version",
+	"yarn":     "yarn --version",
+	"pnpm":     "pnpm --version",
+	"pip":      "pip --version",
+	"cargo":    "cargo --version",
+	"composer": "composer --version",
+	"bundler":  "bundle --version",
+
+	// DevOps & Cloud Tools
+	"docker":    "docker --version",
+	"kubectl":   "kubectl version --client",
+	"terraform": "terraform version",
+	"ansible":   "ansible --version",
+	"vagrant":   "vagrant --version",
+	"helm":      "helm version",
+	"aws":       "aws --version",
+	"gcloud":    "gcloud --version",
+	"azure":     "az --version",
+
+	// Version Control
+	"git":       "git --version",
+	"svn":       "svn --version",
+	"mercurial": "hg --version",
+
+	// Databases
+	"mysql":   "mysql --version",
+	"psql":    "psql --version",
+	"mongodb": "mongod --version",
+	"redis":   "redis-cli --version",
+
+	// Web Servers & Tools
+	"nginx":   "nginx -v",
+	"apache2": "apache2 -v",
+	"curl":    "curl --version",
+	"wget":    "wget --version",
+
+	// Text Editors & IDEs
+	"vim":   "vim --version",
+	"nvim":  "nvim --version",
+	"emacs": "emacs --version",
+	"code":  "code --version",
+
+	// Shell & Terminal Tools
+	"zsh":  "zsh --version",
+	"bash": "bash --version",
+	"fish": "fish --version",
+	"tmux": "tmux -V",
+}
+
+// Probing runs probeWorkers version commands at a time and gives up on one
+// after probeTimeout, e.g. a JVM that takes ages to start
+const (
+	probeWorkers = 8
+	probeTimeout = 5 * time.Second
+)
+
+// The probe results are kept for the lifetime of the process, installing a
+// tool while the analyzer runs is rare enough to need a restart, and in the
+// cache directory for probeCacheTTL
+var (
+	lookPathCache sync.Map
+	probeOnce     sync.Once
+	probed        map[string]string
+)
+
+// checkToolInstalled reports whether tool is on $PATH
+func checkToolInstalled(tool string) bool {
+	if found, ok := lookPathCache.Load(tool); ok {
+		return found.(bool)
+	}
+	probe, ok := cached(cachedPaths, tool)
+	if !ok {
+		path, err := exec.LookPath(tool)
+		probe = cachedProbe{Value: path, Found: err == nil}
+		remember(cachedPaths, tool, probe)
+	}
+	lookPathCache.Store(tool, probe.Found)
+	return probe.Found
+}
+
+// probeBinary is the program the version command of name runs
+func probeBinary(name string) string {
+	return strings.Fields(probeCommands[name])[0]
+}
+
+// getInstalledLanguages returns the version output of up to 10 installed
+// languages and tools. The result is a copy, callers may change it. report
+// is told how many tools were checked as the first call goes.
+func getInstalledLanguages(report func(done, total int)) map[string]string {
+	probeOnce.Do(func() {
+		probed = probeAll(report)
+	})
+	result := make(map[string]string, len(probed))
+	for name, version := range probed {
+		result[name] = version
+	}
+	return result
+}
+
+// probeAll runs the version commands of the tools on $PATH concurrently
+func probeAll(report func(done, total int)) map[string]string {
+	// Most are not installed, which needs no process to find out
+	var found []string
+	for _, name := range SortedKeys(probeCommands) {
+		if checkToolInstalled(probeBinary(name)) {
+			found = append(found, name)
+		}
+	}
+	report(0, len(found))
+
+	names := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	installed := make(map[string]string)
+	done := 0
+	for i := 0; i < probeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				probe, ok := cached(cachedVersions, name)
+				if !ok {
+					version, found := probeVersion(probeCommands[name])
+					probe = cachedProbe{Value: version, Found: found}
+					remember(cachedVersions, name, probe)
+				}
+				mu.Lock()
+				if probe.Found {
+					installed[name] = probe.Value
+				}
+				done++
+				report(done, len(found))
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, name := range found {
+		names <- name
+	}
+	close(names)
+	wg.Wait()
+
+	// Keep only the first 10 by name, so the same tools give the same result
+	sorted := SortedKeys(installed)
+	result := make(map[string]string)
+	for i := 0; i < len(sorted) && i < 10; i++ {
+		result[sorted[i]] = installed[sorted[i]]
+	}
+	return result
+}
+
+// probeVersion runs a version command with probeTimeout
+func probeVersion(command string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	// Children of sh that outlive it would keep the output open
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	return string(out), err == nil
+}
@@ -0,0 +1,132 @@
+// internal/analyzer/probe_cache.go
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// probeCacheTTL is how long probe results are reused by later runs
+const probeCacheTTL = 24 * time.Hour
+
+// The probe results live in one entry of the cache directory
+const (
+	probeBucket = "probe"
+	probeKey    = "tools"
+)
+
+// probeCache is what earlier runs found out about the tools on $PATH
+type probeCache struct {
+	// PathHash identifies the $PATH the results are for, a different one
+	// finds different tools
+	PathHash string `json:"path_hash"`
+	// Paths maps binaries to where they were found
+	Paths map[string]cachedProbe `json:"paths"`
+	// Versions maps languages and tools to their version output
+	Versions map[string]cachedProbe `json:"versions"`
+}
+
+// cachedProbe is one result: a path or version output, or that there is none
+type cachedProbe struct {
+	Value   string    `json:"value,omitempty"`
+	Found   bool      `json:"found"`
+	Checked time.Time `json:"checked"`
+}
+
+var (
+	probeCacheOnce  sync.Once
+	probeCacheMu    sync.Mutex
+	probeCacheData  probeCache
+	probeCacheDirty bool
+)
+
+// pathHash hashes $PATH
+func pathHash() string {
+	sum := sha256.Sum256([]byte(os.Getenv("PATH")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// loadProbeCache reads the results that are still fresh. Binaries that are
+// gone from where they were found are dropped, so are their versions.
+func loadProbeCache() {
+	probeCacheData = probeCache{PathHash: pathHash(), Paths: map[string]cachedProbe{}, Versions: map[string]cachedProbe{}}
+	cache, err := store.NewJSONStore(store.CacheDir())
+	if err != nil {
+		return
+	}
+	defer cache.Close()
+	raw, err := cache.Get(probeBucket, probeKey)
+	if err != nil {
+		return
+	}
+	var saved probeCache
+	if json.Unmarshal(raw, &saved) != nil || saved.PathHash != probeCacheData.PathHash {
+		return
+	}
+
+	now := time.Now()
+	for binary, probe := range saved.Paths {
+		if now.Sub(probe.Checked) > probeCacheTTL {
+			continue
+		}
+		if _, err := os.Stat(probe.Value); probe.Found && err != nil {
+			continue
+		}
+		probeCacheData.Paths[binary] = probe
+	}
+	for name, probe := range saved.Versions {
+		if _, ok := probeCacheData.Paths[probeBinary(name)]; ok && now.Sub(probe.Checked) <= probeCacheTTL {
+			probeCacheData.Versions[name] = probe
+		}
+	}
+}
+
+// cached looks a result up in one of the maps of the probe cache
+func cached(results func(*probeCache) map[string]cachedProbe, name string) (cachedProbe, bool) {
+	probeCacheOnce.Do(loadProbeCache)
+	probeCacheMu.Lock()
+	defer probeCacheMu.Unlock()
+	probe, ok := results(&probeCacheData)[name]
+	return probe, ok
+}
+
+// remember records a new result for the next runs
+func remember(results func(*probeCache) map[string]cachedProbe, name string, probe cachedProbe) {
+	probeCacheOnce.Do(loadProbeCache)
+	probeCacheMu.Lock()
+	defer probeCacheMu.Unlock()
+	probe.Checked = time.Now()
+	results(&probeCacheData)[name] = probe
+	probeCacheDirty = true
+}
+
+func cachedPaths(c *probeCache) map[string]cachedProbe    { return c.Paths }
+func cachedVersions(c *probeCache) map[string]cachedProbe { return c.Versions }
+
+// saveProbeCache writes the results back when there are new ones. Failing
+// to only costs the next run the probing.
+func saveProbeCache() {
+	probeCacheMu.Lock()
+	defer probeCacheMu.Unlock()
+	if !probeCacheDirty {
+		return
+	}
+	raw, err := json.Marshal(probeCacheData)
+	if err != nil {
+		return
+	}
+	cache, err := store.NewJSONStore(store.CacheDir())
+	if err != nil {
+		return
+	}
+	defer cache.Close()
+	if cache.Put(probeBucket, probeKey, raw) == nil {
+		probeCacheDirty = false
+	}
+}
@@ -0,0 +1,29 @@
+// internal/analyzer/problems.go
+package analyzer
+
+import "os"
+
+// Problem IDs, which double as message IDs, see internal/i18n
+const (
+	ProblemHistory = "history"
+	ProblemConfig  = "config"
+	ProblemCast    = "cast"
+)
+
+// Problem is something the analysis could not read and went on without,
+// so a view may be emptier than it should
+type Problem struct {
+	ID string
+	// Path is the file that failed, if any
+	Path string
+	Err  error
+}
+
+// problemFor returns the problem of reading path, none for a file that does
+// not exist since few people use every supported shell
+func problemFor(id, path string, err error) (Problem, bool) {
+	if err == nil || os.IsNotExist(err) {
+		return Problem{}, false
+	}
+	return Problem{ID: id, Path: path, Err: err}, true
+}
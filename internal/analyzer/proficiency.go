@@ -0,0 +1,92 @@
+// internal/analyzer/proficiency.go
+package analyzer
+
+import (
+	"math"
+	"time"
+)
+
+// proficiencyTools are the tools scored next to the languages
+var proficiencyTools = []string{"git", "docker", "kubectl", "terraform", "ansible", "make"}
+
+const (
+	// proficiencyHalfLife is the age at which a command counts half as
+	// much as one run today
+	proficiencyHalfLife = 90 * 24 * time.Hour
+	// ProficiencyRegular and ProficiencyHeavy are the scores from which a
+	// language or tool is used regularly or heavily
+	ProficiencyRegular = 10.0
+	ProficiencyHeavy   = 25.0
+)
+
+// Proficiency levels, as returned by ProficiencyLevel
+const (
+	LevelBeginner = "beginner"
+	LevelRegular  = "regular"
+	LevelHeavy    = "heavy"
+)
+
+// ProficiencyLevel labels a proficiency score, rounded as it is shown
+func ProficiencyLevel(score float64) string {
+	score = math.Round(score)
+	switch {
+	case score >= ProficiencyHeavy:
+		return LevelHeavy
+	case score >= ProficiencyRegular:
+		return LevelRegular
+	}
+	return LevelBeginner
+}
+
+// analyzeProficiency scores each language and tool the histories use from
+// 0 to 100: its share of the uses of all of them, with each command
+// weighted down by its age so current habits count most. Untimed commands
+// count as recent. Languages are recognised by their toolchains, as in
+// skillClusters.
+func analyzeProficiency(histories map[string][]CommandEntry) map[string]float64 {
+	tools := make(map[string]string)
+	for name, cluster := range skillClusters {
+		if cluster.language {
+			for _, program := range cluster.programs {
+				tools[program] = name
+			}
+		}
+	}
+	for _, tool := range proficiencyTools {
+		tools[tool] = tool
+	}
+
+	var newest time.Time
+	for _, history := range histories {
+		for _, entry := range history {
+			if entry.Timestamp.After(newest) {
+				newest = entry.Timestamp
+			}
+		}
+	}
+
+	weights := make(map[string]float64)
+	total := 0.0
+	for _, history := range histories {
+		for _, entry := range history {
+			weight := 1.0
+			if !entry.Timestamp.IsZero() {
+				weight = math.Pow(0.5, float64(newest.Sub(entry.Timestamp))/float64(proficiencyHalfLife))
+			}
+			seen := make(map[string]bool)
+			for _, program := range commandPrograms(entry.Command) {
+				if name, ok := tools[program]; ok && !seen[name] {
+					seen[name] = true
+					weights[name] += weight
+					total += weight
+				}
+			}
+		}
+	}
+
+	proficiency := make(map[string]float64, len(weights))
+	for name, weight := range weights {
+		proficiency[name] = min(100, weight/total*100)
+	}
+	return proficiency
+}
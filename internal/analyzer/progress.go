@@ -0,0 +1,37 @@
+// internal/analyzer/progress.go
+package analyzer
+
+// Stages of the analysis reported through Options.Progress
+const (
+	StageHistory  = "history"
+	StageProbe    = "probe"
+	StageInsights = "insights"
+)
+
+// progressStep is how many bytes of a history file are read between reports
+const progressStep = 256 * 1024
+
+// Progress tells how far the analysis got. For StageHistory Done and Total
+// count the bytes read of Shell's history file, for StageProbe the tools
+// checked; StageInsights comes once the histories are read.
+type Progress struct {
+	Stage string
+	Shell string
+	Done  int64
+	Total int64
+}
+
+// report passes p on to the Progress callback, if there is one
+func (o Options) report(p Progress) {
+	if o.Progress != nil {
+		o.Progress(p)
+	}
+}
+
+// reportHistory returns the callback reporting how much of shell's history
+// file was read
+func (o Options) reportHistory(shell string) func(read, size int64) {
+	return func(read, size int64) {
+		o.report(Progress{Stage: StageHistory, Shell: shell, Done: read, Total: size})
+	}
+}
@@ -0,0 +1,174 @@
+// internal/analyzer/projects.go
+package analyzer
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// projectMarkers map the files found at the root of a project to its kind.
+// They are checked in order, so a Go module that is also a git repository
+// is a Go project.
+var projectMarkers = []struct {
+	file string
+	kind string
+}{
+	{"go.mod", "go"},
+	{"Cargo.toml", "rust"},
+	{"package.json", "node"},
+	{"pyproject.toml", "python"},
+	{"setup.py", "python"},
+	{"requirements.txt", "python"},
+	{"Gemfile", "ruby"},
+	{"pom.xml", "java"},
+	{"build.gradle", "java"},
+	{"build.gradle.kts", "java"},
+	{"composer.json", "php"},
+	{"mix.exs", "elixir"},
+	{".git", "git"},
+}
+
+// Project is a directory under home with a project marker and the shell
+// time spent in it
+type Project struct {
+	Dir      string
+	Kind     string
+	Commands int
+	// Time adds up the pauses between commands run in the project within
+	// a session
+	Time     time.Duration
+	Last     time.Time
+	Programs []CommandCount
+}
+
+// ProjectUsage is where the shell time goes
+type ProjectUsage struct {
+	// Projects are ordered by commands, busiest first
+	Projects []Project
+	// Commands counts every entry, Elsewhere those run outside a project
+	Commands  int
+	Elsewhere int
+}
+
+// projectPrograms is how many programs are listed per project
+const projectPrograms = 3
+
+// AnalyzeProjects attributes each command to the project it ran in. The
+// directory comes from atuin or the cd commands in the history, or for
+// fish from the paths named on the command line. A project is the nearest
+// directory under home, at or above that, holding one of projectMarkers.
+func AnalyzeProjects(data ShellData) ProjectUsage {
+	home, err := utils.HomeDir()
+	if err != nil {
+		return ProjectUsage{}
+	}
+	finder := projectFinder{home: home, roots: make(map[string]projectRoot)}
+
+	var usage ProjectUsage
+	byDir := make(map[string]*Project)
+	programs := make(map[string]map[string]int)
+	var current *Project
+	var last time.Time
+
+	walkDirs(data.Histories, func(entry CommandEntry, cwd string, newSession bool) {
+		// The pause before this command belongs to the previous one
+		if current != nil && !newSession && !last.IsZero() && !entry.Timestamp.IsZero() {
+			if pause := entry.Timestamp.Sub(last); pause > 0 && pause <= SessionGap {
+				current.Time += pause
+			}
+		}
+		usage.Commands++
+
+		root, ok := finder.find(cwd)
+		if !ok {
+			for _, p := range entry.Paths {
+				if root, ok = finder.find(path.Dir(shortenHome(resolveDir(cwd, p), home))); ok {
+					break
+				}
+			}
+		}
+		if !ok {
+			usage.Elsewhere++
+			current, last = nil, entry.Timestamp
+			return
+		}
+
+		project := byDir[root.dir]
+		if project == nil {
+			project = &Project{Dir: root.dir, Kind: root.kind}
+			byDir[root.dir] = project
+			programs[root.dir] = make(map[string]int)
+		}
+		project.Commands++
+		if entry.Timestamp.After(project.Last) {
+			project.Last = entry.Timestamp
+		}
+		if program := commandProgram(entry.Command); program != "" && program != "cd" {
+			programs[root.dir][program]++
+		}
+		current, last = project, entry.Timestamp
+	})
+
+	for dir, project := range byDir {
+		project.Programs = SortedCounts(programs[dir], projectPrograms)
+		usage.Projects = append(usage.Projects, *project)
+	}
+	sort.Slice(usage.Projects, func(i, j int) bool {
+		a, b := usage.Projects[i], usage.Projects[j]
+		if a.Commands != b.Commands {
+			return a.Commands > b.Commands
+		}
+		return a.Dir < b.Dir
+	})
+	return usage
+}
+
+// projectRoot is a directory holding a project marker
+type projectRoot struct {
+	dir  string
+	kind string
+}
+
+// projectFinder looks up the project of a directory, remembering what it
+// found since the same directories come up again and again
+type projectFinder struct {
+	home  string
+	roots map[string]projectRoot
+}
+
+// find returns the project dir belongs to. dir is in ~/ form; directories
+// outside home and home itself belong to no project.
+func (f projectFinder) find(dir string) (projectRoot, bool) {
+	if !strings.HasPrefix(dir, "~/") {
+		return projectRoot{}, false
+	}
+	if root, ok := f.roots[dir]; ok {
+		return root, root.dir != ""
+	}
+
+	root := f.markerIn(dir)
+	if root.dir == "" {
+		if parent := path.Dir(dir); parent != dir {
+			root, _ = f.find(parent)
+		}
+	}
+	f.roots[dir] = root
+	return root, root.dir != ""
+}
+
+// markerIn checks dir itself for a project marker
+func (f projectFinder) markerIn(dir string) projectRoot {
+	full := filepath.Join(f.home, filepath.FromSlash(strings.TrimPrefix(dir, "~/")))
+	for _, marker := range projectMarkers {
+		if _, err := os.Stat(filepath.Join(full, marker.file)); err == nil {
+			return projectRoot{dir: dir, kind: marker.kind}
+		}
+	}
+	return projectRoot{}
+}
@@ -0,0 +1,116 @@
+// internal/analyzer/prompt.go
+package analyzer
+
+import (
+	"os"
+	"strings"
+)
+
+// PromptSetup is the prompt framework a shell starts
+type PromptSetup struct {
+	Shell     string
+	Framework string
+	// Theme is the theme or preset of the framework, if it has one
+	Theme string
+	// Location is the line that starts the framework or picks the theme
+	Location
+	// Config is the framework's own config file, if it exists
+	Config string
+}
+
+// Prompt frameworks, as in PromptSetup.Framework
+const (
+	PromptStarship      = "starship"
+	PromptPowerlevel10k = "powerlevel10k"
+	PromptOhMyPosh      = "oh-my-posh"
+	PromptPure          = "pure"
+	PromptSpaceship     = "spaceship"
+	PromptTide          = "tide"
+	PromptOhMyZsh       = "oh-my-zsh"
+	PromptBashIt        = "bash-it"
+)
+
+// promptBinaries are the frameworks that ship a program, found on $PATH
+// when installed whether or not a shell starts them
+var promptBinaries = map[string]string{PromptStarship: "starship", PromptOhMyPosh: "oh-my-posh"}
+
+// detectPrompt finds the prompt framework the startup files start, if any.
+// When
+// several are, the last one wins, as it would when the shell starts; the
+// theme of a plugin manager only counts when nothing else is started.
+func detectPrompt(shell string, config *ShellConfig) PromptSetup {
+	var found, fallback PromptSetup
+	for _, line := range codeLines(config) {
+		setup := PromptSetup{Shell: shell, Location: line.Location}
+		switch text := line.text; {
+		case strings.Contains(text, "starship init"):
+			setup.Framework = PromptStarship
+			setup.Config = existingFile(os.Getenv("STARSHIP_CONFIG"), expandPath("~/.config/starship.toml"))
+		case strings.Contains(text, "oh-my-posh init"):
+			setup.Framework = PromptOhMyPosh
+			_, args, _ := strings.Cut(text, "oh-my-posh init")
+			if theme := ohMyPoshTheme(args, line.Location); theme.Name != ManagerOhMyPosh {
+				setup.Theme, setup.Config = theme.Name, theme.Source
+			}
+		case strings.Contains(text, "powerlevel10k") || strings.Contains(text, "p10k-instant-prompt"):
+			setup.Framework = PromptPowerlevel10k
+			setup.Config = existingFile(expandPath("~/.p10k.zsh"))
+		case strings.Contains(text, "prompt pure") || strings.Contains(text, "sindresorhus/pure"):
+			setup.Framework = PromptPure
+		case strings.Contains(text, "spaceship"):
+			setup.Framework = PromptSpaceship
+			setup.Config = existingFile(expandPath("~/.spaceshiprc.zsh"), expandPath("~/.config/spaceship.zsh"))
+		default:
+			if theme, ok := strings.CutPrefix(text, "ZSH_THEME="); ok {
+				fallback = PromptSetup{Shell: shell, Framework: PromptOhMyZsh, Theme: strings.Trim(theme, `"'`), Location: line.Location}
+			} else if theme, ok := strings.CutPrefix(strings.TrimPrefix(text, "export "), "BASH_IT_THEME="); ok {
+				fallback = PromptSetup{Shell: shell, Framework: PromptBashIt, Theme: strings.Trim(theme, `"'`), Location: line.Location}
+			}
+			continue
+		}
+		found = setup
+	}
+
+	// tide is a fisher plugin and needs no line of its own
+	for _, plugin := range config.Plugins {
+		if found.Framework == "" && strings.EqualFold(plugin.Name, "ilancosman/tide") {
+			found = PromptSetup{Shell: shell, Framework: PromptTide, Theme: plugin.Version, Location: plugin.Defined}
+		}
+	}
+	if found.Framework == "" {
+		return fallback
+	}
+	return found
+}
+
+// existingFile returns the first of paths that is a file, or ""
+func existingFile(paths ...string) string {
+	for _, path := range paths {
+		if info, err := os.Stat(path); path != "" && err == nil && info.Mode().IsRegular() {
+			return path
+		}
+	}
+	return ""
+}
+
+// analyzePrompts collects the prompt of every shell, and the frameworks
+// installed on $PATH that no shell starts
+func analyzePrompts(configs map[string]ShellConfig, opts Options) ([]PromptSetup, []string) {
+	var prompts []PromptSetup
+	active := make(map[string]bool)
+	for _, shell := range SupportedShells() {
+		if prompt := configs[shell].Prompt; prompt.Framework != "" {
+			prompts = append(prompts, prompt)
+			active[prompt.Framework] = true
+		}
+	}
+	var unused []string
+	if opts.Enabled(ModuleProbe) {
+		for _, framework := range SortedKeys(promptBinaries) {
+			if !active[framework] && checkToolInstalled(promptBinaries[framework]) {
+				unused = append(unused, framework)
+			}
+		}
+	}
+	return prompts, unused
+}
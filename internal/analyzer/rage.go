@@ -0,0 +1,92 @@
+// internal/analyzer/rage.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// RageRepeat is a command the user kept running again within seconds,
+// usually because it failed
+type RageRepeat struct {
+	Command string
+	// Bursts counts the runs of repeats, Runs the commands in them and
+	// Longest the most runs in a single burst
+	Bursts  int
+	Runs    int
+	Longest int
+}
+
+const (
+	// RageGap is the most time between two runs of a burst
+	RageGap = 15 * time.Second
+	// rageMinRuns is how many runs in a row make a burst
+	rageMinRuns = 3
+	// rageShown caps the list of offenders
+	rageShown = 5
+)
+
+// harmlessRepeats are commands run again and again to look at something,
+// not because they failed
+var harmlessRepeats = map[string]bool{
+	"ls": true, "ll": true, "la": true, "l": true, "clear": true, "pwd": true, "date": true,
+	"git status": true, "git diff": true, "git log": true, "kubectl get pods": true, "docker ps": true,
+}
+
+// analyzeRageRepeats finds bursts of the same command run at least
+// rageMinRuns times in a row, each within RageGap of the one before. Where
+// the exit status is known, a burst only counts when the runs before the
+// last one failed.
+func analyzeRageRepeats(histories map[string][]CommandEntry) []RageRepeat {
+	repeats := make(map[string]*RageRepeat)
+	for _, history := range histories {
+		for start := 0; start < len(history); {
+			end := start + 1
+			for end < len(history) && history[end].Command == history[start].Command &&
+				!history[end-1].Timestamp.IsZero() && history[end].Timestamp.Sub(history[end-1].Timestamp) <= RageGap {
+				end++
+			}
+			if burst := history[start:end]; len(burst) >= rageMinRuns && rageBurst(burst) {
+				command := burst[0].Command
+				if repeats[command] == nil {
+					repeats[command] = &RageRepeat{Command: command}
+				}
+				repeats[command].Bursts++
+				repeats[command].Runs += len(burst)
+				repeats[command].Longest = max(repeats[command].Longest, len(burst))
+			}
+			start = end
+		}
+	}
+
+	var offenders []RageRepeat
+	for _, repeat := range repeats {
+		offenders = append(offenders, *repeat)
+	}
+	sort.Slice(offenders, func(i, j int) bool {
+		a, b := offenders[i], offenders[j]
+		if a.Bursts != b.Bursts {
+			return a.Bursts > b.Bursts
+		}
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return a.Command < b.Command
+	})
+	return offenders[:min(len(offenders), rageShown)]
+}
+
+// rageBurst reports whether the runs of burst look like fighting with the
+// command rather than watching its output
+func rageBurst(burst []CommandEntry) bool {
+	if harmlessRepeats[strings.Join(strings.Fields(burst[0].Command), " ")] {
+		return false
+	}
+	for _, entry := range burst[:len(burst)-1] {
+		if entry.HasExit && !entry.failed() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// internal/analyzer/repl.go
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// replOptions lists the interactive interpreters and, for each, the
+// options that make it run code instead of starting a prompt, e.g.
+// `python -c` or `psql -f`. Any other argument that is not an option
+// (a script, a file) means it is not interactive either, except for the
+// interpreters in replOperands.
+var replOptions = map[string]map[string]bool{
+	"python":  flagSet("-c -m"),
+	"python3": flagSet("-c -m"),
+	"ipython": flagSet("-c -m"),
+	"bpython": flagSet("-c"),
+	"node":    flagSet("-e --eval -p --print --test"),
+	"irb":     flagSet("-v --version"),
+	"pry":     flagSet("-e --exec"),
+	"ghci":    flagSet("-e"),
+	"lua":     flagSet("-e -v"),
+	"julia":   flagSet("-e --eval -E --print"),
+	"R":       flagSet("-e -f --file --version"),
+	"psql":    flagSet("-c --command -f --file -l --list -V --version"),
+	"mysql":   flagSet("-e --execute -V --version"),
+	"sqlite3": flagSet("-cmd -version"),
+}
+
+// replOperands still start a prompt when given operands: the database
+// shells connect to the database named and ghci loads the modules
+var replOperands = map[string]bool{"psql": true, "mysql": true, "sqlite3": true, "ghci": true}
+
+// replMaxStay caps the pause after a REPL launch that counts as time
+// spent in it; a longer one more likely means the terminal sat idle
+const replMaxStay = 4 * time.Hour
+
+// ExplorationTime is the time spent in interactive interpreters, which the
+// history only shows as the command that started them
+type ExplorationTime struct {
+	Launches int
+	// Time adds up the pause between each launch and the next command of
+	// the same shell, up to replMaxStay
+	Time time.Duration
+	// REPLs are ordered by time, then launches
+	REPLs []REPLUsage
+}
+
+// REPLUsage is the exploration time of one interpreter
+type REPLUsage struct {
+	Name     string
+	Launches int
+	Time     time.Duration
+}
+
+// analyzeExploration finds the REPL launches in every history and
+// estimates how long each lasted from the gap to the next command
+func analyzeExploration(histories map[string][]CommandEntry) ExplorationTime {
+	var exploration ExplorationTime
+	usage := make(map[string]*REPLUsage)
+	for _, history := range histories {
+		for i, entry := range history {
+			repl, ok := replLaunch(entry.Command)
+			if !ok {
+				continue
+			}
+			if usage[repl] == nil {
+				usage[repl] = &REPLUsage{Name: repl}
+			}
+			usage[repl].Launches++
+			exploration.Launches++
+
+			if i+1 < len(history) && !entry.Timestamp.IsZero() && !history[i+1].Timestamp.IsZero() {
+				if stay := history[i+1].Timestamp.Sub(entry.Timestamp); stay > 0 && stay <= replMaxStay {
+					usage[repl].Time += stay
+					exploration.Time += stay
+				}
+			}
+		}
+	}
+
+	for _, repl := range usage {
+		exploration.REPLs = append(exploration.REPLs, *repl)
+	}
+	sort.Slice(exploration.REPLs, func(i, j int) bool {
+		a, b := exploration.REPLs[i], exploration.REPLs[j]
+		if a.Time != b.Time {
+			return a.Time > b.Time
+		}
+		if a.Launches != b.Launches {
+			return a.Launches > b.Launches
+		}
+		return a.Name < b.Name
+	})
+	return exploration
+}
+
+// replLaunch reports whether command starts an interactive interpreter,
+// and which
+func replLaunch(command string) (string, bool) {
+	words := splitWords(command)
+	if len(words) > 1 && words[0] == "sudo" {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return "", false
+	}
+	repl := path.Base(words[0])
+	options, ok := replOptions[repl]
+	if !ok {
+		return "", false
+	}
+	for _, word := range words[1:] {
+		if options[word] || strings.ContainsAny(word, "|<>;&") {
+			return "", false
+		}
+		if !strings.HasPrefix(word, "-") && !replOperands[repl] {
+			return "", false
+		}
+	}
+	return repl, true
+}
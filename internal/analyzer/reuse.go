@@ -0,0 +1,89 @@
+// internal/analyzer/reuse.go
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// HistoryReuse estimates how many commands were recalled from history
+// rather than typed fresh. Histories do not record how a line was entered,
+// so a repeat of an earlier command is counted as recalled.
+type HistoryReuse struct {
+	Entries int
+	// Repeats are entries identical to an earlier entry of the same shell
+	Repeats int
+	// Expansions are entries using !!, !$, !n, !prefix or ^old^new
+	Expansions int
+	// Edits are fc and r invocations, which re-run or edit earlier commands
+	Edits int
+	// Tools lists the recall tools set up in the rc files, e.g. atuin
+	Tools []string
+}
+
+// Rate returns the share of entries that were recalled
+func (h HistoryReuse) Rate() float64 {
+	if h.Entries == 0 {
+		return 0
+	}
+	return float64(h.Repeats+h.Expansions+h.Edits) / float64(h.Entries)
+}
+
+// historyExpansion matches bash and zsh history expansion and quick
+// substitution. Shells usually save the expanded line, so these are only
+// seen when the expansion failed or was saved verbatim.
+var historyExpansion = regexp.MustCompile(`(^|[\s;|&(])!(!|\$|\*|\^|-?\d+|[A-Za-z?])|^\^[^^]+\^`)
+
+// recallTools maps the rc file snippets that set up a recall tool to its name
+var recallTools = []struct {
+	marker string
+	name   string
+}{
+	{"atuin init", "atuin"},
+	{"fzf", "fzf"},
+	{"mcfly init", "mcfly"},
+	{"hstr", "hstr"},
+	{"history-substring-search", "zsh-history-substring-search"},
+}
+
+// analyzeHistoryReuse measures history reuse across all shells
+func analyzeHistoryReuse(histories map[string][]CommandEntry, configs map[string]ShellConfig) HistoryReuse {
+	var reuse HistoryReuse
+	for _, history := range histories {
+		seen := make(map[string]bool, len(history))
+		for _, entry := range history {
+			reuse.Entries++
+			program := commandProgram(entry.Command)
+			switch {
+			case program == "fc" || program == "r":
+				reuse.Edits++
+			case historyExpansion.MatchString(entry.Command):
+				reuse.Expansions++
+			case seen[entry.Command]:
+				reuse.Repeats++
+			}
+			seen[entry.Command] = true
+		}
+	}
+
+	found := make(map[string]bool)
+	for _, config := range configs {
+		for _, info := range config.ConfigFiles {
+			content := strings.ToLower(info.Content)
+			for _, tool := range recallTools {
+				if strings.Contains(content, tool.marker) {
+					found[tool.name] = true
+				}
+			}
+		}
+		for _, plugin := range config.Plugins {
+			for _, tool := range recallTools {
+				if strings.Contains(strings.ToLower(plugin.Name), tool.marker) {
+					found[tool.name] = true
+				}
+			}
+		}
+	}
+	reuse.Tools = SortedKeys(found)
+	return reuse
+}
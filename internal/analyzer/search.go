@@ -0,0 +1,225 @@
+// internal/analyzer/search.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SearchQuery selects history entries. Text is matched fuzzily; the other
+// fields are exact filters and are ignored when empty.
+type SearchQuery struct {
+	Text     string
+	Shell    string
+	Category string
+	Since    time.Time
+	Until    time.Time
+}
+
+// SearchResult is one distinct command matching a query, with how often and
+// when it last ran within the filters
+type SearchResult struct {
+	Shell   string
+	Command string
+	Runs    int
+	Last    time.Time
+	Score   int
+}
+
+// Date layouts accepted by since: and until:, most specific first
+var searchDateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// ParseSearchQuery splits input into free text and filters. Filters are
+// words of the form shell:zsh, cat:development, since:2024-01 and
+// until:2024-06-30; dates may be given as a year, a month or a day.
+func ParseSearchQuery(input string) SearchQuery {
+	var q SearchQuery
+	var text []string
+	for _, word := range strings.Fields(input) {
+		key, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			text = append(text, word)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "shell":
+			q.Shell = strings.ToLower(value)
+		case "cat", "category":
+			q.Category = strings.ToLower(value)
+		case "since", "after", "from":
+			if t, _, ok := parseSearchDate(value); ok {
+				q.Since = t
+			} else {
+				text = append(text, word)
+			}
+		case "until", "before", "to":
+			if t, layout, ok := parseSearchDate(value); ok {
+				q.Until = endOfPeriod(t, layout)
+			} else {
+				text = append(text, word)
+			}
+		default:
+			text = append(text, word)
+		}
+	}
+	q.Text = strings.Join(text, " ")
+	return q
+}
+
+// parseSearchDate parses a date in local time and reports the layout used
+func parseSearchDate(s string) (time.Time, string, bool) {
+	for _, layout := range searchDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, layout, true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// endOfPeriod returns the last instant of the day, month or year starting at t
+func endOfPeriod(t time.Time, layout string) time.Time {
+	switch layout {
+	case "2006":
+		t = t.AddDate(1, 0, 0)
+	case "2006-01":
+		t = t.AddDate(0, 1, 0)
+	default:
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.Add(-time.Nanosecond)
+}
+
+// Search returns the distinct commands matching q, best match first, and
+// at most limit of them when limit is positive. With no text the most
+// recently run commands come first.
+func Search(data ShellData, q SearchQuery, limit int) []SearchResult {
+	type key struct{ shell, command string }
+	found := make(map[key]*SearchResult)
+
+	for shell, history := range data.Histories {
+		if q.Shell != "" && shell != q.Shell {
+			continue
+		}
+		for _, entry := range history {
+			if !q.matchesFilters(entry) {
+				continue
+			}
+			k := key{shell, entry.Command}
+			if r, ok := found[k]; ok {
+				r.Runs++
+				if entry.Timestamp.After(r.Last) {
+					r.Last = entry.Timestamp
+				}
+				continue
+			}
+			score, ok := fuzzyScore(q.Text, entry.Command)
+			if !ok {
+				continue
+			}
+			found[k] = &SearchResult{Shell: shell, Command: entry.Command, Runs: 1, Last: entry.Timestamp, Score: score}
+		}
+	}
+
+	results := make([]SearchResult, 0, len(found))
+	for _, r := range found {
+		results = append(results, *r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Last.Equal(b.Last) {
+			return a.Last.After(b.Last)
+		}
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		if a.Command != b.Command {
+			return a.Command < b.Command
+		}
+		return a.Shell < b.Shell
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// matchesFilters applies the category and date filters. Entries without a
+// timestamp never match a date range.
+func (q SearchQuery) matchesFilters(entry CommandEntry) bool {
+	if q.Category != "" {
+		matched := false
+		for _, category := range entry.Categories {
+			if strings.HasPrefix(category, q.Category) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if q.Since.IsZero() && q.Until.IsZero() {
+		return true
+	}
+	if entry.Timestamp.IsZero() {
+		return false
+	}
+	if !q.Since.IsZero() && entry.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && entry.Timestamp.After(q.Until) {
+		return false
+	}
+	return true
+}
+
+// fuzzyScore reports whether the characters of pattern appear in order in s,
+// ignoring case, and scores the match. Runs of consecutive characters,
+// matches at the start of a word and exact substrings score higher.
+func fuzzyScore(pattern, s string) (int, bool) {
+	pattern = strings.ToLower(strings.Join(strings.Fields(pattern), " "))
+	if pattern == "" {
+		return 0, true
+	}
+	target := []rune(strings.ToLower(s))
+	score := 0
+	consecutive := 0
+	i := 0
+	for _, p := range pattern {
+		matched := false
+		for ; i < len(target); i++ {
+			if target[i] != p {
+				consecutive = 0
+				continue
+			}
+			score++
+			if consecutive > 0 {
+				score += 2 * consecutive
+			}
+			if i == 0 || !unicode.IsLetter(target[i-1]) && !unicode.IsDigit(target[i-1]) {
+				score += 3
+			}
+			consecutive++
+			matched = true
+			i++
+			break
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+	if idx := strings.Index(string(target), pattern); idx >= 0 {
+		score += 10
+		if idx == 0 {
+			score += 10
+		}
+	}
+	// Prefer shorter commands among equally good matches
+	score -= len(target) / 20
+	return score, true
+}
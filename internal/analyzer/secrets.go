@@ -0,0 +1,100 @@
+// internal/analyzer/secrets.go
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+)
+
+// SecretEntry is a history entry that contains a likely secret
+type SecretEntry struct {
+	// Line is where the entry starts in the history file, counting from 1
+	Line    int
+	Command string
+	// Kinds lists what was found, e.g. "aws_key", see redact.Find
+	Kinds []string
+}
+
+// historyEntry spans the lines of one command in a raw history file,
+// including bash timestamp comments and fish metadata
+type historyEntry struct {
+	start, end int
+	command    string
+}
+
+// HistoryFile returns the path of shell's history file
+func HistoryFile(shell string) string {
+	return expandPath(historyPaths[shell])
+}
+
+// FindSecrets lists the entries of a history file that contain likely secrets
+func FindSecrets(content []byte, shell string) []SecretEntry {
+	var found []SecretEntry
+	for _, entry := range splitHistory(splitLines(content), shell) {
+		if kinds := redact.Find(entry.command); len(kinds) > 0 {
+			found = append(found, SecretEntry{Line: entry.start + 1, Command: entry.command, Kinds: kinds})
+		}
+	}
+	return found
+}
+
+// ScrubSecrets removes the entries that contain likely secrets from a
+// history file, keeping every other byte as it was. It returns the new
+// content and how many entries were removed.
+func ScrubSecrets(content []byte, shell string) ([]byte, int) {
+	lines := splitLines(content)
+	drop := make([]bool, len(lines))
+	removed := 0
+	for _, entry := range splitHistory(lines, shell) {
+		if len(redact.Find(entry.command)) == 0 {
+			continue
+		}
+		for i := entry.start; i < entry.end; i++ {
+			drop[i] = true
+		}
+		removed++
+	}
+
+	var kept strings.Builder
+	for i, line := range lines {
+		if !drop[i] {
+			kept.WriteString(line)
+		}
+	}
+	return []byte(kept.String()), removed
+}
+
+// splitLines splits content after each newline, so joining the lines
+// gives back the exact content
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitHistory groups raw history lines into entries with the splitter
+// scanHistory reads them with
+func splitHistory(lines []string, shell string) []historyEntry {
+	var entries []historyEntry
+	add := func(raw rawEntry) {
+		if entry, ok := parseEntry(shell, raw); ok {
+			entries = append(entries, historyEntry{start: raw.first, end: raw.end, command: entry.Command})
+		}
+	}
+	splitter := historySplitter{shell: shell}
+	for _, line := range lines {
+		if raw, done := splitter.add(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")); done {
+			add(raw)
+		}
+	}
+	if raw, ok := splitter.flush(); ok {
+		add(raw)
+	}
+	return entries
+}
@@ -0,0 +1,78 @@
+// internal/analyzer/security.go
+package analyzer
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+)
+
+// SecurityFinding counts the commands matching one risky pattern. IDs
+// double as message IDs, see internal/i18n.
+type SecurityFinding struct {
+	ID    string
+	Count int
+	// Example is the most recent matching command and Last when it ran,
+	// if the history recorded it
+	Example string
+	Last    time.Time
+}
+
+// securityRules are the risky patterns, most dangerous first
+var securityRules = []struct {
+	id    string
+	match func(command string) bool
+}{
+	{"secret", func(command string) bool { return len(redact.Find(command)) > 0 }},
+	{"rm_root", regexp.MustCompile(`\brm\s+(-\S+\s+)*-[a-zA-Z]*[rR][a-zA-Z]*\s+(-\S+\s+)*(/\*?|~/?|\$HOME/?)(\s|;|$)`).MatchString},
+	{"dd_device", regexp.MustCompile(`\bdd\b.*\bof=/dev/(sd|hd|nvme|disk|mmcblk)`).MatchString},
+	{"sudo_pipe", regexp.MustCompile(`\b(curl|wget)\b[^|]*\|\s*sudo\b`).MatchString},
+	{"pipe_to_shell", regexp.MustCompile(`\b(curl|wget)\b[^|]*\|\s*(ba|z|da|k)?sh\b`).MatchString},
+	{"chmod_777", regexp.MustCompile(`\bchmod\s+(-\S+\s+)*(0?777|a\+rwx)\b`).MatchString},
+	{"force_push", regexp.MustCompile(`\bgit\s+push\b.*\s(-f|--force)(\s|$)`).MatchString},
+	{"insecure_tls", regexp.MustCompile(`\bcurl\b.*\s(-[a-zA-Z]*k[a-zA-Z]*|--insecure)(\s|$)|\bwget\b.*\s--no-check-certificate(\s|$)`).MatchString},
+}
+
+// AuditCommands flags risky commands in the histories and returns one
+// finding per matched rule, in rule order
+func AuditCommands(histories map[string][]CommandEntry) []SecurityFinding {
+	findings := make([]SecurityFinding, len(securityRules))
+	for i, rule := range securityRules {
+		findings[i].ID = rule.id
+	}
+
+	for _, shell := range SortedKeys(histories) {
+		for _, entry := range histories[shell] {
+			for i, rule := range securityRules {
+				if !rule.match(entry.Command) {
+					continue
+				}
+				f := &findings[i]
+				f.Count++
+				if f.Example == "" || !entry.Timestamp.Before(f.Last) {
+					f.Example = entry.Command
+					f.Last = entry.Timestamp
+				}
+			}
+		}
+	}
+
+	var matched []SecurityFinding
+	for _, f := range findings {
+		if f.Count > 0 {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
+
+// isRisky reports whether command matches any security rule
+func isRisky(command string) bool {
+	for _, rule := range securityRules {
+		if rule.match(command) {
+			return true
+		}
+	}
+	return false
+}
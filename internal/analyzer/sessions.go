@@ -0,0 +1,65 @@
+// internal/analyzer/sessions.go
+package analyzer
+
+import (
+	"sort"
+	"time"
+)
+
+// SessionGap is the idle time after which the next command starts a new
+// work session
+const SessionGap = 30 * time.Minute
+
+// SessionStats summarizes the work sessions found in the history
+type SessionStats struct {
+	Count int
+	// AverageLength and Longest span from the first to the last command of
+	// a session
+	AverageLength      time.Duration
+	Longest            time.Duration
+	LongestStart       time.Time
+	CommandsPerSession float64
+}
+
+// analyzeSessions clusters the timestamped entries of every shell into
+// sessions separated by at least SessionGap of inactivity
+func analyzeSessions(histories map[string][]CommandEntry) SessionStats {
+	var times []time.Time
+	for _, history := range histories {
+		for _, entry := range history {
+			if !entry.Timestamp.IsZero() {
+				times = append(times, entry.Timestamp)
+			}
+		}
+	}
+	if len(times) == 0 {
+		return SessionStats{}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	var stats SessionStats
+	var total time.Duration
+	start := times[0]
+	closeSession := func(end time.Time) {
+		length := end.Sub(start)
+		stats.Count++
+		total += length
+		if stats.Count == 1 || length > stats.Longest {
+			stats.Longest = length
+			stats.LongestStart = start
+		}
+	}
+	for i := 1; i < len(times); i++ {
+		if times[i].Sub(times[i-1]) >= SessionGap {
+			closeSession(times[i-1])
+			start = times[i]
+		}
+	}
+	closeSession(times[len(times)-1])
+
+	stats.AverageLength = total / time.Duration(stats.Count)
+	stats.CommandsPerSession = float64(len(times)) / float64(stats.Count)
+	return stats
+}
@@ -4,130 +4,678 @@ package analyzer
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/telemetry"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
 )
 
+// Options controls how the analysis is performed
+type Options struct {
+	// LowMemory streams history files and keeps only aggregates plus a
+	// bounded sample of the most recent entries per shell
+	LowMemory bool
+	// SampleSize caps the entries kept per shell in low-memory mode and
+	// when the counts come from the cache
+	SampleSize int
+	// Full parses every history file again instead of only what was added
+	// since the last run, and keeps all of its entries. Low-memory mode and
+	// a period always parse every file.
+	Full bool
+	// Progress, when set, is called as the analysis goes on, possibly from
+	// several goroutines at once
+	Progress func(Progress)
+	// Shells limits the analysis to these shells; empty means all of them
+	Shells []string
+	// Disabled lists the Modules to skip
+	Disabled []string
+	// Casts lists asciinema recordings, or directories of them, to read
+	// commands from in addition to the shell histories
+	Casts []string
+	// Budgets are the soft limits checked against the history
+	Budgets []Budget
+	// Since and Until limit the analysis to the entries from that period,
+	// see InRange; zero means unbounded
+	Since time.Time
+	Until time.Time
+	// GitReflogs reads the reflogs of the git repositories among the
+	// projects to count commits
+	GitReflogs bool
+}
+
+// Analysis modules that can be disabled for a lean, history-only analysis
+const (
+	// ModuleConfig reads shell startup files for aliases and environment
+	ModuleConfig = "config"
+	// ModulePlugins looks for plugin managers and their plugins
+	ModulePlugins = "plugins"
+	// ModuleProbe runs installed tools to find languages and checks $PATH
+	ModuleProbe = "probe"
+)
+
+// Modules lists the analysis modules, in the order they are documented
+var Modules = []string{ModuleConfig, ModulePlugins, ModuleProbe}
+
+// historyPaths maps each supported shell to its history file
+var historyPaths = map[string]string{
+	"bash": "~/.bash_history",
+	"zsh":  "~/.zsh_history",
+	"fish": "~/.local/share/fish/fish_history",
+}
+
+// SetHistoryPath reads shell's history from path instead of the default,
+// e.g. for a HISTFILE set in the rc file
+func SetHistoryPath(shell, path string) error {
+	if _, ok := historyPaths[shell]; !ok {
+		return fmt.Errorf("unknown shell %q", shell)
+	}
+	historyPaths[shell] = path
+	return nil
+}
+
+// HistoryFiles returns the history file of each shell opts analyzes
+func HistoryFiles(opts Options) map[string]string {
+	files := make(map[string]string)
+	for shell, path := range historyPaths {
+		if opts.Includes(shell) {
+			files[shell] = expandPath(path)
+		}
+	}
+	return files
+}
+
+// SupportedShells returns the shells whose history can be analyzed, by name
+func SupportedShells() []string {
+	shells := make([]string, 0, len(historyPaths))
+	for shell := range historyPaths {
+		shells = append(shells, shell)
+	}
+	sort.Strings(shells)
+	return shells
+}
+
+// Includes reports whether shell should be analyzed
+func (opts Options) Includes(shell string) bool {
+	if len(opts.Shells) == 0 {
+		return true
+	}
+	for _, s := range opts.Shells {
+		if s == shell {
+			return true
+		}
+	}
+	return false
+}
+
+// sampled reports whether the histories hold only the most recent entries:
+// in low-memory mode, and when the counts come from the cache, see
+// readIncrementally
+func (opts Options) sampled() bool {
+	return opts.LowMemory || (!opts.Full && !opts.bounded())
+}
+
+// Enabled reports whether module should run
+func (opts Options) Enabled(module string) bool {
+	for _, m := range opts.Disabled {
+		if m == module {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultSampleSize keeps enough recent history for the entry-based views
+// while bounding memory to a few MB per shell: an entry takes about 150
+// bytes plus its command line, so a sample is 3 to 5 MB, and the bash, zsh
+// and fish samples and the recordings', with the copies the insights make,
+// stay under 40 MB, a fraction of a 512MB machine whatever the history
+// length (see TestLowMemoryBudget)
+const defaultSampleSize = 20000
+
 func AnalyzeShells() tea.Msg {
+	return Analyze(Options{})
+}
+
+// AnalyzeShellsWith returns a command running the analysis with opts
+func AnalyzeShellsWith(opts Options) tea.Cmd {
+	return func() tea.Msg {
+		return Analyze(opts)
+	}
+}
+
+// Analyze reads every supported shell history and computes the insights
+func Analyze(opts Options) ShellData {
 	data := InitShellData()
+	data.Options = opts
+	monthly := make(map[time.Time]map[string]int)
+	span := telemetry.Start("analyze")
+	defer span.End(nil)
+	defer saveProbeCache()
+
+	// Probing runs every known tool, so do it once for all shells, while
+	// the histories are read
+	probed := make(chan map[string]string, 1)
+	go func() {
+		installed := map[string]string{}
+		if opts.Enabled(ModuleProbe) {
+			probe := span.Child("probe")
+			installed = getInstalledLanguages(func(done, total int) {
+				opts.report(Progress{Stage: StageProbe, Done: int64(done), Total: int64(total)})
+			})
+			probe.SetAttribute("languages", len(installed))
+			probe.End(nil)
+		}
+		probed <- installed
+	}()
 
-	// Read shell histories
-	shellPaths := map[string]string{
-		"bash": "~/.bash_history",
-		"zsh":  "~/.zsh_history",
-		"fish": "~/.local/share/fish/fish_history",
+	var shells []string
+	for _, shell := range SupportedShells() {
+		if opts.Includes(shell) {
+			shells = append(shells, shell)
+		}
+	}
+	// Low-memory mode streams each file instead, so only the sample is held
+	var read map[string]*parsedHistory
+	if !opts.LowMemory {
+		read = readHistories(shells, opts)
+	}
+	installed := <-probed
+
+	// Aggregate the histories in a fixed order so reports are reproducible
+	for _, shell := range shells {
+		parse := span.Child("parse")
+		parse.SetAttribute("shell", shell)
+		path := expandPath(historyPaths[shell])
+		history, tail, err := loadHistory(path, shell, read[shell], opts, &data, monthly)
+		endParse(parse, shell, data.CommandCounts[shell], err)
+		if problem, ok := problemFor(ProblemHistory, path, err); ok {
+			data.Problems = append(data.Problems, problem)
+		}
+		if err != nil {
+			continue
+		}
+		data.Histories[shell] = history
+		if tail, err = tail.checksummed(path); err == nil {
+			data.tails[shell] = tail
+		}
+		analyzeCommands(history, installed, opts, &data)
+		if opts.Enabled(ModuleConfig) || opts.Enabled(ModulePlugins) {
+			config := analyzeShellConfigs(shell, opts)
+			config.Issues = lintConfig(shell, config, history, clock.Now())
+			data.ShellConfigs[shell] = config
+			data.Problems = append(data.Problems, data.ShellConfigs[shell].Problems...)
+			if opts.LowMemory {
+				dropConfigContent(data.ShellConfigs[shell])
+			}
+		}
 	}
 
-	for shell, path := range shellPaths {
-		expandedPath := expandPath(path)
-		if history, err := readHistory(expandedPath); err == nil {
-			data.Histories[shell] = history
-			analyzeCommands(history, &data)
-			data.ShellConfigs[shell] = analyzeShellConfigs(shell)
+	opts.report(Progress{Stage: StageInsights})
+
+	// Recordings fill in what happened before the shell histories begin
+	if len(opts.Casts) > 0 {
+		parse := span.Child("parse")
+		parse.SetAttribute("shell", CastSource)
+		history, err := loadCasts(opts, &data, monthly)
+		endParse(parse, CastSource, data.CommandCounts[CastSource], err)
+		if err == nil && len(history) > 0 {
+			data.Histories[CastSource] = history
+			analyzeCommands(history, installed, opts, &data)
 		}
 	}
 
+	insights := span.Child("insights")
+	defer insights.End(nil)
+
+	// atuin and the shell hooks know where each command ran and how it
+	// ended, the shell histories do not
+	addAtuinRecords(data.Histories)
+	addHookRecords(data.Histories)
+	data.installed, data.monthly = installed, monthly
+	deriveInsights(&data, installed, opts, monthly)
+	data.Insights.TechnicalProfile.Prompts, data.Insights.TechnicalProfile.PromptsInstalled = analyzePrompts(data.ShellConfigs, opts)
+
+	slog.Info("analysis finished", "shells", len(data.Histories), "problems", len(data.Problems))
+	return data
+}
+
+// deriveInsights computes the insights drawn from the histories as a
+// whole, once every history is read, and again by Tail
+func deriveInsights(data *ShellData, installed map[string]string, opts Options, monthly map[time.Time]map[string]int) {
+	data.Insights.WorkPatterns.Outcomes = analyzeOutcomes(data.Histories)
+	data.Insights.WorkPatterns.RageRepeats = analyzeRageRepeats(data.Histories)
+	data.Insights.WorkPatterns.CommonWorkflows = analyzeWorkflows(data)
+	inferSkills(data.Histories, &data.Insights.TechnicalProfile)
+	data.Insights.TechnicalProfile.Proficiency = analyzeProficiency(data.Histories)
+
 	// Analyze tool usage separately
 	var allEntries []CommandEntry
 	for _, history := range data.Histories {
 		allEntries = append(allEntries, history...)
 	}
-	data.Insights.ToolUsage = analyzeToolUsage(allEntries)
+	data.Insights.ToolUsage = analyzeToolUsage(allEntries, installed, opts)
+	data.Insights.ToolUsage.Direnv = AnalyzeDirenv(*data)
+	data.Insights.ToolUsage.Network = AnalyzeNetwork(data.Histories)
+	data.Insights.ToolUsage.Edits = analyzeEditing(data.Histories)
+	data.Insights.TechnicalProfile.TechStack = techStack(data.Histories, installed, data.Insights.ToolUsage.Edits)
+	data.Insights.ToolUsage.Packages = AnalyzeInstalls(data.Histories)
+	data.Insights.ToolUsage.Multiplexers = AnalyzeMultiplexers(data.Histories, opts)
+	data.Insights.WorkPatterns.PeakHours = PeakHours(HourlyActivity(data.Insights.WorkPatterns))
+	data.Insights.WorkPatterns.Sessions = analyzeSessions(data.Histories)
+	data.Insights.WorkPatterns.WorkLife = analyzeWorkLife(data.Histories)
+	data.Insights.WorkPatterns.Reuse = analyzeHistoryReuse(data.Histories, data.ShellConfigs)
+	data.Insights.WorkPatterns.Complexity = analyzeComplexity(data.Histories)
+	data.Insights.WorkPatterns.Exploration = analyzeExploration(data.Histories)
+	data.Insights.Projects = AnalyzeProjects(*data)
+	data.Insights.Git = AnalyzeGit(*data)
+	data.Insights.Containers = AnalyzeContainers(data.Histories)
+	data.Insights.Budgets = CheckBudgets(data.Histories, opts.Budgets)
+	data.Migration = analyzeShellMigration(monthly, data.ShellConfigs)
+	data.Insights.Achievements = ComputeAchievements(*data, clock.Now())
+	data.Insights.Security = AuditCommands(data.Histories)
+	data.Insights.Suggestions = Suggest(*data)
+}
 
-	return data
+// endParse finishes the span of reading one history. A missing history
+// file is not a failure, few people use every supported shell.
+func endParse(span *telemetry.Span, shell string, commands int, err error) {
+	span.SetAttribute("commands", commands)
+	if os.IsNotExist(err) {
+		span.SetAttribute("missing", true)
+		span.End(nil)
+		return
+	}
+	if err != nil {
+		slog.Warn("failed to read history", "shell", shell, "err", err)
+	} else {
+		slog.Info("read history", "shell", shell, "commands", commands)
+	}
+	span.End(err)
+	telemetry.Count("k8au.commands.parsed", int64(commands), map[string]string{"shell": shell})
 }
 
-func readHistory(path string) ([]CommandEntry, error) {
-	file, err := os.Open(path)
+// loadHistory streams a history file, updating the per-shell aggregates as
+// it goes, or replays it from read when it was already parsed, or adds the
+// counts of read. When the histories are sampled only the most recent
+// SampleSize entries are kept; otherwise the full history is returned. The tail tells where the file
+// was read up to, without its checksum.
+func loadHistory(path, shell string, read *parsedHistory, opts Options, data *ShellData, monthly map[time.Time]map[string]int) ([]CommandEntry, historyTail, error) {
+	var tail historyTail
+	if read != nil && read.counts != nil {
+		read.counts.addTo(shell, data, monthly)
+		return read.counts.Recent, read.tail, nil
+	}
+	entries, err := loadEntries(shell, func(fn func(CommandEntry)) error {
+		if read == nil {
+			emitted := 0
+			resume, kept, err := scanHistoryFrom(path, shell, 0, func(entry CommandEntry) {
+				emitted++
+				fn(entry)
+			}, opts.reportHistory(shell))
+			tail = historyTail{offset: resume, pending: emitted - kept}
+			return err
+		}
+		if read.err != nil {
+			return read.err
+		}
+		for _, entry := range read.entries {
+			fn(entry)
+		}
+		tail = read.tail
+		return nil
+	}, opts, data, monthly)
+	return entries, tail, err
+}
+
+// parsedHistory is a history file parsed ahead of the aggregation, and
+// where it was read up to
+type parsedHistory struct {
+	entries []CommandEntry
+	// counts replace the entries when they came from the cache
+	counts *historyAggregates
+	tail   historyTail
+	err    error
+}
+
+// readHistories parses the history files of shells concurrently, see
+// readIncrementally. The aggregates are shared, so they are built afterwards
+// from the result.
+func readHistories(shells []string, opts Options) map[string]*parsedHistory {
+	read := make(map[string]*parsedHistory, len(shells))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, shell := range shells {
+		wg.Add(1)
+		go func(shell string) {
+			defer wg.Done()
+			parsed := readIncrementally(expandPath(historyPaths[shell]), shell, opts)
+			mu.Lock()
+			read[shell] = parsed
+			mu.Unlock()
+		}(shell)
+	}
+	wg.Wait()
+	return read
+}
+
+// loadEntries collects the entries produced by scan for shell, which may
+// also be a non-shell source such as CastSource
+func loadEntries(shell string, scan func(func(CommandEntry)) error, opts Options, data *ShellData, monthly map[time.Time]map[string]int) ([]CommandEntry, error) {
+	limit := 0
+	if opts.LowMemory {
+		limit = sampleSize(opts)
+	}
+
+	var entries []CommandEntry
+	next := 0
+	err := scan(func(entry CommandEntry) {
+		if !opts.InRange(entry.Timestamp) || Ignored(entry.Command) {
+			return
+		}
+		data.CommandCounts[shell]++
+		// Only real shells take part in the shell journey
+		if shell != CastSource {
+			addMonthlyActivity(monthly, shell, entry)
+		}
+		addActivity(&data.Insights.WorkPatterns, entry)
+		addCommandCounts(data, shell, entry)
+		addSubcommandCounts(data, entry)
+
+		if limit == 0 || len(entries) < limit {
+			entries = append(entries, entry)
+			return
+		}
+		// Ring buffer: overwrite the oldest sampled entry
+		entries[next] = entry
+		next = (next + 1) % limit
+	})
 	if err != nil {
 		return nil, err
 	}
+
+	// Restore chronological order after the ring buffer wrapped
+	if next > 0 {
+		entries = append(entries[next:], entries[:next]...)
+	}
+	return entries, nil
+}
+
+// dropConfigContent releases the raw rc file contents once they are parsed
+func dropConfigContent(config ShellConfig) {
+	for name, info := range config.ConfigFiles {
+		info.Content = ""
+		config.ConfigFiles[name] = info
+	}
+}
+
+// scanHistory parses a history file line by line and calls fn for each
+// command, so callers decide how much of the history to keep in memory
+func scanHistory(path, shell string, fn func(CommandEntry)) error {
+	_, _, err := scanHistoryFrom(path, shell, 0, fn, nil)
+	return err
+}
+
+// scanHistoryFrom parses a history file from offset on, which must be the
+// start of an entry. It returns where a later scan can resume, the end of
+// the last entry known to be complete, and how many entries came before it.
+// report, when set, is told how far the scan got every progressStep bytes.
+func scanHistoryFrom(path, shell string, offset int64, fn func(CommandEntry), report func(read, size int64)) (resume int64, kept int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return offset, 0, err
+	}
 	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, 0, err
+	}
+	var size int64
+	if info, err := file.Stat(); err == nil {
+		size = info.Size()
+	}
+	if report == nil {
+		report = func(read, size int64) {}
+	}
+	reported := offset
+	report(offset, size)
 
-	var entries []CommandEntry
 	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	// Track where the line starts and ends in the file, and whether it was
+	// ended, as a line still being written has no newline yet
+	start, read, ended := offset, offset, false
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if advance > 0 {
+			start, read = read, read+int64(advance)
+			ended = data[advance-1] == '\n'
+		}
+		return advance, token, err
+	})
+	resume = offset
+	emitted := 0
+	emit := func(raw rawEntry) {
+		if entry, ok := parseEntry(shell, raw); ok {
+			emitted++
+			fn(entry)
+		}
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if cmd := cleanHistoryLine(line); cmd != "" {
-			entries = append(entries, CommandEntry{
-				Command:    cmd,
-				Timestamp:  time.Now(), // For simplicity
-				Categories: categorizeCommand(cmd),
-			})
+	splitter := historySplitter{shell: shell}
+	for line := 0; scanner.Scan(); line++ {
+		if read-reported >= progressStep {
+			report(read, size)
+			reported = read
+		}
+		raw, done := splitter.add(scanner.Text())
+		if !done {
+			continue
+		}
+		emit(raw)
+		// A fish entry ends where the next one starts, on this line
+		if raw.end == line {
+			resume, kept = start, emitted
+		} else if ended {
+			resume, kept = read, emitted
 		}
 	}
+	// The last fish entry has nothing after it to end it
+	if raw, ok := splitter.flush(); ok {
+		emit(raw)
+		if shell == "fish" && ended {
+			resume, kept = read, emitted
+		}
+	}
+	report(read, max(size, read))
 
-	return entries, scanner.Err()
+	return resume, kept, scanner.Err()
 }
 
-func cleanHistoryLine(line string) string {
-	parts := strings.Fields(line)
-	if len(parts) > 0 {
-		return parts[len(parts)-1]
-	}
-	return ""
+// rawEntry is one history entry as the lines of the file it spans, first up
+// to end, counted from where the scan started and without their newlines
+type rawEntry struct {
+	first, end int
+	lines      []string
+}
+
+// historySplitter groups the lines of a history file into entries. The
+// analysis and scrub both use it, so they agree on where an entry starts
+// and ends: a fish entry runs from its "- cmd:" line to the next one, a
+// bash timestamp comment belongs to the command after it, and zsh continues
+// a multi-line command with a trailing backslash.
+type historySplitter struct {
+	shell string
+	line  int
+	entry rawEntry
+	open  bool
 }
 
-func categorizeCommand(cmd string) []string {
-	categories := []string{}
-	patterns := map[string][]string{
-		"development": {"git", "docker", "npm", "go", "python"},
-		"system":      {"sudo", "systemctl", "ps", "top"},
-		"file":        {"ls", "cd", "cp", "mv", "rm"},
+// add takes the next line and returns the entry it completes, if any
+func (s *historySplitter) add(line string) (rawEntry, bool) {
+	i := s.line
+	s.line++
+	switch {
+	case s.shell == "fish":
+		if !strings.HasPrefix(line, "- cmd: ") {
+			if s.open {
+				s.entry.lines = append(s.entry.lines, line)
+				s.entry.end = i + 1
+			}
+			return rawEntry{}, false
+		}
+		done, ok := s.entry, s.open
+		s.entry, s.open = rawEntry{first: i, end: i + 1, lines: []string{line}}, true
+		return done, ok
+	case s.shell == "bash" && isBashTimestamp(line):
+		// A timestamp right after another replaces it
+		s.entry, s.open = rawEntry{first: i, end: i + 1, lines: []string{line}}, true
+		return rawEntry{}, false
+	case s.open:
+		s.entry.lines = append(s.entry.lines, line)
+		s.entry.end = i + 1
+	default:
+		s.entry = rawEntry{first: i, end: i + 1, lines: []string{line}}
+	}
+	if s.shell == "zsh" && strings.HasSuffix(line, "\\") {
+		s.open = true
+		return rawEntry{}, false
 	}
+	s.open = false
+	return s.entry, true
+}
+
+// flush returns the entry left open at the end of the file
+func (s *historySplitter) flush() (rawEntry, bool) {
+	done, ok := s.entry, s.open
+	s.open = false
+	return done, ok
+}
 
-	for category, patterns := range patterns {
-		for _, pattern := range patterns {
-			if strings.HasPrefix(cmd, pattern) {
-				categories = append(categories, category)
-				break
+// isBashTimestamp reports whether line is the "#<epoch>" comment bash
+// writes before each command when HISTTIMEFORMAT is set
+func isBashTimestamp(line string) bool {
+	return strings.HasPrefix(line, "#") && !parseUnixTimestamp(line[1:]).IsZero()
+}
+
+// parseEntry reads the command of an entry, which may have none
+func parseEntry(shell string, raw rawEntry) (CommandEntry, bool) {
+	lines := raw.lines
+	if shell == "fish" {
+		// Fish stores history as a YAML-like list of "- cmd:" / "  when:" pairs
+		cmd, _ := strings.CutPrefix(lines[0], "- cmd: ")
+		entry := newCommandEntry(cmd, time.Time{})
+		for _, line := range lines[1:] {
+			if when, ok := strings.CutPrefix(line, "  when: "); ok {
+				entry.Timestamp = parseUnixTimestamp(when)
+			} else if path, ok := strings.CutPrefix(line, "    - "); ok {
+				// Listed under "  paths:"
+				entry.Paths = append(entry.Paths, path)
 			}
 		}
+		return entry, true
+	}
+
+	var pending time.Time
+	if shell == "bash" && isBashTimestamp(lines[0]) {
+		pending, lines = parseUnixTimestamp(lines[0][1:]), lines[1:]
+	}
+	if len(lines) == 0 {
+		return CommandEntry{}, false
+	}
+	cmd, ts := cleanHistoryLine(strings.Join(lines, "\n"))
+	if cmd == "" {
+		return CommandEntry{}, false
+	}
+	if ts.IsZero() {
+		ts = pending
+	}
+	entry := newCommandEntry(cmd, ts)
+	if shell == "zsh" {
+		entry.Duration, entry.Timed = zshElapsed(lines[0])
+	}
+	return entry, true
+}
+
+// newCommandEntry builds a categorized entry. A zero timestamp means the
+// history file did not record when the command ran.
+func newCommandEntry(cmd string, ts time.Time) CommandEntry {
+	return CommandEntry{
+		Command:    cmd,
+		Timestamp:  ts,
+		Categories: CategorizeCommand(cmd),
+	}
+}
+
+// cleanHistoryLine strips zsh extended history metadata (": <epoch>:<elapsed>;")
+// and returns the command along with its timestamp, if one was recorded
+func cleanHistoryLine(line string) (string, time.Time) {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, ": ") {
+		if meta, cmd, ok := strings.Cut(line[2:], ";"); ok {
+			epoch, _, _ := strings.Cut(meta, ":")
+			return strings.TrimSpace(cmd), parseUnixTimestamp(epoch)
+		}
 	}
+	return line, time.Time{}
+}
 
-	return categories
+// zshElapsed returns the duration zsh recorded in an extended history line,
+// in whole seconds. zsh writes 0 when the line was saved before the command
+// ended, e.g. with INC_APPEND_HISTORY, so 0 counts as unknown.
+func zshElapsed(line string) (time.Duration, bool) {
+	meta, ok := strings.CutPrefix(strings.TrimSpace(line), ": ")
+	if !ok {
+		return 0, false
+	}
+	meta, _, _ = strings.Cut(meta, ";")
+	_, elapsed, _ := strings.Cut(meta, ":")
+	seconds, err := strconv.Atoi(strings.TrimSpace(elapsed))
+	if err != nil || seconds <= 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// parseUnixTimestamp parses a seconds-since-epoch string, returning the zero
+// time if it is not a plausible timestamp
+func parseUnixTimestamp(s string) time.Time {
+	secs, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || secs <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
 }
 
-func analyzeCommands(entries []CommandEntry, data *ShellData) {
+// analyzeCommands fills in the tech profile and productivity metrics.
+// installedLangs comes from getInstalledLanguages and is empty when probing
+// is disabled.
+func analyzeCommands(entries []CommandEntry, installedLangs map[string]string, opts Options, data *ShellData) {
 	// Initialize maps for analysis
 	langUsage := make(map[string]int)
-	toolUsage := make(map[string]int)
-	timeOfDay := make(map[int]int)
 	commandPatterns := make(map[string]int)
 
-	// Get installed languages
-	installedLangs := getInstalledLanguages()
-
 	// Analyze each command
 	for _, entry := range entries {
 		cmd := entry.Command
-		hour := entry.Timestamp.Hour()
-		timeOfDay[hour]++
 
 		// Language usage analysis
 		for lang := range installedLangs {
-			if strings.Contains(cmd, lang) ||
-				strings.Contains(cmd, getPackageManager(lang)) {
+			if manager := getPackageManager(lang); strings.Contains(cmd, lang) ||
+				(manager != "" && strings.Contains(cmd, manager)) {
 				langUsage[lang]++
 			}
 		}
 
-		// Development tool analysis
-		tools := []string{"git", "docker", "kubectl", "terraform", "ansible", "make"}
-		for _, tool := range tools {
-			if strings.HasPrefix(cmd, tool) && checkToolInstalled(tool) {
-				toolUsage[tool]++
-			}
-		}
-
 		// Analyze command patterns
 		analyzeCommandPattern(cmd, commandPatterns)
 	}
@@ -135,48 +683,26 @@ func analyzeCommands(entries []CommandEntry, data *ShellData) {
 	// Update TechnicalProfile
 	techProfile := &data.Insights.TechnicalProfile
 
-	// Calculate primary role based on most used language/tool
+	// The most used installed language, until inferSkills finds a better one
 	if primaryLang, ok := getMostUsed(langUsage); ok {
-		techProfile.PrimaryRole = fmt.Sprintf("%s Developer", strings.Title(primaryLang))
-	}
-
-	// Calculate tech stack
-	techProfile.TechStack = make([]string, 0)
-	for lang := range installedLangs {
-		if langUsage[lang] > 0 {
-			techProfile.TechStack = append(techProfile.TechStack, lang)
-		}
-	}
-
-	// Calculate proficiency
-	totalCommands := len(entries)
-	if totalCommands > 0 {
-		for lang, count := range langUsage {
-			techProfile.Proficiency[lang] = float64(count) / float64(totalCommands)
-		}
-		for tool, count := range toolUsage {
-			techProfile.Proficiency[tool] = float64(count) / float64(totalCommands)
-		}
+		techProfile.PrimaryLanguage = primaryLang
 	}
 
 	// Update WorkPatterns
 	patterns := &data.Insights.WorkPatterns
-	patterns.PeakHours = getPeakHours(timeOfDay)
 
 	// Calculate productivity metrics based on command complexity and variety
 	patterns.Productivity = calculateProductivityMetrics(entries, commandPatterns)
 }
 
 // internal/analyzer/shell_analysis.go
-func analyzeToolUsage(entries []CommandEntry) ToolUsage {
+func analyzeToolUsage(entries []CommandEntry, installedLangs map[string]string, opts Options) ToolUsage {
 	toolUsage := ToolUsage{
 		Editors:    make(map[string]int),
 		Languages:  make(map[string]int),
 		BuildTools: make(map[string]int),
 	}
-
-	// Get installed languages
-	installedLangs := getInstalledLanguages()
+	probe := opts.Enabled(ModuleProbe)
 
 	// Analyze each command
 	for _, entry := range entries {
@@ -184,8 +710,8 @@ func analyzeToolUsage(entries []CommandEntry) ToolUsage {
 
 		// Language usage analysis
 		for lang := range installedLangs {
-			if strings.Contains(cmd, lang) ||
-				strings.Contains(cmd, getPackageManager(lang)) {
+			if manager := getPackageManager(lang); strings.Contains(cmd, lang) ||
+				(manager != "" && strings.Contains(cmd, manager)) {
 				toolUsage.Languages[lang]++
 			}
 		}
@@ -193,7 +719,7 @@ func analyzeToolUsage(entries []CommandEntry) ToolUsage {
 		// Editor usage analysis
 		editors := []string{"vim", "nvim", "emacs", "code", "nano"}
 		for _, editor := range editors {
-			if strings.HasPrefix(cmd, editor) && checkToolInstalled(editor) {
+			if strings.HasPrefix(cmd, editor) && (!probe || checkToolInstalled(editor)) {
 				toolUsage.Editors[editor]++
 			}
 		}
@@ -201,7 +727,7 @@ func analyzeToolUsage(entries []CommandEntry) ToolUsage {
 		// Build tool usage analysis
 		buildTools := []string{"make", "maven", "gradle", "npm", "yarn", "pip", "cargo", "composer", "bundler"}
 		for _, tool := range buildTools {
-			if strings.HasPrefix(cmd, tool) && checkToolInstalled(tool) {
+			if strings.HasPrefix(cmd, tool) && (!probe || checkToolInstalled(tool)) {
 				toolUsage.BuildTools[tool]++
 			}
 		}
@@ -238,11 +764,12 @@ func analyzeCommandPattern(cmd string, patterns map[string]int) {
 	}
 }
 
+// getMostUsed returns the key with the highest count, breaking ties by name
 func getMostUsed(usage map[string]int) (string, bool) {
 	var maxKey string
 	var maxVal int
 	for k, v := range usage {
-		if v > maxVal {
+		if v > maxVal || (v == maxVal && v > 0 && k < maxKey) {
 			maxKey = k
 			maxVal = v
 		}
@@ -250,7 +777,8 @@ func getMostUsed(usage map[string]int) (string, bool) {
 	return maxKey, maxVal > 0
 }
 
-func getPeakHours(timeOfDay map[int]int) []int {
+// PeakHours returns the three busiest hours of the day, busiest first
+func PeakHours(timeOfDay [24]int) []int {
 	type hourCount struct {
 		hour  int
 		count int
@@ -258,10 +786,12 @@ func getPeakHours(timeOfDay map[int]int) []int {
 
 	var hours []hourCount
 	for h, c := range timeOfDay {
-		hours = append(hours, hourCount{h, c})
+		if c > 0 {
+			hours = append(hours, hourCount{h, c})
+		}
 	}
 
-	sort.Slice(hours, func(i, j int) bool {
+	sort.SliceStable(hours, func(i, j int) bool {
 		return hours[i].count > hours[j].count
 	})
 
@@ -281,145 +811,26 @@ func calculateProductivityMetrics(entries []CommandEntry, patterns map[string]in
 		return metrics
 	}
 
+	// Metrics are keyed by message ID so they can be localized when rendered
+
 	// Command variety score
 	uniqueCommands := make(map[string]bool)
 	for _, entry := range entries {
 		uniqueCommands[entry.Command] = true
 	}
-	metrics["Command Variety"] = float64(len(uniqueCommands)) / float64(totalCommands)
+	metrics["command_variety"] = float64(len(uniqueCommands)) / float64(totalCommands)
 
 	// Workflow complexity score
 	workflowScore := float64(patterns["git_workflow"]+patterns["build"]+
 		patterns["deploy"]+patterns["test"]) / float64(totalCommands)
-	metrics["Workflow Complexity"] = workflowScore
+	metrics["workflow_complexity"] = workflowScore
 
 	return metrics
 }
 
-func checkToolInstalled(tool string) bool {
-	_, err := exec.LookPath(tool)
-	return err == nil
-}
-
-func getInstalledLanguages() map[string]string {
-	languages := map[string]string{
-		// Programming Languages
-		"python":  "python --version",
-		"python3": "python3 --version",
-		"node":    "node --version",
-		"go":      "go version",
-		"java":    "java -version",
-		"ruby":    "ruby --version",
-		"php":     "php --version",
-		"rust":    "rustc --version",
-		"perl":    "perl --version",
-		"scala":   "scala -version",
-		"kotlin":  "kotlin -version",
-		"swift":   "swift --version",
-		"r":       "R --version",
-		"julia":   "julia --version",
-		"haskell": "ghc --version",
-		"elixir":  "elixir --version",
-		"erlang":  "erl -version",
-		"clang":   "clang --version",
-		"gcc":     "gcc --version",
-		"dotnet":  "dotnet --version",
-		"lua":     "lua -v",
-		"ocaml":   "ocaml -version",
-		"dart":    "dart --version",
-		"zig":     "zig version",
-		"nim":     "nim --version",
-
-		// Build Tools & Package Managers
-		"maven":    "mvn --version",
-		"gradle":   "gradle --version",
-		"npm":      "npm --version",
-		"yarn":     "yarn --version",
-		"pnpm":     "pnpm --version",
-		"pip":      "pip --version",
-		"cargo":    "cargo --version",
-		"composer": "composer --version",
-		"bundler":  "bundle --version",
-
-		// DevOps & Cloud Tools
-		"docker":    "docker --version",
-		"kubectl":   "kubectl version --client",
-		"terraform": "terraform version",
-		"ansible":   "ansible --version",
-		"vagrant":   "vagrant --version",
-		"helm":      "helm version",
-		"aws":       "aws --version",
-		"gcloud":    "gcloud --version",
-		"azure":     "az --version",
-
-		// Version Control
-		"git":       "git --version",
-		"svn":       "svn --version",
-		"mercurial": "hg --version",
-
-		// Databases
-		"mysql":   "mysql --version",
-		"psql":    "psql --version",
-		"mongodb": "mongod --version",
-		"redis":   "redis-cli --version",
-
-		// Web Servers & Tools
-		"nginx":   "nginx -v",
-		"apache2": "apache2 -v",
-		"curl":    "curl --version",
-		"wget":    "wget --version",
-
-		// Text Editors & IDEs
-		"vim":   "vim --version",
-		"nvim":  "nvim --version",
-		"emacs": "emacs --version",
-		"code":  "code --version",
-
-		// Shell & Terminal Tools
-		"zsh":  "zsh --version",
-		"bash": "bash --version",
-		"fish": "fish --version",
-		"tmux": "tmux -V",
-	}
-
-	installed := make(map[string]string)
-	for lang, cmd := range languages {
-		if out, err := exec.Command("sh", "-c", cmd).Output(); err == nil {
-			installed[lang] = string(out)
-		}
-	}
-
-	// Sort and keep only top 10 most used
-	type usageEntry struct {
-		name  string
-		count int
-	}
-	var usageList []usageEntry
-	for name := range installed {
-		count := 0
-		// Count occurrences in command history (you'll need to pass this data somehow)
-		// For now, we'll just store all installed ones
-		usageList = append(usageList, usageEntry{name, count})
-	}
-
-	// Sort by usage count
-	sort.Slice(usageList, func(i, j int) bool {
-		return usageList[i].count > usageList[j].count
-	})
-
-	// Keep only top 10
-	result := make(map[string]string)
-	for i := 0; i < len(usageList) && i < 10; i++ {
-		name := usageList[i].name
-		result[name] = installed[name]
-	}
-
-	return result
-}
-
 func expandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
+		home, err := utils.HomeDir()
 		if err != nil {
 			return path
 		}
@@ -428,7 +839,15 @@ func expandPath(path string) string {
 	return path
 }
 
-func analyzeShellConfigs(shell string) ShellConfig {
+// AnalyzeShellConfig re-reads the startup files of one shell, e.g. after the
+// user edited them
+func AnalyzeShellConfig(shell string, opts Options) ShellConfig {
+	return analyzeShellConfigs(shell, opts)
+}
+
+// analyzeShellConfigs reads the startup files and detects plugins, as far as
+// those modules are enabled
+func analyzeShellConfigs(shell string, opts Options) ShellConfig {
 	configPaths := map[string][]string{
 		"bash": {
 			"~/.bashrc",
@@ -444,42 +863,108 @@ func analyzeShellConfigs(shell string) ShellConfig {
 			"~/.config/fish/config.fish",
 			"~/.config/fish/functions",
 			"~/.config/fish/conf.d",
+			"~/.config/fish/fish_variables",
 		},
 	}
 
 	config := ShellConfig{
-		ConfigFiles: make(map[string]ConfigInfo),
-		Aliases:     make(map[string]string),
-		Environment: make(map[string]string),
-		Plugins:     make([]PluginInfo, 0),
+		ConfigFiles:    make(map[string]ConfigInfo),
+		Aliases:        make(map[string]string),
+		Environment:    make(map[string]string),
+		Plugins:        make([]PluginInfo, 0),
+		AliasLocations: make(map[string]Location),
+		GlobalAliases:  make(map[string]string),
+		NamedDirs:      make(map[string]string),
+		Abbreviations:  make(map[string]bool),
+		Functions:      make(map[string]string),
 	}
 
 	// Read and analyze config files
-	for _, paths := range configPaths[shell] {
+	files := configPaths[shell]
+	if !opts.Enabled(ModuleConfig) {
+		files = nil
+	}
+	readConfig := func(key, path string, info os.FileInfo) {
+		content, err := os.ReadFile(path)
+		// Directories are read entry by entry below
+		if problem, ok := problemFor(ProblemConfig, path, err); ok && !info.IsDir() {
+			config.Problems = append(config.Problems, problem)
+		}
+		config.ConfigFiles[key] = ConfigInfo{
+			Path:     path,
+			Modified: info.ModTime(),
+			Content:  string(content),
+		}
+
+		// Parse the config file
+		parseShellConfig(path, string(content), &config)
+	}
+	for _, paths := range files {
 		expandedPath := expandPath(paths)
 		if info, err := os.Stat(expandedPath); err == nil {
-			content, _ := os.ReadFile(expandedPath)
-			config.ConfigFiles[paths] = ConfigInfo{
-				Path:     expandedPath,
-				Modified: info.ModTime(),
-				Content:  string(content),
+			if config.RCFile == "" && info.Mode().IsRegular() {
+				config.RCFile = expandedPath
+			}
+			readConfig(paths, expandedPath, info)
+
+			// Fish also sources every .fish file in conf.d and autoloads
+			// those in functions
+			if info.IsDir() && shell == "fish" {
+				entries, _ := os.ReadDir(expandedPath)
+				for _, entry := range entries {
+					if info, err := entry.Info(); err == nil && info.Mode().IsRegular() && strings.HasSuffix(entry.Name(), ".fish") {
+						readConfig(paths+"/"+entry.Name(), filepath.Join(expandedPath, entry.Name()), info)
+					}
+				}
 			}
-
-			// Parse the config file
-			parseShellConfig(string(content), &config)
 		}
 	}
 
 	// Detect plugins based on shell type
-	detectPlugins(shell, &config)
+	if opts.Enabled(ModulePlugins) {
+		detectPlugins(shell, &config)
+	}
+	config.Prompt = detectPrompt(shell, &config)
 
 	return config
 }
 
-func parseShellConfig(content string, config *ShellConfig) {
+func parseShellConfig(path, content string, config *ShellConfig) {
 	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
 	for scanner.Scan() {
 		line := scanner.Text()
+		lineNumber++
+
+		// Parse zsh global aliases and named directories
+		if rest, ok := strings.CutPrefix(line, "alias -g "); ok {
+			if name, value, ok := strings.Cut(rest, "="); ok {
+				name = strings.TrimSpace(name)
+				config.GlobalAliases[name] = strings.Trim(strings.TrimSpace(value), "'\"")
+				config.AliasLocations[name] = Location{Path: path, Line: lineNumber}
+			}
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "hash -d "); ok {
+			for _, field := range strings.Fields(rest) {
+				if name, dir, ok := strings.Cut(field, "="); ok {
+					config.NamedDirs[name] = strings.Trim(dir, "'\"")
+				}
+			}
+			continue
+		}
+
+		// Parse fish abbreviations, set in config files or saved as
+		// universal variables by older fish versions
+		if name, expansion, ok := parseAbbr(line); ok {
+			config.Aliases[name] = expansion
+			config.Abbreviations[name] = true
+			config.AliasLocations[name] = Location{Path: path, Line: lineNumber}
+			config.AliasDefinitions = append(config.AliasDefinitions, AliasDefinition{
+				Name: name, Value: expansion, Location: config.AliasLocations[name],
+			})
+			continue
+		}
 
 		// Parse aliases
 		if strings.HasPrefix(line, "alias ") {
@@ -488,10 +973,17 @@ func parseShellConfig(content string, config *ShellConfig) {
 				name := strings.TrimSpace(parts[0])
 				value := strings.Trim(strings.TrimSpace(parts[1]), "'\"")
 				config.Aliases[name] = value
+				config.AliasLocations[name] = Location{Path: path, Line: lineNumber}
+				config.AliasDefinitions = append(config.AliasDefinitions, AliasDefinition{
+					Name: name, Value: value, Location: config.AliasLocations[name],
+				})
 			}
 		}
 
 		// Parse environment variables
+		if name, value, ok := parseFishSet(line); ok && (strings.HasSuffix(path, ".fish") || strings.HasSuffix(path, "fish_variables")) {
+			config.Environment[name] = value
+		}
 		if strings.HasPrefix(line, "export ") {
 			parts := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
 			if len(parts) == 2 {
@@ -501,6 +993,7 @@ func parseShellConfig(content string, config *ShellConfig) {
 			}
 		}
 	}
+	parseFunctions(path, content, config)
 }
 
 func detectPlugins(shell string, config *ShellConfig) {
@@ -512,20 +1005,35 @@ func detectPlugins(shell string, config *ShellConfig) {
 	case "bash":
 		detectBashPlugins(config)
 	}
+	if theme, ok := promptTheme(config); ok {
+		config.Plugins = append(config.Plugins, theme)
+	}
 }
 
+// detectZshPlugins reads the plugins listed in the startup files. Without
+// a list, e.g. when the config module is off, the installed oh-my-zsh
+// plugins and plugin managers stand in.
 func detectZshPlugins(config *ShellConfig) {
+	listed, managers := listedZshPlugins(config)
+	config.Plugins = append(config.Plugins, listed...)
+
 	// Check for Oh My Zsh plugins
 	omzPath := expandPath("~/.oh-my-zsh")
-	if info, err := os.Stat(omzPath); err == nil && info.IsDir() {
+	if info, err := os.Stat(omzPath); err == nil && info.IsDir() && !managers[ManagerOhMyZsh] {
 		pluginsPath := filepath.Join(omzPath, "plugins")
+		version, updated, _ := checkoutInfo(omzPath)
+		if updated.IsZero() {
+			updated = info.ModTime()
+		}
 		if pluginsDir, err := os.ReadDir(pluginsPath); err == nil {
 			for _, pluginDir := range pluginsDir {
 				if pluginDir.IsDir() {
 					config.Plugins = append(config.Plugins, PluginInfo{
 						Name:        pluginDir.Name(),
+						Manager:     ManagerOhMyZsh,
 						Source:      filepath.Join(pluginsPath, pluginDir.Name()),
-						LastUpdated: info.ModTime(),
+						Version:     version,
+						LastUpdated: updated,
 					})
 				}
 			}
@@ -533,17 +1041,18 @@ func detectZshPlugins(config *ShellConfig) {
 	}
 
 	// Check for other plugin managers (Antigen, Zinit, Zplug, etc.)
-	pluginManagers := []string{
-		"~/.antigen",
-		"~/.zinit",
-		"~/.zplug",
+	pluginManagers := map[string]string{
+		"~/.antigen": ManagerAntigen,
+		"~/.zinit":   ManagerZinit,
+		"~/.zplug":   ManagerZplug,
 	}
 
-	for _, manager := range pluginManagers {
+	for _, manager := range SortedKeys(pluginManagers) {
 		path := expandPath(manager)
-		if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if info, err := os.Stat(path); err == nil && info.IsDir() && !managers[pluginManagers[manager]] {
 			config.Plugins = append(config.Plugins, PluginInfo{
 				Name:        filepath.Base(manager),
+				Manager:     pluginManagers[manager],
 				Source:      path,
 				LastUpdated: info.ModTime(),
 			})
@@ -551,23 +1060,39 @@ func detectZshPlugins(config *ShellConfig) {
 	}
 }
 
+// detectFishPlugins reads fisher's plugin list and the snippets in conf.d,
+// leaving out the ones fisher installed
 func detectFishPlugins(config *ShellConfig) {
+	fisher := fisherPlugins()
+	config.Plugins = append(config.Plugins, fisher...)
+	installed := make(map[string]bool)
+	for _, plugin := range fisher {
+		installed[filepath.Base(plugin.Name)] = true
+	}
+
 	fishPluginPath := expandPath("~/.config/fish/conf.d")
 	if files, err := os.ReadDir(fishPluginPath); err == nil {
 		for _, file := range files {
-			if strings.HasSuffix(file.Name(), ".fish") {
-				info, _ := file.Info()
-				config.Plugins = append(config.Plugins, PluginInfo{
-					Name:        strings.TrimSuffix(file.Name(), ".fish"),
-					Source:      filepath.Join(fishPluginPath, file.Name()),
-					LastUpdated: info.ModTime(),
-				})
+			name, ok := strings.CutSuffix(file.Name(), ".fish")
+			if !ok || installed[name] {
+				continue
 			}
+			info, _ := file.Info()
+			config.Plugins = append(config.Plugins, PluginInfo{
+				Name:        name,
+				Source:      filepath.Join(fishPluginPath, file.Name()),
+				LastUpdated: info.ModTime(),
+			})
 		}
 	}
 }
 
+// detectBashPlugins lists the plugins enabled in bash-it, or bash-it itself
+// when none are, and bash-completion
 func detectBashPlugins(config *ShellConfig) {
+	bashIt := bashItPlugins()
+	config.Plugins = append(config.Plugins, bashIt...)
+
 	// Check for common bash plugin managers and extensions
 	bashPluginPaths := []string{
 		"~/.bash_it",
@@ -575,6 +1100,9 @@ func detectBashPlugins(config *ShellConfig) {
 	}
 
 	for _, path := range bashPluginPaths {
+		if path == "~/.bash_it" && len(bashIt) > 0 {
+			continue
+		}
 		expandedPath := expandPath(path)
 		if info, err := os.Stat(expandedPath); err == nil && info.IsDir() {
 			config.Plugins = append(config.Plugins, PluginInfo{
@@ -586,65 +1114,134 @@ func detectBashPlugins(config *ShellConfig) {
 	}
 }
 
-func analyzeCommandComplexity(data *ShellData) float64 {
-	var totalCommands, complexCommands float64
+// direnvSuggestions caps the export sequences recommended for direnv
+const direnvSuggestions = 3
 
-	for _, history := range data.Histories {
-		for _, entry := range history {
-			totalCommands++
+// generateRecommendations suggests improvements to the shell setup, as far
+// as the enabled modules can tell
+func generateRecommendations(data *ShellData) []Suggestion {
+	recommendations := []Suggestion{}
 
-			// Count pipes and redirections
-			if strings.Contains(entry.Command, "|") ||
-				strings.Contains(entry.Command, ">") ||
-				strings.Contains(entry.Command, "<") {
-				complexCommands++
-			}
+	// Analyze shell configuration
+	for _, shell := range SortedKeys(data.ShellConfigs) {
+		config := data.ShellConfigs[shell]
+		if data.Options.Enabled(ModuleConfig) && len(config.Aliases) < 5 {
+			recommendations = append(recommendations, Suggestion{ID: "few_aliases", Args: []interface{}{shell}})
+		}
 
-			// Count commands with multiple arguments
-			if len(strings.Fields(entry.Command)) > 2 {
-				complexCommands += 0.5
-			}
+		if data.Options.Enabled(ModulePlugins) && len(config.Plugins) < 3 {
+			recommendations = append(recommendations, Suggestion{ID: "few_plugins", Args: []interface{}{shell}})
 		}
 	}
 
-	if totalCommands == 0 {
-		return 0
+	// Zsh global aliases and named directories that are never used
+	if data.Options.Enabled(ModuleConfig) {
+		globals, dirs := ZshShortcuts(*data)
+		var unused []string
+		for _, usage := range append(globals, dirs...) {
+			if usage.Uses == 0 {
+				unused = append(unused, usage.Name)
+			}
+		}
+		if len(unused) > 0 {
+			sort.Strings(unused)
+			recommendations = append(recommendations, Suggestion{ID: "unused_shortcuts", Args: []interface{}{len(unused), strings.Join(unused, ", ")}})
+		}
 	}
 
-	return (complexCommands / totalCommands) * 100
-}
-
-func generateRecommendations(data *ShellData) []string {
-	recommendations := []string{}
-
-	// Analyze shell configuration
-	for shell, config := range data.ShellConfigs {
-		if len(config.Aliases) < 5 {
-			recommendations = append(recommendations,
-				fmt.Sprintf("Consider adding more aliases to your %s configuration to improve productivity", shell))
+	// Exports typed by hand that an .envrc could set
+	direnv := data.Insights.ToolUsage.Direnv
+	for i, sequence := range direnv.ExportSequences {
+		if i == direnvSuggestions {
+			break
 		}
+		recommendations = append(recommendations, Suggestion{ID: "direnv", Args: []interface{}{
+			strings.Join(sequence.Variables, ", "), sequence.Count, sequence.Dir,
+		}})
+	}
+	if len(direnv.Projects) > 0 && !direnv.Hooked && data.Options.Enabled(ModuleConfig) {
+		recommendations = append(recommendations, Suggestion{ID: "direnv_hook"})
+	}
 
-		if len(config.Plugins) < 3 {
-			recommendations = append(recommendations,
-				fmt.Sprintf("Explore popular %s plugins to enhance your shell experience", shell))
+	// Point at the history clean-ups
+	for _, d := range AnalyzeDuplicates(*data) {
+		if !d.Enabled && d.Share() >= 0.3 {
+			recommendations = append(recommendations, Suggestion{ID: "dedupe", Args: []interface{}{d.Shell, d.Share() * 100}})
 		}
 	}
+	secrets, risky := 0, 0
+	for _, finding := range data.Insights.Security {
+		if finding.ID == "secret" {
+			secrets += finding.Count
+		} else {
+			risky += finding.Count
+		}
+	}
+	if secrets > 0 {
+		recommendations = append(recommendations, Suggestion{ID: "scrub", Args: []interface{}{secrets}})
+	}
+	if risky > 0 {
+		recommendations = append(recommendations, Suggestion{ID: "security", Args: []interface{}{risky}})
+	}
 
 	return recommendations
 }
 
-func generateWorkflowTips(data *ShellData) []string {
-	tips := []string{}
+// recallToolRate is the history reuse rate from which a recall tool is
+// recommended
+const recallToolRate = 0.3
 
-	// Analyze command patterns
-	commonPatterns := analyzeCommandPatterns(data)
-	for pattern, count := range commonPatterns {
-		if count > 10 {
-			tips = append(tips, fmt.Sprintf(
-				"You frequently use '%s'. Consider creating an alias for this pattern", pattern))
+// workflowTipsShown caps the frequent-pattern tips
+const workflowTipsShown = 5
+
+// generateWorkflowTips points out habits worth automating or fixing,
+// leaving out the patterns already covered by the proposed aliases
+func generateWorkflowTips(data *ShellData, proposed []AliasSimulation) []Suggestion {
+	tips := []Suggestion{}
+
+	// Frequent two-word patterns that no alias covers yet
+	aliased := make(map[string]bool)
+	for _, config := range data.ShellConfigs {
+		for _, expansion := range shortcuts(config) {
+			aliased[expansion] = true
+		}
+	}
+	for _, sim := range proposed {
+		aliased[sim.Proposal.Expansion] = true
+	}
+	var frequent []CommandCount
+	for _, pattern := range SortedCounts(analyzeCommandPatterns(data), 0) {
+		if pattern.Count > 10 && !aliased[pattern.Command] {
+			frequent = append(frequent, pattern)
+		}
+		if len(frequent) == workflowTipsShown {
+			break
+		}
+	}
+	for _, pattern := range frequent {
+		tips = append(tips, Suggestion{ID: "frequent_pattern", Args: []interface{}{pattern.Command, pattern.Count}})
+	}
+
+	// Command sequences one alias could run
+	for _, workflow := range data.Insights.WorkPatterns.CommonWorkflows {
+		if workflow.Alias.Name != "" {
+			steps := strings.Join(workflow.Steps, " → ")
+			tips = append(tips, Suggestion{ID: "workflow", Args: []interface{}{steps, workflow.Count, workflow.Alias.AliasLine()}})
+			break
 		}
 	}
 
+	// Recalling commands by hand or with plain Ctrl-R
+	if reuse := data.Insights.WorkPatterns.Reuse; len(reuse.Tools) == 0 && reuse.Rate() >= recallToolRate &&
+		data.Options.Enabled(ModuleConfig) {
+		tips = append(tips, Suggestion{ID: "recall_tool", Args: []interface{}{reuse.Rate() * 100}})
+	}
+
+	// Typos that autocorrection would catch
+	if typos := ComputeHighlights(*data).Typos; len(typos) > 0 {
+		tips = append(tips, Suggestion{ID: "typos", Args: []interface{}{typos[0].Command, typos[0].Intended, typos[0].Count}})
+	}
+
 	return tips
 }
 
@@ -0,0 +1,107 @@
+// internal/analyzer/shell_analysis_test.go
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// writeHistory writes a zsh history of lines commands, a minute apart
+func writeHistory(t testing.TB, lines int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".zsh_history")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	commands := []string{"git status", "kubectl get pods -n prod", "docker run --rm -it alpine sh", "ls -la", "vim main.go", "go test ./..."}
+	w := bufio.NewWriter(file)
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(w, ": %d:0;%s %d\n", 1600000000+i*60, commands[i%len(commands)], i)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// useHistory points shell at path until the test ends
+func useHistory(t testing.TB, shell, path string) {
+	t.Helper()
+	previous := historyPaths[shell]
+	t.Cleanup(func() { historyPaths[shell] = previous })
+	if err := SetHistoryPath(shell, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLowMemoryKeepsSample(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	useHistory(t, "zsh", writeHistory(t, 1000))
+	data := Analyze(Options{Shells: []string{"zsh"}, LowMemory: true, SampleSize: 100, Disabled: []string{ModuleProbe}})
+
+	history := data.Histories["zsh"]
+	if len(history) != 100 {
+		t.Fatalf("kept %d entries, want the sample of 100", len(history))
+	}
+	if last := history[len(history)-1].Command; last != "ls -la 999" {
+		t.Errorf("newest kept entry is %q, want the last command", last)
+	}
+	// The aggregates see every entry, not just the sample
+	if data.CommandCounts["zsh"] != 1000 {
+		t.Errorf("counted %d commands, want 1000", data.CommandCounts["zsh"])
+	}
+	if data.CommonCmds["git"] != 167 {
+		t.Errorf("counted %d git runs, want 167", data.CommonCmds["git"])
+	}
+}
+
+// TestLowMemoryBudget analyzes a 2M-line history, the size low-memory mode
+// is meant to handle on a 512MB machine, and checks the heap stays far
+// below that
+func TestLowMemoryBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("writes and parses a 2M-line history")
+	}
+	t.Setenv("HOME", t.TempDir())
+	useHistory(t, "zsh", writeHistory(t, 2_000_000))
+
+	// Collect what writing the history left behind, so only the analysis
+	// is measured
+	runtime.GC()
+	var peak uint64
+	done, sampled := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(sampled)
+		for {
+			var stats runtime.MemStats
+			runtime.ReadMemStats(&stats)
+			peak = max(peak, stats.HeapInuse)
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+	data := Analyze(Options{Shells: []string{"zsh"}, LowMemory: true, Disabled: []string{ModuleProbe}})
+	close(done)
+	<-sampled
+
+	if len(data.Histories["zsh"]) != defaultSampleSize {
+		t.Errorf("kept %d entries, want %d", len(data.Histories["zsh"]), defaultSampleSize)
+	}
+	if data.CommandCounts["zsh"] != 2_000_000 {
+		t.Errorf("counted %d commands, want 2000000", data.CommandCounts["zsh"])
+	}
+	const budget = 128 << 20
+	if peak > budget {
+		t.Errorf("heap peaked at %d MB, want under %d MB", peak>>20, budget>>20)
+	}
+}
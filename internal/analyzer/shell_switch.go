@@ -0,0 +1,121 @@
+// internal/analyzer/shell_switch.go
+package analyzer
+
+import (
+	"sort"
+	"time"
+)
+
+// ShellMigration describes how the user's dominant shell changed over time
+type ShellMigration struct {
+	Periods  []ShellPeriod
+	Switches []ShellSwitch
+}
+
+// ShellPeriod is a run of consecutive months dominated by a single shell
+type ShellPeriod struct {
+	Shell    string
+	Start    time.Time
+	End      time.Time
+	Commands int
+}
+
+// ShellSwitch records a change of dominant shell and how many aliases made the move
+type ShellSwitch struct {
+	From           string
+	To             string
+	At             time.Time
+	CarriedAliases []string
+	MissingAliases []string
+}
+
+// addMonthlyActivity counts a timestamped entry towards its shell's activity
+// in that month. Entries without timestamps cannot be placed in time.
+func addMonthlyActivity(monthly map[time.Time]map[string]int, shell string, entry CommandEntry) {
+	if entry.Timestamp.IsZero() {
+		return
+	}
+	month := monthStart(entry.Timestamp)
+	if monthly[month] == nil {
+		monthly[month] = make(map[string]int)
+	}
+	monthly[month][shell]++
+}
+
+// analyzeShellMigration picks the dominant shell of each month of activity
+// and collapses the result into periods and switches
+func analyzeShellMigration(monthly map[time.Time]map[string]int, configs map[string]ShellConfig) ShellMigration {
+	months := make([]time.Time, 0, len(monthly))
+	for month := range monthly {
+		months = append(months, month)
+	}
+	sort.Slice(months, func(i, j int) bool {
+		return months[i].Before(months[j])
+	})
+
+	var migration ShellMigration
+	for _, month := range months {
+		shell := DominantShell(monthly[month])
+		count := monthly[month][shell]
+		end := month.AddDate(0, 1, 0).Add(-time.Second)
+
+		if n := len(migration.Periods); n > 0 && migration.Periods[n-1].Shell == shell {
+			migration.Periods[n-1].End = end
+			migration.Periods[n-1].Commands += count
+			continue
+		}
+		migration.Periods = append(migration.Periods, ShellPeriod{
+			Shell:    shell,
+			Start:    month,
+			End:      end,
+			Commands: count,
+		})
+	}
+
+	for i := 1; i < len(migration.Periods); i++ {
+		from := migration.Periods[i-1].Shell
+		to := migration.Periods[i].Shell
+		carried, missing := compareAliases(shortcuts(configs[from]), shortcuts(configs[to]))
+		migration.Switches = append(migration.Switches, ShellSwitch{
+			From:           from,
+			To:             to,
+			At:             migration.Periods[i].Start,
+			CarriedAliases: carried,
+			MissingAliases: missing,
+		})
+	}
+
+	return migration
+}
+
+// DominantShell returns the shell with the most commands, breaking ties by
+// name so the result does not depend on map iteration order
+func DominantShell(counts map[string]int) string {
+	var best string
+	for shell, count := range counts {
+		if count > counts[best] || (count == counts[best] && (best == "" || shell < best)) {
+			best = shell
+		}
+	}
+	return best
+}
+
+// compareAliases splits the aliases defined for the old shell into those that
+// also exist in the new shell and those that were left behind. Zsh global
+// aliases and named directories count as aliases, see shortcuts.
+func compareAliases(from, to map[string]string) (carried, missing []string) {
+	for name := range from {
+		if _, ok := to[name]; ok {
+			carried = append(carried, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(carried)
+	sort.Strings(missing)
+	return carried, missing
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,187 @@
+// internal/analyzer/simulate.go
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AliasProposal is a candidate alias and the command line it would expand to
+type AliasProposal struct {
+	Name      string
+	Expansion string
+}
+
+// AliasSimulation estimates what an alias would have saved had it existed
+// for the whole recorded history
+type AliasSimulation struct {
+	Proposal          AliasProposal
+	Matches           int
+	KeystrokesSaved   int
+	Weeks             float64
+	EntriesPerWeek    float64
+	KeystrokesPerWeek float64
+}
+
+// SimulateAliases replays the history against each proposal and counts the
+// entries that start with its expansion. Results are sorted by keystrokes saved.
+func SimulateAliases(data ShellData, proposals []AliasProposal) []AliasSimulation {
+	weeks := historyWeeks(data)
+	simulations := make([]AliasSimulation, 0, len(proposals))
+
+	for _, proposal := range proposals {
+		sim := AliasSimulation{Proposal: proposal, Weeks: weeks}
+		saved := len(proposal.Expansion) - len(proposal.Name)
+
+		for _, history := range data.Histories {
+			for _, entry := range history {
+				if entry.Command == proposal.Expansion ||
+					strings.HasPrefix(entry.Command, proposal.Expansion+" ") {
+					sim.Matches++
+				}
+			}
+		}
+
+		if saved > 0 {
+			sim.KeystrokesSaved = sim.Matches * saved
+		}
+		sim.EntriesPerWeek = float64(sim.Matches) / weeks
+		sim.KeystrokesPerWeek = float64(sim.KeystrokesSaved) / weeks
+		simulations = append(simulations, sim)
+	}
+
+	sort.SliceStable(simulations, func(i, j int) bool {
+		return simulations[i].KeystrokesSaved > simulations[j].KeystrokesSaved
+	})
+
+	return simulations
+}
+
+// Thresholds for proposing an alias for a whole command line rather than
+// its first two words
+const (
+	longCommandWords = 3
+	longCommandChars = 12
+	longCommandRuns  = 5
+)
+
+// ProposeAliases suggests short aliases for frequently typed two-word
+// commands, project entry points and long command lines, skipping names
+// that are already defined or, when probing is enabled, taken by a binary
+func ProposeAliases(data ShellData, limit int) []AliasProposal {
+	taken := make(map[string]bool)
+	for _, config := range data.ShellConfigs {
+		for name, expansion := range shortcuts(config) {
+			taken[name] = true
+			taken["="+expansion] = true
+		}
+	}
+
+	var candidates []CommandCount
+	for pattern, count := range analyzeCommandPatterns(&data) {
+		if count >= 10 && len(pattern) > 6 && !taken["="+pattern] {
+			candidates = append(candidates, CommandCount{Command: pattern, Count: count})
+		}
+	}
+	lines := make(map[string]int)
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			lines[entry.Command]++
+		}
+	}
+	for command, count := range projectEntryCommands(data) {
+		if count >= longCommandRuns && len(command) > 6 && !taken["="+command] {
+			candidates = append(candidates, CommandCount{Command: command, Count: count})
+		}
+	}
+	for line, count := range lines {
+		// Quotes and multi-line commands do not fit in a single-quoted alias,
+		// and risky commands should not be made easier to run
+		if count >= longCommandRuns && len(line) >= longCommandChars && !taken["="+line] &&
+			len(strings.Fields(line)) >= longCommandWords && !strings.ContainsAny(line, "'\n") && !isRisky(line) {
+			candidates = append(candidates, CommandCount{Command: line, Count: count})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		wi := candidates[i].Count * len(candidates[i].Command)
+		wj := candidates[j].Count * len(candidates[j].Command)
+		if wi != wj {
+			return wi > wj
+		}
+		return candidates[i].Command < candidates[j].Command
+	})
+
+	var proposals []AliasProposal
+	for _, candidate := range candidates {
+		name := aliasName(candidate.Command)
+		if name == "" || taken[name] || (data.Options.Enabled(ModuleProbe) && checkToolInstalled(name)) {
+			continue
+		}
+		taken[name] = true
+		proposals = append(proposals, AliasProposal{Name: name, Expansion: candidate.Command})
+		if len(proposals) >= limit {
+			break
+		}
+	}
+
+	return proposals
+}
+
+// aliasName builds an alias name from the initial of each word, e.g.
+// "git status" becomes "gs" and "go test ./..." becomes "gt". A word whose
+// initial is not safe in an alias name is skipped, so the name is empty when
+// no word has one. A script run on its own is named after the file, e.g.
+// "./scripts/deploy.sh" becomes "deploy", leaving out the unsafe characters.
+func aliasName(command string) string {
+	if fields := strings.Fields(command); len(fields) == 1 && strings.Contains(command, "/") {
+		base := path.Base(command)
+		name := strings.Map(func(r rune) rune {
+			if isAliasChar(r) || r == '-' || r == '_' {
+				return r
+			}
+			return -1
+		}, strings.TrimSuffix(base, path.Ext(base)))
+		return strings.ToLower(strings.TrimLeft(name, "-"))
+	}
+	var name strings.Builder
+	for _, word := range strings.Fields(command) {
+		if r, _ := utf8.DecodeRuneInString(word); isAliasChar(r) {
+			name.WriteRune(r)
+		}
+	}
+	return strings.ToLower(name.String())
+}
+
+// isAliasChar reports whether r is safe in an alias name in every shell
+func isAliasChar(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// historyWeeks returns the number of weeks covered by timestamped history,
+// never less than one so per-week rates stay meaningful
+func historyWeeks(data ShellData) float64 {
+	var first, last time.Time
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			if entry.Timestamp.IsZero() {
+				continue
+			}
+			if first.IsZero() || entry.Timestamp.Before(first) {
+				first = entry.Timestamp
+			}
+			if entry.Timestamp.After(last) {
+				last = entry.Timestamp
+			}
+		}
+	}
+
+	weeks := last.Sub(first).Hours() / (24 * 7)
+	if weeks < 1 {
+		return 1
+	}
+	return weeks
+}
@@ -0,0 +1,36 @@
+// internal/analyzer/simulate_test.go
+package analyzer
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestAliasName(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"git status", "gs"},
+		{"go test ./...", "gt"},
+		{"./scripts/deploy.sh", "deploy"},
+		{"./scripts/deploy-prod.sh", "deploy-prod"},
+		{"./-x.sh", "x"},
+		{"./déployer.sh", "dployer"},
+		{"écho bonjour monde", "bm"},
+		{"über größe", "g"},
+		{"ls -la", "l"},
+		{"日本 語", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.command, func(t *testing.T) {
+			got := aliasName(test.command)
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("%q is not valid UTF-8", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// internal/analyzer/skills.go
+package analyzer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Skill is an area of work inferred from the programs of a cluster the user
+// runs, e.g. kubernetes from kubectl and helm
+type Skill struct {
+	Name string
+	// Language marks the clusters of a programming language, whose role
+	// is "<Name> Developer"
+	Language bool
+	// Runs counts the commands using the cluster and Programs the
+	// different programs of it seen
+	Runs     int
+	Programs int
+	// Confidence rates from 0 to 1 how surely the history shows the skill
+	Confidence float64
+}
+
+// skillCluster is a skill with the programs that show it and the role of
+// someone for whom it is the main skill
+type skillCluster struct {
+	role     string
+	language bool
+	programs []string
+}
+
+// skillClusters maps each skill to its cluster
+var skillClusters = map[string]skillCluster{
+	"kubernetes": {role: "Platform Engineer", programs: []string{"kubectl", "helm", "k9s", "kubectx", "kubens",
+		"minikube", "kind", "k3d", "kustomize", "stern", "argocd", "skaffold", "tilt", "eksctl"}},
+	"containers": {role: "DevOps Engineer", programs: []string{"docker", "docker-compose", "podman", "buildah",
+		"nerdctl", "lazydocker", "dive", "skopeo"}},
+	"ci": {role: "DevOps Engineer", programs: []string{"gh", "glab", "act", "circleci", "gitlab-runner", "tkn",
+		"jenkins", "drone", "buildkite-agent"}},
+	"infrastructure": {role: "Infrastructure Engineer", programs: []string{"terraform", "tofu", "terragrunt",
+		"pulumi", "ansible", "ansible-playbook", "packer", "vagrant", "nomad", "consul", "vault"}},
+	"cloud": {role: "Cloud Engineer", programs: []string{"aws", "gcloud", "gsutil", "az", "doctl", "flyctl",
+		"heroku", "vercel", "netlify", "wrangler", "oci", "sam", "cdk", "serverless"}},
+	"databases": {role: "Database Engineer", programs: []string{"psql", "pg_dump", "pg_restore", "pgcli", "mysql",
+		"mysqldump", "mycli", "mongo", "mongosh", "mongodump", "redis-cli", "sqlite3", "litecli", "cqlsh",
+		"clickhouse-client", "duckdb", "usql", "influx"}},
+	"debugging": {role: "Systems Engineer", programs: []string{"gdb", "lldb", "dlv", "strace", "ltrace", "valgrind",
+		"perf", "rr", "bpftrace", "lsof", "pstack", "coredumpctl"}},
+	"networking": {role: "Network Engineer", programs: []string{"dig", "nslookup", "host", "tcpdump", "tshark",
+		"wireshark", "traceroute", "mtr", "iperf3", "ss", "netstat", "ip", "iptables", "nft", "nc", "socat"}},
+	"security": {role: "Security Engineer", programs: []string{"nmap", "masscan", "sqlmap", "nikto", "gobuster",
+		"ffuf", "hydra", "hashcat", "john", "msfconsole", "trivy", "grype", "semgrep", "gpg", "age"}},
+	"data": {role: "Data Engineer", programs: []string{"jupyter", "jupyter-lab", "ipython", "dbt", "airflow",
+		"spark-submit", "pyspark", "mlflow", "conda", "mamba", "csvlook", "xsv", "qsv", "mlr"}},
+
+	"python": {language: true, programs: []string{"python", "python3", "pip", "pip3", "pipx", "poetry", "uv",
+		"pytest", "ruff", "mypy", "black", "tox"}},
+	"go":         {language: true, programs: []string{"go", "gofmt", "golangci-lint", "goreleaser"}},
+	"rust":       {language: true, programs: []string{"cargo", "rustc", "rustup", "rust-analyzer"}},
+	"javascript": {language: true, programs: []string{"node", "npm", "npx", "yarn", "pnpm", "bun", "deno", "tsc", "eslint", "prettier"}},
+	"java":       {language: true, programs: []string{"java", "javac", "mvn", "gradle", "gradlew", "jshell"}},
+	"ruby":       {language: true, programs: []string{"ruby", "gem", "bundle", "rails", "rake", "irb", "rspec"}},
+	"php":        {language: true, programs: []string{"php", "composer", "artisan", "phpunit"}},
+	"c":          {language: true, programs: []string{"gcc", "g++", "clang", "clang++", "cmake", "ninja", "meson"}},
+}
+
+// skillPrograms maps each program to its skills, built from skillClusters
+var skillPrograms = func() map[string][]string {
+	programs := make(map[string][]string)
+	for name, cluster := range skillClusters {
+		for _, program := range cluster.programs {
+			programs[program] = append(programs[program], name)
+		}
+	}
+	return programs
+}()
+
+const (
+	// skillMinRuns is how many commands a skill needs at all
+	skillMinRuns = 5
+	// skillFullShare is the share of all commands from which usage alone
+	// makes a skill certain
+	skillFullShare = 0.1
+	// skillFullBreadth is how many programs of a cluster make it certain
+	// the user knows more than one trick
+	skillFullBreadth = 3
+	// skillMinConfidence is the confidence a secondary skill needs
+	skillMinConfidence = 0.25
+)
+
+// inferSkills scores every skill cluster against the histories. The most
+// confident skill gives the primary role, those after it that are
+// confident enough are the secondary skills.
+func inferSkills(histories map[string][]CommandEntry, profile *TechProfile) {
+	runs := make(map[string]int)
+	programs := make(map[string]map[string]bool)
+	total := 0
+	for _, history := range histories {
+		for _, entry := range history {
+			total++
+			seen := make(map[string]bool)
+			for _, program := range commandPrograms(entry.Command) {
+				for _, name := range skillPrograms[program] {
+					if programs[name] == nil {
+						programs[name] = make(map[string]bool)
+					}
+					programs[name][program] = true
+					if !seen[name] {
+						seen[name] = true
+						runs[name]++
+					}
+				}
+			}
+		}
+	}
+
+	var skills []Skill
+	for name, count := range runs {
+		if count < skillMinRuns {
+			continue
+		}
+		usage := min(1, float64(count)/float64(total)/skillFullShare)
+		breadth := min(1, float64(len(programs[name]))/skillFullBreadth)
+		skills = append(skills, Skill{
+			Name:       name,
+			Language:   skillClusters[name].language,
+			Runs:       count,
+			Programs:   len(programs[name]),
+			Confidence: 0.7*usage + 0.3*breadth,
+		})
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		a, b := skills[i], skills[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return a.Name < b.Name
+	})
+	if len(skills) == 0 {
+		return
+	}
+
+	profile.PrimarySkill = skills[0]
+	profile.PrimaryRole = skills[0].Role()
+	for _, skill := range skills {
+		if skill.Language {
+			profile.PrimaryLanguage = skill.Name
+			break
+		}
+	}
+	profile.SecondarySkills = nil
+	for _, skill := range skills[1:] {
+		if skill.Confidence >= skillMinConfidence {
+			profile.SecondarySkills = append(profile.SecondarySkills, skill)
+		}
+	}
+}
+
+// Role returns the role of someone whose main skill is s, in English
+func (s Skill) Role() string {
+	if s.Language {
+		return strings.Title(s.Name) + " Developer"
+	}
+	return skillClusters[s.Name].role
+}
+
+// commandPrograms returns the program of every part of a pipeline or
+// chain, looking past sudo and paths
+func commandPrograms(command string) []string {
+	var programs []string
+	for _, segment := range strings.FieldsFunc(command, func(r rune) bool {
+		return r == '|' || r == ';' || r == '&'
+	}) {
+		if program := commandProgram(segment); program != "" {
+			programs = append(programs, filepath.Base(program))
+		}
+	}
+	return programs
+}
@@ -0,0 +1,44 @@
+// internal/analyzer/sources.go
+package analyzer
+
+// DataSource is what the analysis extracted from one shell or from the
+// recordings, so the parsing can be checked before anything is shared
+type DataSource struct {
+	Name string
+	// Path is the history file; recordings have none
+	Path string
+	// Entries counts the parsed entries, Recent holds the newest of them
+	// in history order
+	Entries     int
+	Recent      []CommandEntry
+	Aliases     []AliasDefinition
+	Plugins     []PluginInfo
+	Environment map[string]string
+}
+
+// DataSources lists the shells that had a history or config, in the order
+// they are read, then the recordings. Each keeps its n most recent entries.
+func DataSources(data ShellData, n int) []DataSource {
+	var sources []DataSource
+	for _, name := range append(SupportedShells(), CastSource) {
+		history, read := data.Histories[name]
+		config, configured := data.ShellConfigs[name]
+		if !read && !configured {
+			continue
+		}
+
+		source := DataSource{
+			Name:        name,
+			Entries:     data.CommandCounts[name],
+			Recent:      history[max(0, len(history)-n):],
+			Aliases:     config.AliasDefinitions,
+			Plugins:     config.Plugins,
+			Environment: config.Environment,
+		}
+		if name != CastSource {
+			source.Path = HistoryFile(name)
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
@@ -0,0 +1,186 @@
+// internal/analyzer/subcommands.go
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// subcommandSpec describes how to read the command line of a tool with
+// subcommands
+type subcommandSpec struct {
+	// valueFlags take the next word as their value, e.g. "-n prod"
+	valueFlags map[string]bool
+	// nested lists subcommands whose first argument is itself a
+	// subcommand or resource, e.g. "docker compose up" or "kubectl get pods"
+	nested map[string]bool
+}
+
+var subcommandTools = map[string]subcommandSpec{
+	"git": {
+		valueFlags: map[string]bool{"-C": true, "-c": true, "--git-dir": true, "--work-tree": true, "--namespace": true},
+		nested:     map[string]bool{"stash": true, "remote": true, "submodule": true, "worktree": true, "bisect": true, "lfs": true},
+	},
+	"docker": {
+		valueFlags: map[string]bool{"--context": true, "-H": true, "--host": true, "--config": true, "-l": true, "--log-level": true, "-f": true, "--file": true, "-p": true, "--project-name": true, "-e": true, "--env": true, "-v": true, "--volume": true, "--name": true, "-w": true, "--workdir": true, "--network": true, "--entrypoint": true},
+		nested:     map[string]bool{"compose": true, "container": true, "image": true, "network": true, "volume": true, "system": true, "buildx": true, "context": true, "builder": true, "plugin": true, "swarm": true, "node": true, "service": true, "secret": true, "stack": true},
+	},
+	"kubectl": {
+		valueFlags: map[string]bool{"-n": true, "--namespace": true, "--context": true, "--kubeconfig": true, "--cluster": true, "--user": true, "-o": true, "--output": true, "-l": true, "--selector": true, "-f": true, "--filename": true, "-c": true, "--container": true},
+		nested:     map[string]bool{"get": true, "describe": true, "delete": true, "edit": true, "explain": true, "rollout": true, "config": true, "top": true, "create": true, "auth": true},
+	},
+	"helm": {
+		valueFlags: map[string]bool{"-n": true, "--namespace": true, "--kube-context": true, "-f": true, "--values": true, "--set": true, "--version": true},
+		nested:     map[string]bool{"repo": true, "plugin": true, "dependency": true, "registry": true, "show": true, "get": true},
+	},
+	"npm": {
+		valueFlags: map[string]bool{"--prefix": true, "-w": true, "--workspace": true},
+		nested:     map[string]bool{"run": true, "cache": true, "config": true},
+	},
+	"cargo": {
+		valueFlags: map[string]bool{"-p": true, "--package": true, "--bin": true, "--features": true, "-j": true, "--jobs": true, "--target": true, "--manifest-path": true},
+	},
+	"go": {
+		valueFlags: map[string]bool{"-o": true, "-C": true, "-tags": true, "-run": true, "-ldflags": true, "-gcflags": true, "-p": true, "-count": true, "-bench": true, "-timeout": true},
+		nested:     map[string]bool{"mod": true, "tool": true},
+	},
+	"systemctl": {
+		valueFlags: map[string]bool{"-H": true, "--host": true, "-M": true, "--machine": true},
+	},
+}
+
+func init() {
+	// docker's drop-in replacement reads the same
+	subcommandTools["podman"] = subcommandTools["docker"]
+}
+
+var (
+	subcommandWord = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	flagWord       = regexp.MustCompile(`^--?[A-Za-z][A-Za-z0-9-]*$`)
+)
+
+// ToolDrilldown breaks the runs of one tool down by subcommand
+type ToolDrilldown struct {
+	Tool        string
+	Runs        int
+	Subcommands []SubcommandUsage
+}
+
+// SubcommandUsage is one subcommand with its most used flags
+type SubcommandUsage struct {
+	Name  string
+	Count int
+	Flags []CommandCount
+}
+
+// addSubcommandCounts counts the subcommand and flags of an entry when it
+// runs a tool from subcommandTools. Like addCommandCounts it sees every
+// entry, so the counts stay exact in low-memory mode.
+func addSubcommandCounts(data *ShellData, entry CommandEntry) {
+	fields := strings.Fields(entry.Command)
+	if len(fields) > 1 && fields[0] == "sudo" {
+		fields = fields[1:]
+	}
+	if len(fields) < 2 {
+		return
+	}
+	spec, ok := subcommandTools[fields[0]]
+	if !ok {
+		return
+	}
+
+	subcommand, flags := parseSubcommand(fields[1:], spec)
+	if subcommand == "" {
+		return
+	}
+	tool := fields[0]
+	if data.Subcommands[tool] == nil {
+		data.Subcommands[tool] = make(map[string]int)
+	}
+	data.Subcommands[tool][subcommand]++
+
+	key := tool + " " + subcommand
+	for _, flag := range flags {
+		if data.SubcommandFlags[key] == nil {
+			data.SubcommandFlags[key] = make(map[string]int)
+		}
+		data.SubcommandFlags[key][flag]++
+	}
+}
+
+// parseSubcommand reads the subcommand, one or two words deep, and the
+// names of the flags from the arguments of a tool. Flag values and other
+// arguments are dropped; parsing stops at the end of the first command of
+// a pipeline or list.
+func parseSubcommand(args []string, spec subcommandSpec) (string, []string) {
+	var subcommand string
+	var flags []string
+	depth := 0
+	seen := make(map[string]bool)
+
+	for i := 0; i < len(args); i++ {
+		word := args[i]
+		switch word {
+		case "|", "||", "&&", ";", "&", "--":
+			return subcommand, flags
+		}
+
+		if strings.HasPrefix(word, "-") {
+			name, _, hasValue := strings.Cut(word, "=")
+			if !hasValue && spec.valueFlags[name] {
+				i++
+			}
+			if flagWord.MatchString(name) && !seen[name] {
+				seen[name] = true
+				flags = append(flags, name)
+			}
+			continue
+		}
+
+		if !subcommandWord.MatchString(word) {
+			// A path, quoted value or variable ends the subcommand
+			if depth == 0 {
+				return "", nil
+			}
+			depth = 2
+			continue
+		}
+		switch {
+		case depth == 0:
+			subcommand = word
+			depth = 1
+			if !spec.nested[word] {
+				depth = 2
+			}
+		case depth == 1:
+			subcommand += " " + word
+			depth = 2
+		}
+	}
+	return subcommand, flags
+}
+
+// Drilldowns returns the tools with subcommands that were run, busiest
+// first, each with up to n subcommands and n flags per subcommand
+func Drilldowns(data ShellData, n int) []ToolDrilldown {
+	var tools []ToolDrilldown
+	for tool, counts := range data.Subcommands {
+		drilldown := ToolDrilldown{Tool: tool, Runs: data.CommonCmds[tool]}
+		for _, cc := range SortedCounts(counts, n) {
+			drilldown.Subcommands = append(drilldown.Subcommands, SubcommandUsage{
+				Name:  cc.Command,
+				Count: cc.Count,
+				Flags: SortedCounts(data.SubcommandFlags[tool+" "+cc.Command], n),
+			})
+		}
+		tools = append(tools, drilldown)
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].Runs != tools[j].Runs {
+			return tools[i].Runs > tools[j].Runs
+		}
+		return tools[i].Tool < tools[j].Tool
+	})
+	return tools
+}
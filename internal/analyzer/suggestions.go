@@ -0,0 +1,72 @@
+// internal/analyzer/suggestions.go
+package analyzer
+
+// Suggestion is one piece of advice. The ID doubles as the message ID under
+// "suggestions." in the i18n catalogs and Args fill in its placeholders.
+type Suggestion struct {
+	ID   string
+	Args []interface{}
+}
+
+// Suggestions contains the advice shown on the Suggestions tab
+type Suggestions struct {
+	// Aliases are proposed aliases with the keystrokes they would have saved
+	Aliases         []AliasSimulation
+	Recommendations []Suggestion
+	Tips            []Suggestion
+	// Cleanup lists unused, shadowing and duplicate aliases
+	Cleanup AliasAudit
+	// Alternatives are modern replacements for classic commands
+	Alternatives []ToolAlternative
+	// TypoFixes alias frequent typos to the intended program
+	TypoFixes []TypoFix
+}
+
+// TypoFix is an alias that turns a typo into the intended command
+type TypoFix struct {
+	Proposal AliasProposal
+	Count    int
+}
+
+// suggestedAliases caps the number of proposed aliases
+const suggestedAliases = 8
+
+// Suggest derives alias proposals, setup recommendations and workflow tips.
+// Insights.Security must already be filled in.
+func Suggest(data ShellData) Suggestions {
+	var aliases []AliasSimulation
+	for _, sim := range SimulateAliases(data, ProposeAliases(data, suggestedAliases)) {
+		if sim.KeystrokesSaved > 0 {
+			aliases = append(aliases, sim)
+		}
+	}
+	return Suggestions{
+		Aliases:         aliases,
+		Recommendations: generateRecommendations(&data),
+		Tips:            generateWorkflowTips(&data, aliases),
+		Cleanup:         AuditAliases(data),
+		Alternatives:    ModernAlternatives(data),
+		TypoFixes:       typoFixes(data),
+	}
+}
+
+// AliasLine returns the line that defines the alias, which bash, zsh and
+// fish all accept
+func (p AliasProposal) AliasLine() string {
+	return "alias " + p.Name + "='" + p.Expansion + "'"
+}
+
+// typoFixesShown caps the typo fixes
+const typoFixesShown = 5
+
+// typoFixes proposes an alias for each of the most frequent typos
+func typoFixes(data ShellData) []TypoFix {
+	var fixes []TypoFix
+	for _, typo := range DetectTypos(data) {
+		fixes = append(fixes, TypoFix{Proposal: AliasProposal{Name: typo.Command, Expansion: typo.Intended}, Count: typo.Count})
+		if len(fixes) == typoFixesShown {
+			break
+		}
+	}
+	return fixes
+}
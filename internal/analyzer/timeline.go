@@ -0,0 +1,68 @@
+// internal/analyzer/timeline.go
+package analyzer
+
+import (
+	"slices"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/types"
+)
+
+// The kinds of timeline entries: a typo, an interesting tool, or a
+// pipeline, chain or redirection
+const (
+	TimelineTypo     = "typo"
+	TimelineTool     = "tool"
+	TimelinePipeline = "pipeline"
+)
+
+// TimelineKinds lists the kinds in the order they are filtered on
+var TimelineKinds = []string{TimelineTool, TimelinePipeline, TimelineTypo}
+
+// TimelineFilter narrows the timeline to a shell, a category and a kind;
+// an empty field matches everything
+type TimelineFilter struct {
+	Shell    string
+	Category string
+	Kind     string
+}
+
+// FilterTimeline returns the entries matching filter, in their order
+func FilterTimeline(entries []types.TimelineEntry, filter TimelineFilter) []types.TimelineEntry {
+	if filter == (TimelineFilter{}) {
+		return entries
+	}
+	var matched []types.TimelineEntry
+	for _, entry := range entries {
+		if filter.Shell != "" && entry.Shell != filter.Shell {
+			continue
+		}
+		if filter.Category != "" && !slices.Contains(entry.Categories, filter.Category) {
+			continue
+		}
+		if filter.Kind != "" && entry.Kind != filter.Kind {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	return matched
+}
+
+// TimelineShells lists, sorted, the shells the timeline can be filtered on
+func TimelineShells(entries []types.TimelineEntry) []string {
+	return timelineValues(entries, func(entry types.TimelineEntry) []string { return []string{entry.Shell} })
+}
+
+// TimelineCategories lists, sorted, the categories of the timeline's commands
+func TimelineCategories(entries []types.TimelineEntry) []string {
+	return timelineValues(entries, func(entry types.TimelineEntry) []string { return entry.Categories })
+}
+
+func timelineValues(entries []types.TimelineEntry, values func(types.TimelineEntry) []string) []string {
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		for _, value := range values(entry) {
+			seen[value] = true
+		}
+	}
+	return SortedKeys(seen)
+}
@@ -0,0 +1,78 @@
+// internal/analyzer/top_commands.go
+package analyzer
+
+import "strings"
+
+// TopCommand is a leaderboard entry: a program or command prefix, how often
+// it ran, its share of all commands and how the runs split across shells
+type TopCommand struct {
+	Command  string
+	Count    int
+	Share    float64
+	PerShell map[string]int
+}
+
+// addCommandCounts counts an entry towards the program and prefix
+// leaderboards. It sees every entry, so the counts stay exact in low-memory mode.
+func addCommandCounts(data *ShellData, shell string, entry CommandEntry) {
+	program := commandProgram(entry.Command)
+	if program == "" {
+		return
+	}
+	data.CommonCmds[program]++
+	if data.ShellCmds[shell] == nil {
+		data.ShellCmds[shell] = make(map[string]int)
+	}
+	data.ShellCmds[shell][program]++
+
+	if fields := strings.Fields(entry.Command); len(fields) > 1 {
+		data.CommonPrefixes[strings.Join(fields[:2], " ")]++
+	}
+}
+
+// TopCommands returns the n most run programs with their per-shell breakdown
+func TopCommands(data ShellData, n int) []TopCommand {
+	total := totalCommands(data)
+	var top []TopCommand
+	for _, cc := range SortedCounts(data.CommonCmds, n) {
+		perShell := make(map[string]int)
+		for shell, counts := range data.ShellCmds {
+			if counts[cc.Command] > 0 {
+				perShell[shell] = counts[cc.Command]
+			}
+		}
+		top = append(top, TopCommand{
+			Command:  cc.Command,
+			Count:    cc.Count,
+			Share:    share(cc.Count, total),
+			PerShell: perShell,
+		})
+	}
+	return top
+}
+
+// TopPrefixes returns the n most common two-word command prefixes, such as
+// "git status"
+func TopPrefixes(data ShellData, n int) []TopCommand {
+	total := totalCommands(data)
+	var top []TopCommand
+	for _, cc := range SortedCounts(data.CommonPrefixes, n) {
+		top = append(top, TopCommand{Command: cc.Command, Count: cc.Count, Share: share(cc.Count, total)})
+	}
+	return top
+}
+
+func totalCommands(data ShellData) int {
+	total := 0
+	for _, count := range data.CommandCounts {
+		total += count
+	}
+	return total
+}
+
+func share(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
@@ -0,0 +1,195 @@
+// internal/analyzer/typos.go
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Typo is a mistyped program name and the program that was meant
+type Typo struct {
+	// Command is the program name as typed
+	Command  string
+	Intended string
+	Count    int
+}
+
+// commonTypos are typos recognized even when nothing else points at them
+var commonTypos = map[string]string{
+	"sl": "ls", "cd..": "cd ..", "pythoon": "python", "gti": "git", "vmi": "vim",
+	"nivm": "nvim", "emasc": "emacs", "clea": "clear", "exot": "exit",
+}
+
+// shellBuiltins are not found on $PATH but are no typos
+var shellBuiltins = map[string]bool{
+	".": true, ":": true, "[": true, "abbr": true, "alias": true, "bg": true, "bind": true,
+	"builtin": true, "cd": true, "command": true, "dirs": true, "disown": true, "echo": true,
+	"eval": true, "exec": true, "exit": true, "export": true, "fg": true, "funced": true,
+	"funcsave": true, "functions": true, "hash": true, "history": true, "jobs": true,
+	"kill": true, "popd": true, "pushd": true, "read": true, "rehash": true, "set": true,
+	"setopt": true, "source": true, "test": true, "trap": true, "type": true, "ulimit": true,
+	"umask": true, "unalias": true, "unset": true, "unsetopt": true, "wait": true, "z": true, "zi": true,
+}
+
+// Typo detection thresholds: the intended program must be run at least
+// minIntendedRuns times and typoRatio times as often as the typo
+const (
+	minIntendedRuns = 10
+	typoRatio       = 3
+)
+
+// DetectTypos finds program names within a small edit distance of a
+// program the user runs much more often, or of an installed binary when
+// probing is enabled. A candidate counts as a typo when it is a known
+// typo, is not installed, or was at least once followed straight away by
+// the intended program. Aliases and builtins are never typos. The most
+// frequent typos come first.
+func DetectTypos(data ShellData) []Typo {
+	probe := data.Options.Enabled(ModuleProbe)
+	defined := make(map[string]bool)
+	for _, config := range data.ShellConfigs {
+		for name := range shortcuts(config) {
+			defined[name] = true
+		}
+	}
+
+	var intended []string
+	for program, count := range data.CommonCmds {
+		if count >= minIntendedRuns {
+			intended = append(intended, program)
+		}
+	}
+	var binaries []string
+	if probe {
+		binaries = pathBinaries()
+	}
+
+	corrected := correctedPrograms(data)
+	var typos []Typo
+	for _, typed := range SortedKeys(data.CommonCmds) {
+		count := data.CommonCmds[typed]
+		if defined[typed] || shellBuiltins[typed] {
+			continue
+		}
+		if meant, ok := commonTypos[typed]; ok {
+			typos = append(typos, Typo{Command: typed, Intended: meant, Count: count})
+			continue
+		}
+
+		meant := closestProgram(typed, intended, data.CommonCmds, count)
+		// Something run this often is no typo of a program never used
+		if meant == "" && probe && count < minIntendedRuns {
+			meant = closestProgram(typed, binaries, nil, count)
+		}
+		if meant == "" {
+			continue
+		}
+		if corrected[typed+"\x00"+meant] || (probe && !checkToolInstalled(typed)) {
+			typos = append(typos, Typo{Command: typed, Intended: meant, Count: count})
+		}
+	}
+
+	sort.SliceStable(typos, func(i, j int) bool {
+		return typos[i].Count > typos[j].Count
+	})
+	return typos
+}
+
+// closestProgram returns the candidate nearest to typed, preferring the
+// most run one. With counts set, a candidate must be run often enough
+// compared to typed.
+func closestProgram(typed string, candidates []string, counts map[string]int, typedCount int) string {
+	if len(typed) < 2 {
+		return ""
+	}
+	maxDistance := 1
+	if len(typed) >= 7 {
+		maxDistance = 2
+	}
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		if candidate == typed || len(candidate) < 2 {
+			continue
+		}
+		if counts != nil && counts[candidate] < typedCount*typoRatio {
+			continue
+		}
+		d := editDistance(typed, candidate)
+		if d < bestDistance || (d == bestDistance && best != "" &&
+			(counts[candidate] > counts[best] || (counts[candidate] == counts[best] && candidate < best))) {
+			best, bestDistance = candidate, d
+		}
+	}
+	if bestDistance > maxDistance {
+		return ""
+	}
+	return best
+}
+
+// correctedPrograms records "typed\x00intended" for every entry that was
+// followed by an entry running a different program differing by one edit
+func correctedPrograms(data ShellData) map[string]bool {
+	corrected := make(map[string]bool)
+	for _, history := range data.Histories {
+		for i := 1; i < len(history); i++ {
+			typed, next := commandProgram(history[i-1].Command), commandProgram(history[i].Command)
+			if typed != next && typed != "" && next != "" && editDistance(typed, next) <= 2 {
+				corrected[typed+"\x00"+next] = true
+			}
+		}
+	}
+	return corrected
+}
+
+// editDistance is the optimal string alignment distance: insertions,
+// deletions, substitutions and swaps of adjacent characters
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// pathBinaries lists the executables on $PATH
+func pathBinaries() []string {
+	seen := make(map[string]bool)
+	var binaries []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if seen[name] || strings.HasPrefix(name, ".") {
+				continue
+			}
+			if info, err := entry.Info(); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+				seen[name] = true
+				binaries = append(binaries, name)
+			}
+		}
+	}
+	sort.Strings(binaries)
+	return binaries
+}
@@ -0,0 +1,104 @@
+// internal/analyzer/weekly.go
+package analyzer
+
+import (
+	"strings"
+	"time"
+)
+
+// weeklyTop is how many programs the weekly highlights name
+const weeklyTop = 3
+
+// weeklyNewMinRuns is how often a program has to run in its first week to
+// count as a new tool rather than a typo
+const weeklyNewMinRuns = 2
+
+// lateNightEnd is the hour before which commands count as late night
+const lateNightEnd = 5
+
+// WeeklyHighlights sums up the seven days before End in a form that is safe
+// to share: programs are named, arguments and paths never are
+type WeeklyHighlights struct {
+	Start, End time.Time
+	Commands   int
+	// PreviousCommands is the count for the seven days before Start
+	PreviousCommands int
+	ActiveDays       int
+	Top              []CommandCount
+	// NewTools are programs run this week, at least weeklyNewMinRuns
+	// times, and never before
+	NewTools       []string
+	BusiestDay     time.Time
+	BusiestDayRuns int
+	// LateNight counts the commands run between midnight and lateNightEnd
+	LateNight int
+	Streak    int
+	// NextSkill is the first skill GrowthPlan suggests learning, if any
+	NextSkill Skill
+}
+
+// Weekly computes the highlights of the seven full days before the day of
+// now. Entries without a timestamp cannot be placed and are left out.
+func Weekly(data ShellData, now time.Time) WeeklyHighlights {
+	end := dayStart(now)
+	w := WeeklyHighlights{Start: end.AddDate(0, 0, -7), End: end, Streak: data.Insights.Achievements.CurrentStreak}
+	previousStart := w.Start.AddDate(0, 0, -7)
+
+	programs := make(map[string]int)
+	seenBefore := make(map[string]bool)
+	days := make(map[time.Time]int)
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			t := entry.Timestamp
+			program := shareableProgram(entry.Command)
+			switch {
+			case t.IsZero() || !t.Before(end):
+				continue
+			case t.Before(w.Start):
+				if program != "" {
+					seenBefore[program] = true
+				}
+				if !t.Before(previousStart) {
+					w.PreviousCommands++
+				}
+				continue
+			}
+			w.Commands++
+			days[dayStart(t)]++
+			if t.Hour() < lateNightEnd {
+				w.LateNight++
+			}
+			if program != "" {
+				programs[program]++
+			}
+		}
+	}
+
+	w.ActiveDays = len(days)
+	for day, runs := range days {
+		if runs > w.BusiestDayRuns || (runs == w.BusiestDayRuns && day.Before(w.BusiestDay)) {
+			w.BusiestDay, w.BusiestDayRuns = day, runs
+		}
+	}
+	w.Top = SortedCounts(programs, weeklyTop)
+	for _, program := range SortedKeys(programs) {
+		if !seenBefore[program] && programs[program] >= weeklyNewMinRuns {
+			w.NewTools = append(w.NewTools, program)
+		}
+	}
+	if next := GrowthPlan(data).Next; len(next) > 0 {
+		w.NextSkill = next[0].Skill
+	}
+	return w
+}
+
+// shareableProgram returns the program a command runs if its name says
+// nothing private. Scripts run by path, which name the directories they
+// are in, are left out, as are words holding variables or quotes.
+func shareableProgram(command string) string {
+	program := commandProgram(command)
+	if program == "" || strings.Contains(program, "/") || strings.ContainsAny(program, "$=\"'`") {
+		return ""
+	}
+	return program
+}
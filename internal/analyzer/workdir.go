@@ -0,0 +1,86 @@
+// internal/analyzer/workdir.go
+package analyzer
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// walkDirs calls fn for every entry of every history, in shell order, with
+// the directory the entry was probably run in. Shell histories do not record
+// the working directory, so unless atuin did it is inferred by following cd
+// commands from home, starting over at the first entry of each session
+// (newSession).
+func walkDirs(histories map[string][]CommandEntry, fn func(entry CommandEntry, cwd string, newSession bool)) {
+	home, _ := utils.HomeDir()
+	for _, shell := range SortedKeys(histories) {
+		cwd, previous := "~", "~"
+		var last time.Time
+		for i, entry := range histories[shell] {
+			newSession := i == 0
+			if !entry.Timestamp.IsZero() {
+				if !last.IsZero() && entry.Timestamp.Sub(last) > SessionGap {
+					cwd, previous = "~", "~"
+					newSession = true
+				}
+				last = entry.Timestamp
+			}
+			if entry.Dir != "" && home != "" {
+				cwd = shortenHome(entry.Dir, home)
+			}
+			fn(entry, cwd, newSession)
+
+			words := strings.Fields(entry.Command)
+			if len(words) == 0 || words[0] != "cd" {
+				continue
+			}
+			target := "~"
+			if len(words) > 1 {
+				target = words[1]
+			}
+			if target == "-" {
+				cwd, previous = previous, cwd
+			} else {
+				cwd, previous = resolveDir(cwd, target), cwd
+			}
+		}
+	}
+}
+
+// resolveDir applies a cd target to the current directory, keeping paths
+// under home in ~/ form
+func resolveDir(cwd, target string) string {
+	target = strings.Trim(target, "'\"")
+	switch {
+	case target == "" || target == ".":
+		return cwd
+	case target == "~" || strings.HasPrefix(target, "~/") || strings.HasPrefix(target, "/"):
+		return path.Clean(target)
+	case strings.HasPrefix(target, "$HOME"):
+		return path.Clean("~" + strings.TrimPrefix(target, "$HOME"))
+	}
+	dir := path.Clean(path.Join(cwd, target))
+	if dir == "." || strings.HasPrefix(dir, "../") || dir == ".." {
+		// Climbed out of home, e.g. cd ../.. from ~/src
+		home, err := utils.HomeDir()
+		if err != nil {
+			return "/"
+		}
+		return shortenHome(path.Join(home, strings.TrimPrefix(cwd, "~"), target), home)
+	}
+	return dir
+}
+
+// shortenHome writes dir in ~/ form if it is under home
+func shortenHome(dir, home string) string {
+	if dir == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(dir, home+"/"); ok {
+		return "~/" + rest
+	}
+	return dir
+}
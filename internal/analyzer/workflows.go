@@ -0,0 +1,260 @@
+// internal/analyzer/workflows.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Workflow is a sequence of commands the user keeps running in the same
+// order, e.g. git add → git commit → git push
+type Workflow struct {
+	// Steps are the programs, with the subcommand for tools like git
+	Steps []string
+	Count int
+	// Alias collapses the workflow into one command; it has no name when
+	// an alias already does the same or no free name was found
+	Alias AliasProposal
+}
+
+const (
+	// workflowGap is the most time between two steps of a workflow
+	workflowGap = 10 * time.Minute
+	// workflowMinSteps and workflowMaxSteps bound the length of a workflow
+	workflowMinSteps = 2
+	workflowMaxSteps = 4
+	// workflowMinRuns is how often a sequence must recur to be a workflow
+	workflowMinRuns = 5
+	// workflowShown caps the list of workflows
+	workflowShown = 5
+)
+
+// workflowNoise are commands run in between the steps to look around; they
+// are skipped rather than breaking a workflow
+var workflowNoise = map[string]bool{
+	"cd": true, "clear": true, "history": true, "exit": true,
+}
+
+// workflowStep is one command of a history as a workflow sees it
+type workflowStep struct {
+	key     string
+	command string
+	time    time.Time
+}
+
+// analyzeWorkflows mines the sequences of two to four steps that recur at
+// least workflowMinRuns times, each step within workflowGap of the one
+// before. A sequence is left out when a longer one containing it recurs
+// nearly as often.
+func analyzeWorkflows(data *ShellData) []Workflow {
+	counts := make(map[string]int)
+	eachWorkflowWindow(data.Histories, func(key string, _ []workflowStep) {
+		counts[key]++
+	})
+
+	// Only sequences that recur nearly as often as a workflow can subsume one
+	frequent := make(map[string]int)
+	for key, count := range counts {
+		if count*4 >= workflowMinRuns*3 {
+			frequent[key] = count
+		}
+	}
+	var candidates []CommandCount
+	for key, count := range frequent {
+		if count >= workflowMinRuns && !subsumed(key, count, frequent) {
+			candidates = append(candidates, CommandCount{Command: key, Count: count})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		wa, wb := a.Count*len(strings.Split(a.Command, "\n")), b.Count*len(strings.Split(b.Command, "\n"))
+		if wa != wb {
+			return wa > wb
+		}
+		return a.Command < b.Command
+	})
+
+	taken := make(map[string]bool)
+	for _, config := range data.ShellConfigs {
+		for name, expansion := range shortcuts(config) {
+			taken[name] = true
+			taken["="+expansion] = true
+		}
+		for name := range config.Functions {
+			taken[name] = true
+		}
+	}
+	candidates = candidates[:min(len(candidates), workflowShown)]
+
+	// How each step of the shown workflows is typed
+	commands := make(map[string][]map[string]int)
+	for _, candidate := range candidates {
+		commands[candidate.Command] = nil
+	}
+	eachWorkflowWindow(data.Histories, func(key string, window []workflowStep) {
+		typed, ok := commands[key]
+		if !ok {
+			return
+		}
+		if typed == nil {
+			typed = make([]map[string]int, len(window))
+			for i := range typed {
+				typed[i] = make(map[string]int)
+			}
+			commands[key] = typed
+		}
+		for i, step := range window {
+			typed[i][step.command]++
+		}
+	})
+
+	var workflows []Workflow
+	for _, candidate := range candidates {
+		workflow := Workflow{Steps: strings.Split(candidate.Command, "\n"), Count: candidate.Count}
+		expansion := workflowExpansion(workflow.Steps, commands[candidate.Command])
+		if name := workflowAliasName(workflow.Steps); !taken["="+expansion] && workflowName(data, name, taken) {
+			taken[name] = true
+			workflow.Alias = AliasProposal{Name: name, Expansion: expansion}
+		}
+		workflows = append(workflows, workflow)
+	}
+	return workflows
+}
+
+// eachWorkflowWindow calls fn with every sequence of steps in histories
+// that could be a pass through a workflow
+func eachWorkflowWindow(histories map[string][]CommandEntry, fn func(key string, window []workflowStep)) {
+	for _, history := range histories {
+		steps := workflowSteps(history)
+		for start := range steps {
+			for n := workflowMinSteps; n <= workflowMaxSteps && start+n <= len(steps); n++ {
+				window := steps[start : start+n]
+				if !workflowWindow(window) {
+					break
+				}
+				fn(workflowKey(window), window)
+			}
+		}
+	}
+}
+
+// workflowSteps turns a history into steps, dropping the noise and merging
+// runs of the same step, e.g. several git add in a row
+func workflowSteps(history []CommandEntry) []workflowStep {
+	var steps []workflowStep
+	for _, entry := range history {
+		key := stepKey(entry.Command)
+		if key == "" || workflowNoise[key] || harmlessRepeats[key] ||
+			harmlessRepeats[strings.Join(strings.Fields(entry.Command), " ")] {
+			continue
+		}
+		if n := len(steps); n > 0 && steps[n-1].key == key {
+			steps[n-1].time = entry.Timestamp
+			continue
+		}
+		steps = append(steps, workflowStep{key: key, command: entry.Command, time: entry.Timestamp})
+	}
+	return steps
+}
+
+// stepKey names the step a command line is: the program, followed by the
+// subcommand for the tools that have them
+func stepKey(command string) string {
+	program := commandProgram(command)
+	spec, ok := subcommandTools[program]
+	if !ok {
+		return program
+	}
+	fields := strings.Fields(command)
+	if fields[0] == "sudo" {
+		fields = fields[1:]
+	}
+	if subcommand, _ := parseSubcommand(fields[1:], spec); subcommand != "" {
+		return program + " " + subcommand
+	}
+	return program
+}
+
+// workflowWindow reports whether window is a single pass through a
+// workflow: no step twice and no long pause between steps
+func workflowWindow(window []workflowStep) bool {
+	seen := make(map[string]bool)
+	for i, step := range window {
+		if seen[step.key] {
+			return false
+		}
+		seen[step.key] = true
+		if i > 0 && !step.time.IsZero() && !window[i-1].time.IsZero() &&
+			step.time.Sub(window[i-1].time) > workflowGap {
+			return false
+		}
+	}
+	return true
+}
+
+// workflowKey joins the step keys of window; keys never contain newlines
+func workflowKey(window []workflowStep) string {
+	keys := make([]string, len(window))
+	for i, step := range window {
+		keys[i] = step.key
+	}
+	return strings.Join(keys, "\n")
+}
+
+// subsumed reports whether a longer sequence containing key recurs at
+// least three quarters as often, making key only part of a workflow
+func subsumed(key string, count int, counts map[string]int) bool {
+	for other, otherCount := range counts {
+		if len(other) > len(key) && strings.Contains("\n"+other+"\n", "\n"+key+"\n") && otherCount*4 >= count*3 {
+			return true
+		}
+	}
+	return false
+}
+
+// workflowExpansion chains the steps with &&. A step the user types the
+// same way most of the time is used as typed, otherwise just the step, so
+// e.g. a commit message is still asked for.
+func workflowExpansion(steps []string, commands []map[string]int) string {
+	parts := make([]string, len(steps))
+	for i, step := range steps {
+		parts[i] = step
+		top := SortedCounts(commands[i], 1)
+		total := 0
+		for _, count := range commands[i] {
+			total += count
+		}
+		if len(top) > 0 && top[0].Count*2 > total && !strings.ContainsAny(top[0].Command, "'\n") &&
+			!strings.Contains(top[0].Command, "&&") && !isRisky(top[0].Command) {
+			parts[i] = strings.TrimSpace(top[0].Command)
+		}
+	}
+	return strings.Join(parts, " && ")
+}
+
+// workflowAliasName names a workflow after its steps, leaving out a tool
+// repeated from the step before, e.g. git add → git commit → git push
+// becomes "gacp"
+func workflowAliasName(steps []string) string {
+	var words []string
+	previous := ""
+	for _, step := range steps {
+		tool, subcommand, found := strings.Cut(step, " ")
+		if found && tool == previous {
+			words = append(words, subcommand)
+		} else {
+			words = append(words, step)
+		}
+		previous = tool
+	}
+	return aliasName(strings.Join(words, " "))
+}
+
+// workflowName reports whether name is free for a workflow alias
+func workflowName(data *ShellData, name string, taken map[string]bool) bool {
+	if len(name) < workflowMinSteps || taken[name] || data.CommonCmds[name] > 0 {
+		return false
+	}
+	return !data.Options.Enabled(ModuleProbe) || !checkToolInstalled(name)
+}
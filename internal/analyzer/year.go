@@ -0,0 +1,86 @@
+// internal/analyzer/year.go
+package analyzer
+
+import "time"
+
+// yearNewMinRuns is how often a program has to run in the year to count as
+// learned rather than tried once
+const yearNewMinRuns = 5
+
+// YearInReview holds the statistics of one calendar year for `wrapped`
+type YearInReview struct {
+	Year       int
+	Commands   int
+	ActiveDays int
+	// Months are January to December; months without commands are empty
+	Months         [12]MonthHighlight
+	BiggestDay     time.Time
+	BiggestDayRuns int
+	// NewTools are the programs first run this year, most run first
+	NewTools []CommandCount
+}
+
+// MonthHighlight is one month of the year in review
+type MonthHighlight struct {
+	Commands   int
+	ActiveDays int
+	Top        CommandCount
+	// Tools counts the different programs run
+	Tools int
+}
+
+// ReviewYear computes the year in review from data restricted to the year.
+// before holds the programs run before it, with counts, so the ones learned
+// this year can be told apart.
+func ReviewYear(data ShellData, year int, before map[string]int) YearInReview {
+	review := YearInReview{Year: year}
+	programs := make(map[string]int)
+	var monthPrograms [12]map[string]int
+	var monthDays [12]map[time.Time]bool
+	days := make(map[time.Time]int)
+	for i := range monthPrograms {
+		monthPrograms[i] = make(map[string]int)
+		monthDays[i] = make(map[time.Time]bool)
+	}
+
+	for _, history := range data.Histories {
+		for _, entry := range history {
+			if entry.Timestamp.IsZero() || entry.Timestamp.Year() != year {
+				continue
+			}
+			month := int(entry.Timestamp.Month()) - 1
+			day := dayStart(entry.Timestamp)
+			review.Commands++
+			review.Months[month].Commands++
+			monthDays[month][day] = true
+			days[day]++
+			if program := commandProgram(entry.Command); program != "" {
+				programs[program]++
+				monthPrograms[month][program]++
+			}
+		}
+	}
+
+	review.ActiveDays = len(days)
+	for day, runs := range days {
+		if runs > review.BiggestDayRuns || (runs == review.BiggestDayRuns && day.Before(review.BiggestDay)) {
+			review.BiggestDay, review.BiggestDayRuns = day, runs
+		}
+	}
+	for i := range review.Months {
+		review.Months[i].ActiveDays = len(monthDays[i])
+		review.Months[i].Tools = len(monthPrograms[i])
+		if top := SortedCounts(monthPrograms[i], 1); len(top) > 0 {
+			review.Months[i].Top = top[0]
+		}
+	}
+
+	learned := make(map[string]int)
+	for program, count := range programs {
+		if before[program] == 0 && count >= yearNewMinRuns {
+			learned[program] = count
+		}
+	}
+	review.NewTools = SortedCounts(learned, 0)
+	return review
+}
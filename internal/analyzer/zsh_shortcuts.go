@@ -0,0 +1,78 @@
+// internal/analyzer/zsh_shortcuts.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+)
+
+// ShortcutUsage counts the uses of a zsh global alias or named directory
+type ShortcutUsage struct {
+	// Name is the alias name, or ~name for a named directory
+	Name      string
+	Expansion string
+	Uses      int
+}
+
+// ZshShortcuts counts how often each global alias and named directory of
+// the zsh config appears in the zsh history, most used first. Global
+// aliases match whole words; named directories match ~name and ~name/...
+func ZshShortcuts(data ShellData) (globals, dirs []ShortcutUsage) {
+	config := data.ShellConfigs["zsh"]
+	if len(config.GlobalAliases) == 0 && len(config.NamedDirs) == 0 {
+		return nil, nil
+	}
+
+	globalUses := make(map[string]int)
+	dirUses := make(map[string]int)
+	for _, entry := range data.Histories["zsh"] {
+		for _, word := range strings.Fields(entry.Command) {
+			if _, ok := config.GlobalAliases[word]; ok {
+				globalUses[word]++
+			}
+			if rest, ok := strings.CutPrefix(word, "~"); ok {
+				name, _, _ := strings.Cut(rest, "/")
+				if _, ok := config.NamedDirs[name]; ok {
+					dirUses[name]++
+				}
+			}
+		}
+	}
+
+	for name, expansion := range config.GlobalAliases {
+		globals = append(globals, ShortcutUsage{Name: name, Expansion: expansion, Uses: globalUses[name]})
+	}
+	for name, dir := range config.NamedDirs {
+		dirs = append(dirs, ShortcutUsage{Name: "~" + name, Expansion: dir, Uses: dirUses[name]})
+	}
+	sortShortcuts(globals)
+	sortShortcuts(dirs)
+	return globals, dirs
+}
+
+// sortShortcuts orders by uses, then name
+func sortShortcuts(usage []ShortcutUsage) {
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Uses != usage[j].Uses {
+			return usage[i].Uses > usage[j].Uses
+		}
+		return usage[i].Name < usage[j].Name
+	})
+}
+
+// shortcuts returns every name a config defines for the user to type:
+// aliases, zsh global aliases and ~name for zsh named directories, mapped
+// to their expansions
+func shortcuts(config ShellConfig) map[string]string {
+	all := make(map[string]string, len(config.Aliases)+len(config.GlobalAliases)+len(config.NamedDirs))
+	for name, expansion := range config.Aliases {
+		all[name] = expansion
+	}
+	for name, expansion := range config.GlobalAliases {
+		all[name] = expansion
+	}
+	for name, dir := range config.NamedDirs {
+		all["~"+name] = dir
+	}
+	return all
+}
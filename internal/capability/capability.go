@@ -0,0 +1,145 @@
+// internal/capability/capability.go
+package capability
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// Report is what the environment supports. Tabs consult it to say what to
+// enable instead of showing empty sections.
+type Report struct {
+	// Histories are the shells whose history was read, Untimed those of
+	// them without a single timestamp
+	Histories []string
+	Untimed   []string
+	// AI is set when an API key is configured, AIDisabled when the AI was
+	// turned off on purpose
+	AI         bool
+	AIDisabled bool
+	// Clipboard is the command that copies to the clipboard, "" when none
+	// was found
+	Clipboard string
+	// Images is the inline image protocol of the terminal: "kitty",
+	// "iterm2", "sixel" or ""
+	Images string
+	// Outcomes is set when any command has an exit status or duration
+	Outcomes bool
+}
+
+// timestampSettings is what turns on timestamps in each shell's history;
+// fish always records them
+var timestampSettings = map[string]string{
+	"bash": "export HISTTIMEFORMAT='%F %T '",
+	"zsh":  "setopt EXTENDED_HISTORY",
+}
+
+// timedTabs are the tabs that place commands in time
+var timedTabs = map[string]bool{"work_patterns": true, "timeline": true, "achievements": true}
+
+// historyFreeTabs are the tabs that show something without any history
+var historyFreeTabs = map[string]bool{"tech_profile": true, "trends": true, "data": true, "diagnostics": true, "settings": true}
+
+// Detect builds the report from the analysis and the environment. noAI is
+// whether the AI was disabled for this run.
+func Detect(data analyzer.ShellData, noAI bool) Report {
+	r := Report{AI: gemini.HasAPIKey(), AIDisabled: noAI, Clipboard: clipboard(), Images: images()}
+	for _, shell := range analyzer.SupportedShells() {
+		history, ok := data.Histories[shell]
+		if !ok || data.CommandCounts[shell] == 0 {
+			continue
+		}
+		r.Histories = append(r.Histories, shell)
+		timed := false
+		for _, entry := range history {
+			if !entry.Timestamp.IsZero() {
+				timed = true
+				break
+			}
+		}
+		if !timed {
+			r.Untimed = append(r.Untimed, shell)
+		}
+	}
+	outcomes := data.Insights.WorkPatterns.Outcomes
+	r.Outcomes = outcomes.Exited > 0 || outcomes.Timed > 0
+	return r
+}
+
+// Hints says what to enable to unlock what tab would show
+func (r Report) Hints(tab string) []string {
+	if len(r.Histories) == 0 {
+		if historyFreeTabs[tab] {
+			return nil
+		}
+		return []string{i18n.T("capability.hint.history")}
+	}
+
+	var hints []string
+	if timedTabs[tab] {
+		for _, shell := range r.Untimed {
+			if setting, ok := timestampSettings[shell]; ok {
+				hints = append(hints, i18n.T("capability.hint.timestamps", setting, analyzer.RCFiles[shell], i18n.T("capability.unlock."+tab)))
+			}
+		}
+	}
+	if tab == "work_patterns" && !r.Outcomes {
+		hints = append(hints, i18n.T("capability.hint.outcomes"))
+	}
+	if tab == "wrapped" && !r.AI && !r.AIDisabled {
+		hints = append(hints, i18n.T("capability.hint.ai"))
+	}
+	if tab == "chat" && !r.AI {
+		hints = append(hints, i18n.T("capability.hint.chat"))
+	}
+	if tab == "growth" && !r.AI && !r.AIDisabled {
+		hints = append(hints, i18n.T("capability.hint.growth"))
+	}
+	return hints
+}
+
+// clipboard finds a command that copies its input to the clipboard
+func clipboard() string {
+	var candidates []string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = []string{"pbcopy"}
+	case "windows":
+		candidates = []string{"clip.exe"}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, "wl-copy")
+		}
+		if os.Getenv("DISPLAY") != "" {
+			candidates = append(candidates, "xclip", "xsel")
+		}
+		// Termux, and WSL through the Windows clipboard
+		candidates = append(candidates, "termux-clipboard-set", "clip.exe")
+	}
+	for _, name := range candidates {
+		if _, err := exec.LookPath(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// images guesses the inline image protocol from the variables terminals set
+func images() string {
+	term, program := os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")
+	switch {
+	case term == "xterm-kitty" || os.Getenv("KITTY_WINDOW_ID") != "" || program == "ghostty":
+		return "kitty"
+	case program == "iTerm.app" || program == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return "iterm2"
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "foot") || program == "mlterm":
+		return "sixel"
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+// internal/clock/clock.go
+package clock
+
+import "time"
+
+// Epoch is the time reported by Now in deterministic mode
+var Epoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var fixed time.Time
+
+// Now returns the current time, or the fixed time set by Fix
+func Now() time.Time {
+	if !fixed.IsZero() {
+		return fixed
+	}
+	return time.Now()
+}
+
+// Fix makes Now always return t, so reports do not depend on when they
+// were generated. The zero time restores the real clock.
+func Fix(t time.Time) {
+	fixed = t
+}
+
+// Deterministic fixes the clock at Epoch and switches the local time zone
+// to UTC, so the same history renders the same on every machine
+func Deterministic() {
+	Fix(Epoch)
+	time.Local = time.UTC
+}
@@ -0,0 +1,401 @@
+// internal/config/config.go
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the user's settings from config.yaml
+type Config struct {
+	GeminiAPIKey string              `yaml:"gemini_api_key,omitempty"`
+	Language     string              `yaml:"language,omitempty"`
+	NoAI         bool                `yaml:"no_ai,omitempty"`
+	Roast        bool                `yaml:"roast,omitempty"`
+	GitReflogs   bool                `yaml:"git_reflogs,omitempty"`
+	Theme        string              `yaml:"theme,omitempty"`
+	Redaction    string              `yaml:"redaction,omitempty"`
+	Shells       []string            `yaml:"shells,omitempty"`
+	Store        string              `yaml:"store,omitempty"`
+	Disable      []string            `yaml:"disable,omitempty"`
+	Keys         map[string][]string `yaml:"keys,omitempty"`
+	Casts        []string            `yaml:"casts,omitempty"`
+	SigningKey   string              `yaml:"signing_key,omitempty"`
+	Budgets      []Budget            `yaml:"budgets,omitempty"`
+	Report       Report              `yaml:"report,omitempty"`
+	AI           AI                  `yaml:"ai,omitempty"`
+	// History overrides the history file of a shell, e.g. a HISTFILE
+	History map[string]string `yaml:"history,omitempty"`
+	// Ignore lists the commands left out of the analysis, as regular
+	// expressions or "prefix:" followed by their leading words
+	Ignore []string `yaml:"ignore,omitempty"`
+	// Categories adds tools and patterns to the built-in categories or
+	// defines new ones
+	Categories map[string]CategoryRule `yaml:"categories,omitempty"`
+	// RedactionRules are regular expressions scrubbed before anything
+	// leaves the machine, on top of the built-in ones
+	RedactionRules []string `yaml:"redaction_rules,omitempty"`
+	// SlideInterval is how long a Wrapped slide shows before the next, in
+	// the TUI and in `wrapped`, e.g. 8s
+	SlideInterval time.Duration `yaml:"slide_interval,omitempty"`
+}
+
+// AI picks the model that writes the Wrapped slides. Gemini is the only
+// provider for now.
+type AI struct {
+	Provider string `yaml:"provider,omitempty"`
+	Model    string `yaml:"model,omitempty"`
+}
+
+// Supported reports whether the provider can be used; unset means Gemini
+func (a AI) Supported() bool {
+	return a.Provider == "" || a.Provider == "gemini"
+}
+
+// Report configures where the weekly highlights are posted
+type Report struct {
+	SlackWebhook   string `yaml:"slack_webhook,omitempty"`
+	DiscordWebhook string `yaml:"discord_webhook,omitempty"`
+	// Weekday is the day scheduled runs post on, Monday by default
+	Weekday string `yaml:"weekday,omitempty"`
+}
+
+// Budget is a soft limit on the share of commands, in percent, that run
+// program, fall in category or match the pattern regular expression
+type Budget struct {
+	Name     string  `yaml:"name"`
+	Program  string  `yaml:"program,omitempty"`
+	Category string  `yaml:"category,omitempty"`
+	Pattern  string  `yaml:"pattern,omitempty"`
+	Max      float64 `yaml:"max"`
+}
+
+// CategoryRule lists the programs of a category and regular expressions of
+// whole command lines in it. In YAML it is either that mapping or just the
+// list of programs.
+type CategoryRule struct {
+	Tools    []string `yaml:"tools,omitempty"`
+	Patterns []string `yaml:"patterns,omitempty"`
+}
+
+// UnmarshalYAML accepts the short form, a plain list of programs
+func (r *CategoryRule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode(&r.Tools)
+	}
+	type plain CategoryRule
+	return node.Decode((*plain)(r))
+}
+
+// Dir returns $XDG_CONFIG_HOME/k8au, defaulting to ~/.config/k8au. A
+// k8au-shell-analyzer directory there is used instead when k8au does not
+// exist.
+func Dir() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "k8au"
+		}
+		base = filepath.Join(home, ".config")
+	}
+	dir := filepath.Join(base, "k8au")
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		if info, err := os.Stat(filepath.Join(base, "k8au-shell-analyzer")); err == nil && info.IsDir() {
+			return filepath.Join(base, "k8au-shell-analyzer")
+		}
+	}
+	return dir
+}
+
+// Path returns the location of config.yaml
+func Path() string {
+	return filepath.Join(Dir(), "config.yaml")
+}
+
+// Load reads the config file. A missing file is not an error and yields
+// the zero Config.
+func Load() (Config, error) {
+	var cfg Config
+	content, err := os.ReadFile(Path())
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %v", err)
+	}
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %v", Path(), err)
+	}
+	return cfg, nil
+}
+
+// RulesDir returns the directory of extra category rules
+func RulesDir() string {
+	return filepath.Join(Dir(), "rules.d")
+}
+
+// RulesFile is one file of RulesDir
+type RulesFile struct {
+	Categories map[string]CategoryRule `yaml:"categories"`
+}
+
+// Merge adds the tools and patterns of other to the rule
+func (r CategoryRule) Merge(other CategoryRule) CategoryRule {
+	return CategoryRule{
+		Tools:    append(append([]string{}, r.Tools...), other.Tools...),
+		Patterns: append(append([]string{}, r.Patterns...), other.Patterns...),
+	}
+}
+
+// LoadRules reads the *.yaml and *.yml files of RulesDir in name order and
+// merges their rules by category. A file that cannot be read is reported and
+// skipped.
+func LoadRules() (map[string]CategoryRule, error) {
+	var paths []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, _ := filepath.Glob(filepath.Join(RulesDir(), pattern))
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+
+	rules := make(map[string]CategoryRule)
+	var errs []error
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read rules: %v", err))
+			continue
+		}
+		var file RulesFile
+		if err := yaml.Unmarshal(content, &file); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse %s: %v", path, err))
+			continue
+		}
+		for category, rule := range file.Categories {
+			rules[category] = rules[category].Merge(rule)
+		}
+	}
+	return rules, errors.Join(errs...)
+}
+
+// Save writes the config file with owner-only permissions since it may
+// contain the API key. Only the settings that differ from the file are
+// written: its comments, key order and anything else in it are kept.
+func Save(cfg Config) error {
+	content, err := os.ReadFile(Path())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config: %v", err)
+	}
+	if content, err = patch(content, cfg); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(Dir(), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(Path(), content, 0600); err != nil {
+		return fmt.Errorf("failed to write config: %v", err)
+	}
+	return nil
+}
+
+// patch returns the config file content with the settings of cfg that
+// differ from it changed, added or removed
+func patch(content []byte, cfg Config) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", Path(), err)
+	}
+	var current Config
+	if err := doc.Decode(&current); err != nil && doc.Kind != 0 {
+		return nil, fmt.Errorf("failed to parse %s: %v", Path(), err)
+	}
+	before, err := encodeNode(current)
+	if err != nil {
+		return nil, err
+	}
+	after, err := encodeNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// A file of comments alone, like the one `config init` writes, parses to
+	// nothing, so the settings are appended to it as they are
+	if doc.Kind == 0 {
+		if len(after.Content) == 0 {
+			return content, nil
+		}
+		encoded, err := encode(after)
+		if err != nil {
+			return nil, err
+		}
+		if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+			content = append(content, '\n')
+		}
+		if len(bytes.TrimSpace(content)) > 0 {
+			content = append(content, '\n')
+		}
+		return append(content, encoded...), nil
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse %s: not a mapping", Path())
+	}
+	if !patchMapping(doc.Content[0], before, after) {
+		return content, nil
+	}
+	keepBlankLines(doc.Content[0], strings.Split(string(content), "\n"))
+	return encode(&doc)
+}
+
+// keepBlankLines marks the keys of a mapping that follow a blank line in
+// lines, which the encoder would otherwise drop, by starting their head
+// comment with an empty line
+func keepBlankLines(mapping *yaml.Node, lines []string) {
+	for i := 2; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		above := key.Line - 2
+		if key.HeadComment != "" {
+			above -= strings.Count(key.HeadComment, "\n") + 1
+		}
+		if above >= 0 && above < len(lines) && strings.TrimSpace(lines[above]) == "" {
+			key.HeadComment = "\n" + key.HeadComment
+		}
+	}
+}
+
+// patchMapping changes the keys of dst whose value differs between before
+// and after, the encoded settings dst was read as and the ones to save.
+// Mappings on both sides are patched key by key so comments in them stay.
+// It reports whether anything changed.
+func patchMapping(dst, before, after *yaml.Node) bool {
+	changed := false
+	keys := mappingKeys(before)
+	for _, key := range mappingKeys(after) {
+		if lookup(before, key) == nil {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		old, value := lookup(before, key), lookup(after, key)
+		if old != nil && value != nil && sameNode(old, value) {
+			continue
+		}
+		changed = true
+		i := indexOf(dst, key)
+		switch {
+		case value == nil:
+			if i >= 0 {
+				dst.Content = append(dst.Content[:i], dst.Content[i+2:]...)
+			}
+		case i < 0:
+			dst.Content = append(dst.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+		case old != nil && old.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode && dst.Content[i+1].Kind == yaml.MappingNode:
+			patchMapping(dst.Content[i+1], old, value)
+		default:
+			replaced := dst.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = replaced.HeadComment, replaced.LineComment, replaced.FootComment
+			dst.Content[i+1] = value
+		}
+	}
+	return changed
+}
+
+// mappingKeys lists the keys of a mapping node in order
+func mappingKeys(node *yaml.Node) []string {
+	var keys []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
+
+// indexOf returns the index of key in a mapping node, or -1
+func indexOf(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookup returns the value of key in a mapping node, or nil
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if i := indexOf(node, key); i >= 0 {
+		return node.Content[i+1]
+	}
+	return nil
+}
+
+// sameNode reports whether two encoded values are equal
+func sameNode(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind || a.Tag != b.Tag || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+	for i := range a.Content {
+		if !sameNode(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeNode encodes v into a node
+func encodeNode(v any) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %v", err)
+	}
+	return &node, nil
+}
+
+// encode writes v with the two-space indent of the default file
+func encode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Update loads the config file, applies fn and saves it, so settings changed
+// elsewhere (such as a freshly stored API key) are preserved
+func Update(fn func(*Config)) error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	fn(&cfg)
+	return Save(cfg)
+}
+
+// ErrExists is returned by Init when there is a config file already
+var ErrExists = errors.New("config file already exists")
+
+// Init writes the commented default config file. An existing one is kept
+// unless force is set.
+func Init(force bool) error {
+	if _, err := os.Stat(Path()); err == nil && !force {
+		return ErrExists
+	}
+	if err := os.MkdirAll(Dir(), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(Path(), []byte(defaultFile), 0600); err != nil {
+		return fmt.Errorf("failed to write config: %v", err)
+	}
+	return nil
+}
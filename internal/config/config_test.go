@@ -0,0 +1,111 @@
+// internal/config/config_test.go
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// writeConfig points Path at a temporary config file holding content
+func writeConfig(t *testing.T, content string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := os.MkdirAll(Dir(), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readConfig(t *testing.T) string {
+	t.Helper()
+	content, err := os.ReadFile(Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestSaveKeepsDefaultFile(t *testing.T) {
+	writeConfig(t, defaultFile)
+	if err := Update(func(cfg *Config) { cfg.Theme = "light" }); err != nil {
+		t.Fatal(err)
+	}
+	content := readConfig(t)
+	if !strings.HasPrefix(content, defaultFile) {
+		t.Errorf("the comments of the default file were not kept:\n%s", content)
+	}
+	if !strings.HasSuffix(content, "\ntheme: light\n") {
+		t.Errorf("theme was not appended:\n%s", content)
+	}
+
+	// Saving again edits the key in place
+	if err := Update(func(cfg *Config) { cfg.Theme = "mono" }); err != nil {
+		t.Fatal(err)
+	}
+	content = readConfig(t)
+	if !strings.Contains(content, "# Color theme: default, light or mono") || strings.Count(content, "\ntheme:") != 1 {
+		t.Errorf("theme was not replaced in place:\n%s", content)
+	}
+	if cfg, err := Load(); err != nil || cfg.Theme != "mono" {
+		t.Errorf("got theme %q (%v), want mono", cfg.Theme, err)
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	writeConfig(t, `# My settings
+language: es # Spanish labels
+
+# Colors
+theme: dark
+ai:
+  # The fast one
+  model: gemini-1.5-flash
+future_setting: 1
+shells: [bash, zsh]
+`)
+	err := Update(func(cfg *Config) {
+		cfg.Theme = "light"
+		cfg.AI.Model = "gemini-1.5-pro"
+		cfg.Shells = nil
+		cfg.Roast = true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# My settings
+language: es # Spanish labels
+
+# Colors
+theme: light
+ai:
+  # The fast one
+  model: gemini-1.5-pro
+future_setting: 1
+roast: true
+`
+	if got := readConfig(t); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	// Saving what was loaded leaves the file as it is
+	if err := Update(func(*Config) {}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readConfig(t); got != want {
+		t.Errorf("an unchanged save rewrote the file:\n%s", got)
+	}
+}
+
+func TestSaveNewFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := Save(Config{Language: "ja"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readConfig(t); got != "language: ja\n" {
+		t.Errorf("got %q, want %q", got, "language: ja\n")
+	}
+}
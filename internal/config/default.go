@@ -0,0 +1,94 @@
+// internal/config/default.go
+package config
+
+// defaultFile is what `config init` writes: every setting, commented out so
+// the built-in defaults apply until one is uncommented
+const defaultFile = `# K8au Shell Analyzer settings, see the Configuration section of the README.
+# The Settings tab edits only the settings it changes and keeps these comments.
+
+# Gemini API key; GEMINI_API_KEY and the keyring are used first
+# gemini_api_key: ""
+
+# Language of labels and reports: en, es, ja or a catalog in messages/
+# language: en
+
+# Never send anything to the AI, build the Wrapped slides locally
+# no_ai: false
+
+# Make Wrapped a comedic roast of your shell habits
+# roast: false
+
+# Read the reflogs of the git repositories among your projects on the Git tab
+# git_reflogs: false
+
+# The model that writes the Wrapped slides; gemini is the only provider
+# ai:
+#   provider: gemini
+#   model: gemini-1.5-flash
+
+# How long each Wrapped slide shows before the next; space pauses them
+# slide_interval: 10s
+
+# Color theme: default, light or mono
+# theme: default
+
+# How much is scrubbed before anything leaves the machine: strict or secrets
+# redaction: strict
+
+# Extra regular expressions scrubbed on top of the built-in ones
+# redaction_rules:
+#   - 'ACME-[0-9]{6}'
+
+# Shells to analyze; all of them when empty
+# shells: [bash, zsh, fish]
+
+# History files, for shells whose HISTFILE is not the default
+# history:
+#   bash: ~/.bash_history
+#   zsh: ~/.zsh_history
+#   fish: ~/.local/share/fish/fish_history
+
+# Commands left out of every statistic, the AI and exports: regular
+# expressions, or the leading words of a command after prefix:
+# ignore:
+#   - '^clear$'
+#   - 'prefix:ls'
+
+# Programs and command-line patterns added to the built-in categories
+# (development, build, packages, containers, network, system, file) or to new
+# ones; files in rules.d/ next to this one use the same format
+# categories:
+#   development: [zig, elixir]
+#   deploy:
+#     tools: [terraform, ansible-playbook]
+#     patterns: ['^make deploy']
+
+# Analysis modules to skip: config, plugins, probe, ai
+# disable: []
+
+# asciinema recordings, or directories of them, to read commands from
+# casts: []
+
+# Where snapshots are kept: json or sqlite
+# store: json
+
+# Key to sign exports with (HMAC-SHA256)
+# signing_key: ""
+
+# Soft limits on the share of commands, in percent
+# budgets:
+#   - name: kubectl
+#     program: kubectl
+#     max: 20
+
+# Where the weekly highlights are posted
+# report:
+#   slack_webhook: ""
+#   discord_webhook: ""
+#   weekday: monday
+
+# Key bindings by action, see Navigation Keys in the README
+# keys:
+#   next_tab: [tab, L]
+#   quit: [q, ctrl+c]
+`
@@ -0,0 +1,98 @@
+// internal/config/keyring.go
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const keyringService = "k8au-shell-analyzer"
+
+// ErrKeyringUnavailable is returned when no supported keyring tool is installed
+var ErrKeyringUnavailable = errors.New("no OS keyring available")
+
+// KeyringAvailable reports whether secrets can be stored in the OS keyring,
+// using the macOS `security` tool or libsecret's `secret-tool` on Linux
+func KeyringAvailable() bool {
+	return keyringTool() != ""
+}
+
+// keyringDisabled is set by DisableKeyring
+var keyringDisabled bool
+
+// DisableKeyring stops the keyring tools from being run, for runs that must
+// not start other programs
+func DisableKeyring() {
+	keyringDisabled = true
+}
+
+func keyringTool() string {
+	if keyringDisabled {
+		return ""
+	}
+	tool := ""
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "security"
+	case "linux", "freebsd", "openbsd":
+		tool = "secret-tool"
+	}
+	if tool == "" {
+		return ""
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return ""
+	}
+	return tool
+}
+
+// KeyringGet looks up the secret stored for account
+func KeyringGet(account string) (string, error) {
+	var cmd *exec.Cmd
+	switch keyringTool() {
+	case "security":
+		cmd = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	case "secret-tool":
+		cmd = exec.Command("secret-tool", "lookup", "service", keyringService, "account", account)
+	default:
+		return "", ErrKeyringUnavailable
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("keyring lookup failed: %v", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// KeyringSet stores secret for account, replacing any previous value
+func KeyringSet(account, secret string) error {
+	var cmd *exec.Cmd
+	switch keyringTool() {
+	case "security":
+		// Read from stdin with -i, hex-encoded with -X, so the secret never
+		// shows up in the arguments other users can list with ps
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+			securityQuote(keyringService), securityQuote(account), hex.EncodeToString([]byte(secret))))
+	case "secret-tool":
+		cmd = exec.Command("secret-tool", "store", "--label=K8au Shell Analyzer", "service", keyringService, "account", account)
+		cmd.Stdin = strings.NewReader(secret)
+	default:
+		return ErrKeyringUnavailable
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("keyring store failed: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// securityQuote quotes an argument of a command read by `security -i`
+func securityQuote(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
@@ -0,0 +1,139 @@
+// internal/gemini/chat.go
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/telemetry"
+)
+
+const (
+	// chatRecentDays is how far back the commands sent with every question
+	// go, and chatRecent caps them
+	chatRecentDays = 14
+	chatRecent     = 300
+	// chatMatches caps the commands picked for the words of a question,
+	// and chatWordMatches those for each word
+	chatMatches     = 100
+	chatWordMatches = 20
+	// chatMinWord is the length from which a word of the question is
+	// looked up in the history
+	chatMinWord = 4
+)
+
+// chatInstructions open the context of every question
+const chatInstructions = `You answer questions about the user's shell history from the data below, which was prepared on their machine with secrets redacted. Answer briefly in plain text, in the language of the question. When the data does not tell, say so rather than guess.`
+
+// ChatMessage is one turn of a chat: Role is "user" for a question and
+// "model" for an answer
+type ChatMessage struct {
+	Role string
+	Text string
+}
+
+// ChatContext prepares what a question is answered from: the summary also
+// used for Wrapped, the recent commands with their time and the commands
+// matching the words of the question. Everything is redacted.
+func ChatContext(data analyzer.ShellData, question string, now time.Time) string {
+	var context strings.Builder
+	context.WriteString(chatInstructions + "\n\n")
+	context.WriteString("Now: " + now.Format("Monday 2006-01-02 15:04 MST") + "\n\n")
+	context.WriteString("Summary:\n" + analyzer.ShellDataToString(data) + "\n")
+
+	type line struct {
+		at    time.Time
+		shell string
+		cmd   string
+	}
+	var recent []line
+	since := now.AddDate(0, 0, -chatRecentDays)
+	for shell, history := range data.Histories {
+		for _, entry := range history {
+			if !entry.Timestamp.Before(since) && !entry.Timestamp.After(now) {
+				recent = append(recent, line{entry.Timestamp, shell, entry.Command})
+			}
+		}
+	}
+	sort.Slice(recent, func(i, j int) bool { return recent[i].at.Before(recent[j].at) })
+	recent = recent[max(0, len(recent)-chatRecent):]
+	if len(recent) > 0 {
+		context.WriteString(fmt.Sprintf("\nCommands of the last %d days, oldest first:\n", chatRecentDays))
+		for _, l := range recent {
+			context.WriteString(fmt.Sprintf("%s [%s] %s\n", l.at.Local().Format("Mon 2006-01-02 15:04"), l.shell, l.cmd))
+		}
+	}
+
+	seen := make(map[string]bool)
+	var matches []analyzer.SearchResult
+	for _, word := range questionWords(question) {
+		for _, result := range analyzer.Search(data, analyzer.SearchQuery{Text: word}, chatWordMatches) {
+			if !seen[result.Command] && len(matches) < chatMatches {
+				seen[result.Command] = true
+				matches = append(matches, result)
+			}
+		}
+	}
+	if len(matches) > 0 {
+		context.WriteString("\nCommands related to the question, with runs and last run:\n")
+		for _, result := range matches {
+			last := "unknown"
+			if !result.Last.IsZero() {
+				last = result.Last.Local().Format("Mon 2006-01-02 15:04")
+			}
+			context.WriteString(fmt.Sprintf("[%s] %s (%d runs, last %s)\n", result.Shell, result.Command, result.Runs, last))
+		}
+	}
+
+	// Never let secrets, IPs or home paths leave the machine
+	return redact.String(context.String())
+}
+
+// questionWords are the distinct words of the question long enough to look
+// up, lower case
+func questionWords(question string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(question), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.'
+	}) {
+		word = strings.Trim(word, "-_.")
+		if len([]rune(word)) >= chatMinWord && !slices.Contains(words, word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// StreamChat asks the model to answer the last question of messages from
+// context, calling onText with each piece of the answer as it arrives
+func StreamChat(context string, messages []ChatMessage, onText func(string)) error {
+	span := telemetry.Start("ai.chat")
+	err := streamChat(context, messages, onText)
+	span.End(err)
+	return err
+}
+
+func streamChat(context string, messages []ChatMessage, onText func(string)) error {
+	if apiKey == "" {
+		return ErrNoAPIKey
+	}
+
+	payload := generateRequest{SystemInstruction: &content{Parts: []part{{Text: context}}}}
+	for _, message := range messages {
+		payload.Contents = append(payload.Contents, content{Role: message.Role, Parts: []part{{Text: redact.String(message.Text)}}})
+	}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %v", err)
+	}
+
+	_, err = stream(jsonPayload, onText)
+	return err
+}
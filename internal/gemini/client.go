@@ -0,0 +1,224 @@
+// internal/gemini/client.go
+package gemini
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+const (
+	maxAttempts = 4
+	maxBackoff  = 30 * time.Second
+	cacheBucket = "wrapped"
+)
+
+// initialBackoff is the delay before the first retry, doubled for each one
+var initialBackoff = time.Second
+
+var httpClient = &http.Client{Timeout: 90 * time.Second}
+
+// jitter randomizes retry delays; Seed makes it repeatable
+var jitter = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// Seed reseeds the retry jitter, for deterministic runs
+func Seed(seed int64) {
+	jitter = rand.New(rand.NewSource(seed))
+}
+
+// openWithRetry sends the request, retrying network errors, rate limits
+// (429) and server errors with exponential backoff. A Retry-After header
+// from the API takes precedence over the computed delay. It returns the
+// response of the last attempt with its body unread, so that a stream can
+// be read as it arrives; the caller closes the body. The API key is sent in
+// a header rather than the URL, which errors of the HTTP client quote.
+func openWithRetry(url string, body []byte) (*http.Response, error) {
+	backoff := initialBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-goog-api-key", apiKey)
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to send request: %v", err)
+		} else if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			if attempt == maxAttempts {
+				return resp, nil
+			}
+			resp.Body.Close()
+			lastErr = &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+			if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				backoff = wait
+			}
+		} else {
+			return resp, nil
+		}
+
+		if attempt < maxAttempts {
+			time.Sleep(withJitter(backoff))
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+
+	return nil, lastErr
+}
+
+// stream posts the request to the streaming endpoint and passes on the
+// text of the answer as it arrives. It returns the HTTP status, 0 when the
+// API could not be reached.
+func stream(payload []byte, onText func(string)) (int, error) {
+	resp, err := openWithRetry(streamURL(), payload)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var result generateResponse
+		if raw, err := io.ReadAll(resp.Body); err == nil && json.Unmarshal(raw, &result) == nil && result.Error != nil {
+			apiErr.Message = result.Error.Message
+		}
+		return resp.StatusCode, apiErr
+	}
+	return resp.StatusCode, readStream(resp.Body, onText)
+}
+
+// readStream reads the server-sent events of a streamed answer, each a
+// generateResponse, and passes on their text
+func readStream(body io.Reader, onText func(string)) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	answered := false
+	finish := ""
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		// The chunks may quote the history, so only --debug logs them
+		slog.Debug("gemini stream", "chunk", data)
+		var chunk generateResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to decode response: %v", err)
+		}
+		if chunk.Error != nil {
+			return &APIError{StatusCode: chunk.Error.Code, Message: chunk.Error.Message}
+		}
+		if reason := chunk.PromptFeedback.BlockReason; reason != "" {
+			return &ResponseError{Kind: ErrBlocked, Detail: reason}
+		}
+		for _, candidate := range chunk.Candidates[:min(1, len(chunk.Candidates))] {
+			if candidate.FinishReason == "SAFETY" || candidate.FinishReason == "RECITATION" {
+				return &ResponseError{Kind: ErrBlocked, Detail: candidate.FinishReason}
+			}
+			if candidate.FinishReason != "" {
+				finish = candidate.FinishReason
+			}
+			for _, p := range candidate.Content.Parts {
+				if p.Text != "" {
+					answered = true
+					onText(p.Text)
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+	if !answered {
+		return &ResponseError{Kind: ErrEmptyResponse, Detail: finish}
+	}
+	return nil
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(header string) (time.Duration, bool) {
+	secs, err := strconv.Atoi(header)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	wait := time.Duration(secs) * time.Second
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+	return wait, true
+}
+
+// withJitter spreads retries by up to 25% so parallel clients don't align
+func withJitter(d time.Duration) time.Duration {
+	return d + time.Duration(jitter.Int63n(int64(d)/4+1))
+}
+
+// cacheKey hashes the request payload together with the model endpoint
+func cacheKey(payload []byte) string {
+	sum := sha256.Sum256(append([]byte(apiURL()+"\n"), payload...))
+	return hex.EncodeToString(sum[:])
+}
+
+// storeBackend is the store the responses and ratings are kept in
+var storeBackend string
+
+// SetStore picks the store backend of the response cache and the ratings,
+// the one snapshots use
+func SetStore(backend string) {
+	storeBackend = backend
+}
+
+func openCache() store.Store {
+	return store.OpenCache(storeBackend)
+}
+
+func loadCachedWrapped(key string) (WrappedResponse, bool) {
+	cache := openCache()
+	defer cache.Close()
+
+	raw, err := cache.Get(cacheBucket, key)
+	if err != nil {
+		return WrappedResponse{}, false
+	}
+	var resp WrappedResponse
+	if err := json.Unmarshal(raw, &resp); err != nil || len(resp.Sections) == 0 {
+		return WrappedResponse{}, false
+	}
+	return resp, true
+}
+
+// storeCachedWrapped saves a response; failures only cost a future API call.
+// A response another run cached first is kept, so every run serves the same
+// one.
+func storeCachedWrapped(key string, resp WrappedResponse) {
+	cache := openCache()
+	defer cache.Close()
+
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	cache.Update(cacheBucket, key, func(old []byte) ([]byte, error) {
+		var saved WrappedResponse
+		if json.Unmarshal(old, &saved) == nil && len(saved.Sections) > 0 {
+			return old, nil
+		}
+		return raw, nil
+	})
+}
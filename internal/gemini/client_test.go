@@ -0,0 +1,60 @@
+// internal/gemini/client_test.go
+package gemini
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc lets a test stand in for the network
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stubClient answers the requests of the test with transport
+func stubClient(t *testing.T, transport roundTripFunc) {
+	t.Helper()
+	client, backoff, key := httpClient, initialBackoff, apiKey
+	t.Cleanup(func() { httpClient, initialBackoff, apiKey = client, backoff, key })
+	httpClient = &http.Client{Transport: transport}
+	initialBackoff = 0
+	apiKey = "secret-test-key"
+}
+
+func TestStreamSendsKeyInHeader(t *testing.T) {
+	stubClient(t, func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.String(), apiKey) {
+			t.Errorf("the API key is in the URL %s", req.URL)
+		}
+		if got := req.Header.Get("x-goog-api-key"); got != apiKey {
+			t.Errorf("got x-goog-api-key %q, want %q", got, apiKey)
+		}
+		body := `data: {"candidates": [{"content": {"parts": [{"text": "hi"}]}}]}` + "\n"
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	var text string
+	if _, err := stream([]byte(`{}`), func(s string) { text += s }); err != nil {
+		t.Fatal(err)
+	}
+	if text != "hi" {
+		t.Errorf("got %q, want %q", text, "hi")
+	}
+}
+
+func TestStreamErrorHidesKey(t *testing.T) {
+	stubClient(t, func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	_, err := stream([]byte(`{}`), func(string) {})
+	if err == nil {
+		t.Fatal("a failed request returned no error")
+	}
+	if strings.Contains(err.Error(), apiKey) {
+		t.Errorf("the error quotes the API key: %v", err)
+	}
+}
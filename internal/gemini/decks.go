@@ -0,0 +1,91 @@
+// internal/gemini/decks.go
+package gemini
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// DeckBucket is the store bucket Wrapped decks are archived in
+const DeckBucket = "decks"
+
+// Deck is a Wrapped slideshow kept to be revisited later
+type Deck struct {
+	// Key is the year of a year in review, e.g. "2025", or the month of the
+	// TUI's Wrapped, e.g. "2025-06". A later deck for the same period
+	// replaces the earlier one.
+	Key       string    `json:"key"`
+	Generated time.Time `json:"generated"`
+	Sections  []Section `json:"sections"`
+}
+
+// MonthDeckKey is the key of the TUI's Wrapped generated at t
+func MonthDeckKey(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// YearDeckKey is the key of the year in review of year
+func YearDeckKey(year int) string {
+	return strconv.Itoa(year)
+}
+
+// Month returns the month of a monthly deck, false for a year in review
+func (d Deck) Month() (time.Time, bool) {
+	t, err := time.ParseInLocation("2006-01", d.Key, time.Local)
+	return t, err == nil
+}
+
+// SaveDeck archives deck under its key, unless another run has archived
+// one generated later in the meantime
+func SaveDeck(s store.Store, deck Deck) error {
+	value, err := json.Marshal(deck)
+	if err != nil {
+		return fmt.Errorf("failed to encode deck: %v", err)
+	}
+	err = s.Update(DeckBucket, deck.Key, func(old []byte) ([]byte, error) {
+		var saved Deck
+		if json.Unmarshal(old, &saved) == nil && saved.Generated.After(deck.Generated) {
+			return old, nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save deck %s: %v", deck.Key, err)
+	}
+	return nil
+}
+
+// LoadDecks reads every archived deck, the latest period first. Decks that
+// cannot be read are skipped and reported in the error.
+func LoadDecks(s store.Store) ([]Deck, error) {
+	keys, err := s.List(DeckBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list decks: %v", err)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	var decks []Deck
+	var failed error
+	for _, key := range keys {
+		value, err := s.Get(DeckBucket, key)
+		var deck Deck
+		if err == nil {
+			err = json.Unmarshal(value, &deck)
+		}
+		if err == nil && len(deck.Sections) == 0 {
+			err = errors.New("no slides")
+		}
+		if err != nil {
+			failed = fmt.Errorf("failed to read deck %s: %v", key, err)
+			continue
+		}
+		decks = append(decks, deck)
+	}
+	return decks, failed
+}
@@ -2,13 +2,16 @@
 package gemini
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"os"
+	"log/slog"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/telemetry"
 )
 
 type WrappedResponse struct {
@@ -20,55 +23,172 @@ type Section struct {
 	Description string   `json:"description"`
 	Animation   []string `json:"animation"`
 	Quotes      []string `json:"quotes,omitempty"`
+	// Card names the kind of a locally built slide, e.g. "streak", for
+	// ratings; slides the AI wrote have none. It is left out of the
+	// response schema.
+	Card string `json:"card,omitempty"`
 }
 
+// apiKey is resolved at startup by ResolveAPIKey. It can also be compiled in
+// with -ldflags "-X .../internal/gemini.apiKey=KEY".
 var apiKey string
 
-/*
-// Make a .env file while compiling on your local machine with your GEMINI_API_KEY
-func init() {
-	// Load .env file
-	if err := godotenv.Load(); err != nil {
-		panic("Error loading .env file. Please ensure it exists with GEMINI_API_KEY")
+// ErrNoAPIKey is returned when no Gemini API key could be resolved
+var ErrNoAPIKey = errors.New("no Gemini API key configured")
+
+// Errors describing how the model misbehaved, for use with errors.Is
+var (
+	ErrEmptyResponse   = errors.New("model returned no content")
+	ErrBlocked         = errors.New("model blocked the request")
+	ErrInvalidJSON     = errors.New("model returned invalid JSON")
+	ErrSchemaViolation = errors.New("model output does not match the schema")
+)
+
+// APIError is returned when the Gemini API responds with a non-200 status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gemini API error %d: %s", e.StatusCode, e.Message)
+}
+
+// ResponseError wraps one of the Err* sentinels with the details of what went wrong
+type ResponseError struct {
+	Kind   error
+	Detail string
+}
+
+func (e *ResponseError) Error() string {
+	if e.Detail == "" {
+		return e.Kind.Error()
 	}
+	return fmt.Sprintf("%v: %s", e.Kind, e.Detail)
+}
 
-	// Get API key from environment
-	apiKey = os.Getenv("GEMINI_API_KEY")
-	if apiKey == "" {
-		panic("GEMINI_API_KEY not found in .env file")
+func (e *ResponseError) Unwrap() error {
+	return e.Kind
+}
+
+// DefaultModel is the Gemini model asked unless the config names another
+const DefaultModel = "gemini-1.5-flash"
+
+var model = DefaultModel
+
+// SetModel picks the Gemini model, e.g. "gemini-1.5-pro"; "" restores
+// DefaultModel
+func SetModel(name string) {
+	if name == "" {
+		name = DefaultModel
 	}
+	model = name
 }
 
-*/
+// apiURL is the endpoint of the model in use
+func apiURL() string {
+	return "https://generativelanguage.googleapis.com/v1beta/models/" + model + ":generateContent"
+}
 
-const (
-	geminiAPIURL = "https://generativelanguage.googleapis.com/v1beta/models/gemini-1.5-flash:generateContent"
-)
+// streamURL is the endpoint streaming the answer of the model in use as
+// server-sent events
+func streamURL() string {
+	return "https://generativelanguage.googleapis.com/v1beta/models/" + model + ":streamGenerateContent?alt=sse"
+}
+
+type generateRequest struct {
+	SystemInstruction *content         `json:"systemInstruction,omitempty"`
+	Contents          []content        `json:"contents"`
+	GenerationConfig  generationConfig `json:"generationConfig"`
+}
+
+// generationConfig asks for JSON matching a schema; left empty, the model
+// answers in free text
+type generationConfig struct {
+	ResponseMimeType string          `json:"responseMimeType,omitempty"`
+	ResponseSchema   json.RawMessage `json:"responseSchema,omitempty"`
+}
+
+// content is a turn of the conversation; Role is "user" or "model", and
+// may be left out when there is only one
+type content struct {
+	Role  string `json:"role,omitempty"`
+	Parts []part `json:"parts"`
+}
+
+type part struct {
+	Text string `json:"text"`
+}
+
+type generateResponse struct {
+	Candidates []struct {
+		Content      content `json:"content"`
+		FinishReason string  `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// wrappedSchema mirrors WrappedResponse in Gemini's OpenAPI schema subset
+var wrappedSchema = json.RawMessage(`{
+  "type": "OBJECT",
+  "properties": {
+    "sections": {
+      "type": "ARRAY",
+      "items": {
+        "type": "OBJECT",
+        "properties": {
+          "title": {"type": "STRING"},
+          "description": {"type": "STRING"},
+          "animation": {"type": "ARRAY", "items": {"type": "STRING"}},
+          "quotes": {"type": "ARRAY", "items": {"type": "STRING"}}
+        },
+        "required": ["title", "description"]
+      }
+    }
+  },
+  "required": ["sections"]
+}`)
+
+// GenerateWrapped asks Gemini for the Wrapped sections
+func GenerateWrapped(data analyzer.ShellData) (WrappedResponse, error) {
+	return GenerateWrappedStream(data, nil)
+}
+
+// GenerateWrappedStream asks Gemini for the Wrapped sections, streaming the
+// answer: onSections, when set, is called with the sections received so far
+// each time more arrive, the last one possibly cut short. A cached answer
+// is returned without calling it.
+func GenerateWrappedStream(data analyzer.ShellData, onSections func([]Section)) (WrappedResponse, error) {
+	span := telemetry.Start("ai.generate")
+	resp, err := generateWrapped(data, onSections, span)
+	span.End(err)
+	return resp, err
+}
+
+func generateWrapped(data analyzer.ShellData, onSections func([]Section), span *telemetry.Span) (WrappedResponse, error) {
+	if apiKey == "" {
+		return WrappedResponse{}, ErrNoAPIKey
+	}
 
-func GenerateWrapped(data string) (WrappedResponse, error) {
-	payload := map[string]interface{}{
-		"contents": []map[string]interface{}{
-			{
-				"parts": []map[string]interface{}{
-					{
-						"text": fmt.Sprintf(`Analyze the following shell data and generate a summary with insights, quotes, and animations in the following JSON format:
-
-{
-  "sections": [
-    {
-      "title": "Section Title",
-      "description": "Section description.",
-      "animation": ["RowAnimation1", "RowAnimation2", ...],
-      "quotes": ["Quote1", "Quote2", ...]
-    },
-    ...
-  ]
-}
-
-Shell data: %s`, data),
-					},
-				},
-			},
+	prompt, err := buildPrompt(data)
+	if err != nil {
+		return WrappedResponse{}, err
+	}
+
+	// Never let secrets, IPs or home paths leave the machine
+	prompt = redact.String(prompt)
+
+	payload := generateRequest{
+		Contents: []content{{Parts: []part{{Text: prompt}}}},
+		GenerationConfig: generationConfig{
+			ResponseMimeType: "application/json",
+			ResponseSchema:   wrappedSchema,
 		},
 	}
 
@@ -77,139 +197,154 @@ Shell data: %s`, data),
 		return WrappedResponse{}, fmt.Errorf("failed to marshal payload: %v", err)
 	}
 
-	req, err := http.NewRequest("POST", geminiAPIURL+"?key="+apiKey, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return WrappedResponse{}, fmt.Errorf("failed to create request: %v", err)
+	// Identical analyses produce identical payloads, so reuse the last answer
+	key := cacheKey(jsonPayload)
+	if cached, ok := loadCachedWrapped(key); ok {
+		span.SetAttribute("cached", true)
+		return cached, nil
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	var text strings.Builder
+	status, err := stream(jsonPayload, func(chunk string) {
+		text.WriteString(chunk)
+		if onSections != nil {
+			onSections(partialSections(text.String()))
+		}
+	})
+	if status != 0 {
+		span.SetAttribute("http.status_code", status)
+	}
+	// The raw response may quote the history, so only --debug logs it
+	slog.Debug("gemini response", "status", status, "body", text.String())
 	if err != nil {
-		return WrappedResponse{}, fmt.Errorf("failed to send request: %v", err)
+		return WrappedResponse{}, err
 	}
-	defer resp.Body.Close()
 
-	rawResponse, err := ioutil.ReadAll(resp.Body)
+	wrappedResp, err := parseWrapped(text.String())
 	if err != nil {
-		return WrappedResponse{}, fmt.Errorf("failed to read response body: %v", err)
-	}
-
-	// Log the raw response
-	if err := logResponse(rawResponse); err != nil {
-		return WrappedResponse{}, fmt.Errorf("failed to log response: %v", err)
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(rawResponse, &result); err != nil {
-		return WrappedResponse{}, fmt.Errorf("failed to decode response: %v", err)
-	}
-
-	if candidates, ok := result["candidates"].([]interface{}); ok && len(candidates) > 0 {
-		if firstCandidate, ok := candidates[0].(map[string]interface{}); ok {
-			if content, ok := firstCandidate["content"].(map[string]interface{}); ok {
-				if parts, ok := content["parts"].([]interface{}); ok && len(parts) > 0 {
-					if firstPart, ok := parts[0].(map[string]interface{}); ok {
-						if text, ok := firstPart["text"].(string); ok {
-							// Log the extracted text
-							if err := logResponse([]byte("Extracted text: " + text)); err != nil {
-								return WrappedResponse{}, fmt.Errorf("failed to log extracted text: %v", err)
-							}
-
-							// Remove the ```json``` markers
-							jsonText := strings.TrimPrefix(text, "```json\n")
-							jsonText = strings.TrimSuffix(jsonText, "\n```")
-
-							// Remove any remaining backticks
-							jsonText = strings.ReplaceAll(jsonText, "`", "")
-
-							// Remove the note at the end of the JSON text
-							noteIndex := strings.Index(jsonText, "**Note:**")
-							if noteIndex != -1 {
-								jsonText = jsonText[:noteIndex]
-							}
-
-							// Log the final JSON text before parsing
-							if err := logResponse([]byte("Final jsonText: " + jsonText)); err != nil {
-								return WrappedResponse{}, fmt.Errorf("failed to log final jsonText: %v", err)
-							}
-
-							var wrappedResp WrappedResponse
-
-							// Log the JSON text before parsing
-							if err := logResponse([]byte("JSON text to be parsed: " + jsonText)); err != nil {
-								return WrappedResponse{}, fmt.Errorf("failed to log JSON text: %v", err)
-							}
-
-							// Parse the JSON text
-							if err := json.Unmarshal([]byte(jsonText), &wrappedResp); err != nil {
-								// Log the error
-								if logErr := logResponse([]byte(fmt.Sprintf("Failed to parse text as JSON: %v\nJSON text: %s", err, jsonText))); logErr != nil {
-									return WrappedResponse{}, fmt.Errorf("failed to log JSON parsing error: %v", logErr)
-								}
-								return WrappedResponse{}, fmt.Errorf("failed to parse text as JSON: %v", err)
-							}
-
-							// Log the successfully parsed response
-							if err := logResponse([]byte(fmt.Sprintf("Successfully parsed WrappedResponse: %v", wrappedResp))); err != nil {
-								return WrappedResponse{}, fmt.Errorf("failed to log parsed response: %v", err)
-							}
-
-							return wrappedResp, nil
-						}
-					}
-				}
-			}
-		}
+		return WrappedResponse{}, err
 	}
+	storeCachedWrapped(key, wrappedResp)
 
-	// Log the invalid response format
-	if err := logResponse([]byte("Invalid response format")); err != nil {
-		return WrappedResponse{}, fmt.Errorf("failed to log invalid response format: %v", err)
+	return wrappedResp, nil
+}
+
+// parseWrapped strictly decodes the JSON document the model answered into
+// a WrappedResponse
+func parseWrapped(text string) (WrappedResponse, error) {
+	var wrappedResp WrappedResponse
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&wrappedResp); err != nil {
+		return WrappedResponse{}, &ResponseError{Kind: ErrInvalidJSON, Detail: err.Error()}
 	}
 
-	return WrappedResponse{}, fmt.Errorf("invalid response format")
+	if err := validateWrapped(wrappedResp); err != nil {
+		return WrappedResponse{}, err
+	}
+
+	return wrappedResp, nil
 }
 
-func logResponse(response []byte) error {
-	// Define log file path
-	logPath := "gemini_response.log"
+// partialSections decodes the sections of a WrappedResponse still arriving,
+// as far as it goes. The last section, and the text it ends in, may be cut
+// short.
+func partialSections(text string) []Section {
+	type level struct {
+		delim   json.Delim
+		key     string
+		wantKey bool
+	}
+	var sections []Section
+	var stack []level
+	// set stores a string at the current position, if it belongs to a section
+	set := func(value string) {
+		if len(sections) == 0 || len(stack) < 3 || stack[0].key != "sections" {
+			return
+		}
+		section := &sections[len(sections)-1]
+		switch {
+		case len(stack) == 3 && stack[2].key == "title":
+			section.Title = value
+		case len(stack) == 3 && stack[2].key == "description":
+			section.Description = value
+		case len(stack) == 4 && stack[2].key == "quotes":
+			section.Quotes = append(section.Quotes, value)
+		case len(stack) == 4 && stack[2].key == "animation":
+			section.Animation = append(section.Animation, value)
+		}
+	}
+	// valueDone expects the next key once a value of an object was read
+	valueDone := func() {
+		if len(stack) > 0 && stack[len(stack)-1].delim == '{' {
+			stack[len(stack)-1].wantKey = true
+		}
+	}
 
-	// Open the file in append mode or create it if it doesn't exist
-	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		// Log the error to a separate error log file
-		errorLogPath := "gemini_error.log"
-		errorLogFile, err := os.OpenFile(errorLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	decoder := json.NewDecoder(strings.NewReader(text))
+	for {
+		token, err := decoder.Token()
 		if err != nil {
-			return fmt.Errorf("failed to open error log file: %v", err)
+			break
 		}
-		defer errorLogFile.Close()
-		_, errWrite := errorLogFile.WriteString(fmt.Sprintf("Error writing to log file: %v\n", err))
-		if errWrite != nil {
-			return fmt.Errorf("failed to write error to log file: %v", errWrite)
+		switch t := token.(type) {
+		case json.Delim:
+			if t == '{' || t == '[' {
+				if t == '{' && len(stack) == 2 && stack[0].key == "sections" {
+					sections = append(sections, Section{})
+				}
+				stack = append(stack, level{delim: t, wantKey: t == '{'})
+				continue
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			valueDone()
+		case string:
+			// A bare string at the top level belongs to no object
+			if len(stack) > 0 && stack[len(stack)-1].delim == '{' && stack[len(stack)-1].wantKey {
+				stack[len(stack)-1].key, stack[len(stack)-1].wantKey = t, false
+				continue
+			}
+			set(t)
+			valueDone()
+		default:
+			valueDone()
 		}
-		return fmt.Errorf("failed to open log file: %v", err)
 	}
-	defer file.Close()
 
-	// Write the response to the log file
-	_, err = file.Write(response)
-	if err != nil {
-		// Log the error to a separate error log file
-		errorLogPath := "gemini_error.log"
-		errorLogFile, err := os.OpenFile(errorLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			return fmt.Errorf("failed to open error log file: %v", err)
+	// The string being received when the text ends
+	rest := strings.TrimLeft(text[min(int(decoder.InputOffset()), len(text)):], " \t\r\n:,")
+	if len(stack) > 0 && strings.HasPrefix(rest, `"`) && !(stack[len(stack)-1].delim == '{' && stack[len(stack)-1].wantKey) {
+		// Drop a character or escape sequence cut in half
+		for !utf8.ValidString(rest) {
+			rest = rest[:len(rest)-1]
 		}
-		defer errorLogFile.Close()
-		_, errWrite := errorLogFile.WriteString(fmt.Sprintf("Error writing to log file: %v\n", err))
-		if errWrite != nil {
-			return fmt.Errorf("failed to write error to log file: %v", errWrite)
+		for cut := 0; cut <= 6 && cut < len(rest); cut++ {
+			var value string
+			if json.Unmarshal([]byte(rest[:len(rest)-cut]+`"`), &value) == nil {
+				if value != "" {
+					set(value)
+				}
+				break
+			}
 		}
-		return fmt.Errorf("failed to write to log file: %v", err)
 	}
+	return sections
+}
 
+// validateWrapped enforces the parts of the schema the API does not guarantee
+func validateWrapped(resp WrappedResponse) error {
+	if len(resp.Sections) == 0 {
+		return &ResponseError{Kind: ErrSchemaViolation, Detail: "no sections"}
+	}
+	for i, section := range resp.Sections {
+		if strings.TrimSpace(section.Title) == "" {
+			return &ResponseError{Kind: ErrSchemaViolation, Detail: fmt.Sprintf("section %d has no title", i)}
+		}
+		if strings.TrimSpace(section.Description) == "" {
+			return &ResponseError{Kind: ErrSchemaViolation, Detail: fmt.Sprintf("section %d has no description", i)}
+		}
+	}
 	return nil
 }
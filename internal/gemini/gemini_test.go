@@ -0,0 +1,63 @@
+// internal/gemini/gemini_test.go
+package gemini
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+func TestPartialSections(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		count int
+		title string
+	}{
+		{"empty", ``, 0, ""},
+		{"top-level string", `"oops"`, 0, ""},
+		{"top-level number", `42`, 0, ""},
+		{"truncated top-level string", `"oo`, 0, ""},
+		{"truncated key", `{"sect`, 0, ""},
+		{"truncated title", `{"sections": [{"title": "Your ye`, 1, "Your ye"},
+		{"complete", `{"sections": [{"title": "A", "description": "B"}, {"title": "C"}]}`, 2, "C"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sections := partialSections(test.text)
+			if len(sections) != test.count {
+				t.Fatalf("got %d sections, want %d", len(sections), test.count)
+			}
+			if test.count > 0 && sections[len(sections)-1].Title != test.title {
+				t.Errorf("got title %q, want %q", sections[len(sections)-1].Title, test.title)
+			}
+		})
+	}
+}
+
+func TestRatingsUseStoreBackend(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	defer SetStore("")
+
+	SetStore(store.BackendMemory)
+	if err := RateSection(Section{Card: "top"}, 4, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(store.DefaultDir(), ratingsBucket)); !os.IsNotExist(err) {
+		t.Errorf("the memory backend wrote ratings to disk: %v", err)
+	}
+	// The rating is still there for the rest of the run
+	if scores := LoadRatings()["top"].Scores; len(scores) != 1 || scores[0] != 4 {
+		t.Errorf("got scores %v from the memory backend, want [4]", scores)
+	}
+
+	SetStore(store.BackendJSON)
+	if err := RateSection(Section{Card: "top"}, 4, false); err != nil {
+		t.Fatal(err)
+	}
+	if scores := LoadRatings()["top"].Scores; len(scores) != 1 || scores[0] != 4 {
+		t.Errorf("got scores %v, want [4]", scores)
+	}
+}
@@ -0,0 +1,67 @@
+// internal/gemini/growth.go
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/telemetry"
+)
+
+// growthInstructions open the prompt of the Growth advice
+const growthInstructions = `You mentor the developer whose shell history is summarized below, prepared on their machine with secrets redacted. Recommend what to learn next for their career, building on what they already do: two or three skills, a few tools, and for each skill one concrete practice project. Answer in at most 12 short lines of plain text without markdown, in the language with code %s.`
+
+// GenerateGrowth asks the model for career and learning advice from the
+// analysis and the heuristic plan
+func GenerateGrowth(data analyzer.ShellData, plan analyzer.Growth) (string, error) {
+	var advice strings.Builder
+	err := StreamGrowth(data, plan, func(text string) { advice.WriteString(text) })
+	return advice.String(), err
+}
+
+// StreamGrowth is GenerateGrowth calling onText with each piece of the
+// advice as it arrives
+func StreamGrowth(data analyzer.ShellData, plan analyzer.Growth, onText func(string)) error {
+	span := telemetry.Start("ai.growth")
+	err := streamGrowth(data, plan, onText)
+	span.End(err)
+	return err
+}
+
+func streamGrowth(data analyzer.ShellData, plan analyzer.Growth, onText func(string)) error {
+	if apiKey == "" {
+		return ErrNoAPIKey
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString(fmt.Sprintf(growthInstructions, i18n.Language()) + "\n\n")
+	prompt.WriteString("Summary:\n" + analyzer.ShellDataToString(data) + "\n")
+	if len(plan.Next) > 0 {
+		prompt.WriteString("\nSkills a simple heuristic suggests next:")
+		for _, next := range plan.Next {
+			prompt.WriteString(fmt.Sprintf(" %s (after %s);", next.Skill.Name, next.After.Name))
+		}
+		prompt.WriteString("\n")
+	}
+	if len(plan.Tools) > 0 {
+		prompt.WriteString("\nTools that go with their skills and never appear in the history:")
+		for _, tool := range plan.Tools {
+			prompt.WriteString(fmt.Sprintf(" %s (%s);", tool.Program, tool.For.Name))
+		}
+		prompt.WriteString("\n")
+	}
+
+	// Never let secrets, IPs or home paths leave the machine
+	payload := generateRequest{Contents: []content{{Parts: []part{{Text: redact.String(prompt.String())}}}}}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %v", err)
+	}
+
+	_, err = stream(jsonPayload, onText)
+	return err
+}
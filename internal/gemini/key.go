@@ -0,0 +1,64 @@
+// internal/gemini/key.go
+package gemini
+
+import (
+	"os"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+)
+
+// keyringAccount is the keyring entry holding the Gemini API key
+const keyringAccount = "gemini-api-key"
+
+// ResolveAPIKey picks the API key from, in order of precedence: the --api-key
+// flag, the GEMINI_API_KEY environment variable, the config file, the OS
+// keyring and finally the key compiled in with -ldflags. It returns the key
+// and a short description of where it came from.
+func ResolveAPIKey(flagKey string) (string, string) {
+	if flagKey != "" {
+		return flagKey, "flag"
+	}
+	if key := os.Getenv("GEMINI_API_KEY"); key != "" {
+		return key, "environment"
+	}
+	if cfg, err := config.Load(); err == nil && cfg.GeminiAPIKey != "" {
+		return cfg.GeminiAPIKey, "config file"
+	}
+	if key, err := config.KeyringGet(keyringAccount); err == nil && key != "" {
+		return key, "keyring"
+	}
+	if apiKey != "" {
+		return apiKey, "build"
+	}
+	return "", ""
+}
+
+// SetAPIKey sets the key used for subsequent requests
+func SetAPIKey(key string) {
+	apiKey = key
+}
+
+// HasAPIKey reports whether a key is configured
+func HasAPIKey() bool {
+	return apiKey != ""
+}
+
+// StoreAPIKey persists the key in the OS keyring when one is available and
+// otherwise in the config file, returning where it was stored
+func StoreAPIKey(key string) (string, error) {
+	if config.KeyringAvailable() {
+		if err := config.KeyringSet(keyringAccount, key); err == nil {
+			return "keyring", nil
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+	cfg.GeminiAPIKey = key
+	if err := config.Save(cfg); err != nil {
+		return "", err
+	}
+	return config.Path(), nil
+}
@@ -0,0 +1,290 @@
+// internal/gemini/local.go
+package gemini
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+)
+
+// GenerateLocalWrapped builds Wrapped sections from the analyzed data without
+// calling the API. It is used when no API key is configured or the request
+// fails, and always produces the same sections for the same input and roast
+// mode.
+func GenerateLocalWrapped(data analyzer.ShellData) WrappedResponse {
+	if roast {
+		return WrappedResponse{Sections: roastSections(data)}
+	}
+	highlights := analyzer.ComputeHighlights(data)
+	var sections []Section
+
+	if len(highlights.TopCommands) > 0 {
+		var quotes []string
+		for i, cmd := range highlights.TopCommands {
+			quotes = append(quotes, i18n.T("wrapped.top.quote", i+1, cmd.Command, cmd.Count))
+		}
+		sections = append(sections, Section{
+			Card:  "top",
+			Title: i18n.T("wrapped.top.title"),
+			Description: i18n.T("wrapped.top.description",
+				highlights.TotalCommands, highlights.TopCommands[0].Command),
+			Quotes: quotes,
+		})
+	}
+
+	if highlights.LongestStreak > 0 {
+		sections = append(sections, Section{
+			Card:  "streak",
+			Title: i18n.T("wrapped.streak.title"),
+			Description: i18n.T("wrapped.streak.description",
+				highlights.LongestStreak, highlights.StreakStart.Format(i18n.T("date.long")), highlights.ActiveDays),
+			Quotes: []string{i18n.T("wrapped.streak.quote")},
+		})
+	}
+
+	if highlights.BusiestDayRuns > 0 {
+		sections = append(sections, Section{
+			Card:  "busiest",
+			Title: i18n.T("wrapped.busiest.title"),
+			Description: i18n.T("wrapped.busiest.description",
+				highlights.BusiestDay.Format(i18n.T("date.day")), highlights.BusiestDayRuns),
+		})
+	}
+
+	if len(highlights.Typos) > 0 {
+		var quotes []string
+		for _, typo := range highlights.Typos {
+			quotes = append(quotes, i18n.T("wrapped.typos.quote", typo.Command, typo.Intended, typo.Count))
+		}
+		sections = append(sections, Section{
+			Card:        "typos",
+			Title:       i18n.T("wrapped.typos.title"),
+			Description: i18n.T("wrapped.typos.description", highlights.Typos[0].Command),
+			Quotes:      quotes,
+		})
+	}
+
+	if len(sections) == 0 {
+		sections = append(sections, Section{
+			Card:        "quiet",
+			Title:       i18n.T("wrapped.quiet.title"),
+			Description: i18n.T("wrapped.quiet.description"),
+		})
+	}
+
+	return WrappedResponse{Sections: sections}
+}
+
+// ForecastSection builds a forward-looking Wrapped slide from the forecasts.
+// It returns false when nothing is on track for a milestone.
+func ForecastSection(forecasts []analyzer.Forecast) (Section, bool) {
+	if len(forecasts) == 0 {
+		return Section{}, false
+	}
+
+	var quotes []string
+	for _, f := range forecasts {
+		quotes = append(quotes, i18n.T("wrapped.forecast.quote",
+			f.Command, f.WeeklyRate, f.Milestone, f.ETA.Format(i18n.T("date.month"))))
+	}
+
+	first := forecasts[0]
+	return Section{
+		Card:  "forecast",
+		Title: i18n.T("wrapped.forecast.title"),
+		Description: i18n.T("wrapped.forecast.description",
+			first.Milestone, first.Command, first.ETA.Format(i18n.T("date.month"))),
+		Quotes: quotes,
+	}, true
+}
+
+// ElaborateSection crowns the most elaborate one-liner. It returns false
+// when no command was elaborate enough to show off.
+func ElaborateSection(complexity analyzer.CommandComplexity) (Section, bool) {
+	if !complexity.Remarkable() {
+		return Section{}, false
+	}
+
+	var features []string
+	for _, feature := range analyzer.ComplexityFeatures {
+		if complexity.Features[feature] > 0 {
+			features = append(features, i18n.T("complexity."+feature))
+		}
+	}
+
+	return Section{
+		Card:        "elaborate",
+		Title:       i18n.T("wrapped.elaborate.title"),
+		Description: i18n.T("wrapped.elaborate.description", complexity.Score, strings.Join(features, ", ")),
+		Quotes:      []string{redact.String(complexity.MostElaborate)},
+	}, true
+}
+
+// RetrySection names the command the user kept running again right after
+// it failed. It returns false when no command was retried.
+func RetrySection(outcomes analyzer.Outcomes) (Section, bool) {
+	if outcomes.Retried.Retries == 0 {
+		return Section{}, false
+	}
+	return Section{
+		Card:        "retry",
+		Title:       i18n.T("wrapped.retry.title"),
+		Description: i18n.T("wrapped.retry.description", outcomes.Retried.Retries),
+		Quotes:      []string{redact.String(outcomes.Retried.Command)},
+	}, true
+}
+
+// RageSection names the command the user fought with most, run again and
+// again within seconds. It returns false when there was none.
+func RageSection(repeats []analyzer.RageRepeat) (Section, bool) {
+	if len(repeats) == 0 {
+		return Section{}, false
+	}
+	return Section{
+		Card:        "rage",
+		Title:       i18n.T("wrapped.rage.title"),
+		Description: i18n.T("wrapped.rage.description", repeats[0].Bursts, repeats[0].Longest),
+		Quotes:      []string{redact.String(repeats[0].Command)},
+	}, true
+}
+
+// containersMinCommands is how many container and Kubernetes commands
+// earn a Wrapped slide
+const containersMinCommands = 20
+
+// ContainersSection names the image the user ran most among their
+// container and Kubernetes commands. It returns false when there were few
+// of them or no image was run.
+func ContainersSection(usage analyzer.ContainerUsage) (Section, bool) {
+	if usage.Total() < containersMinCommands || len(usage.Images) == 0 {
+		return Section{}, false
+	}
+	return Section{
+		Card:        "containers",
+		Title:       i18n.T("wrapped.containers.title"),
+		Description: i18n.T("wrapped.containers.description", usage.Total()),
+		Quotes:      []string{redact.String(usage.Images[0].Command)},
+	}, true
+}
+
+// ShellJourneySection builds a Wrapped slide telling the story of the user's
+// shell switches. It returns false when the user never changed shells.
+func ShellJourneySection(migration analyzer.ShellMigration) (Section, bool) {
+	if len(migration.Switches) == 0 {
+		return Section{}, false
+	}
+
+	var shells []string
+	for _, period := range migration.Periods {
+		shells = append(shells, period.Shell)
+	}
+
+	var quotes []string
+	for _, sw := range migration.Switches {
+		total := len(sw.CarriedAliases) + len(sw.MissingAliases)
+		switch {
+		case total == 0:
+			quotes = append(quotes, i18n.T("wrapped.journey.none",
+				sw.From, sw.To, sw.At.Format(i18n.T("date.month"))))
+		case len(sw.MissingAliases) == 0:
+			quotes = append(quotes, i18n.T("wrapped.journey.all",
+				sw.From, sw.To, sw.At.Format(i18n.T("date.month")), total))
+		default:
+			quotes = append(quotes, i18n.T("wrapped.journey.some",
+				sw.From, sw.To, sw.At.Format(i18n.T("date.month")), len(sw.CarriedAliases), total))
+		}
+	}
+
+	return Section{
+		Card:        "journey",
+		Title:       i18n.T("wrapped.journey.title"),
+		Description: i18n.T("wrapped.journey.description", strings.Join(shells, " → ")),
+		Quotes:      quotes,
+	}, true
+}
+
+// yearNewShown caps the new tools quoted on the year in review slide
+const yearNewShown = 5
+
+// GenerateYearWrapped builds the longer year in review slideshow for
+// `wrapped --year`: an intro, a slide per active month and the tools
+// learned, then the usual local slides for data restricted to the year
+func GenerateYearWrapped(data analyzer.ShellData, review analyzer.YearInReview) WrappedResponse {
+	if review.Commands == 0 {
+		return WrappedResponse{Sections: []Section{{
+			Card:        "quiet",
+			Title:       i18n.T("wrapped.quiet.title"),
+			Description: i18n.T("wrapped.year.quiet", review.Year),
+		}}}
+	}
+
+	sections := []Section{{
+		Card:        "year",
+		Title:       i18n.T("wrapped.year.title", review.Year),
+		Description: i18n.T("wrapped.year.description", review.Commands, review.ActiveDays),
+	}}
+
+	busiest := 0
+	for i, month := range review.Months {
+		if month.Commands > review.Months[busiest].Commands {
+			busiest = i
+		}
+	}
+	for i, month := range review.Months {
+		if month.Commands == 0 {
+			continue
+		}
+		section := Section{
+			Card:  "month",
+			Title: time.Date(review.Year, time.Month(i+1), 1, 0, 0, 0, 0, time.Local).Format(i18n.T("date.month")),
+			Description: i18n.T("wrapped.month.description",
+				month.Commands, month.ActiveDays, month.Tools, month.Top.Command, month.Top.Count),
+		}
+		if i == busiest {
+			section.Quotes = []string{i18n.T("wrapped.month.busiest")}
+		}
+		sections = append(sections, section)
+	}
+
+	if len(review.NewTools) > 0 {
+		var quotes []string
+		for i, tool := range review.NewTools {
+			if i == yearNewShown {
+				break
+			}
+			quotes = append(quotes, i18n.T("wrapped.new.quote", tool.Command, tool.Count))
+		}
+		sections = append(sections, Section{
+			Card:        "new",
+			Title:       i18n.T("wrapped.new.title"),
+			Description: i18n.T("wrapped.new.description", len(review.NewTools), review.NewTools[0].Command),
+			Quotes:      quotes,
+		})
+	}
+
+	sections = append(sections, GenerateLocalWrapped(data).Sections...)
+	if journey, ok := ShellJourneySection(data.Migration); ok {
+		sections = append(sections, journey)
+	}
+	if elaborate, ok := ElaborateSection(data.Insights.WorkPatterns.Complexity); ok {
+		sections = append(sections, elaborate)
+	}
+	if retry, ok := RetrySection(data.Insights.WorkPatterns.Outcomes); ok {
+		sections = append(sections, retry)
+	}
+	if rage, ok := RageSection(data.Insights.WorkPatterns.RageRepeats); ok {
+		sections = append(sections, rage)
+	}
+	if containers, ok := ContainersSection(data.Insights.Containers); ok {
+		sections = append(sections, containers)
+	}
+	sections = append(sections, Section{
+		Card:        "outro",
+		Title:       i18n.T("wrapped.year.outro.title", review.Year+1),
+		Description: i18n.T("wrapped.year.outro.description", review.Year),
+	})
+	return WrappedResponse{Sections: sections}
+}
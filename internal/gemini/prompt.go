@@ -0,0 +1,88 @@
+// internal/gemini/prompt.go
+package gemini
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// defaultPromptTemplate is used when the user has no prompt.tmpl
+const defaultPromptTemplate = `Analyze the following shell data and generate a summary made of sections.
+Each section has a title, a description, a few short quotes and a list of text animation frames.
+
+Shell data: {{.Summary}}{{if .Roast}}
+
+Write a comedic roast instead of a celebration: tease the user about their shell habits, such as their typos, late-night sessions and the commands they run over and over. Keep it playful and never mean about anything but the commands.{{range .Highlights.Typos}}
+They typed {{.Command}} for {{.Intended}} {{.Count}} times.{{end}}{{with .Highlights.LongestRun}}{{if ge .Count 5}}
+They once ran {{.Command}} {{.Count}} times in a row.{{end}}{{end}}{{end}}{{if .Data.Insights.WorkPatterns.RageRepeats}}
+
+The rage repeats are commands the user ran again and again within seconds, usually because they kept failing. Include a section with light-hearted commentary on what the user fights with most.{{end}}{{if .Feedback}}
+
+The user rated earlier summaries from 1 to 5. Give more room to the topics they rated high and less to the ones they rated low:
+{{.Feedback}}{{end}}`
+
+// PromptData is the value passed to prompt templates. Summary is the same
+// text the default prompt uses; Data and Highlights give access to the
+// structured analysis, e.g. {{.Data.Insights.TechnicalProfile.PrimaryRole}}.
+// Language is the active UI language code, e.g. "es". Feedback lists the
+// average rating of each topic the user rated, one per line, best first.
+// Roast is set in roast mode, see SetRoast.
+type PromptData struct {
+	Summary    string
+	Data       analyzer.ShellData
+	Highlights analyzer.Highlights
+	Language   string
+	Feedback   string
+	Roast      bool
+}
+
+var promptFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// PromptTemplatePath returns the location of the user's prompt override
+func PromptTemplatePath() string {
+	return filepath.Join(config.Dir(), "prompt.tmpl")
+}
+
+// buildPrompt renders the user's prompt template, or the built-in one when
+// none exists. The response schema is enforced separately, so templates only
+// need to describe tone and content (roast, formal report, another language).
+func buildPrompt(data analyzer.ShellData) (string, error) {
+	text := defaultPromptTemplate
+	path := PromptTemplatePath()
+	if content, err := os.ReadFile(path); err == nil {
+		text = string(content)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read prompt template: %v", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(promptFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse prompt template: %v", err)
+	}
+
+	var prompt strings.Builder
+	err = tmpl.Execute(&prompt, PromptData{
+		Summary:    analyzer.ShellDataToString(data),
+		Data:       data,
+		Highlights: analyzer.ComputeHighlights(data),
+		Language:   i18n.Language(),
+		Feedback:   LoadRatings().feedback(),
+		Roast:      roast,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt template: %v", err)
+	}
+	return prompt.String(), nil
+}
@@ -0,0 +1,202 @@
+// internal/gemini/ratings.go
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// The ratings are kept with the snapshots, they are not a cache
+const (
+	ratingsBucket = "ratings"
+	ratingsKey    = "wrapped"
+)
+
+// ratingsKept is how many of the latest scores of a slide count, so the
+// Wrapped view follows changing tastes
+const ratingsKept = 5
+
+// ratingsAIKept caps the ratings of AI-written slides, whose titles differ
+// from run to run; the oldest are forgotten first
+const ratingsAIKept = 20
+
+// hiddenBelow hides a card whose average score is below it
+const hiddenBelow = 2.5
+
+// cardTopics describes each card to the AI, see Section.Card
+var cardTopics = map[string]string{
+	"top":         "most used commands",
+	"streak":      "streaks of active days",
+	"busiest":     "the busiest day",
+	"typos":       "typos",
+	"journey":     "switching shells",
+	"elaborate":   "the most elaborate one-liner",
+	"retry":       "commands retried after failing",
+	"rage":        "commands run again and again within seconds",
+	"containers":  "the most run container image",
+	"forecast":    "forecasts of upcoming milestones",
+	"month":       "month by month recaps",
+	"new":         "newly learned tools",
+	"roast_top":   "roasts of the most used command",
+	"roast_typos": "roasts of typos",
+	"roast_late":  "roasts of late nights",
+	"roast_run":   "roasts of the command run over and over",
+}
+
+// Rating holds the latest scores, from 1 to 5, given to a slide
+type Rating struct {
+	Scores []int     `json:"scores"`
+	Rated  time.Time `json:"rated"`
+}
+
+// Average is the mean of the scores
+func (r Rating) Average() float64 {
+	if len(r.Scores) == 0 {
+		return 0
+	}
+	total := 0
+	for _, score := range r.Scores {
+		total += score
+	}
+	return float64(total) / float64(len(r.Scores))
+}
+
+// Ratings maps each rated slide to its scores, by card for the local slides
+// and by title prefixed with "ai:" for the ones the AI wrote
+type Ratings map[string]Rating
+
+// ratingKey is what a slide's scores are kept under
+func ratingKey(section Section) string {
+	if section.Card != "" {
+		return section.Card
+	}
+	return "ai:" + section.Title
+}
+
+// LoadRatings reads the scores given in earlier runs. Without any, or when
+// they cannot be read, nothing is adjusted.
+func LoadRatings() Ratings {
+	ratings := Ratings{}
+	s := store.OpenDefault(storeBackend)
+	defer s.Close()
+	if raw, err := s.Get(ratingsBucket, ratingsKey); err == nil {
+		json.Unmarshal(raw, &ratings)
+	}
+	return ratings
+}
+
+// RateSection records a score for a slide. replace overwrites the latest
+// score instead, for a slide rated again in the same session.
+func RateSection(section Section, score int, replace bool) error {
+	if score < 1 || score > 5 {
+		return fmt.Errorf("rating %d is not between 1 and 5", score)
+	}
+	s := store.OpenDefault(storeBackend)
+	defer s.Close()
+
+	// Another run may rate a slide in between, so read and write the
+	// ratings under one lock
+	err := s.Update(ratingsBucket, ratingsKey, func(old []byte) ([]byte, error) {
+		ratings := Ratings{}
+		if old != nil {
+			json.Unmarshal(old, &ratings)
+		}
+
+		key := ratingKey(section)
+		rating := ratings[key]
+		if replace && len(rating.Scores) > 0 {
+			rating.Scores = rating.Scores[:len(rating.Scores)-1]
+		}
+		rating.Scores = append(rating.Scores, score)
+		if len(rating.Scores) > ratingsKept {
+			rating.Scores = rating.Scores[len(rating.Scores)-ratingsKept:]
+		}
+		rating.Rated = time.Now()
+		ratings[key] = rating
+		ratings.forgetAI()
+
+		raw, err := json.Marshal(ratings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode ratings: %v", err)
+		}
+		return raw, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save ratings: %v", err)
+	}
+	return nil
+}
+
+// forgetAI drops the oldest ratings of AI-written slides beyond ratingsAIKept
+func (r Ratings) forgetAI() {
+	var ai []string
+	for key := range r {
+		if strings.HasPrefix(key, "ai:") {
+			ai = append(ai, key)
+		}
+	}
+	sort.Slice(ai, func(i, j int) bool { return r[ai[i]].Rated.After(r[ai[j]].Rated) })
+	for _, key := range ai[min(len(ai), ratingsAIKept):] {
+		delete(r, key)
+	}
+}
+
+// Hidden reports whether a local card was rated low enough to leave out
+func (r Ratings) Hidden(section Section) bool {
+	rating, ok := r[section.Card]
+	return section.Card != "" && ok && rating.Average() < hiddenBelow
+}
+
+// ApplyRatings leaves out the cards rated low. Slides the AI wrote are kept,
+// their ratings steer the prompt instead, and so is the first slide when
+// every card would go.
+func ApplyRatings(sections []Section, ratings Ratings) []Section {
+	var shown []Section
+	for _, section := range sections {
+		if !ratings.Hidden(section) {
+			shown = append(shown, section)
+		}
+	}
+	if len(shown) == 0 && len(sections) > 0 {
+		shown = sections[:1]
+	}
+	return shown
+}
+
+// feedback describes the ratings for the prompt, liked topics first
+func (r Ratings) feedback() string {
+	type rated struct {
+		topic   string
+		average float64
+	}
+	var topics []rated
+	for key, rating := range r {
+		topic, ok := cardTopics[key]
+		if title, found := strings.CutPrefix(key, "ai:"); found {
+			topic, ok = fmt.Sprintf("%q", title), true
+		}
+		if ok && len(rating.Scores) > 0 {
+			topics = append(topics, rated{topic, rating.Average()})
+		}
+	}
+	if len(topics) == 0 {
+		return ""
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if topics[i].average != topics[j].average {
+			return topics[i].average > topics[j].average
+		}
+		return topics[i].topic < topics[j].topic
+	})
+
+	var lines []string
+	for _, t := range topics {
+		lines = append(lines, fmt.Sprintf("- %s: %.1f/5", t.topic, t.average))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,81 @@
+// internal/gemini/roast.go
+package gemini
+
+import (
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+)
+
+// roastRunMin is how many times in a row a command has to run to be roasted
+const roastRunMin = 5
+
+var roast bool
+
+// SetRoast switches Wrapped, from the AI and the offline generator, to a
+// comedic roast of the user's shell habits
+func SetRoast(on bool) {
+	roast = on
+}
+
+// Roasting reports whether Wrapped is a roast
+func Roasting() bool {
+	return roast
+}
+
+// roastSections are the offline generator's slides in roast mode: the
+// favorite command, typos, late nights and the command run over and over
+func roastSections(data analyzer.ShellData) []Section {
+	highlights := analyzer.ComputeHighlights(data)
+	var sections []Section
+
+	if len(highlights.TopCommands) > 0 {
+		top := highlights.TopCommands[0]
+		sections = append(sections, Section{
+			Card:        "roast_top",
+			Title:       i18n.T("wrapped.roast.top.title"),
+			Description: i18n.T("wrapped.roast.top.description", highlights.TotalCommands, top.Command, top.Count),
+		})
+	}
+
+	if len(highlights.Typos) > 0 {
+		var quotes []string
+		for _, typo := range highlights.Typos {
+			quotes = append(quotes, i18n.T("wrapped.typos.quote", typo.Command, typo.Intended, typo.Count))
+		}
+		typo := highlights.Typos[0]
+		sections = append(sections, Section{
+			Card:        "roast_typos",
+			Title:       i18n.T("wrapped.roast.typos.title"),
+			Description: i18n.T("wrapped.roast.typos.description", typo.Command, typo.Intended, typo.Count),
+			Quotes:      quotes,
+		})
+	}
+
+	if late := data.Insights.WorkPatterns.WorkLife; late.LateNight > 0 {
+		sections = append(sections, Section{
+			Card:  "roast_late",
+			Title: i18n.T("wrapped.roast.late.title"),
+			Description: i18n.T("wrapped.roast.late.description",
+				late.LateNight, late.LateNights, late.Latest.Format("15:04")),
+		})
+	}
+
+	if run := highlights.LongestRun; run.Count >= roastRunMin {
+		sections = append(sections, Section{
+			Card:        "roast_run",
+			Title:       i18n.T("wrapped.roast.run.title"),
+			Description: i18n.T("wrapped.roast.run.description", run.Count),
+			Quotes:      []string{redact.String(run.Command)},
+		})
+	}
+
+	if len(sections) == 0 {
+		sections = append(sections, Section{
+			Card:        "roast_quiet",
+			Title:       i18n.T("wrapped.roast.quiet.title"),
+			Description: i18n.T("wrapped.roast.quiet.description"),
+		})
+	}
+	return sections
+}
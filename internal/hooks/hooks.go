@@ -0,0 +1,177 @@
+// internal/hooks/hooks.go
+package hooks
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// Record is one command as the shell hooks saw it run
+type Record struct {
+	Shell    string        `json:"shell"`
+	Command  string        `json:"command"`
+	Dir      string        `json:"dir,omitempty"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Exit     int           `json:"exit"`
+}
+
+// dialTimeout bounds how long a hook waits for the daemon before writing
+// the log itself, so a stuck daemon never slows the prompt
+const dialTimeout = 200 * time.Millisecond
+
+// LogPath returns the file the records are appended to
+func LogPath() string {
+	return filepath.Join(store.DefaultDir(), "hooks.jsonl")
+}
+
+// SocketPath returns where the daemon listens: $XDG_RUNTIME_DIR when set,
+// which is private to the user and cleared at logout, else the state
+// directory
+func SocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "k8au-shell-analyzer.sock")
+	}
+	return filepath.Join(store.StateDir(), "k8au.sock")
+}
+
+// Send hands rec to the daemon, or appends it to the log when no daemon
+// is running
+func Send(rec Record) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %v", err)
+	}
+	conn, err := net.DialTimeout("unix", SocketPath(), dialTimeout)
+	if err != nil {
+		return appendLines([][]byte{line})
+	}
+	defer conn.Close()
+	conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+	if _, err := conn.Write(append(line, '\n')); err != nil {
+		return appendLines([][]byte{line})
+	}
+	return nil
+}
+
+// appendLines adds lines to the log. Each write is a single append of
+// whole lines, so concurrent shells never interleave their records.
+func appendLines(lines [][]byte) error {
+	if err := os.MkdirAll(filepath.Dir(LogPath()), 0700); err != nil {
+		return fmt.Errorf("failed to create data directory: %v", err)
+	}
+	file, err := os.OpenFile(LogPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open hook log: %v", err)
+	}
+	defer file.Close()
+
+	var buf []byte
+	for _, line := range lines {
+		buf = append(append(buf, line...), '\n')
+	}
+	if _, err := file.Write(buf); err != nil {
+		return fmt.Errorf("failed to write hook log: %v", err)
+	}
+	return nil
+}
+
+// Listen opens the daemon's socket at SocketPath. A socket left behind by a
+// daemon that died is replaced; one in use is an error.
+func Listen() (net.Listener, error) {
+	path := SocketPath()
+	if conn, err := net.DialTimeout("unix", path, dialTimeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a daemon is already listening on %s", path)
+	}
+	os.Remove(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %v", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %v", path, err)
+	}
+	os.Chmod(path, 0600)
+	return listener, nil
+}
+
+// Serve appends the records the hooks send to listener to the log until
+// stop is closed
+func Serve(listener net.Listener, stop <-chan struct{}) error {
+	go func() {
+		<-stop
+		listener.Close()
+	}()
+
+	var mu sync.Mutex
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to accept connection: %v", err)
+		}
+		go func() {
+			defer conn.Close()
+			lines := readLines(conn)
+			mu.Lock()
+			defer mu.Unlock()
+			if err := appendLines(lines); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}()
+	}
+}
+
+// readLines returns the valid records sent on conn, as they were sent
+func readLines(conn net.Conn) [][]byte {
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var lines [][]byte
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var rec Record
+		if json.Unmarshal(scanner.Bytes(), &rec) != nil || rec.Command == "" {
+			continue
+		}
+		lines = append(lines, append([]byte{}, scanner.Bytes()...))
+	}
+	return lines
+}
+
+// Read calls fn for every record in the log at path, oldest first. A
+// missing log is not an error; malformed lines are skipped.
+func Read(path string, fn func(Record)) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open hook log: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var rec Record
+		if json.Unmarshal(scanner.Bytes(), &rec) == nil && rec.Command != "" {
+			fn(rec)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read hook log: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// internal/hooks/snippets.go
+package hooks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shells lists the shells Snippet has hooks for
+var Shells = []string{"bash", "zsh", "fish"}
+
+// Snippet returns the code that makes shell report each command to
+// `exe record` in the background once it finished. The start time is
+// taken before the command runs; the record command works out the duration.
+func Snippet(shell, exe string) (string, error) {
+	switch shell {
+	case "zsh":
+		return fmt.Sprintf(zshSnippet, quote(exe)), nil
+	case "bash":
+		return fmt.Sprintf(bashSnippet, quote(exe)), nil
+	case "fish":
+		return fmt.Sprintf(fishSnippet, quote(exe)), nil
+	}
+	return "", fmt.Errorf("unsupported shell %q, use one of %s", shell, strings.Join(Shells, ", "))
+}
+
+// quote single-quotes s for all three shells
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// zshSnippet uses the preexec and precmd hooks; &! disowns the reporter
+// so no job message is printed
+const zshSnippet = `# k8au-shell-analyzer hooks, add to ~/.zshrc: eval "$(k8au-shell-analyzer init zsh)"
+zmodload zsh/datetime
+autoload -Uz add-zsh-hook
+_k8au_preexec() {
+  _k8au_command=$1
+  _k8au_start=$EPOCHREALTIME
+}
+_k8au_precmd() {
+  local exit=$?
+  [[ -n $_k8au_start ]] || return
+  %[1]s record --shell zsh --exit $exit --start $_k8au_start --cwd "$PWD" -- "$_k8au_command" &!
+  unset _k8au_command _k8au_start
+}
+add-zsh-hook preexec _k8au_preexec
+add-zsh-hook precmd _k8au_precmd
+`
+
+// bashSnippet emulates preexec with the DEBUG trap, which also fires for
+// PROMPT_COMMAND; _k8au_ready limits it to the first command after the
+// prompt. The command is taken from the history, so pressing enter on an
+// empty line reports nothing. Needs bash 5 for EPOCHREALTIME.
+const bashSnippet = `# k8au-shell-analyzer hooks, add to ~/.bashrc: eval "$(k8au-shell-analyzer init bash)"
+_k8au_preexec() {
+  [[ -n $_k8au_ready && -z $COMP_LINE ]] || return
+  _k8au_ready=
+  _k8au_start=$EPOCHREALTIME
+}
+_k8au_precmd() {
+  local exit=$? entry
+  entry=$(HISTTIMEFORMAT= builtin history 1)
+  if [[ -n $_k8au_start && $entry != "$_k8au_last" ]]; then
+    _k8au_last=$entry
+    [[ $entry =~ ^\ *[0-9]+\*?\ +(.*)$ ]] &&
+      (%[1]s record --shell bash --exit "$exit" --start "$_k8au_start" --cwd "$PWD" -- "${BASH_REMATCH[1]}" &)
+  fi
+  _k8au_start=
+}
+trap '_k8au_preexec' DEBUG
+PROMPT_COMMAND="_k8au_precmd${PROMPT_COMMAND:+; $PROMPT_COMMAND}; _k8au_ready=1"
+`
+
+// fishSnippet uses the fish_postexec event, where $status and
+// $CMD_DURATION still belong to the command
+const fishSnippet = `# k8au-shell-analyzer hooks, add to ~/.config/fish/config.fish: k8au-shell-analyzer init fish | source
+function _k8au_postexec --on-event fish_postexec
+    set -l exit $status
+    test -n "$argv[1]"; or return
+    %[1]s record --shell fish --exit $exit --duration $CMD_DURATION --cwd "$PWD" -- $argv[1] &
+    disown 2>/dev/null
+end
+`
@@ -0,0 +1,864 @@
+// internal/i18n/en.go
+package i18n
+
+var english = Catalog{
+	// Dates, as Go time layouts
+	"date.long":  "January 2, 2006",
+	"date.day":   "Monday, January 2, 2006",
+	"date.month": "Jan 2006",
+
+	"weekday.0":       "Sunday",
+	"weekday.1":       "Monday",
+	"weekday.2":       "Tuesday",
+	"weekday.3":       "Wednesday",
+	"weekday.4":       "Thursday",
+	"weekday.5":       "Friday",
+	"weekday.6":       "Saturday",
+	"weekday.short.0": "Sun",
+	"weekday.short.1": "Mon",
+	"weekday.short.2": "Tue",
+	"weekday.short.3": "Wed",
+	"weekday.short.4": "Thu",
+	"weekday.short.5": "Fri",
+	"weekday.short.6": "Sat",
+
+	// Tabs and chrome
+	"tab.overview":      "Overview",
+	"tab.tech_profile":  "Tech Profile",
+	"tab.work_patterns": "Work Patterns",
+	"tab.tool_usage":    "Tool Usage",
+	"tab.wrapped":       "Wrapped",
+	"tab.timeline":      "Timeline",
+	"app.title":         "🚀 K8au Shell Analyzer v1.0.1-beta",
+	"app.credit":        "By Ksauraj",
+	"app.loading":       "Analyzing your shell history... 🔍",
+
+	// Loading screen, see internal/models/progress.go
+	"progress.history":  "Reading the %s history",
+	"progress.probe":    "Checking installed tools",
+	"progress.insights": "Computing insights",
+	"progress.ai":       "Writing your Wrapped with Gemini",
+	"progress.local":    "Building your Wrapped",
+
+	"edit.hint":   "%s: Edit %s",
+	"edit.failed": "Could not run the editor: %v (set $EDITOR)",
+
+	// Scheduled snapshots
+	"background.newer": "A background run at %s found newer data • %s: Reload",
+
+	// --watch
+	"watch.live": "● Live, updated %s",
+
+	// Key bindings, see internal/models/keys.go
+	"keys.next_tab":        "next tab",
+	"keys.prev_tab":        "previous tab",
+	"keys.next_slide":      "next slide",
+	"keys.prev_slide":      "previous slide",
+	"keys.up":              "up",
+	"keys.down":            "down",
+	"keys.select":          "change setting",
+	"keys.rate":            "rate slide",
+	"keys.search":          "search history",
+	"keys.filter_shell":    "filter timeline by shell",
+	"keys.filter_category": "filter timeline by category",
+	"keys.filter_kind":     "filter timeline by type",
+	"keys.edit":            "edit rc file",
+	"keys.apply":           "add suggested aliases",
+	"keys.reload":          "reload",
+	"keys.help":            "toggle help",
+	"keys.quit":            "quit",
+	"help.title":           "⌨️  Key Bindings",
+	"help.close":           "Press any key to close. Remap keys under keys: in %s",
+
+	// Linear (accessible) output
+	"linear.loading": "Analyzing your shell history...",
+	"linear.prompt":  "Enter a section number, a for all, or q to quit:",
+	"linear.invalid": "%q is not a section number.",
+
+	// API key wizard
+	"wizard.title":   "🔑 Gemini API Key",
+	"wizard.body":    "No API key was found. The AI-generated Wrapped view needs one;\neverything else works offline.",
+	"wizard.storage": "The key is saved in your OS keyring when available,\notherwise in %s.",
+	"wizard.help":    "Enter: Save • Esc: Skip (offline Wrapped)",
+	"wizard.input":   "Paste your Gemini API key",
+
+	// Overview
+	"overview.title":             "📊 Shell Usage Overview",
+	"overview.shell":             "Shell: %s",
+	"overview.commands":          "Commands: %d",
+	"overview.configuration":     "Configuration:",
+	"overview.aliases":           "Aliases: %d",
+	"overview.plugins":           "Plugins: %d",
+	"overview.env":               "Environment Variables: %d",
+	"overview.plugin_list":       "Installed Plugins:",
+	"overview.plugin_from":       "%s (from %s)",
+	"overview.more":              "And %d more...",
+	"overview.alias_list":        "Some Aliases:",
+	"overview.global_aliases":    "Global Aliases: %d",
+	"overview.named_dirs":        "Named Directories: %d",
+	"overview.global_alias_list": "Global Aliases:",
+	"overview.named_dir_list":    "Named Directories:",
+	"overview.abbreviations":     "Abbreviations: %d",
+	"overview.abbreviation_list": "Abbreviations:",
+	"overview.shortcut":          "%s → %s (used %d times)",
+
+	// Shell journey
+	"journey.title":      "🔀 Shell Journey",
+	"journey.period":     "%s: %s → %s (%d commands)",
+	"journey.switch":     "%s → %s in %s",
+	"journey.no_aliases": "No aliases to carry over",
+	"journey.carried":    "Carried over %d/%d aliases",
+	"journey.left":       "Left behind: %s",
+
+	// Budgets
+	"budgets.title":      "🎯 Budgets",
+	"budgets.over":       "over budget",
+	"budgets.within":     "within budget",
+	"budgets.alert":      "⚠ Over budget: %s at %.1f%% of commands (max %.0f%%)",
+	"budgets.alert_more": "and %d more",
+
+	// Shell comparison
+	"tab.shells":                 "Shells",
+	"compare.title":              "⚖️  Shells Side by Side",
+	"compare.none":               "No shell history found",
+	"compare.commands":           "Commands",
+	"compare.recent":             "Last 90 days",
+	"compare.last":               "Last used",
+	"compare.top":                "Top commands",
+	"compare.aliases":            "Aliases",
+	"compare.plugins":            "Plugins",
+	"compare.config":             "Config",
+	"compare.config_size":        "%d files, %d lines",
+	"compare.only":               "%s is the only shell with a history.",
+	"compare.recommended_recent": "%s gets the most real use: it ran the most commands in the last %d days of activity.",
+	"compare.recommended_total":  "%s gets the most real use: it has run the most commands.",
+
+	// Trends
+	"tab.trends":          "Trends",
+	"trends.title":        "📈 Month over Month",
+	"trends.none":         "No snapshots yet. Each run is recorded, and install-service records one every day.",
+	"trends.loading":      "Reading snapshots...",
+	"trends.volume":       "📦 Commands Added:",
+	"trends.total":        "%d in history",
+	"trends.trimmed":      "%d in history (trimmed)",
+	"trends.stack":        "🧰 Tech Stack:",
+	"trends.unchanged":    "no change",
+	"trends.productivity": "⚡ Productivity:",
+	"trends.per_session":  "Commands per Session",
+
+	// Top commands
+	"tab.top_commands": "Top Commands",
+	"top.title":        "🏆 Top Commands",
+	"top.none":         "No commands recorded yet",
+	"top.runs":         "%d runs",
+	"top.prefixes":     "🔁 Top Command Prefixes:",
+	"top.entry_points": "🎯 Project Entry Points:",
+
+	// Subcommand drill-down
+	"drilldown.title": "🔍 Subcommands & Flags",
+	"drilldown.none":  "No git, docker, kubectl or similar tool runs yet",
+
+	// Tech profile
+	"tech.title":             "💻 Technical Profile",
+	"tech.role":              "🎯 Primary Role: %s",
+	"tech.role_none":         "Not enough data",
+	"tech.stack":             "💻 Tech Stack:",
+	"tech.stack_none":        "No tech stack data available",
+	"tech.prompt":            "🎨 Prompt:",
+	"tech.prompt_theme":      "%s, theme %s",
+	"tech.prompt_none":       "No prompt framework found; the shells use their own prompt",
+	"tech.prompt_installed":  "Installed but not started: %s",
+	"tech.skills":            "🛠️  Secondary Skills:",
+	"tech.skills_none":       "No secondary skills data available",
+	"tech.skill":             "%s: %.0f%% confidence, %d commands",
+	"tech.proficiency":       "📊 Proficiency Levels:",
+	"tech.proficiency_no":    "No proficiency data available",
+	"tech.proficiency_about": "Score out of 100: share of your language and tool use, recent commands weighing most",
+	"tech.level.beginner":    "Beginner",
+	"tech.level.regular":     "Regular user",
+	"tech.level.heavy":       "Heavy user",
+
+	// Personas, keyed by what the analysis found
+	"persona.developer": "%s Developer",
+
+	// Skills
+	"skill.kubernetes":     "Kubernetes",
+	"skill.containers":     "Containers",
+	"skill.ci":             "CI/CD",
+	"skill.infrastructure": "Infrastructure as code",
+	"skill.cloud":          "Cloud CLIs",
+	"skill.databases":      "Databases",
+	"skill.debugging":      "Debugging and tracing",
+	"skill.networking":     "Networking",
+	"skill.security":       "Security",
+	"skill.data":           "Data and notebooks",
+
+	"role.kubernetes":     "Platform Engineer",
+	"role.containers":     "DevOps Engineer",
+	"role.ci":             "DevOps Engineer",
+	"role.infrastructure": "Infrastructure Engineer",
+	"role.cloud":          "Cloud Engineer",
+	"role.databases":      "Database Engineer",
+	"role.debugging":      "Systems Engineer",
+	"role.networking":     "Network Engineer",
+	"role.security":       "Security Engineer",
+	"role.data":           "Data Engineer",
+
+	// Growth
+	"tab.growth":                     "Growth",
+	"growth.title":                   "🌱 Growth",
+	"growth.none":                    "Not enough history to tell your skills yet. Keep using your shell and come back for suggestions.",
+	"growth.from":                    "Building on: %s",
+	"growth.next":                    "🎯 Skills to learn next:",
+	"growth.next_skill":              "%s, a natural step after %s",
+	"growth.next_none":               "You already cover the usual next steps.",
+	"growth.tools":                   "🧰 Tools to try:",
+	"growth.tool":                    "%s, for %s",
+	"growth.tools_none":              "You already use the tools that go with your skills.",
+	"growth.ai":                      "🤖 Advice from Gemini:",
+	"growth.ai_thinking":             "Asking Gemini for advice…",
+	"growth.ai_failed":               "Could not get advice from Gemini: %v",
+	"growth.practice.kubernetes":     "Practice: deploy a small app to a local kind cluster with a Deployment, a Service and a health check.",
+	"growth.practice.containers":     "Practice: containerize a project with a multi-stage Dockerfile and a compose file for its database.",
+	"growth.practice.ci":             "Practice: add a pipeline that lints, tests and builds a project on every push, and run it locally with act.",
+	"growth.practice.infrastructure": "Practice: describe a throwaway VM or bucket in Terraform, then plan, apply and destroy it.",
+	"growth.practice.cloud":          "Practice: host a static site or a function on a free tier, entirely from the CLI.",
+	"growth.practice.databases":      "Practice: model a small schema, load sample data and read the query plan of your slowest query.",
+	"growth.practice.debugging":      "Practice: find why a program is slow or stuck with strace, perf or a debugger instead of print statements.",
+	"growth.practice.networking":     "Practice: trace a request end to end with dig, curl -v and tcpdump and explain every hop.",
+	"growth.practice.security":       "Practice: scan one of your images or repositories for known vulnerabilities and leaked secrets, then fix what you find.",
+	"growth.practice.data":           "Practice: answer a question about a public CSV dataset with DuckDB or a notebook.",
+	"growth.practice.python":         "Practice: write a small CLI with typed functions, tests and a pyproject managed by uv.",
+	"growth.practice.go":             "Practice: build a small HTTP service with the standard library, table-driven tests and a graceful shutdown.",
+	"growth.practice.rust":           "Practice: port one of your scripts to a Rust CLI and make clippy happy.",
+	"growth.practice.javascript":     "Practice: build a small TypeScript API with tests and strict type checking.",
+	"growth.practice.java":           "Practice: write a small service with Gradle, JUnit tests and a container image.",
+	"growth.practice.ruby":           "Practice: write a small gem with RSpec tests and publish it locally.",
+	"growth.practice.php":            "Practice: build a small app with Composer, PHPUnit tests and static analysis.",
+	"growth.practice.c":              "Practice: write a small C tool with a CMake build and check it with valgrind and sanitizers.",
+
+	// Work patterns
+	"work.title":               "⏰ Work Patterns",
+	"work.daily":               "📅 Daily Activity:",
+	"work.peak_hours":          "Peak hours: %s",
+	"work.weekly":              "🗓️  Weekly Heatmap:",
+	"work.legend":              "Less %s More",
+	"work.no_timestamps":       "No timestamped history yet",
+	"work.hour_count":          "%02d:00: %d commands",
+	"work.day_count":           "%s: %d commands, busiest at %02d:00",
+	"work.sessions":            "🧭 Sessions:",
+	"work.session_count":       "%d sessions (a pause of %d minutes starts a new one)",
+	"work.session_average":     "Average length: %s",
+	"work.session_longest":     "Longest: %s, starting %s",
+	"work.session_commands":    "Commands per session: %.1f",
+	"work.week":                "📆 Weekdays and Weekends:",
+	"work.weekend_share":       "Weekend share",
+	"work.weekdays":            "Weekdays: %d commands on %d days",
+	"work.weekends":            "Weekends: %d commands on %d days",
+	"work.day_span":            "Average day: first command at %s, last at %s",
+	"work.late_night":          "🌙 Late night: %d commands after midnight on %d nights, %d of them coding",
+	"work.latest":              "Latest: %s on the night of %s",
+	"work.balance":             "⚖️  Work-life balance: %s",
+	"work.balance.healthy":     "Healthy",
+	"work.balance.fair":        "Fair",
+	"work.balance.strained":    "Strained",
+	"work.balance.weekends":    "busy weekends",
+	"work.balance.late":        "late nights",
+	"work.balance.long_days":   "long days",
+	"work.reuse":               "♻️  History Reuse:",
+	"work.reuse_rate":          "Recalled",
+	"work.reuse_breakdown":     "%d repeats of earlier commands, %d history expansions (!!, !$, ^a^b), %d fc/r",
+	"work.reuse_tools":         "Recall tools: %s",
+	"work.reuse_no_tools":      "No recall tool such as atuin or fzf found in your rc files",
+	"work.exploration":         "🧪 Exploration Time (REPLs):",
+	"work.exploration_total":   "%s exploring in interpreters over %d launches, on top of running commands",
+	"work.exploration_repl":    "%s over %d launches",
+	"work.outcomes":            "🚦 Failures and Durations:",
+	"work.outcomes_counted":    "%d commands with an exit status, %d with a duration",
+	"work.failures":            "Failing most often:",
+	"work.failure":             "%.0f%% of %d runs failed",
+	"work.slowest":             "Slowest on average:",
+	"work.slow":                "%s on average, longest %s, %d runs",
+	"work.retried":             "Run again right after failing, %d times:",
+	"work.rage":                "😤 Rage Repeats:",
+	"work.rage_about":          "Commands run three or more times in a row, each within %d seconds of the last",
+	"work.rage_bursts":         "%d bursts, up to %d in a row",
+	"work.complexity":          "🧩 Command Complexity:",
+	"work.complexity_features": "Commands using %s",
+	"work.complexity_pipeline": "Longest pipeline: %d programs",
+	"work.complexity_top":      "Most elaborate one-liner (score %d):",
+	"work.productivity":        "📈 Productivity Metrics:",
+	"work.workflows":           "🔄 Common Workflows:",
+	"work.workflows_none":      "No command sequence recurs often enough yet",
+	"work.workflow_runs":       "(%d×)",
+	"work.workflow_alias":      "One command for it: %s",
+
+	// Command complexity
+	"complexity.simple":        "Simple",
+	"complexity.moderate":      "Moderate",
+	"complexity.complex":       "Complex",
+	"complexity.elaborate":     "Elaborate",
+	"complexity.pipes":         "pipes",
+	"complexity.redirections":  "redirections",
+	"complexity.substitutions": "substitutions",
+	"complexity.subshells":     "subshells",
+	"complexity.loops":         "loops",
+	"complexity.conditionals":  "conditionals",
+	"complexity.xargs":         "xargs",
+	"complexity.chains":        "&&/||/; chains",
+
+	// Metrics
+	"metric.command_variety":     "Command Variety",
+	"metric.workflow_complexity": "Workflow Complexity",
+
+	// Command categories
+	"category.development": "Development",
+	"category.system":      "System",
+	"category.file":        "Files",
+
+	// Tool usage
+	"tools.title":               "🔧 Tool Usage Statistics",
+	"tools.editors":             "📝 Editors:",
+	"tools.editors_none":        "No editor usage data available",
+	"tools.edited":              "✏️  Languages edited and run:",
+	"tools.edited_run":          "%s: %d files edited, %d runs",
+	"tools.edited_only":         "%s: %d files edited, never run",
+	"tools.run_only":            "%s: %d runs, never edited",
+	"tools.edited_files":        "Edited most: %s",
+	"tools.languages":           "💻 Programming Languages:",
+	"tools.languages_none":      "No language usage data available",
+	"tools.build":               "🛠️  Build Tools:",
+	"tools.build_none":          "No build tool usage data available",
+	"tools.mux":                 "🪟 Terminal Multiplexers (tmux, screen, zellij):",
+	"tools.mux_none":            "No tmux, screen or zellij use found",
+	"tools.mux_program":         "%s: %d commands",
+	"tools.mux_commands":        "tmux commands run most: %s",
+	"tools.mux_sessions":        "Sessions by name: %s",
+	"tools.mux_config":          "%s: prefix %s, %d key bindings",
+	"tools.mux_root":            "%s (no prefix)",
+	"tools.mux_plugins":         "Plugins (tpm):",
+	"tools.direnv":              "🌱 Per-project Environments (direnv):",
+	"tools.direnv_project":      "%s: %d direnv commands or .envrc edits",
+	"tools.direnv_none":         "No direnv projects found",
+	"tools.direnv_exports":      "Exported by hand %[2]d times in %[3]s: %[1]s",
+	"tools.network":             "🌐 Remote Hosts (ssh, scp, rsync, mosh):",
+	"tools.network_host":        "%s: %d connections via %s",
+	"tools.network_none":        "No remote hosts found",
+	"tools.domains":             "📥 Domains fetched with curl and wget:",
+	"tools.domain":              "%s: %d requests",
+	"tools.domains_none":        "No URLs fetched",
+	"tools.packages":            "📦 Packages installed (npm, pip, go, cargo, brew, apt):",
+	"tools.packages_none":       "No packages installed by name",
+	"tools.package":             "%s (%s): %d installs",
+	"tools.packages_new":        "🆕 Newly adopted in the last 90 days:",
+	"tools.package_new":         "%s (%s), first installed %s",
+	"tools.hoarder":             "Dependency hoarder score: %d/100, %s",
+	"tools.hoarder_about":       "%d different packages, %.1f a month; 100 means 20 or more a month",
+	"tools.hoarding.minimalist": "a minimalist",
+	"tools.hoarding.collector":  "a collector",
+	"tools.hoarding.hoarder":    "a hoarder",
+	"tools.uses":                "%s: %d uses",
+
+	// Projects
+	"tab.projects":         "Projects",
+	"projects.title":       "🗂️  Projects",
+	"projects.none":        "No projects found yet. Commands run under your home in a directory with go.mod, package.json, Cargo.toml, .git or similar show up here.",
+	"projects.summary":     "%d of %d commands ran in %d projects",
+	"projects.time":        "%s of shell time",
+	"projects.last":        "last %s",
+	"projects.elsewhere":   "Outside any project: %d commands",
+	"projects.kind.go":     "Go",
+	"projects.kind.rust":   "Rust",
+	"projects.kind.node":   "Node.js",
+	"projects.kind.python": "Python",
+	"projects.kind.ruby":   "Ruby",
+	"projects.kind.java":   "Java",
+	"projects.kind.php":    "PHP",
+	"projects.kind.elixir": "Elixir",
+	"projects.kind.git":    "git",
+
+	// Git
+	"tab.git":            "Git",
+	"git.title":          "🌿 Git",
+	"git.none":           "No git commands in your history yet.",
+	"git.summary":        "%d git commands, %d of them commits",
+	"git.ratios":         "Per commit:",
+	"git.per_commit":     "%.2f",
+	"git.prefers.merge":  "You merge rather than rebase: %d merges and pulls, %d rebases",
+	"git.prefers.rebase": "You rebase rather than merge: %d merges and pulls, %d rebases",
+	"git.prefers.none":   "No clear preference between merging and rebasing: %d merges and pulls, %d rebases",
+	"git.force":          "Force pushes: %d with --force, %d with --force-with-lease",
+	"git.force_tip":      "--force-with-lease refuses to overwrite commits you have not fetched yet",
+	"git.stash":          "Stash: %s",
+	"git.stash_left":     "%d more stashes than pops, applies and drops; git stash list may hold forgotten work",
+	"git.branches":       "Branch names typed:",
+	"git.reflogs":        "Repositories:",
+	"git.reflogs_off":    "Run with --git-reflogs to count the commits in the reflogs of your projects' repositories.",
+	"git.reflogs_none":   "No reflogs found in the repositories of your projects.",
+	"git.reflog":         "%d commits, %d amends, %d rebases, %d merges, %d checkouts",
+
+	// Containers
+	"tab.containers":        "Containers",
+	"containers.title":      "🐳 Containers & Kubernetes",
+	"containers.none":       "No docker, podman, kubectl or helm commands in your history yet.",
+	"containers.docker":     "%d docker commands, %d of them through compose (%.0f%%)",
+	"containers.images":     "Images run most:",
+	"containers.kubectl":    "%d kubectl commands:",
+	"containers.helm":       "%d helm commands: %s",
+	"containers.namespaces": "Namespaces:",
+	"containers.contexts":   "%d context switches: %s",
+
+	// Timeline
+	"timeline.title":         "⏳ Interesting Commands Timeline",
+	"timeline.unknown":       "unknown time",
+	"timeline.undated":       "Undated",
+	"timeline.none":          "No commands match the filters",
+	"timeline.page":          "Page %d of %d · Commands: %d",
+	"timeline.pages":         "page",
+	"timeline.filters":       "Shell: %s · Category: %s · Type: %s",
+	"timeline.all":           "all",
+	"timeline.kind.tool":     "tools",
+	"timeline.kind.pipeline": "pipelines",
+	"timeline.kind.typo":     "typos",
+
+	// Data
+	"tab.data":            "Data",
+	"data.title":          "🔬 Parsed Data",
+	"data.redacted":       "Redaction on (%s): this is how commands look when sent to the AI",
+	"data.raw":            "Redaction off: raw values as read from your files, never sent anywhere",
+	"data.none":           "No shell history or config was found",
+	"data.entries":        "%d entries parsed",
+	"data.recent":         "Newest %d entries:",
+	"data.aliases":        "Aliases (%d):",
+	"data.plugins":        "Plugins (%d):",
+	"data.plugin_updated": "updated %s",
+	"data.plugin_stale":   "⚠ not updated since %s",
+	"data.environment":    "Environment variables (%d):",
+	"data.toggle":         "toggle redaction",
+
+	// Capabilities, see internal/capability
+	"capability.title":                "🧭 Capabilities",
+	"capability.histories":            "History: %s",
+	"capability.timestamps":           "Timestamps: %s",
+	"capability.ai":                   "AI: %s",
+	"capability.ai.configured":        "configured",
+	"capability.ai.missing":           "no API key",
+	"capability.ai.disabled":          "disabled",
+	"capability.clipboard":            "Clipboard: %s",
+	"capability.images":               "Inline images: %s",
+	"capability.none":                 "none",
+	"capability.hint.history":         "No shell history was found. Enable history saving in your shell to unlock this tab.",
+	"capability.hint.timestamps":      "Enable timestamps with `%s` in %s to unlock %s.",
+	"capability.hint.ai":              "Set a Gemini API key to unlock AI-written slides; these are built locally.",
+	"capability.hint.chat":            "Set a Gemini API key to ask questions about your history.",
+	"capability.hint.growth":          "Set a Gemini API key to get AI advice on top of these suggestions.",
+	"capability.hint.outcomes":        "Add the shell hooks (init bash|zsh|fish, see Shell Hooks in the README) to see failure rates and slow commands.",
+	"capability.unlock.work_patterns": "activity by hour and sessions",
+	"capability.unlock.timeline":      "the timeline",
+	"capability.unlock.achievements":  "streaks",
+
+	// Diagnostics, see internal/render RenderProblems
+	"tab.diagnostics":       "Diagnostics",
+	"problems.title":        "🩺 Diagnostics",
+	"problems.none":         "✅ Everything was read without problems",
+	"problems.why":          "Why: %v",
+	"problems.fix":          "Fix: %s",
+	"problems.indicator":    "⚠️  %d problem(s) left views incomplete, see %s",
+	"problems.log":          "Details are logged to %s; run with --verbose or --debug to log more.",
+	"problem.history.path":  "Could not read the history file %s",
+	"problem.history.fix":   "Make sure the file is readable by you; that shell's commands are missing from every tab.",
+	"problem.config.path":   "Could not read the startup file %s",
+	"problem.config.fix":    "Make sure the file is readable by you; its aliases and plugins are missing.",
+	"problem.cast.path":     "Could not read the recording %s",
+	"problem.cast.fix":      "Check the casts paths in config.yaml or --cast; only asciinema v2 and v3 recordings are read.",
+	"problem.settings.path": "Could not read the settings in %s",
+	"problem.settings.fix":  "Fix the YAML or delete the file; the defaults are used meanwhile.",
+	"problem.keys.path":     "Ignored key bindings in %s",
+	"problem.keys.fix":      "Use the action names listed in the README under Navigation Keys.",
+	"problem.ai":            "Gemini could not write your Wrapped",
+	"problem.ai.fix":        "Check the API key and your connection; the Wrapped tab shows the locally built slides meanwhile.",
+	"problem.api_key":       "Could not store the API key",
+	"problem.api_key.fix":   "It is used for this session only; set GEMINI_API_KEY or api_key in config.yaml to keep it.",
+	"problem.archive":       "Could not archive the Wrapped slides",
+	"problem.archive.fix":   "Make sure the data directory is writable and has free space.",
+	"problem.trends":        "Could not record or read the trends",
+	"problem.trends.fix":    "Make sure the data directory is writable and has free space.",
+
+	// Security
+	"tab.security":                   "Security",
+	"security.title":                 "🛡️  Security Audit",
+	"security.none":                  "✅ No risky commands found in your history",
+	"security.finding":               "⚠️  %s: %d times",
+	"security.example":               "Last: %s",
+	"security.example_at":            "Last: %s (%s)",
+	"security.secret":                "Likely secret on the command line",
+	"security.secret.warning":        "Tokens and passwords typed into commands are saved in plain text. Rotate them and run `scrub` to remove the entries.",
+	"security.rm_root":               "Recursive delete of / or home",
+	"security.rm_root.warning":       "One typo away from wiping the system or your home directory. Delete specific paths instead.",
+	"security.dd_device":             "dd onto a disk device",
+	"security.dd_device.warning":     "Writing to the wrong device destroys it. Double-check of= with lsblk first.",
+	"security.sudo_pipe":             "Download piped into sudo",
+	"security.sudo_pipe.warning":     "Runs code from the network as root without a chance to read it. Download, inspect, then run.",
+	"security.pipe_to_shell":         "Download piped into a shell",
+	"security.pipe_to_shell.warning": "Runs whatever the server sends, even if the download breaks halfway. Save the script and read it first.",
+	"security.chmod_777":             "chmod 777",
+	"security.chmod_777.warning":     "Lets every user modify the file. Grant only what is needed, e.g. 755 or 644.",
+	"security.force_push":            "Force push",
+	"security.force_push.warning":    "Overwrites the remote branch and others' commits. Prefer --force-with-lease.",
+	"security.insecure_tls":          "TLS verification disabled",
+	"security.insecure_tls.warning":  "-k/--insecure accepts any certificate, so the connection can be intercepted.",
+
+	// Config Health
+	"tab.config_health":                    "Config Health",
+	"health.title":                         "🩺 Config Health",
+	"health.none":                          "No problems found in your startup files.",
+	"health.no_configs":                    "No startup files were read.",
+	"health.duplicate_path":                "%s is added to PATH again, first at %s",
+	"health.interactive_guard":             "%s runs for non-interactive shells too, e.g. scp or ssh host command",
+	"health.deprecated":                    "Deprecated syntax: %s",
+	"health.enormous":                      "%s lines of code",
+	"health.unused_plugin":                 "The %s plugin is loaded, but its programs never show up in your history",
+	"health.stale_plugin":                  "The %s plugin has not been updated since %s",
+	"health.syntax.backticks":              "`...` command substitution",
+	"health.syntax.dollar_bracket":         "$[...] arithmetic",
+	"health.syntax.egrep":                  "egrep",
+	"health.syntax.fgrep":                  "fgrep",
+	"health.syntax.caret":                  "^ to redirect stderr",
+	"health.syntax.dot":                    ". to source a file",
+	"health.fix.duplicate_path":            "Remove the repeated entry; the directory is already on PATH",
+	"health.fix.interactive_guard.bash":    "Add [[ $- == *i* ]] || return above it",
+	"health.fix.interactive_guard.fish":    "Wrap it in if status is-interactive ... end",
+	"health.fix.deprecated.backticks":      "Use $(...), which nests and reads better",
+	"health.fix.deprecated.dollar_bracket": "Use $((...))",
+	"health.fix.deprecated.egrep":          "Use grep -E",
+	"health.fix.deprecated.fgrep":          "Use grep -F",
+	"health.fix.deprecated.caret":          "Use 2> instead",
+	"health.fix.deprecated.dot":            "Use source instead",
+	"health.fix.enormous.bash":             "Split it into files by topic and source them from it",
+	"health.fix.enormous.zsh":              "Split it into files by topic and source them from it",
+	"health.fix.enormous.fish":             "Move parts into ~/.config/fish/conf.d and functions into ~/.config/fish/functions",
+	"health.fix.unused_plugin":             "Remove it from your plugin list so the shell starts faster",
+	"health.fix.stale_plugin":              "Update it with its plugin manager, or look for a maintained replacement",
+
+	// Suggestions
+	"tab.suggestions":                   "Suggestions",
+	"suggestions.title":                 "💡 Suggestions",
+	"suggestions.aliases":               "⌨️  Aliases worth adding:",
+	"suggestions.alias_savings":         "%d runs, saves about %.0f keystrokes a week",
+	"suggestions.no_aliases":            "No alias would save many keystrokes",
+	"suggestions.apply_hint":            "%s: Add these aliases to %s",
+	"suggestions.reason":                "add suggested aliases",
+	"suggestions.applied":               "Added %d aliases to %s. Run `undo` to revert, or open a new shell to use them.",
+	"suggestions.apply_failed":          "Could not add the aliases: %v",
+	"suggestions.cleanup":               "🧹 Alias clean-up:",
+	"suggestions.unused_aliases":        "%s: %d aliases never used: %s",
+	"suggestions.shadowing":             "%s (%s) hides the program of the same name and runs %s instead",
+	"suggestions.duplicate":             "%s is defined %d times: %s",
+	"suggestions.conflicting":           "%s is defined %d times with different commands: %s",
+	"suggestions.alternatives":          "🦀 Modern alternatives:",
+	"suggestions.alternative_installed": "You ran %s %d times, and %s is already installed. Try %[4]s instead.",
+	"suggestions.alternative_install":   "You ran %s %d times. Consider installing %s, a faster, friendlier replacement.",
+	"suggestions.recommendations":       "🔧 Setup:",
+	"suggestions.few_aliases":           "Your %s config has few aliases. Add some for the commands you type most.",
+	"suggestions.few_plugins":           "Your %s setup uses few plugins. Autosuggestions and syntax highlighting are good first picks.",
+	"suggestions.dedupe":                "%.0[2]f%% of your %[1]s history is duplicates. Run `dedupe --apply` to stop saving them.",
+	"suggestions.scrub":                 "%d history entries contain likely secrets. Run `scrub` to remove them.",
+	"suggestions.unused_shortcuts":      "%d zsh global aliases or named directories are never used: %s. Remove them or start using them.",
+	"suggestions.direnv":                "You exported %s by hand %d times in %s. Put them in an .envrc there and let direnv load them.",
+	"suggestions.direnv_hook":           "You use .envrc files, but no rc file runs `direnv hook`, so they are only loaded by hand.",
+	"suggestions.security":              "%d risky commands found, see the Security tab.",
+	"suggestions.tips":                  "🚀 Workflow tips:",
+	"suggestions.frequent_pattern":      "You typed \"%s\" %d times. A shorter alias or function would help.",
+	"suggestions.workflow":              "You ran %s in a row %d times. An alias runs them in one go: %s",
+	"suggestions.recall_tool":           "%.0f%% of your commands repeat earlier ones. A history search tool like atuin or fzf's Ctrl-R finds them faster than scrolling.",
+	"suggestions.typos":                 "You typed \"%s\" instead of \"%s\" %d times. Try shell autocorrection (setopt CORRECT in zsh) or thefuck.",
+	"suggestions.typo_fixes":            "🤦 Typo fixes:",
+	"suggestions.typo_count":            "typed %d times",
+
+	// Achievements
+	"tab.achievements":           "Achievements",
+	"achievements.title":         "🏆 Achievements",
+	"achievements.streaks":       "🔥 Streaks:",
+	"achievements.current":       "Current streak: %d days",
+	"achievements.longest":       "Longest streak: %d days, starting %s",
+	"achievements.milestones":    "🏁 Milestones:",
+	"achievements.first":         "First %s on %s",
+	"achievements.nth":           "%s #%d on %s",
+	"achievements.no_milestones": "No milestones reached yet",
+	"achievements.badges":        "🏅 Badges:",
+	"achievements.earned":        "✅ %s — %s",
+	"achievements.locked":        "🔒 %s (locked) — %s",
+
+	"badge.night_owl":            "Night Owl",
+	"badge.night_owl.desc":       "a fifth of your commands ran between midnight and 5am",
+	"badge.early_bird":           "Early Bird",
+	"badge.early_bird.desc":      "a fifth of your commands ran between 5am and 9am",
+	"badge.weekend_warrior":      "Weekend Warrior",
+	"badge.weekend_warrior.desc": "a quarter of your commands ran on weekends",
+	"badge.pipe_wizard":          "Pipe Wizard",
+	"badge.pipe_wizard.desc":     "50 commands chaining three or more programs",
+	"badge.marathoner":           "Marathoner",
+	"badge.marathoner.desc":      "a work session of three hours or more",
+	"badge.streaker":             "Streaker",
+	"badge.streaker.desc":        "active 30 days in a row",
+	"badge.shell_hopper":         "Shell Hopper",
+	"badge.shell_hopper.desc":    "switched your main shell at least once",
+
+	// Search
+	"search.title":       "🔎 Search History",
+	"search.placeholder": "type to search, e.g. docker shell:zsh since:2024-01",
+	"search.filters":     "Filters: shell:zsh cat:development since:2024-01 until:2024-06-30",
+	"search.matches":     "Matches: %d",
+	"search.none":        "No commands match",
+	"search.help":        "↑/↓ PgUp/PgDn: Scroll • Esc: Close",
+
+	// Chat
+	"tab.chat":         "Chat",
+	"chat.title":       "💬 Ask About Your History",
+	"chat.placeholder": "e.g. which kubectl flags do I use most?",
+	"chat.empty":       "Press Enter to ask a question. Answers come from the AI, given a summary and the commands that matter to the question, with secrets redacted.",
+	"chat.thinking":    "Thinking…",
+	"chat.failed":      "No answer: %v",
+	"chat.more":        "↓ %d more lines",
+	"chat.help":        "Enter: Ask • PgUp/PgDn: Scroll • Esc: Done",
+
+	// Wrapped
+	"wrapped.generating": "Generating wrapped view...",
+	"wrapped.slide":      "📺 Slide %d/%d",
+	"wrapped.quotes":     "📜 Quotes",
+
+	"wrapped.top.title":       "Your Top Commands",
+	"wrapped.top.description": "Out of %d commands, %s was the one you reached for most.",
+	"wrapped.top.quote":       "#%d %s — %d runs",
+
+	"wrapped.streak.title":       "Your Longest Streak",
+	"wrapped.streak.description": "You opened a shell %d days in a row starting %s, and were active on %d days in total.",
+	"wrapped.streak.quote":       "Consistency is the real 10x skill.",
+
+	"wrapped.busiest.title":       "Your Busiest Day",
+	"wrapped.busiest.description": "On %s you ran %d commands. Whatever happened that day, your keyboard remembers.",
+
+	"wrapped.typos.title":       "Fat Finger Awards",
+	"wrapped.typos.description": "Your most beloved typo was '%s'. Nobody is perfect.",
+	"wrapped.typos.quote":       "%s → %s (%d times)",
+
+	"wrapped.quiet.title":       "A Quiet Year",
+	"wrapped.quiet.description": "There was not enough shell history to build your Wrapped. Run some commands and come back!",
+
+	"wrapped.roast.top.title":         "Creature of Habit",
+	"wrapped.roast.top.description":   "%d commands, and %s was %d of them. At this point it is less a tool than a personality.",
+	"wrapped.roast.typos.title":       "Keyboard Optional",
+	"wrapped.roast.typos.description": "You typed '%s' when you meant '%s' %d times. Autocorrect gave up on you.",
+	"wrapped.roast.late.title":        "Night Owl, Allegedly",
+	"wrapped.roast.late.description":  "%d commands between midnight and 5 AM over %d nights, the latest at %s. Whatever broke, it could have waited.",
+	"wrapped.roast.run.title":         "Are We There Yet?",
+	"wrapped.roast.run.description":   "You ran this %d times in a row. Spoiler: the output did not change.",
+	"wrapped.roast.quiet.title":       "Nothing to Roast",
+	"wrapped.roast.quiet.description": "Barely any history. Either you are very efficient or you use a GUI, and we both know which.",
+
+	"wrapped.journey.title":       "Your Shell Journey",
+	"wrapped.journey.description": "Your dominant shell over time: %s",
+	"wrapped.journey.none":        "You left %s for %s in %s and packed light: no aliases to move.",
+	"wrapped.journey.all":         "You moved from %s to %s in %s and brought all %d aliases along.",
+	"wrapped.journey.some":        "You moved from %s to %s in %s, carrying %d of %d aliases.",
+
+	"wrapped.forecast.title":       "Looking Ahead",
+	"wrapped.forecast.description": "At this rate you'll hit %d %s commands by %s.",
+	"wrapped.forecast.quote":       "%s: about %.0f a week, %d by %s",
+
+	"wrapped.elaborate.title":       "One-Liner of the Year",
+	"wrapped.elaborate.description": "Your most elaborate command scored %d, pulling in %s.",
+
+	"wrapped.retry.title":       "Try, Try Again",
+	"wrapped.retry.description": "This one failed, and you ran it again right away — %d times. Persistence is a virtue.",
+
+	"wrapped.rage.title":             "Your Nemesis",
+	"wrapped.rage.description":       "You hammered this one %d times, up to %d runs in a row within seconds. It did not go quietly.",
+	"wrapped.containers.title":       "Captain of the Containers",
+	"wrapped.containers.description": "%d container and Kubernetes commands this time, and one image you kept coming back to.",
+
+	"wrapped.year.title":             "Your %d in the Shell",
+	"wrapped.year.description":       "%d commands over %d active days. Here is how the year went, month by month.",
+	"wrapped.year.quiet":             "There is no shell history from %d to look back on.",
+	"wrapped.year.outro.title":       "See You in %d",
+	"wrapped.year.outro.description": "That's a wrap on %d. Here's to fewer typos next year.",
+	"wrapped.month.description":      "%d commands on %d days with %d different programs. %s led the way with %d runs.",
+	"wrapped.month.busiest":          "Your busiest month of the year.",
+	"wrapped.new.title":              "New Tools Learned",
+	"wrapped.new.description":        "You picked up %d new tools this year, %s most of all.",
+	"wrapped.new.quote":              "%s — %d runs",
+	"wrapped.pause":                  "pause",
+	"wrapped.paused":                 "⏸ paused",
+	"wrapped.year.invalid":           "--year %d is not a year with history to look back on",
+	"wrapped.rate":                   "How was this slide? %s",
+	"wrapped.rated":                  "★ You rated this slide %d/5",
+	"wrapped.rate_failed":            "Could not save the rating: %v",
+	"wrapped.decks":                  "🗂 Decks:",
+	"wrapped.deck.current":           "This run",
+	"wrapped.deck.year":              "%s in review",
+
+	// Settings
+	"tab.settings":         "Settings",
+	"settings.title":       "⚙️  Settings",
+	"settings.ai":          "AI summary",
+	"settings.roast":       "Roast mode for Wrapped",
+	"settings.on":          "on",
+	"settings.off":         "off",
+	"settings.theme":       "Theme",
+	"settings.redaction":   "Redaction before AI",
+	"settings.shell":       "Analyze %s history",
+	"settings.saved_to":    "Changes are saved to %s",
+	"settings.saved":       "Saved.",
+	"settings.save_failed": "Could not save settings: %v",
+	"settings.last_shell":  "At least one shell must stay enabled.",
+	"redaction.strict":     "strict (secrets, IPs, home paths)",
+	"redaction.secrets":    "secrets only",
+	"settings.period":      "Period (this session)",
+	"period.all":           "all time",
+	"period.30d":           "last 30 days",
+	"period.90d":           "last 90 days",
+	"period.this_year":     "this year",
+	"period.last_year":     "last year",
+	"period.since":         "since %s",
+	"period.until":         "until %s",
+	"period.range":         "%s to %s",
+
+	// simulate command
+	"simulate.none":   "No alias proposals to simulate.",
+	"simulate.header": "ALIAS\tEXPANSION\tMATCHES\tKEYSTROKES SAVED\tENTRIES/WEEK\tKEYSTROKES/WEEK",
+
+	// undo command
+	"undo.none":     "Nothing to undo.",
+	"undo.header":   "CHANGED\tFILE\tREASON",
+	"undo.restored": "Restored %s to its version from before %s (%s).",
+	"undo.removed":  "Removed %s, which was created for: %s.",
+
+	// config command
+	"config.written": "Wrote the default settings to %s; uncomment what you want to change.",
+	"config.exists":  "%s already exists; pass --force to replace it.",
+
+	// Shell hooks
+	"daemon.listening": "Collecting commands from the shell hooks on %s into %s, Ctrl+C to stop",
+
+	// Migrate
+	"migrate.title":       "Moving from %s to %s",
+	"migrate.aliases":     "Aliases",
+	"migrate.functions":   "Functions",
+	"migrate.environment": "Environment variables",
+	"migrate.port":        "to port",
+	"migrate.review":      "to port, check the translation",
+	"migrate.defined":     "already defined",
+	"migrate.same":        "--from and --to are both %s, nothing to migrate",
+	"migrate.none":        "No aliases, functions or environment variables found in the %s rc files.",
+	"migrate.summary":     "%d of %d definitions still need porting to %s.",
+	"migrate.starter":     "Starter %s config, to append to %s or source from it:",
+	"migrate.written":     "Wrote the starter config to %s. Source it from %s, or copy what you need.",
+
+	// dotfiles command
+	"dotfiles.none":            "No shell startup files found to export.",
+	"dotfiles.exists":          "%s already exists and is not empty; pick another --output or add --force.",
+	"dotfiles.shell":           "%s: %d files, %d aliases, %d environment variables, %d plugins",
+	"dotfiles.redacted":        "Redacted %d lines that looked like secrets; check them before committing.",
+	"dotfiles.written_dir":     "Wrote the dotfiles to %[1]s. Link them back into place with `stow -d %[1]s -t ~ %[2]s`.",
+	"dotfiles.written_archive": "Wrote the dotfiles to %s.",
+
+	// dedupe command
+	"dedupe.none":    "No bash or zsh history to check; fish never saves duplicates.",
+	"dedupe.header":  "SHELL\tENTRIES\tDUPLICATES\tSHARE\tREPEATED IN A ROW",
+	"dedupe.enabled": "%s already drops duplicates.",
+	"dedupe.clean":   "%s has no duplicate entries.",
+	"dedupe.suggest": "%s: %d entries are duplicates. Add this to %s, or rerun with --apply:",
+	"dedupe.reason":  "skip duplicate history entries",
+	"dedupe.applied": "Updated %s. New duplicates will not be saved; run `undo` to revert.",
+
+	// scrub command
+	"scrub.none":           "No likely secrets found in your shell history.",
+	"scrub.found":          "%d entries with likely secrets in %s:",
+	"scrub.confirm":        "Remove these %d entries from %s? [y/N]",
+	"scrub.yes":            "y",
+	"scrub.skipped":        "Left %s unchanged.",
+	"scrub.removed":        "Removed %d entries from %s. No copy of them was kept, so `undo` cannot restore them.",
+	"scrub.running_shells": "Shells that are still open keep their history in memory and may write it back on exit; restart them, and rotate any secret that was exposed.",
+
+	// snapshot command
+	"snapshot.saved": "Saved snapshot %s (%d commands) to %s",
+
+	// compare command
+	"diff.title":          "🔀 %s → %s",
+	"diff.commands":       "Commands: %d → %d",
+	"diff.commands_since": "Commands: %d, then %d more",
+	"diff.adopted":        "🆕 Adopted:",
+	"diff.abandoned":      "💤 Abandoned:",
+	"diff.tech":           "🧰 Tech Stack:",
+	"diff.peak":           "🕐 Peak Hours:",
+	"diff.proficiency":    "📊 Proficiency:",
+	"diff.need_snapshots": "need %d snapshots to compare, found %d; run `snapshot` or name two periods, e.g. compare 2024-Q1 2024-Q2",
+
+	// Weekly report
+	"report.title":           "🐚 Weekly Shell Highlights",
+	"report.period":          "%s – %s",
+	"report.commands":        "⌨️ Commands",
+	"report.change":          "(%+.0f%% vs last week)",
+	"report.active_days":     "📅 Active days",
+	"report.days_of_week":    "%d of 7",
+	"report.streak":          "🔥 Streak",
+	"report.streak_days":     "%d days",
+	"report.top":             "🏆 Top tools",
+	"report.new":             "🆕 New this week",
+	"report.more":            "and %d more",
+	"report.busiest":         "📈 Busiest day",
+	"report.busiest_day":     "%s, %d commands",
+	"report.late_night":      "🦉 After midnight",
+	"report.late_night_runs": "%d commands",
+	"report.next_skill":      "🌱 Next to learn",
+	"report.footer":          "Shared with k8au-shell-analyzer · program names only, never arguments or paths",
+	"report.posted":          "Posted the weekly highlights to %s",
+	"report.no_webhook":      "No webhook configured; set report.slack_webhook or report.discord_webhook in %s",
+
+	// export and import commands
+	"export.none":       "No snapshots yet; run `snapshot` first.",
+	"export.written":    "Exported snapshot %s to %s",
+	"export.unsigned":   "The export is not signed; set K8AU_SIGNING_KEY or signing_key in the config file to sign it.",
+	"import.saved":      "Imported snapshot %s (schema %d) into %s",
+	"import.unverified": "Warning: the export is signed but no signing key is set, so the signature was not checked.",
+
+	// aggregate command
+	"team.title":    "👥 Team of %d",
+	"team.commands": "%d commands between them",
+	"team.tools":    "🧰 Shared Tools:",
+	"team.members":  "%d of %d",
+	"team.peak":     "Collective peak hours: %s",
+	"team.stack":    "🧱 Tech Stack Distribution:",
+	"team.roles":    "🎭 Roles:",
+	"team.none":     "Nothing shared by two members or more",
+	"team.hidden":   "%d tools, technologies or roles of a single member are left out so no one can be picked out.",
+	"team.small":    "Warning: with only %d members, shared statistics may still point at someone.",
+
+	// serve command
+	"serve.listening":   "Serving the dashboard on %s, Ctrl+C to stop",
+	"serve.metrics":     "Serving Prometheus metrics on %s",
+	"serve.exposed":     "Warning: %s is reachable from other machines, and the dashboard shows your history to anyone who opens it.",
+	"serve.title":       "K8au Shell Analyzer",
+	"serve.since":       "Since",
+	"serve.until":       "Until",
+	"serve.shell":       "Shell",
+	"serve.all_shells":  "All shells",
+	"serve.apply":       "Apply",
+	"serve.copy":        "Copy link",
+	"serve.copied":      "Link copied",
+	"serve.loading":     "Analyzing…",
+	"serve.empty":       "Nothing yet",
+	"serve.commands":    "Commands",
+	"serve.total":       "commands analyzed",
+	"serve.top":         "Top commands",
+	"serve.hours":       "Commands by hour",
+	"serve.week":        "Week at a glance",
+	"serve.stack":       "Tech stack",
+	"serve.proficiency": "Proficiency",
+
+	// install-service command
+	"service.wrote":         "Wrote %s",
+	"service.installed":     "A snapshot will be recorded every day.",
+	"service.removed":       "Removed %s",
+	"service.not_installed": "The daily snapshot is not installed.",
+}
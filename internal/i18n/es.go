@@ -0,0 +1,809 @@
+// internal/i18n/es.go
+package i18n
+
+var spanish = Catalog{
+	"date.long":  "02/01/2006",
+	"date.day":   "02/01/2006",
+	"date.month": "01/2006",
+
+	"weekday.0":       "domingo",
+	"weekday.1":       "lunes",
+	"weekday.2":       "martes",
+	"weekday.3":       "miércoles",
+	"weekday.4":       "jueves",
+	"weekday.5":       "viernes",
+	"weekday.6":       "sábado",
+	"weekday.short.0": "Dom",
+	"weekday.short.1": "Lun",
+	"weekday.short.2": "Mar",
+	"weekday.short.3": "Mié",
+	"weekday.short.4": "Jue",
+	"weekday.short.5": "Vie",
+	"weekday.short.6": "Sáb",
+
+	"tab.overview":      "Resumen",
+	"tab.tech_profile":  "Perfil Técnico",
+	"tab.work_patterns": "Hábitos de Trabajo",
+	"tab.tool_usage":    "Herramientas",
+	"tab.wrapped":       "Wrapped",
+	"tab.timeline":      "Cronología",
+	"app.credit":        "Por Ksauraj",
+	"app.loading":       "Analizando tu historial de shell... 🔍",
+
+	"progress.history":  "Leyendo el historial de %s",
+	"progress.probe":    "Comprobando las herramientas instaladas",
+	"progress.insights": "Calculando estadísticas",
+	"progress.ai":       "Escribiendo tu Wrapped con Gemini",
+	"progress.local":    "Preparando tu Wrapped",
+
+	"edit.hint":   "%s: Editar %s",
+	"edit.failed": "No se pudo abrir el editor: %v (define $EDITOR)",
+
+	"background.newer": "Una ejecución en segundo plano a las %s encontró datos nuevos • %s: Recargar",
+
+	"watch.live": "● En vivo, actualizado a las %s",
+
+	"keys.next_tab":        "pestaña siguiente",
+	"keys.prev_tab":        "pestaña anterior",
+	"keys.next_slide":      "diapositiva siguiente",
+	"keys.prev_slide":      "diapositiva anterior",
+	"keys.up":              "arriba",
+	"keys.down":            "abajo",
+	"keys.select":          "cambiar ajuste",
+	"keys.rate":            "valorar diapositiva",
+	"keys.search":          "buscar en el historial",
+	"keys.filter_shell":    "filtrar la cronología por shell",
+	"keys.filter_category": "filtrar la cronología por categoría",
+	"keys.filter_kind":     "filtrar la cronología por tipo",
+	"keys.edit":            "editar archivo rc",
+	"keys.apply":           "añadir alias sugeridos",
+	"keys.reload":          "recargar",
+	"keys.help":            "mostrar ayuda",
+	"keys.quit":            "salir",
+	"help.title":           "⌨️  Atajos de teclado",
+	"help.close":           "Pulsa cualquier tecla para cerrar. Cambia las teclas en keys: de %s",
+
+	"linear.loading": "Analizando tu historial de shell...",
+	"linear.prompt":  "Escribe el número de una sección, a para todas o q para salir:",
+	"linear.invalid": "%q no es un número de sección.",
+
+	"wizard.title":   "🔑 Clave de API de Gemini",
+	"wizard.body":    "No se encontró ninguna clave de API. La vista Wrapped generada por IA\nla necesita; todo lo demás funciona sin conexión.",
+	"wizard.storage": "La clave se guarda en el llavero del sistema si está disponible,\nsi no, en %s.",
+	"wizard.help":    "Enter: Guardar • Esc: Omitir (Wrapped sin conexión)",
+	"wizard.input":   "Pega tu clave de API de Gemini",
+
+	"overview.title":             "📊 Resumen de uso de la shell",
+	"overview.shell":             "Shell: %s",
+	"overview.commands":          "Comandos: %d",
+	"overview.configuration":     "Configuración:",
+	"overview.aliases":           "Alias: %d",
+	"overview.plugins":           "Plugins: %d",
+	"overview.env":               "Variables de entorno: %d",
+	"overview.plugin_list":       "Plugins instalados:",
+	"overview.plugin_from":       "%s (de %s)",
+	"overview.more":              "Y %d más...",
+	"overview.alias_list":        "Algunos alias:",
+	"overview.global_aliases":    "Alias globales: %d",
+	"overview.named_dirs":        "Directorios con nombre: %d",
+	"overview.global_alias_list": "Alias globales:",
+	"overview.named_dir_list":    "Directorios con nombre:",
+	"overview.abbreviations":     "Abreviaturas: %d",
+	"overview.abbreviation_list": "Abreviaturas:",
+	"overview.shortcut":          "%s → %s (usado %d veces)",
+
+	"journey.title":      "🔀 Tu recorrido por las shells",
+	"journey.period":     "%s: %s → %s (%d comandos)",
+	"journey.switch":     "%s → %s en %s",
+	"journey.no_aliases": "No había alias que migrar",
+	"journey.carried":    "Alias migrados: %d/%d",
+	"journey.left":       "Se quedaron atrás: %s",
+
+	"budgets.title":      "🎯 Presupuestos",
+	"budgets.over":       "por encima del presupuesto",
+	"budgets.within":     "dentro del presupuesto",
+	"budgets.alert":      "⚠ Presupuesto superado: %s en el %.1f%% de los comandos (máximo %.0f%%)",
+	"budgets.alert_more": "y %d más",
+
+	"tab.shells":                 "Shells",
+	"compare.title":              "⚖️  Shells lado a lado",
+	"compare.none":               "No se encontró historial de shell",
+	"compare.commands":           "Comandos",
+	"compare.recent":             "Últimos 90 días",
+	"compare.last":               "Último uso",
+	"compare.top":                "Más usados",
+	"compare.aliases":            "Alias",
+	"compare.plugins":            "Plugins",
+	"compare.config":             "Configuración",
+	"compare.config_size":        "%d archivos, %d líneas",
+	"compare.only":               "%s es la única shell con historial.",
+	"compare.recommended_recent": "%s es la que más usas de verdad: ejecutó más comandos en los últimos %d días de actividad.",
+	"compare.recommended_total":  "%s es la que más usas de verdad: ha ejecutado más comandos.",
+
+	"tab.trends":          "Tendencias",
+	"trends.title":        "📈 Mes a mes",
+	"trends.none":         "Aún no hay instantáneas. Cada ejecución se registra, e install-service registra una cada día.",
+	"trends.loading":      "Leyendo instantáneas...",
+	"trends.volume":       "📦 Comandos añadidos:",
+	"trends.total":        "%d en el historial",
+	"trends.trimmed":      "%d en el historial (recortado)",
+	"trends.stack":        "🧰 Stack tecnológico:",
+	"trends.unchanged":    "sin cambios",
+	"trends.productivity": "⚡ Productividad:",
+	"trends.per_session":  "Comandos por sesión",
+
+	"tab.top_commands": "Comandos Top",
+	"top.title":        "🏆 Comandos más usados",
+	"top.none":         "Todavía no hay comandos registrados",
+	"top.runs":         "%d veces",
+	"top.prefixes":     "🔁 Prefijos más usados:",
+	"top.entry_points": "🎯 Puntos de entrada de proyectos:",
+
+	"drilldown.title": "🔍 Subcomandos y opciones",
+	"drilldown.none":  "Aún no hay ejecuciones de git, docker, kubectl ni herramientas similares",
+
+	"tech.title":             "💻 Perfil Técnico",
+	"tech.role":              "🎯 Rol principal: %s",
+	"tech.role_none":         "No hay suficientes datos",
+	"tech.stack":             "💻 Stack tecnológico:",
+	"tech.stack_none":        "No hay datos del stack tecnológico",
+	"tech.prompt":            "🎨 Prompt:",
+	"tech.prompt_theme":      "%s, tema %s",
+	"tech.prompt_none":       "No se encontró ningún framework de prompt; las shells usan su propio prompt",
+	"tech.prompt_installed":  "Instalado pero sin iniciar: %s",
+	"tech.skills":            "🛠️  Habilidades secundarias:",
+	"tech.skills_none":       "No hay datos de habilidades secundarias",
+	"tech.skill":             "%s: %.0f%% de confianza, %d comandos",
+	"tech.proficiency":       "📊 Nivel de dominio:",
+	"tech.proficiency_no":    "No hay datos de dominio",
+	"tech.proficiency_about": "Puntuación sobre 100: parte de tu uso de lenguajes y herramientas, con más peso para los comandos recientes",
+	"tech.level.beginner":    "Principiante",
+	"tech.level.regular":     "Uso habitual",
+	"tech.level.heavy":       "Uso intensivo",
+
+	"persona.developer": "Desarrollador/a de %s",
+
+	"skill.kubernetes":     "Kubernetes",
+	"skill.containers":     "Contenedores",
+	"skill.ci":             "CI/CD",
+	"skill.infrastructure": "Infraestructura como código",
+	"skill.cloud":          "CLIs de la nube",
+	"skill.databases":      "Bases de datos",
+	"skill.debugging":      "Depuración y trazas",
+	"skill.networking":     "Redes",
+	"skill.security":       "Seguridad",
+	"skill.data":           "Datos y notebooks",
+
+	"role.kubernetes":     "Ingeniero/a de plataforma",
+	"role.containers":     "Ingeniero/a DevOps",
+	"role.ci":             "Ingeniero/a DevOps",
+	"role.infrastructure": "Ingeniero/a de infraestructura",
+	"role.cloud":          "Ingeniero/a cloud",
+	"role.databases":      "Ingeniero/a de bases de datos",
+	"role.debugging":      "Ingeniero/a de sistemas",
+	"role.networking":     "Ingeniero/a de redes",
+	"role.security":       "Ingeniero/a de seguridad",
+	"role.data":           "Ingeniero/a de datos",
+
+	"tab.growth":                     "Crecimiento",
+	"growth.title":                   "🌱 Crecimiento",
+	"growth.none":                    "Aún no hay suficiente historial para conocer tus habilidades. Sigue usando tu shell y vuelve a por sugerencias.",
+	"growth.from":                    "A partir de: %s",
+	"growth.next":                    "🎯 Habilidades para aprender después:",
+	"growth.next_skill":              "%s, un paso natural después de %s",
+	"growth.next_none":               "Ya cubres los siguientes pasos habituales.",
+	"growth.tools":                   "🧰 Herramientas para probar:",
+	"growth.tool":                    "%s, para %s",
+	"growth.tools_none":              "Ya usas las herramientas que acompañan a tus habilidades.",
+	"growth.ai":                      "🤖 Consejos de Gemini:",
+	"growth.ai_thinking":             "Pidiendo consejo a Gemini…",
+	"growth.ai_failed":               "No se pudo obtener consejo de Gemini: %v",
+	"growth.practice.kubernetes":     "Práctica: despliega una app pequeña en un clúster local de kind con un Deployment, un Service y un health check.",
+	"growth.practice.containers":     "Práctica: conteneriza un proyecto con un Dockerfile multi-etapa y un archivo compose para su base de datos.",
+	"growth.practice.ci":             "Práctica: añade un pipeline que pase el linter, los tests y compile en cada push, y ejecútalo en local con act.",
+	"growth.practice.infrastructure": "Práctica: describe una VM o un bucket desechable en Terraform; planifica, aplica y destrúyelo.",
+	"growth.practice.cloud":          "Práctica: aloja un sitio estático o una función en un plan gratuito, todo desde la CLI.",
+	"growth.practice.databases":      "Práctica: modela un esquema pequeño, carga datos de ejemplo y lee el plan de tu consulta más lenta.",
+	"growth.practice.debugging":      "Práctica: averigua por qué un programa va lento o se cuelga con strace, perf o un depurador en lugar de prints.",
+	"growth.practice.networking":     "Práctica: sigue una petición de extremo a extremo con dig, curl -v y tcpdump y explica cada salto.",
+	"growth.practice.security":       "Práctica: analiza una de tus imágenes o repositorios en busca de vulnerabilidades y secretos filtrados, y corrige lo que encuentres.",
+	"growth.practice.data":           "Práctica: responde una pregunta sobre un CSV público con DuckDB o un notebook.",
+	"growth.practice.python":         "Práctica: escribe una CLI pequeña con funciones tipadas, tests y un pyproject gestionado con uv.",
+	"growth.practice.go":             "Práctica: crea un servicio HTTP pequeño con la biblioteca estándar, tests por tablas y un apagado ordenado.",
+	"growth.practice.rust":           "Práctica: porta uno de tus scripts a una CLI en Rust y deja contento a clippy.",
+	"growth.practice.javascript":     "Práctica: crea una API pequeña en TypeScript con tests y comprobación de tipos estricta.",
+	"growth.practice.java":           "Práctica: escribe un servicio pequeño con Gradle, tests de JUnit y una imagen de contenedor.",
+	"growth.practice.ruby":           "Práctica: escribe una gema pequeña con tests de RSpec e instálala en local.",
+	"growth.practice.php":            "Práctica: crea una app pequeña con Composer, tests de PHPUnit y análisis estático.",
+	"growth.practice.c":              "Práctica: escribe una herramienta pequeña en C con CMake y compruébala con valgrind y sanitizers.",
+
+	"work.title":               "⏰ Hábitos de Trabajo",
+	"work.daily":               "📅 Actividad diaria:",
+	"work.peak_hours":          "Horas punta: %s",
+	"work.weekly":              "🗓️  Mapa de calor semanal:",
+	"work.legend":              "Menos %s Más",
+	"work.no_timestamps":       "Todavía no hay historial con marcas de tiempo",
+	"work.hour_count":          "%02d:00: %d comandos",
+	"work.day_count":           "%s: %d comandos, más activo a las %02d:00",
+	"work.sessions":            "🧭 Sesiones:",
+	"work.session_count":       "%d sesiones (una pausa de %d minutos inicia una nueva)",
+	"work.session_average":     "Duración media: %s",
+	"work.session_longest":     "La más larga: %s, desde %s",
+	"work.session_commands":    "Comandos por sesión: %.1f",
+	"work.week":                "📆 Entre semana y fines de semana:",
+	"work.weekend_share":       "Fin de semana",
+	"work.weekdays":            "Entre semana: %d comandos en %d días",
+	"work.weekends":            "Fines de semana: %d comandos en %d días",
+	"work.day_span":            "Día medio: primer comando a las %s, último a las %s",
+	"work.late_night":          "🌙 Madrugada: %d comandos después de medianoche en %d noches, %d de ellos programando",
+	"work.latest":              "El más tardío: %s en la noche del %s",
+	"work.balance":             "⚖️  Equilibrio trabajo-vida: %s",
+	"work.balance.healthy":     "Saludable",
+	"work.balance.fair":        "Aceptable",
+	"work.balance.strained":    "Tenso",
+	"work.balance.weekends":    "fines de semana ocupados",
+	"work.balance.late":        "noches largas",
+	"work.balance.long_days":   "días largos",
+	"work.reuse":               "♻️  Reutilización del historial:",
+	"work.reuse_rate":          "Recuperados",
+	"work.reuse_breakdown":     "%d repeticiones de comandos anteriores, %d expansiones del historial (!!, !$, ^a^b), %d fc/r",
+	"work.reuse_tools":         "Herramientas de búsqueda: %s",
+	"work.reuse_no_tools":      "No se encontró ninguna herramienta como atuin o fzf en tus archivos rc",
+	"work.exploration":         "🧪 Tiempo de exploración (REPL):",
+	"work.exploration_total":   "%s explorando en intérpretes en %d sesiones, además de ejecutar comandos",
+	"work.exploration_repl":    "%s en %d sesiones",
+	"work.outcomes":            "🚦 Fallos y duraciones:",
+	"work.outcomes_counted":    "%d comandos con código de salida, %d con duración",
+	"work.failures":            "Los que más fallan:",
+	"work.failure":             "falló el %.0f%% de %d ejecuciones",
+	"work.slowest":             "Los más lentos de media:",
+	"work.slow":                "%s de media, máximo %s, %d ejecuciones",
+	"work.retried":             "Repetido justo después de fallar, %d veces:",
+	"work.rage":                "😤 Repeticiones de rabia:",
+	"work.rage_about":          "Comandos lanzados tres o más veces seguidas, cada una a menos de %d segundos de la anterior",
+	"work.rage_bursts":         "%d rachas, hasta %d seguidas",
+	"work.complexity":          "🧩 Complejidad de los comandos:",
+	"work.complexity_features": "Comandos con %s",
+	"work.complexity_pipeline": "Tubería más larga: %d programas",
+	"work.complexity_top":      "Línea más elaborada (puntuación %d):",
+	"work.productivity":        "📈 Métricas de productividad:",
+	"work.workflows":           "🔄 Flujos de trabajo frecuentes:",
+	"work.workflows_none":      "Ninguna secuencia de comandos se repite lo suficiente todavía",
+	"work.workflow_runs":       "(%d×)",
+	"work.workflow_alias":      "Un solo comando para ello: %s",
+
+	"complexity.simple":        "Simple",
+	"complexity.moderate":      "Moderada",
+	"complexity.complex":       "Compleja",
+	"complexity.elaborate":     "Elaborada",
+	"complexity.pipes":         "tuberías",
+	"complexity.redirections":  "redirecciones",
+	"complexity.substitutions": "sustituciones",
+	"complexity.subshells":     "subshells",
+	"complexity.loops":         "bucles",
+	"complexity.conditionals":  "condicionales",
+	"complexity.xargs":         "xargs",
+	"complexity.chains":        "cadenas &&/||/;",
+
+	"metric.command_variety":     "Variedad de comandos",
+	"metric.workflow_complexity": "Complejidad de flujos",
+
+	"category.development": "Desarrollo",
+	"category.system":      "Sistema",
+	"category.file":        "Archivos",
+
+	"tools.title":               "🔧 Estadísticas de herramientas",
+	"tools.editors":             "📝 Editores:",
+	"tools.editors_none":        "No hay datos de editores",
+	"tools.edited":              "✏️  Lenguajes editados y ejecutados:",
+	"tools.edited_run":          "%s: %d archivos editados, %d ejecuciones",
+	"tools.edited_only":         "%s: %d archivos editados, nunca ejecutado",
+	"tools.run_only":            "%s: %d ejecuciones, nunca editado",
+	"tools.edited_files":        "Más editados: %s",
+	"tools.languages":           "💻 Lenguajes de programación:",
+	"tools.languages_none":      "No hay datos de lenguajes",
+	"tools.build":               "🛠️  Herramientas de compilación:",
+	"tools.build_none":          "No hay datos de herramientas de compilación",
+	"tools.mux":                 "🪟 Multiplexores de terminal (tmux, screen, zellij):",
+	"tools.mux_none":            "No se usa tmux, screen ni zellij",
+	"tools.mux_program":         "%s: %d comandos",
+	"tools.mux_commands":        "Comandos de tmux más usados: %s",
+	"tools.mux_sessions":        "Sesiones por nombre: %s",
+	"tools.mux_config":          "%s: prefijo %s, %d atajos de teclado",
+	"tools.mux_root":            "%s (sin prefijo)",
+	"tools.mux_plugins":         "Plugins (tpm):",
+	"tools.direnv":              "🌱 Entornos por proyecto (direnv):",
+	"tools.direnv_project":      "%s: %d comandos de direnv o ediciones de .envrc",
+	"tools.direnv_none":         "No se encontraron proyectos con direnv",
+	"tools.direnv_exports":      "Exportado a mano %[2]d veces en %[3]s: %[1]s",
+	"tools.network":             "🌐 Hosts remotos (ssh, scp, rsync, mosh):",
+	"tools.network_host":        "%s: %d conexiones con %s",
+	"tools.network_none":        "No se encontraron hosts remotos",
+	"tools.domains":             "📥 Dominios descargados con curl y wget:",
+	"tools.domain":              "%s: %d peticiones",
+	"tools.domains_none":        "No se descargó ninguna URL",
+	"tools.packages":            "📦 Paquetes instalados (npm, pip, go, cargo, brew, apt):",
+	"tools.packages_none":       "No se instalaron paquetes por nombre",
+	"tools.package":             "%s (%s): %d instalaciones",
+	"tools.packages_new":        "🆕 Adoptados en los últimos 90 días:",
+	"tools.package_new":         "%s (%s), instalado por primera vez %s",
+	"tools.hoarder":             "Puntuación de acaparador de dependencias: %d/100, %s",
+	"tools.hoarder_about":       "%d paquetes distintos, %.1f al mes; 100 significa 20 o más al mes",
+	"tools.hoarding.minimalist": "minimalista",
+	"tools.hoarding.collector":  "coleccionista",
+	"tools.hoarding.hoarder":    "acaparador",
+	"tools.uses":                "%s: %d usos",
+
+	"tab.projects":         "Proyectos",
+	"projects.title":       "🗂️  Proyectos",
+	"projects.none":        "Aún no hay proyectos. Aquí aparecen los comandos ejecutados bajo tu home en un directorio con go.mod, package.json, Cargo.toml, .git o similar.",
+	"projects.summary":     "%d de %d comandos se ejecutaron en %d proyectos",
+	"projects.time":        "%s de tiempo en la shell",
+	"projects.last":        "último uso %s",
+	"projects.elsewhere":   "Fuera de cualquier proyecto: %d comandos",
+	"projects.kind.go":     "Go",
+	"projects.kind.rust":   "Rust",
+	"projects.kind.node":   "Node.js",
+	"projects.kind.python": "Python",
+	"projects.kind.ruby":   "Ruby",
+	"projects.kind.java":   "Java",
+	"projects.kind.php":    "PHP",
+	"projects.kind.elixir": "Elixir",
+	"projects.kind.git":    "git",
+
+	"tab.git":            "Git",
+	"git.title":          "🌿 Git",
+	"git.none":           "Todavía no hay comandos git en tu historial.",
+	"git.summary":        "%d comandos git, %d de ellos commits",
+	"git.ratios":         "Por commit:",
+	"git.per_commit":     "%.2f",
+	"git.prefers.merge":  "Prefieres merge a rebase: %d merges y pulls, %d rebases",
+	"git.prefers.rebase": "Prefieres rebase a merge: %d merges y pulls, %d rebases",
+	"git.prefers.none":   "Sin preferencia clara entre merge y rebase: %d merges y pulls, %d rebases",
+	"git.force":          "Push forzados: %d con --force, %d con --force-with-lease",
+	"git.force_tip":      "--force-with-lease no sobrescribe commits que aún no has descargado",
+	"git.stash":          "Stash: %s",
+	"git.stash_left":     "%d stashes más que pops, applies y drops; git stash list puede guardar trabajo olvidado",
+	"git.branches":       "Ramas escritas:",
+	"git.reflogs":        "Repositorios:",
+	"git.reflogs_off":    "Ejecuta con --git-reflogs para contar los commits en los reflogs de los repositorios de tus proyectos.",
+	"git.reflogs_none":   "No se encontraron reflogs en los repositorios de tus proyectos.",
+	"git.reflog":         "%d commits, %d amends, %d rebases, %d merges, %d checkouts",
+
+	"tab.containers":        "Contenedores",
+	"containers.title":      "🐳 Contenedores y Kubernetes",
+	"containers.none":       "Aún no hay comandos de docker, podman, kubectl ni helm en tu historial.",
+	"containers.docker":     "%d comandos de docker, %d de ellos con compose (%.0f%%)",
+	"containers.images":     "Imágenes más ejecutadas:",
+	"containers.kubectl":    "%d comandos de kubectl:",
+	"containers.helm":       "%d comandos de helm: %s",
+	"containers.namespaces": "Namespaces:",
+	"containers.contexts":   "%d cambios de contexto: %s",
+
+	"timeline.title":         "⏳ Cronología de comandos interesantes",
+	"timeline.unknown":       "hora desconocida",
+	"timeline.undated":       "Sin fecha",
+	"timeline.none":          "Ningún comando coincide con los filtros",
+	"timeline.page":          "Página %d de %d · Comandos: %d",
+	"timeline.pages":         "página",
+	"timeline.filters":       "Shell: %s · Categoría: %s · Tipo: %s",
+	"timeline.all":           "todas",
+	"timeline.kind.tool":     "herramientas",
+	"timeline.kind.pipeline": "tuberías",
+	"timeline.kind.typo":     "erratas",
+
+	"tab.data":            "Datos",
+	"data.title":          "🔬 Datos analizados",
+	"data.redacted":       "Redacción activada (%s): así se ven los comandos al enviarlos a la IA",
+	"data.raw":            "Redacción desactivada: valores tal como se leyeron de tus archivos, nunca se envían",
+	"data.none":           "No se encontró historial ni configuración de shell",
+	"data.entries":        "%d entradas analizadas",
+	"data.recent":         "Las %d entradas más recientes:",
+	"data.aliases":        "Alias (%d):",
+	"data.plugins":        "Plugins (%d):",
+	"data.plugin_updated": "actualizado %s",
+	"data.plugin_stale":   "⚠ sin actualizar desde %s",
+	"data.environment":    "Variables de entorno (%d):",
+	"data.toggle":         "alternar redacción",
+
+	"capability.title":                "🧭 Capacidades",
+	"capability.histories":            "Historial: %s",
+	"capability.timestamps":           "Marcas de tiempo: %s",
+	"capability.ai":                   "IA: %s",
+	"capability.ai.configured":        "configurada",
+	"capability.ai.missing":           "sin clave de API",
+	"capability.ai.disabled":          "desactivada",
+	"capability.clipboard":            "Portapapeles: %s",
+	"capability.images":               "Imágenes en línea: %s",
+	"capability.none":                 "ninguno",
+	"capability.hint.history":         "No se encontró historial de la shell. Activa el guardado del historial en tu shell para desbloquear esta pestaña.",
+	"capability.hint.timestamps":      "Activa las marcas de tiempo con `%s` en %s para desbloquear %s.",
+	"capability.hint.ai":              "Configura una clave de API de Gemini para desbloquear diapositivas escritas por la IA; estas se generan localmente.",
+	"capability.hint.chat":            "Configura una clave de API de Gemini para hacer preguntas sobre tu historial.",
+	"capability.hint.growth":          "Configura una clave de API de Gemini para recibir consejos de IA además de estas sugerencias.",
+	"capability.hint.outcomes":        "Añade los hooks del shell (init bash|zsh|fish, ver Shell Hooks en el README) para ver tasas de fallo y comandos lentos.",
+	"capability.unlock.work_patterns": "la actividad por hora y las sesiones",
+	"capability.unlock.timeline":      "la cronología",
+	"capability.unlock.achievements":  "las rachas",
+
+	"tab.diagnostics":       "Diagnóstico",
+	"problems.title":        "🩺 Diagnóstico",
+	"problems.none":         "✅ Todo se leyó sin problemas",
+	"problems.why":          "Motivo: %v",
+	"problems.fix":          "Solución: %s",
+	"problems.indicator":    "⚠️  %d problema(s) dejaron vistas incompletas, consulta %s",
+	"problems.log":          "Los detalles se registran en %s; ejecuta con --verbose o --debug para registrar más.",
+	"problem.history.path":  "No se pudo leer el historial %s",
+	"problem.history.fix":   "Asegúrate de poder leer el archivo; faltan los comandos de esa shell en todas las pestañas.",
+	"problem.config.path":   "No se pudo leer el archivo de inicio %s",
+	"problem.config.fix":    "Asegúrate de poder leer el archivo; faltan sus alias y plugins.",
+	"problem.cast.path":     "No se pudo leer la grabación %s",
+	"problem.cast.fix":      "Revisa las rutas de casts en config.yaml o --cast; solo se leen grabaciones de asciinema v2 y v3.",
+	"problem.settings.path": "No se pudieron leer los ajustes de %s",
+	"problem.settings.fix":  "Corrige el YAML o borra el archivo; mientras tanto se usan los valores por defecto.",
+	"problem.keys.path":     "Atajos ignorados en %s",
+	"problem.keys.fix":      "Usa los nombres de acción del README, en Navigation Keys.",
+	"problem.ai":            "Gemini no pudo escribir tu Wrapped",
+	"problem.ai.fix":        "Revisa la clave de API y tu conexión; mientras tanto la pestaña Wrapped muestra las diapositivas locales.",
+	"problem.api_key":       "No se pudo guardar la clave de API",
+	"problem.api_key.fix":   "Solo se usa en esta sesión; define GEMINI_API_KEY o api_key en config.yaml para conservarla.",
+	"problem.archive":       "No se pudieron archivar las diapositivas del Wrapped",
+	"problem.archive.fix":   "Asegúrate de que el directorio de datos admite escritura y tiene espacio libre.",
+	"problem.trends":        "No se pudieron guardar ni leer las tendencias",
+	"problem.trends.fix":    "Asegúrate de que el directorio de datos admite escritura y tiene espacio libre.",
+
+	"tab.security":                   "Seguridad",
+	"security.title":                 "🛡️  Auditoría de seguridad",
+	"security.none":                  "✅ No se encontraron comandos peligrosos en tu historial",
+	"security.finding":               "⚠️  %s: %d veces",
+	"security.example":               "Último: %s",
+	"security.example_at":            "Último: %s (%s)",
+	"security.secret":                "Posible secreto en la línea de comandos",
+	"security.secret.warning":        "Los tokens y contraseñas escritos en comandos se guardan en texto plano. Cámbialos y ejecuta `scrub` para borrar las entradas.",
+	"security.rm_root":               "Borrado recursivo de / o del home",
+	"security.rm_root.warning":       "A una errata de borrar el sistema o tu directorio personal. Borra rutas concretas.",
+	"security.dd_device":             "dd sobre un disco",
+	"security.dd_device.warning":     "Escribir en el dispositivo equivocado lo destruye. Comprueba of= con lsblk antes.",
+	"security.sudo_pipe":             "Descarga redirigida a sudo",
+	"security.sudo_pipe.warning":     "Ejecuta código de la red como root sin poder leerlo. Descarga, revisa y después ejecuta.",
+	"security.pipe_to_shell":         "Descarga redirigida a una shell",
+	"security.pipe_to_shell.warning": "Ejecuta lo que envíe el servidor, aunque la descarga se corte a medias. Guarda el script y léelo antes.",
+	"security.chmod_777":             "chmod 777",
+	"security.chmod_777.warning":     "Permite que cualquier usuario modifique el archivo. Da solo los permisos necesarios, p. ej. 755 o 644.",
+	"security.force_push":            "Push forzado",
+	"security.force_push.warning":    "Sobrescribe la rama remota y los commits de otros. Mejor usa --force-with-lease.",
+	"security.insecure_tls":          "Verificación TLS desactivada",
+	"security.insecure_tls.warning":  "-k/--insecure acepta cualquier certificado, así que la conexión puede ser interceptada.",
+
+	"tab.config_health":                    "Salud de la configuración",
+	"health.title":                         "🩺 Salud de la configuración",
+	"health.none":                          "No se encontraron problemas en tus archivos de inicio.",
+	"health.no_configs":                    "No se leyó ningún archivo de inicio.",
+	"health.duplicate_path":                "%s se vuelve a añadir al PATH, la primera vez en %s",
+	"health.interactive_guard":             "%s también se ejecuta en shells no interactivas, p. ej. scp o ssh host comando",
+	"health.deprecated":                    "Sintaxis obsoleta: %s",
+	"health.enormous":                      "%s líneas de código",
+	"health.unused_plugin":                 "El plugin %s está cargado, pero sus programas no aparecen nunca en tu historial",
+	"health.stale_plugin":                  "El plugin %s no se actualiza desde %s",
+	"health.syntax.backticks":              "sustitución de comandos con `...`",
+	"health.syntax.dollar_bracket":         "aritmética con $[...]",
+	"health.syntax.egrep":                  "egrep",
+	"health.syntax.fgrep":                  "fgrep",
+	"health.syntax.caret":                  "^ para redirigir stderr",
+	"health.syntax.dot":                    ". para cargar un archivo",
+	"health.fix.duplicate_path":            "Elimina la entrada repetida; el directorio ya está en el PATH",
+	"health.fix.interactive_guard.bash":    "Añade [[ $- == *i* ]] || return antes",
+	"health.fix.interactive_guard.fish":    "Envuélvelo en if status is-interactive ... end",
+	"health.fix.deprecated.backticks":      "Usa $(...), que se anida y se lee mejor",
+	"health.fix.deprecated.dollar_bracket": "Usa $((...))",
+	"health.fix.deprecated.egrep":          "Usa grep -E",
+	"health.fix.deprecated.fgrep":          "Usa grep -F",
+	"health.fix.deprecated.caret":          "Usa 2> en su lugar",
+	"health.fix.deprecated.dot":            "Usa source en su lugar",
+	"health.fix.enormous.bash":             "Divídelo en archivos por tema y cárgalos desde él",
+	"health.fix.enormous.zsh":              "Divídelo en archivos por tema y cárgalos desde él",
+	"health.fix.enormous.fish":             "Mueve partes a ~/.config/fish/conf.d y las funciones a ~/.config/fish/functions",
+	"health.fix.unused_plugin":             "Quítalo de tu lista de plugins para que la shell arranque más rápido",
+	"health.fix.stale_plugin":              "Actualízalo con su gestor de plugins o busca una alternativa mantenida",
+
+	"tab.suggestions":                   "Sugerencias",
+	"suggestions.title":                 "💡 Sugerencias",
+	"suggestions.aliases":               "⌨️  Alias que vale la pena añadir:",
+	"suggestions.alias_savings":         "%d ejecuciones, ahorra unas %.0f pulsaciones por semana",
+	"suggestions.no_aliases":            "Ningún alias ahorraría muchas pulsaciones",
+	"suggestions.apply_hint":            "%s: Añadir estos alias a %s",
+	"suggestions.reason":                "añadir alias sugeridos",
+	"suggestions.applied":               "Se añadieron %d alias a %s. Ejecuta `undo` para revertir, o abre una nueva shell para usarlos.",
+	"suggestions.apply_failed":          "No se pudieron añadir los alias: %v",
+	"suggestions.cleanup":               "🧹 Limpieza de alias:",
+	"suggestions.unused_aliases":        "%s: %d alias nunca usados: %s",
+	"suggestions.shadowing":             "%s (%s) oculta el programa del mismo nombre y ejecuta %s en su lugar",
+	"suggestions.duplicate":             "%s está definido %d veces: %s",
+	"suggestions.conflicting":           "%s está definido %d veces con comandos distintos: %s",
+	"suggestions.alternatives":          "🦀 Alternativas modernas:",
+	"suggestions.alternative_installed": "Ejecutaste %s %d veces y %s ya está instalado. Prueba %[4]s en su lugar.",
+	"suggestions.alternative_install":   "Ejecutaste %s %d veces. Considera instalar %s, un reemplazo más rápido y cómodo.",
+	"suggestions.recommendations":       "🔧 Configuración:",
+	"suggestions.few_aliases":           "Tu configuración de %s tiene pocos alias. Añade algunos para los comandos que más escribes.",
+	"suggestions.few_plugins":           "Tu configuración de %s usa pocos plugins. Autosugerencias y resaltado de sintaxis son buenas primeras opciones.",
+	"suggestions.dedupe":                "El %.0[2]f%% de tu historial de %[1]s son duplicados. Ejecuta `dedupe --apply` para dejar de guardarlos.",
+	"suggestions.scrub":                 "%d entradas del historial contienen posibles secretos. Ejecuta `scrub` para eliminarlas.",
+	"suggestions.unused_shortcuts":      "%d alias globales o directorios con nombre de zsh nunca se usan: %s. Elimínalos o empieza a usarlos.",
+	"suggestions.direnv":                "Exportaste %s a mano %d veces en %s. Ponlas en un .envrc allí y deja que direnv las cargue.",
+	"suggestions.direnv_hook":           "Usas archivos .envrc, pero ningún archivo rc ejecuta `direnv hook`, así que solo se cargan a mano.",
+	"suggestions.security":              "Se encontraron %d comandos arriesgados, mira la pestaña Seguridad.",
+	"suggestions.tips":                  "🚀 Consejos de flujo de trabajo:",
+	"suggestions.frequent_pattern":      "Escribiste \"%s\" %d veces. Un alias o función más corto ayudaría.",
+	"suggestions.workflow":              "Ejecutaste %s seguidos %d veces. Un alias los ejecuta de una vez: %s",
+	"suggestions.recall_tool":           "El %.0f%% de tus comandos repite otros anteriores. Una herramienta de búsqueda como atuin o el Ctrl-R de fzf los encuentra más rápido que desplazarse.",
+	"suggestions.typos":                 "Escribiste \"%s\" en lugar de \"%s\" %d veces. Prueba la autocorrección de la shell (setopt CORRECT en zsh) o thefuck.",
+	"suggestions.typo_fixes":            "🤦 Correcciones de erratas:",
+	"suggestions.typo_count":            "escrito %d veces",
+
+	"tab.achievements":           "Logros",
+	"achievements.title":         "🏆 Logros",
+	"achievements.streaks":       "🔥 Rachas:",
+	"achievements.current":       "Racha actual: %d días",
+	"achievements.longest":       "Racha más larga: %d días, desde %s",
+	"achievements.milestones":    "🏁 Hitos:",
+	"achievements.first":         "Primer %s el %s",
+	"achievements.nth":           "%s n.º %d el %s",
+	"achievements.no_milestones": "Todavía no hay hitos",
+	"achievements.badges":        "🏅 Insignias:",
+	"achievements.earned":        "✅ %s — %s",
+	"achievements.locked":        "🔒 %s (bloqueada) — %s",
+
+	"badge.night_owl":            "Búho nocturno",
+	"badge.night_owl.desc":       "una quinta parte de tus comandos entre medianoche y las 5",
+	"badge.early_bird":           "Madrugador",
+	"badge.early_bird.desc":      "una quinta parte de tus comandos entre las 5 y las 9",
+	"badge.weekend_warrior":      "Guerrero de fin de semana",
+	"badge.weekend_warrior.desc": "una cuarta parte de tus comandos en fin de semana",
+	"badge.pipe_wizard":          "Mago de las tuberías",
+	"badge.pipe_wizard.desc":     "50 comandos que encadenan tres o más programas",
+	"badge.marathoner":           "Maratonista",
+	"badge.marathoner.desc":      "una sesión de trabajo de tres horas o más",
+	"badge.streaker":             "Constante",
+	"badge.streaker.desc":        "30 días seguidos de actividad",
+	"badge.shell_hopper":         "Saltashells",
+	"badge.shell_hopper.desc":    "cambiaste de shell principal al menos una vez",
+
+	"search.title":       "🔎 Buscar en el historial",
+	"search.placeholder": "escribe para buscar, p. ej. docker shell:zsh since:2024-01",
+	"search.filters":     "Filtros: shell:zsh cat:development since:2024-01 until:2024-06-30",
+	"search.matches":     "Coincidencias: %d",
+	"search.none":        "Ningún comando coincide",
+	"search.help":        "↑/↓ RePág/AvPág: Desplazar • Esc: Cerrar",
+
+	"tab.chat":         "Chat",
+	"chat.title":       "💬 Pregunta sobre tu historial",
+	"chat.placeholder": "p. ej. ¿qué flags de kubectl uso más?",
+	"chat.empty":       "Pulsa Enter para hacer una pregunta. Responde la IA, a partir de un resumen y los comandos relevantes para la pregunta, con los secretos ocultos.",
+	"chat.thinking":    "Pensando…",
+	"chat.failed":      "Sin respuesta: %v",
+	"chat.more":        "↓ %d líneas más",
+	"chat.help":        "Enter: Preguntar • RePág/AvPág: Desplazar • Esc: Terminar",
+
+	"wrapped.generating": "Generando la vista Wrapped...",
+	"wrapped.slide":      "📺 Diapositiva %d/%d",
+	"wrapped.quotes":     "📜 Citas",
+
+	"wrapped.top.title":       "Tus comandos favoritos",
+	"wrapped.top.description": "De %d comandos, %s fue al que más recurriste.",
+	"wrapped.top.quote":       "#%d %s — %d veces",
+
+	"wrapped.streak.title":       "Tu racha más larga",
+	"wrapped.streak.description": "Abriste una shell %d días seguidos a partir del %s, y estuviste activo %d días en total.",
+	"wrapped.streak.quote":       "La constancia es la verdadera habilidad 10x.",
+
+	"wrapped.busiest.title":       "Tu día más intenso",
+	"wrapped.busiest.description": "El %s ejecutaste %d comandos. Pasara lo que pasara ese día, tu teclado lo recuerda.",
+
+	"wrapped.typos.title":       "Premio a los dedos torpes",
+	"wrapped.typos.description": "Tu errata favorita fue '%s'. Nadie es perfecto.",
+	"wrapped.typos.quote":       "%s → %s (%d veces)",
+
+	"wrapped.quiet.title":       "Un año tranquilo",
+	"wrapped.quiet.description": "No hay suficiente historial para crear tu Wrapped. ¡Ejecuta algunos comandos y vuelve!",
+
+	"wrapped.roast.top.title":         "Animal de costumbres",
+	"wrapped.roast.top.description":   "%[1]d comandos, y %[3]d de ellos fueron %[2]s. A estas alturas es menos una herramienta que una personalidad.",
+	"wrapped.roast.typos.title":       "Teclado opcional",
+	"wrapped.roast.typos.description": "Escribiste '%s' queriendo decir '%s' %d veces. El autocorrector se rindió contigo.",
+	"wrapped.roast.late.title":        "Búho nocturno, supuestamente",
+	"wrapped.roast.late.description":  "%d comandos entre medianoche y las 5 en %d noches, el último a las %s. Lo que fuera que se rompió podía esperar.",
+	"wrapped.roast.run.title":         "¿Ya llegamos?",
+	"wrapped.roast.run.description":   "Ejecutaste esto %d veces seguidas. Spoiler: la salida no cambió.",
+	"wrapped.roast.quiet.title":       "Nada que criticar",
+	"wrapped.roast.quiet.description": "Apenas hay historial. O eres muy eficiente o usas una interfaz gráfica, y los dos sabemos cuál.",
+
+	"wrapped.journey.title":       "Tu recorrido por las shells",
+	"wrapped.journey.description": "Tu shell principal a lo largo del tiempo: %s",
+	"wrapped.journey.none":        "Dejaste %s por %s en %s y viajaste ligero: no había alias que mover.",
+	"wrapped.journey.all":         "Pasaste de %s a %s en %s y te llevaste los %d alias.",
+	"wrapped.journey.some":        "Pasaste de %s a %s en %s, llevándote %d de %d alias.",
+
+	"wrapped.forecast.title":       "Mirando al futuro",
+	"wrapped.forecast.description": "A este ritmo llegarás a %d comandos %s en %s.",
+	"wrapped.forecast.quote":       "%s: unos %.0f por semana, %d en %s",
+
+	"wrapped.elaborate.title":       "La línea del año",
+	"wrapped.elaborate.description": "Tu comando más elaborado sumó %d puntos usando %s.",
+
+	"wrapped.retry.title":       "Si no sale, otra vez",
+	"wrapped.retry.description": "Este falló y lo volviste a lanzar al momento — %d veces. La perseverancia es una virtud.",
+
+	"wrapped.rage.title":             "Tu némesis",
+	"wrapped.rage.description":       "Insististe con este %d veces, hasta %d ejecuciones seguidas en segundos. No se rindió fácilmente.",
+	"wrapped.containers.title":       "Capitán de los contenedores",
+	"wrapped.containers.description": "%d comandos de contenedores y Kubernetes esta vez, y una imagen a la que siempre volvías.",
+
+	"wrapped.year.title":             "Tu %d en la shell",
+	"wrapped.year.description":       "%d comandos en %d días activos. Así fue el año, mes a mes.",
+	"wrapped.year.quiet":             "No hay historial de %d que repasar.",
+	"wrapped.year.outro.title":       "Nos vemos en %d",
+	"wrapped.year.outro.description": "Y así termina %d. Por menos erratas el año que viene.",
+	"wrapped.month.description":      "%d comandos en %d días con %d programas distintos. %s fue el protagonista con %d usos.",
+	"wrapped.month.busiest":          "Tu mes más intenso del año.",
+	"wrapped.new.title":              "Herramientas nuevas",
+	"wrapped.new.description":        "Este año aprendiste %d herramientas nuevas, sobre todo %s.",
+	"wrapped.new.quote":              "%s — %d veces",
+	"wrapped.pause":                  "pausar",
+	"wrapped.paused":                 "⏸ en pausa",
+	"wrapped.year.invalid":           "--year %d no es un año con historial que repasar",
+	"wrapped.rate":                   "¿Qué te pareció esta diapositiva? %s",
+	"wrapped.rated":                  "★ Valoraste esta diapositiva con %d/5",
+	"wrapped.rate_failed":            "No se pudo guardar la valoración: %v",
+	"wrapped.decks":                  "🗂 Resúmenes:",
+	"wrapped.deck.current":           "Esta ejecución",
+	"wrapped.deck.year":              "Resumen de %s",
+
+	"tab.settings":         "Ajustes",
+	"settings.title":       "⚙️  Ajustes",
+	"settings.ai":          "Resumen con IA",
+	"settings.roast":       "Modo burla para Wrapped",
+	"settings.on":          "activado",
+	"settings.off":         "desactivado",
+	"settings.theme":       "Tema",
+	"settings.redaction":   "Ocultación antes de la IA",
+	"settings.shell":       "Analizar historial de %s",
+	"settings.saved_to":    "Los cambios se guardan en %s",
+	"settings.saved":       "Guardado.",
+	"settings.save_failed": "No se pudieron guardar los ajustes: %v",
+	"settings.last_shell":  "Al menos una shell debe seguir activada.",
+	"redaction.strict":     "estricta (secretos, IPs, rutas personales)",
+	"redaction.secrets":    "solo secretos",
+	"settings.period":      "Periodo (esta sesión)",
+	"period.all":           "todo",
+	"period.30d":           "últimos 30 días",
+	"period.90d":           "últimos 90 días",
+	"period.this_year":     "este año",
+	"period.last_year":     "el año pasado",
+	"period.since":         "desde %s",
+	"period.until":         "hasta %s",
+	"period.range":         "del %s al %s",
+
+	"simulate.none":   "No hay propuestas de alias que simular.",
+	"simulate.header": "ALIAS\tEXPANSIÓN\tCOINCIDENCIAS\tPULSACIONES AHORRADAS\tENTRADAS/SEMANA\tPULSACIONES/SEMANA",
+
+	"undo.none":     "No hay nada que deshacer.",
+	"undo.header":   "CAMBIO\tARCHIVO\tMOTIVO",
+	"undo.restored": "Se restauró %s a su versión anterior al %s (%s).",
+	"undo.removed":  "Se eliminó %s, creado para: %s.",
+
+	"config.written": "Se escribieron los ajustes por defecto en %s; descomenta lo que quieras cambiar.",
+	"config.exists":  "%s ya existe; usa --force para reemplazarlo.",
+
+	"daemon.listening": "Recogiendo los comandos de los hooks del shell desde %s en %s, Ctrl+C para parar",
+
+	"migrate.title":       "Mudanza de %s a %s",
+	"migrate.aliases":     "Alias",
+	"migrate.functions":   "Funciones",
+	"migrate.environment": "Variables de entorno",
+	"migrate.port":        "por portar",
+	"migrate.review":      "por portar, revisa la traducción",
+	"migrate.defined":     "ya definido",
+	"migrate.same":        "--from y --to son ambos %s, no hay nada que migrar",
+	"migrate.none":        "No se encontraron alias, funciones ni variables de entorno en los archivos rc de %s.",
+	"migrate.summary":     "Quedan %d de %d definiciones por portar a %s.",
+	"migrate.starter":     "Configuración inicial de %s, para añadir a %s o cargar desde él:",
+	"migrate.written":     "Se escribió la configuración inicial en %s. Cárgala desde %s o copia lo que necesites.",
+
+	"dotfiles.none":            "No se encontraron archivos de inicio de la shell que exportar.",
+	"dotfiles.exists":          "%s ya existe y no está vacío; elige otro --output o añade --force.",
+	"dotfiles.shell":           "%s: %d archivos, %d alias, %d variables de entorno, %d plugins",
+	"dotfiles.redacted":        "Se ocultaron %d líneas que parecían secretos; revísalas antes de hacer commit.",
+	"dotfiles.written_dir":     "Dotfiles escritos en %[1]s. Vuelve a enlazarlos en su sitio con `stow -d %[1]s -t ~ %[2]s`.",
+	"dotfiles.written_archive": "Dotfiles escritos en %s.",
+
+	"dedupe.none":    "No hay historial de bash ni zsh que revisar; fish nunca guarda duplicados.",
+	"dedupe.header":  "SHELL\tENTRADAS\tDUPLICADOS\tPROPORCIÓN\tREPETIDOS SEGUIDOS",
+	"dedupe.enabled": "%s ya descarta los duplicados.",
+	"dedupe.clean":   "%s no tiene entradas duplicadas.",
+	"dedupe.suggest": "%s: %d entradas son duplicados. Añade esto a %s o vuelve a ejecutar con --apply:",
+	"dedupe.reason":  "omitir entradas duplicadas del historial",
+	"dedupe.applied": "%s actualizado. Los nuevos duplicados no se guardarán; ejecuta `undo` para revertirlo.",
+
+	"scrub.none":           "No se encontraron posibles secretos en tu historial.",
+	"scrub.found":          "%d entradas con posibles secretos en %s:",
+	"scrub.confirm":        "¿Eliminar estas %d entradas de %s? [s/N]",
+	"scrub.yes":            "s",
+	"scrub.skipped":        "%s no se ha modificado.",
+	"scrub.removed":        "Se eliminaron %d entradas de %s. No se guardó ninguna copia, así que `undo` no puede restaurarlas.",
+	"scrub.running_shells": "Las shells abiertas guardan el historial en memoria y pueden volver a escribirlo al salir; reinícialas y cambia cualquier secreto expuesto.",
+
+	"snapshot.saved": "Instantánea %s guardada (%d comandos) en %s",
+
+	"diff.title":          "🔀 %s → %s",
+	"diff.commands":       "Comandos: %d → %d",
+	"diff.commands_since": "Comandos: %d, después %d más",
+	"diff.adopted":        "🆕 Adoptados:",
+	"diff.abandoned":      "💤 Abandonados:",
+	"diff.tech":           "🧰 Stack tecnológico:",
+	"diff.peak":           "🕐 Horas pico:",
+	"diff.proficiency":    "📊 Dominio:",
+	"diff.need_snapshots": "se necesitan %d instantáneas para comparar y hay %d; ejecuta `snapshot` o indica dos periodos, p. ej. compare 2024-Q1 2024-Q2",
+
+	"report.title":           "🐚 Resumen semanal de la shell",
+	"report.period":          "%s – %s",
+	"report.commands":        "⌨️ Comandos",
+	"report.change":          "(%+.0f%% frente a la semana anterior)",
+	"report.active_days":     "📅 Días activos",
+	"report.days_of_week":    "%d de 7",
+	"report.streak":          "🔥 Racha",
+	"report.streak_days":     "%d días",
+	"report.top":             "🏆 Herramientas top",
+	"report.new":             "🆕 Nuevas esta semana",
+	"report.more":            "y %d más",
+	"report.busiest":         "📈 Día más activo",
+	"report.busiest_day":     "%s, %d comandos",
+	"report.late_night":      "🦉 Después de medianoche",
+	"report.late_night_runs": "%d comandos",
+	"report.next_skill":      "🌱 Para aprender después",
+	"report.footer":          "Compartido con k8au-shell-analyzer · solo nombres de programas, nunca argumentos ni rutas",
+	"report.posted":          "Resumen semanal publicado en %s",
+	"report.no_webhook":      "No hay webhook configurado; define report.slack_webhook o report.discord_webhook en %s",
+
+	"export.none":       "Aún no hay instantáneas; ejecuta `snapshot` primero.",
+	"export.written":    "Instantánea %s exportada a %s",
+	"export.unsigned":   "La exportación no está firmada; define K8AU_SIGNING_KEY o signing_key en el archivo de configuración para firmarla.",
+	"import.saved":      "Instantánea %s (esquema %d) importada en %s",
+	"import.unverified": "Aviso: la exportación está firmada pero no hay clave de firma, así que la firma no se comprobó.",
+
+	"team.title":    "👥 Equipo de %d",
+	"team.commands": "%d comandos entre todos",
+	"team.tools":    "🧰 Herramientas compartidas:",
+	"team.members":  "%d de %d",
+	"team.peak":     "Horas pico del equipo: %s",
+	"team.stack":    "🧱 Reparto del stack tecnológico:",
+	"team.roles":    "🎭 Roles:",
+	"team.none":     "Nada compartido por dos miembros o más",
+	"team.hidden":   "Se omiten %d herramientas, tecnologías o roles de un solo miembro para que nadie pueda ser identificado.",
+	"team.small":    "Aviso: con solo %d miembros, las estadísticas compartidas aún podrían señalar a alguien.",
+
+	"serve.listening":   "Sirviendo el panel en %s, Ctrl+C para parar",
+	"serve.metrics":     "Sirviendo métricas de Prometheus en %s",
+	"serve.exposed":     "Aviso: %s es accesible desde otras máquinas, y el panel muestra tu historial a quien lo abra.",
+	"serve.title":       "K8au Shell Analyzer",
+	"serve.since":       "Desde",
+	"serve.until":       "Hasta",
+	"serve.shell":       "Shell",
+	"serve.all_shells":  "Todas las shells",
+	"serve.apply":       "Aplicar",
+	"serve.copy":        "Copiar enlace",
+	"serve.copied":      "Enlace copiado",
+	"serve.loading":     "Analizando…",
+	"serve.empty":       "Nada todavía",
+	"serve.commands":    "Comandos",
+	"serve.total":       "comandos analizados",
+	"serve.top":         "Comandos más usados",
+	"serve.hours":       "Comandos por hora",
+	"serve.week":        "La semana de un vistazo",
+	"serve.stack":       "Stack tecnológico",
+	"serve.proficiency": "Dominio",
+
+	"service.wrote":         "Escrito %s",
+	"service.installed":     "Se guardará una instantánea cada día.",
+	"service.removed":       "Eliminado %s",
+	"service.not_installed": "La instantánea diaria no está instalada.",
+}
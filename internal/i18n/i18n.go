@@ -0,0 +1,141 @@
+// internal/i18n/i18n.go
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog maps message IDs to translated format strings
+type Catalog map[string]string
+
+// DefaultLanguage is used for any message missing from the active catalog
+const DefaultLanguage = "en"
+
+var catalogs = map[string]Catalog{
+	"en": english,
+	"es": spanish,
+	"ja": japanese,
+}
+
+var current = DefaultLanguage
+
+// Setup picks the language to use, in order: the explicit choice (usually
+// --lang), `language` in config.yaml, then LC_ALL, LC_MESSAGES and LANG.
+// User catalogs from ~/.config/k8au/messages/<lang>.yaml are merged over
+// the built-in ones.
+func Setup(lang string) error {
+	err := loadUserCatalogs(filepath.Join(config.Dir(), "messages"))
+	if lang == "" {
+		if cfg, cfgErr := config.Load(); cfgErr == nil {
+			lang = cfg.Language
+		}
+	}
+	if lang == "" {
+		lang = detect()
+	}
+	SetLanguage(lang)
+	return err
+}
+
+// SetLanguage switches the active catalog. Unknown languages fall back to
+// English, and regional variants such as "es_MX" use the base language.
+func SetLanguage(lang string) {
+	lang = normalize(lang)
+	if _, ok := catalogs[lang]; ok {
+		current = lang
+		return
+	}
+	if base, _, ok := strings.Cut(lang, "-"); ok {
+		if _, ok := catalogs[base]; ok {
+			current = base
+			return
+		}
+	}
+	current = DefaultLanguage
+}
+
+// Language returns the active language code
+func Language() string {
+	return current
+}
+
+// Languages returns the codes of all available catalogs
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	return langs
+}
+
+// T returns the message for id in the active language, formatted with args.
+// Missing translations fall back to English, then to the ID itself.
+func T(id string, args ...interface{}) string {
+	format, ok := catalogs[current][id]
+	if !ok {
+		format, ok = catalogs[DefaultLanguage][id]
+	}
+	if !ok {
+		format = id
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// detect reads the language from the usual POSIX locale variables
+func detect() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" && value != "C" && value != "POSIX" {
+			return value
+		}
+	}
+	return DefaultLanguage
+}
+
+// normalize turns locale strings like "es_MX.UTF-8" into "es-mx"
+func normalize(lang string) string {
+	lang, _, _ = strings.Cut(lang, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
+
+// loadUserCatalogs merges every <lang>.yaml in dir into the catalogs, so
+// users can fix translations or add languages without recompiling
+func loadUserCatalogs(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read message catalog: %v", err))
+			continue
+		}
+		var messages Catalog
+		if err := yaml.Unmarshal(content, &messages); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse %s: %v", file, err))
+			continue
+		}
+		lang := normalize(strings.TrimSuffix(filepath.Base(file), ".yaml"))
+		merged := make(Catalog)
+		for id, message := range catalogs[lang] {
+			merged[id] = message
+		}
+		for id, message := range messages {
+			merged[id] = message
+		}
+		catalogs[lang] = merged
+	}
+	return errors.Join(errs...)
+}
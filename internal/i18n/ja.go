@@ -0,0 +1,809 @@
+// internal/i18n/ja.go
+package i18n
+
+var japanese = Catalog{
+	"date.long":  "2006年1月2日",
+	"date.day":   "2006年1月2日",
+	"date.month": "2006年1月",
+
+	"weekday.0":       "日曜日",
+	"weekday.1":       "月曜日",
+	"weekday.2":       "火曜日",
+	"weekday.3":       "水曜日",
+	"weekday.4":       "木曜日",
+	"weekday.5":       "金曜日",
+	"weekday.6":       "土曜日",
+	"weekday.short.0": "日",
+	"weekday.short.1": "月",
+	"weekday.short.2": "火",
+	"weekday.short.3": "水",
+	"weekday.short.4": "木",
+	"weekday.short.5": "金",
+	"weekday.short.6": "土",
+
+	"tab.overview":      "概要",
+	"tab.tech_profile":  "技術プロフィール",
+	"tab.work_patterns": "作業パターン",
+	"tab.tool_usage":    "ツール使用状況",
+	"tab.wrapped":       "まとめ",
+	"tab.timeline":      "タイムライン",
+	"app.credit":        "By Ksauraj",
+	"app.loading":       "シェル履歴を分析しています... 🔍",
+
+	"progress.history":  "%s の履歴を読み込んでいます",
+	"progress.probe":    "インストール済みのツールを確認しています",
+	"progress.insights": "統計を計算しています",
+	"progress.ai":       "Gemini でまとめを作成しています",
+	"progress.local":    "まとめを作成しています",
+
+	"edit.hint":   "%s: %s を編集",
+	"edit.failed": "エディタを起動できませんでした: %v（$EDITOR を設定してください）",
+
+	"background.newer": "%s のバックグラウンド実行で新しいデータが見つかりました • %s: 再読み込み",
+
+	"watch.live": "● ライブ（%s に更新）",
+
+	"keys.next_tab":        "次のタブ",
+	"keys.prev_tab":        "前のタブ",
+	"keys.next_slide":      "次のスライド",
+	"keys.prev_slide":      "前のスライド",
+	"keys.up":              "上へ",
+	"keys.down":            "下へ",
+	"keys.select":          "設定を変更",
+	"keys.rate":            "スライドを評価",
+	"keys.search":          "履歴を検索",
+	"keys.filter_shell":    "タイムラインをシェルで絞り込む",
+	"keys.filter_category": "タイムラインをカテゴリで絞り込む",
+	"keys.filter_kind":     "タイムラインを種類で絞り込む",
+	"keys.edit":            "rc ファイルを編集",
+	"keys.apply":           "提案エイリアスを追加",
+	"keys.reload":          "再読み込み",
+	"keys.help":            "ヘルプを表示",
+	"keys.quit":            "終了",
+	"help.title":           "⌨️  キー操作",
+	"help.close":           "いずれかのキーで閉じます。キーの割り当ては %s の keys: で変更できます",
+
+	"linear.loading": "シェル履歴を分析しています...",
+	"linear.prompt":  "セクション番号、全部表示は a、終了は q を入力してください:",
+	"linear.invalid": "%q はセクション番号ではありません。",
+
+	"wizard.title":   "🔑 Gemini API キー",
+	"wizard.body":    "API キーが見つかりません。AI によるまとめ表示に必要です。\nそれ以外の機能はオフラインで動作します。",
+	"wizard.storage": "キーは OS のキーリングに保存されます。\n利用できない場合は %s に保存されます。",
+	"wizard.help":    "Enter: 保存 • Esc: スキップ（オフラインのまとめ）",
+	"wizard.input":   "Gemini API キーを貼り付けてください",
+
+	"overview.title":             "📊 シェル使用状況の概要",
+	"overview.shell":             "シェル: %s",
+	"overview.commands":          "コマンド数: %d",
+	"overview.configuration":     "設定:",
+	"overview.aliases":           "エイリアス: %d",
+	"overview.plugins":           "プラグイン: %d",
+	"overview.env":               "環境変数: %d",
+	"overview.plugin_list":       "インストール済みプラグイン:",
+	"overview.plugin_from":       "%s（%s）",
+	"overview.more":              "ほか %d 件...",
+	"overview.alias_list":        "エイリアスの例:",
+	"overview.global_aliases":    "グローバルエイリアス: %d",
+	"overview.named_dirs":        "名前付きディレクトリ: %d",
+	"overview.global_alias_list": "グローバルエイリアス:",
+	"overview.named_dir_list":    "名前付きディレクトリ:",
+	"overview.abbreviations":     "略語 (abbr): %d",
+	"overview.abbreviation_list": "略語 (abbr):",
+	"overview.shortcut":          "%s → %s（%d 回使用）",
+
+	"journey.title":      "🔀 シェルの移り変わり",
+	"journey.period":     "%s: %s → %s（%d コマンド）",
+	"journey.switch":     "%s → %s（%s）",
+	"journey.no_aliases": "移行するエイリアスはありません",
+	"journey.carried":    "%d/%d 個のエイリアスを移行",
+	"journey.left":       "移行されなかったもの: %s",
+
+	"budgets.title":      "🎯 バジェット",
+	"budgets.over":       "超過",
+	"budgets.within":     "範囲内",
+	"budgets.alert":      "⚠ バジェット超過: %s がコマンドの %.1f%%（上限 %.0f%%）",
+	"budgets.alert_more": "ほか %d 件",
+
+	"tab.shells":                 "シェル比較",
+	"compare.title":              "⚖️  シェルの比較",
+	"compare.none":               "シェルの履歴が見つかりませんでした",
+	"compare.commands":           "コマンド数",
+	"compare.recent":             "直近 90 日",
+	"compare.last":               "最終使用",
+	"compare.top":                "よく使うコマンド",
+	"compare.aliases":            "エイリアス",
+	"compare.plugins":            "プラグイン",
+	"compare.config":             "設定",
+	"compare.config_size":        "%d ファイル、%d 行",
+	"compare.only":               "履歴があるシェルは %s だけです。",
+	"compare.recommended_recent": "最もよく使われているのは %[1]s です。活動の直近 %[2]d 日間で最も多くのコマンドを実行しました。",
+	"compare.recommended_total":  "最もよく使われているのは %s です。最も多くのコマンドを実行しています。",
+
+	"tab.trends":          "推移",
+	"trends.title":        "📈 月ごとの推移",
+	"trends.none":         "スナップショットはまだありません。実行のたびに記録され、install-service を使うと毎日記録されます。",
+	"trends.loading":      "スナップショットを読み込んでいます...",
+	"trends.volume":       "📦 追加されたコマンド:",
+	"trends.total":        "履歴に %d 件",
+	"trends.trimmed":      "履歴に %d 件 (切り詰め)",
+	"trends.stack":        "🧰 技術スタック:",
+	"trends.unchanged":    "変化なし",
+	"trends.productivity": "⚡ 生産性:",
+	"trends.per_session":  "セッションあたりのコマンド数",
+
+	"tab.top_commands": "トップコマンド",
+	"top.title":        "🏆 よく使うコマンド",
+	"top.none":         "まだコマンドの記録がありません",
+	"top.runs":         "%d 回",
+	"top.prefixes":     "🔁 よく使うコマンドの組み合わせ:",
+	"top.entry_points": "🎯 プロジェクトのエントリポイント:",
+
+	"drilldown.title": "🔍 サブコマンドとフラグ",
+	"drilldown.none":  "git、docker、kubectl などの実行はまだありません",
+
+	"tech.title":             "💻 技術プロフィール",
+	"tech.role":              "🎯 主な役割: %s",
+	"tech.role_none":         "データが不足しています",
+	"tech.stack":             "💻 技術スタック:",
+	"tech.stack_none":        "技術スタックのデータがありません",
+	"tech.prompt":            "🎨 プロンプト:",
+	"tech.prompt_theme":      "%s（テーマ %s）",
+	"tech.prompt_none":       "プロンプトフレームワークは見つかりません。シェル標準のプロンプトを使用しています",
+	"tech.prompt_installed":  "インストール済みだが未使用: %s",
+	"tech.skills":            "🛠️  サブスキル:",
+	"tech.skills_none":       "サブスキルのデータがありません",
+	"tech.skill":             "%s: 確信度 %.0f%%、%d コマンド",
+	"tech.proficiency":       "📊 習熟度:",
+	"tech.proficiency_no":    "習熟度のデータがありません",
+	"tech.proficiency_about": "100 点満点: 言語とツールの利用に占める割合（最近のコマンドほど重視）",
+	"tech.level.beginner":    "初心者",
+	"tech.level.regular":     "常用",
+	"tech.level.heavy":       "ヘビーユーザー",
+
+	"persona.developer": "%s 開発者",
+
+	"skill.kubernetes":     "Kubernetes",
+	"skill.containers":     "コンテナ",
+	"skill.ci":             "CI/CD",
+	"skill.infrastructure": "Infrastructure as Code",
+	"skill.cloud":          "クラウド CLI",
+	"skill.databases":      "データベース",
+	"skill.debugging":      "デバッグとトレース",
+	"skill.networking":     "ネットワーク",
+	"skill.security":       "セキュリティ",
+	"skill.data":           "データとノートブック",
+
+	"role.kubernetes":     "プラットフォームエンジニア",
+	"role.containers":     "DevOps エンジニア",
+	"role.ci":             "DevOps エンジニア",
+	"role.infrastructure": "インフラエンジニア",
+	"role.cloud":          "クラウドエンジニア",
+	"role.databases":      "データベースエンジニア",
+	"role.debugging":      "システムエンジニア",
+	"role.networking":     "ネットワークエンジニア",
+	"role.security":       "セキュリティエンジニア",
+	"role.data":           "データエンジニア",
+
+	"tab.growth":                     "成長",
+	"growth.title":                   "🌱 成長",
+	"growth.none":                    "スキルを判断できるほどの履歴がまだありません。シェルを使い続けてから、また提案を見に来てください。",
+	"growth.from":                    "現在のスキル: %s",
+	"growth.next":                    "🎯 次に学ぶスキル:",
+	"growth.next_skill":              "%s（%s の自然な次の一歩）",
+	"growth.next_none":               "よくある次のステップはすでにカバーしています。",
+	"growth.tools":                   "🧰 試してみたいツール:",
+	"growth.tool":                    "%s（%s 向け）",
+	"growth.tools_none":              "スキルに合うツールはすでに使っています。",
+	"growth.ai":                      "🤖 Gemini からのアドバイス:",
+	"growth.ai_thinking":             "Gemini にアドバイスを求めています…",
+	"growth.ai_failed":               "Gemini からアドバイスを取得できませんでした: %v",
+	"growth.practice.kubernetes":     "練習: ローカルの kind クラスタに Deployment、Service、ヘルスチェック付きの小さなアプリをデプロイする。",
+	"growth.practice.containers":     "練習: マルチステージの Dockerfile と、データベース用の compose ファイルでプロジェクトをコンテナ化する。",
+	"growth.practice.ci":             "練習: push のたびに lint・テスト・ビルドを行うパイプラインを追加し、act でローカル実行する。",
+	"growth.practice.infrastructure": "練習: 使い捨ての VM かバケットを Terraform で記述し、plan・apply・destroy する。",
+	"growth.practice.cloud":          "練習: 静的サイトか関数を無料枠で、すべて CLI からホストする。",
+	"growth.practice.databases":      "練習: 小さなスキーマを設計してサンプルデータを入れ、最も遅いクエリの実行計画を読む。",
+	"growth.practice.debugging":      "練習: print 文の代わりに strace、perf、デバッガでプログラムが遅い・止まる原因を探す。",
+	"growth.practice.networking":     "練習: dig、curl -v、tcpdump でリクエストを端から端まで追い、各ホップを説明する。",
+	"growth.practice.security":       "練習: 自分のイメージやリポジトリの既知の脆弱性と漏れたシークレットをスキャンし、見つけたものを直す。",
+	"growth.practice.data":           "練習: 公開 CSV データについての問いに DuckDB かノートブックで答える。",
+	"growth.practice.python":         "練習: 型付きの関数とテストを備え、uv で管理する pyproject の小さな CLI を書く。",
+	"growth.practice.go":             "練習: 標準ライブラリ、テーブル駆動テスト、グレースフルシャットダウンで小さな HTTP サービスを作る。",
+	"growth.practice.rust":           "練習: スクリプトをひとつ Rust の CLI に移植し、clippy の指摘をなくす。",
+	"growth.practice.javascript":     "練習: テストと厳格な型チェック付きの小さな TypeScript API を作る。",
+	"growth.practice.java":           "練習: Gradle、JUnit テスト、コンテナイメージ付きの小さなサービスを書く。",
+	"growth.practice.ruby":           "練習: RSpec テスト付きの小さな gem を書き、ローカルにインストールする。",
+	"growth.practice.php":            "練習: Composer、PHPUnit テスト、静的解析付きの小さなアプリを作る。",
+	"growth.practice.c":              "練習: CMake でビルドする小さな C ツールを書き、valgrind とサニタイザで確認する。",
+
+	"work.title":               "⏰ 作業パターン",
+	"work.daily":               "📅 1日の活動:",
+	"work.peak_hours":          "ピーク時間: %s",
+	"work.weekly":              "🗓️  週間ヒートマップ:",
+	"work.legend":              "少 %s 多",
+	"work.no_timestamps":       "タイムスタンプ付きの履歴はまだありません",
+	"work.hour_count":          "%02d:00: %d コマンド",
+	"work.day_count":           "%s: %d コマンド、最も多いのは %02d:00",
+	"work.sessions":            "🧭 セッション:",
+	"work.session_count":       "%d セッション（%d 分の休止で新しいセッション）",
+	"work.session_average":     "平均の長さ: %s",
+	"work.session_longest":     "最長: %s（%s 開始）",
+	"work.session_commands":    "セッションあたりのコマンド数: %.1f",
+	"work.week":                "📆 平日と週末:",
+	"work.weekend_share":       "週末の割合",
+	"work.weekdays":            "平日: %[2]d 日で %[1]d コマンド",
+	"work.weekends":            "週末: %[2]d 日で %[1]d コマンド",
+	"work.day_span":            "平均的な 1 日: 最初のコマンド %s、最後 %s",
+	"work.late_night":          "🌙 深夜: %[2]d 晩で午前 0 時以降に %[1]d コマンド（うち開発 %[3]d）",
+	"work.latest":              "最も遅い時刻: %[2]s の夜の %[1]s",
+	"work.balance":             "⚖️  ワークライフバランス: %s",
+	"work.balance.healthy":     "良好",
+	"work.balance.fair":        "まずまず",
+	"work.balance.strained":    "要注意",
+	"work.balance.weekends":    "週末も忙しい",
+	"work.balance.late":        "夜更かし",
+	"work.balance.long_days":   "長い 1 日",
+	"work.reuse":               "♻️  履歴の再利用:",
+	"work.reuse_rate":          "呼び出し",
+	"work.reuse_breakdown":     "以前のコマンドの繰り返し %d 回、履歴展開 (!!, !$, ^a^b) %d 回、fc/r %d 回",
+	"work.reuse_tools":         "履歴検索ツール: %s",
+	"work.reuse_no_tools":      "rc ファイルに atuin や fzf などの履歴検索ツールが見つかりません",
+	"work.exploration":         "🧪 探索の時間（REPL）:",
+	"work.exploration_total":   "コマンド実行とは別に、インタプリタで %[1]s を探索（起動 %[2]d 回）",
+	"work.exploration_repl":    "%[1]s（起動 %[2]d 回）",
+	"work.outcomes":            "🚦 失敗と実行時間:",
+	"work.outcomes_counted":    "終了ステータスのあるコマンド %d 件、実行時間のあるコマンド %d 件",
+	"work.failures":            "よく失敗するもの:",
+	"work.failure":             "%[2]d 回中 %[1].0f%% が失敗",
+	"work.slowest":             "平均して遅いもの:",
+	"work.slow":                "平均 %s、最長 %s、%d 回",
+	"work.retried":             "失敗直後にもう一度実行（%d 回）:",
+	"work.rage":                "😤 連打されたコマンド:",
+	"work.rage_about":          "3 回以上続けて、それぞれ前回から %d 秒以内に実行されたコマンド",
+	"work.rage_bursts":         "連打 %d 回、最大 %d 回連続",
+	"work.complexity":          "🧩 コマンドの複雑さ:",
+	"work.complexity_features": "機能ごとのコマンド数: %s",
+	"work.complexity_pipeline": "最長のパイプライン: %d 個のプログラム",
+	"work.complexity_top":      "最も凝ったワンライナー（スコア %d）:",
+	"work.productivity":        "📈 生産性の指標:",
+	"work.workflows":           "🔄 よく使うワークフロー:",
+	"work.workflows_none":      "十分に繰り返されているコマンドの流れはまだありません",
+	"work.workflow_runs":       "（%d 回）",
+	"work.workflow_alias":      "まとめるなら: %s",
+
+	"complexity.simple":        "シンプル",
+	"complexity.moderate":      "ふつう",
+	"complexity.complex":       "複雑",
+	"complexity.elaborate":     "凝りまくり",
+	"complexity.pipes":         "パイプ",
+	"complexity.redirections":  "リダイレクト",
+	"complexity.substitutions": "コマンド置換",
+	"complexity.subshells":     "サブシェル",
+	"complexity.loops":         "ループ",
+	"complexity.conditionals":  "条件分岐",
+	"complexity.xargs":         "xargs",
+	"complexity.chains":        "&&/||/; の連結",
+
+	"metric.command_variety":     "コマンドの多様性",
+	"metric.workflow_complexity": "ワークフローの複雑さ",
+
+	"category.development": "開発",
+	"category.system":      "システム",
+	"category.file":        "ファイル",
+
+	"tools.title":               "🔧 ツール使用統計",
+	"tools.editors":             "📝 エディタ:",
+	"tools.editors_none":        "エディタの使用データがありません",
+	"tools.edited":              "✏️  編集した言語と実行した言語:",
+	"tools.edited_run":          "%s: 編集 %d ファイル、実行 %d 回",
+	"tools.edited_only":         "%s: 編集 %d ファイル、実行なし",
+	"tools.run_only":            "%s: 実行 %d 回、編集なし",
+	"tools.edited_files":        "よく編集したファイル: %s",
+	"tools.languages":           "💻 プログラミング言語:",
+	"tools.languages_none":      "言語の使用データがありません",
+	"tools.build":               "🛠️  ビルドツール:",
+	"tools.build_none":          "ビルドツールの使用データがありません",
+	"tools.mux":                 "🪟 ターミナルマルチプレクサ（tmux・screen・zellij）:",
+	"tools.mux_none":            "tmux・screen・zellij の使用はありません",
+	"tools.mux_program":         "%s: %d コマンド",
+	"tools.mux_commands":        "よく使う tmux コマンド: %s",
+	"tools.mux_sessions":        "名前付きセッション: %s",
+	"tools.mux_config":          "%s: プレフィックス %s、キーバインド %d 個",
+	"tools.mux_root":            "%s（プレフィックスなし）",
+	"tools.mux_plugins":         "プラグイン（tpm）:",
+	"tools.direnv":              "🌱 プロジェクト別の環境 (direnv):",
+	"tools.direnv_project":      "%s: direnv コマンドまたは .envrc の編集 %d 回",
+	"tools.direnv_none":         "direnv を使うプロジェクトは見つかりませんでした",
+	"tools.direnv_exports":      "%[3]s で %[2]d 回手動でエクスポート: %[1]s",
+	"tools.network":             "🌐 リモートホスト (ssh, scp, rsync, mosh):",
+	"tools.network_host":        "%[1]s: %[3]s で %[2]d 回接続",
+	"tools.network_none":        "リモートホストは見つかりませんでした",
+	"tools.domains":             "📥 curl と wget で取得したドメイン:",
+	"tools.domain":              "%s: %d 回のリクエスト",
+	"tools.domains_none":        "取得した URL はありません",
+	"tools.packages":            "📦 インストールしたパッケージ (npm, pip, go, cargo, brew, apt):",
+	"tools.packages_none":       "名前を指定してインストールしたパッケージはありません",
+	"tools.package":             "%s (%s): %d 回インストール",
+	"tools.packages_new":        "🆕 直近 90 日で新たに導入:",
+	"tools.package_new":         "%s (%s)、初回インストール %s",
+	"tools.hoarder":             "依存関係コレクター度: %d/100、%s",
+	"tools.hoarder_about":       "異なるパッケージ %d 個、月 %.1f 個。月 20 個以上で 100",
+	"tools.hoarding.minimalist": "ミニマリスト",
+	"tools.hoarding.collector":  "コレクター",
+	"tools.hoarding.hoarder":    "ため込み屋",
+	"tools.uses":                "%s: %d 回",
+
+	"tab.projects":         "プロジェクト",
+	"projects.title":       "🗂️  プロジェクト",
+	"projects.none":        "まだプロジェクトが見つかりません。ホーム以下の go.mod、package.json、Cargo.toml、.git などがあるディレクトリで実行したコマンドがここに表示されます。",
+	"projects.summary":     "%[2]d 件中 %[1]d 件のコマンドが %[3]d 個のプロジェクトで実行されました",
+	"projects.time":        "シェル時間 %s",
+	"projects.last":        "最終 %s",
+	"projects.elsewhere":   "プロジェクト外: %d 件のコマンド",
+	"projects.kind.go":     "Go",
+	"projects.kind.rust":   "Rust",
+	"projects.kind.node":   "Node.js",
+	"projects.kind.python": "Python",
+	"projects.kind.ruby":   "Ruby",
+	"projects.kind.java":   "Java",
+	"projects.kind.php":    "PHP",
+	"projects.kind.elixir": "Elixir",
+	"projects.kind.git":    "git",
+
+	"tab.git":            "Git",
+	"git.title":          "🌿 Git",
+	"git.none":           "履歴にまだ git コマンドがありません。",
+	"git.summary":        "git コマンド %d 回、うちコミット %d 回",
+	"git.ratios":         "コミットあたり:",
+	"git.per_commit":     "%.2f",
+	"git.prefers.merge":  "リベースよりマージ派: マージとプル %d 回、リベース %d 回",
+	"git.prefers.rebase": "マージよりリベース派: マージとプル %d 回、リベース %d 回",
+	"git.prefers.none":   "マージとリベースに明確な好みはありません: マージとプル %d 回、リベース %d 回",
+	"git.force":          "強制プッシュ: --force で %d 回、--force-with-lease で %d 回",
+	"git.force_tip":      "--force-with-lease ならまだ取得していないコミットを上書きしません",
+	"git.stash":          "スタッシュ: %s",
+	"git.stash_left":     "スタッシュが pop・apply・drop より %d 回多いです。git stash list に忘れた作業があるかもしれません",
+	"git.branches":       "入力したブランチ名:",
+	"git.reflogs":        "リポジトリ:",
+	"git.reflogs_off":    "--git-reflogs を付けて実行すると、プロジェクトのリポジトリの reflog からコミット数を数えます。",
+	"git.reflogs_none":   "プロジェクトのリポジトリに reflog が見つかりませんでした。",
+	"git.reflog":         "コミット %d 回、amend %d 回、リベース %d 回、マージ %d 回、チェックアウト %d 回",
+
+	"tab.containers":        "コンテナ",
+	"containers.title":      "🐳 コンテナと Kubernetes",
+	"containers.none":       "履歴に docker、podman、kubectl、helm のコマンドはまだありません。",
+	"containers.docker":     "docker コマンド %d 回、そのうち compose 経由 %d 回 (%.0f%%)",
+	"containers.images":     "よく実行したイメージ:",
+	"containers.kubectl":    "kubectl コマンド %d 回:",
+	"containers.helm":       "helm コマンド %d 回: %s",
+	"containers.namespaces": "ネームスペース:",
+	"containers.contexts":   "コンテキスト切り替え %d 回: %s",
+
+	"timeline.title":         "⏳ 注目コマンドのタイムライン",
+	"timeline.unknown":       "時刻不明",
+	"timeline.undated":       "日付なし",
+	"timeline.none":          "条件に合うコマンドはありません",
+	"timeline.page":          "%d / %d ページ · コマンド: %d",
+	"timeline.pages":         "ページ送り",
+	"timeline.filters":       "シェル: %s · カテゴリ: %s · 種類: %s",
+	"timeline.all":           "すべて",
+	"timeline.kind.tool":     "ツール",
+	"timeline.kind.pipeline": "パイプライン",
+	"timeline.kind.typo":     "タイプミス",
+
+	"tab.data":            "データ",
+	"data.title":          "🔬 解析データ",
+	"data.redacted":       "マスキング有効（%s）：AI に送信されるときのコマンドの見え方です",
+	"data.raw":            "マスキング無効：ファイルから読み取ったままの値です。どこにも送信されません",
+	"data.none":           "シェルの履歴や設定が見つかりませんでした",
+	"data.entries":        "%d 件のエントリを解析",
+	"data.recent":         "最新の %d 件:",
+	"data.aliases":        "エイリアス（%d）:",
+	"data.plugins":        "プラグイン（%d）:",
+	"data.plugin_updated": "更新 %s",
+	"data.plugin_stale":   "⚠ %s から更新なし",
+	"data.environment":    "環境変数（%d）:",
+	"data.toggle":         "マスキング切替",
+
+	"capability.title":                "🧭 利用可能な機能",
+	"capability.histories":            "履歴: %s",
+	"capability.timestamps":           "タイムスタンプ: %s",
+	"capability.ai":                   "AI: %s",
+	"capability.ai.configured":        "設定済み",
+	"capability.ai.missing":           "APIキーなし",
+	"capability.ai.disabled":          "無効",
+	"capability.clipboard":            "クリップボード: %s",
+	"capability.images":               "インライン画像: %s",
+	"capability.none":                 "なし",
+	"capability.hint.history":         "シェル履歴が見つかりません。シェルで履歴の保存を有効にすると、このタブが使えるようになります。",
+	"capability.hint.timestamps":      "%[2]s で `%[1]s` を設定してタイムスタンプを有効にすると、%[3]s が表示されます。",
+	"capability.hint.ai":              "Gemini APIキーを設定すると AI が書いたスライドが使えます。これらはローカルで作成されています。",
+	"capability.hint.chat":            "Gemini APIキーを設定すると履歴について質問できます。",
+	"capability.hint.growth":          "Gemini APIキーを設定すると、これらの提案に加えて AI のアドバイスを受けられます。",
+	"capability.hint.outcomes":        "シェルフック（init bash|zsh|fish、README の Shell Hooks を参照）を追加すると、失敗率と遅いコマンドがわかります。",
+	"capability.unlock.work_patterns": "時間帯別のアクティビティとセッション",
+	"capability.unlock.timeline":      "タイムライン",
+	"capability.unlock.achievements":  "連続記録",
+
+	"tab.diagnostics":       "診断",
+	"problems.title":        "🩺 診断",
+	"problems.none":         "✅ すべて問題なく読み込めました",
+	"problems.why":          "原因: %v",
+	"problems.fix":          "対処: %s",
+	"problems.indicator":    "⚠️  %[1]d 件の問題で一部の表示が不完全です（%[2]s を参照）",
+	"problems.log":          "詳細は %s に記録されます。--verbose か --debug を付けるとさらに記録します。",
+	"problem.history.path":  "履歴ファイル %s を読み込めませんでした",
+	"problem.history.fix":   "ファイルの読み取り権限を確認してください。そのシェルのコマンドはどのタブにも含まれません。",
+	"problem.config.path":   "起動ファイル %s を読み込めませんでした",
+	"problem.config.fix":    "ファイルの読み取り権限を確認してください。そこで定義されたエイリアスとプラグインが含まれません。",
+	"problem.cast.path":     "録画 %s を読み込めませんでした",
+	"problem.cast.fix":      "config.yaml の casts か --cast のパスを確認してください。asciinema v2 と v3 の録画のみ読み込めます。",
+	"problem.settings.path": "%s の設定を読み込めませんでした",
+	"problem.settings.fix":  "YAML を修正するかファイルを削除してください。それまでは既定値を使います。",
+	"problem.keys.path":     "%s のキー割り当てを無視しました",
+	"problem.keys.fix":      "README の Navigation Keys にあるアクション名を使ってください。",
+	"problem.ai":            "Gemini でまとめを作成できませんでした",
+	"problem.ai.fix":        "API キーと接続を確認してください。それまではローカルで作成したスライドを表示します。",
+	"problem.api_key":       "API キーを保存できませんでした",
+	"problem.api_key.fix":   "このセッションでのみ使います。保存するには GEMINI_API_KEY か config.yaml の api_key を設定してください。",
+	"problem.archive":       "まとめのスライドを保存できませんでした",
+	"problem.archive.fix":   "データディレクトリに書き込めて、空き容量があることを確認してください。",
+	"problem.trends":        "推移を記録または読み込みできませんでした",
+	"problem.trends.fix":    "データディレクトリに書き込めて、空き容量があることを確認してください。",
+
+	"tab.security":                   "セキュリティ",
+	"security.title":                 "🛡️  セキュリティ監査",
+	"security.none":                  "✅ 履歴に危険なコマンドは見つかりませんでした",
+	"security.finding":               "⚠️  %s: %d 回",
+	"security.example":               "最後: %s",
+	"security.example_at":            "最後: %s（%s）",
+	"security.secret":                "コマンドラインに秘密情報らしきもの",
+	"security.secret.warning":        "コマンドに入力したトークンやパスワードは平文で保存されます。無効化したうえで `scrub` でエントリを削除してください。",
+	"security.rm_root":               "/ やホームの再帰削除",
+	"security.rm_root.warning":       "タイプミス 1 つでシステムやホームディレクトリが消えます。削除するパスを具体的に指定してください。",
+	"security.dd_device":             "ディスクデバイスへの dd",
+	"security.dd_device.warning":     "デバイスを間違えるとデータが失われます。先に lsblk で of= を確認してください。",
+	"security.sudo_pipe":             "ダウンロードを sudo にパイプ",
+	"security.sudo_pipe.warning":     "ネットワークからのコードを読まずに root で実行します。ダウンロードして確認してから実行してください。",
+	"security.pipe_to_shell":         "ダウンロードをシェルにパイプ",
+	"security.pipe_to_shell.warning": "途中で切れたダウンロードも含め、サーバーが送るものをそのまま実行します。スクリプトを保存して読んでから実行してください。",
+	"security.chmod_777":             "chmod 777",
+	"security.chmod_777.warning":     "すべてのユーザーがファイルを変更できます。755 や 644 など必要な権限だけを与えてください。",
+	"security.force_push":            "強制プッシュ",
+	"security.force_push.warning":    "リモートのブランチと他人のコミットを上書きします。--force-with-lease を使いましょう。",
+	"security.insecure_tls":          "TLS 検証の無効化",
+	"security.insecure_tls.warning":  "-k/--insecure はどの証明書も受け入れるため、通信を傍受される恐れがあります。",
+
+	"tab.config_health":                    "設定の健全性",
+	"health.title":                         "🩺 設定の健全性",
+	"health.none":                          "起動ファイルに問題は見つかりませんでした。",
+	"health.no_configs":                    "起動ファイルを読み込んでいません。",
+	"health.duplicate_path":                "%[1]s が PATH に再度追加されています (最初は %[2]s)",
+	"health.interactive_guard":             "%s が非対話シェル (scp や ssh host command など) でも実行されます",
+	"health.deprecated":                    "非推奨の構文: %s",
+	"health.enormous":                      "コード %s 行",
+	"health.unused_plugin":                 "%s プラグインが読み込まれていますが、そのプログラムは履歴に一度も出てきません",
+	"health.stale_plugin":                  "%s プラグインは %s から更新されていません",
+	"health.syntax.backticks":              "`...` によるコマンド置換",
+	"health.syntax.dollar_bracket":         "$[...] による算術式",
+	"health.syntax.egrep":                  "egrep",
+	"health.syntax.fgrep":                  "fgrep",
+	"health.syntax.caret":                  "stderr をリダイレクトする ^",
+	"health.syntax.dot":                    "ファイルを読み込む .",
+	"health.fix.duplicate_path":            "重複したエントリを削除してください。このディレクトリはすでに PATH にあります",
+	"health.fix.interactive_guard.bash":    "その前に [[ $- == *i* ]] || return を追加してください",
+	"health.fix.interactive_guard.fish":    "if status is-interactive ... end で囲んでください",
+	"health.fix.deprecated.backticks":      "ネストでき読みやすい $(...) を使ってください",
+	"health.fix.deprecated.dollar_bracket": "$((...)) を使ってください",
+	"health.fix.deprecated.egrep":          "grep -E を使ってください",
+	"health.fix.deprecated.fgrep":          "grep -F を使ってください",
+	"health.fix.deprecated.caret":          "代わりに 2> を使ってください",
+	"health.fix.deprecated.dot":            "代わりに source を使ってください",
+	"health.fix.enormous.bash":             "テーマごとのファイルに分けて source してください",
+	"health.fix.enormous.zsh":              "テーマごとのファイルに分けて source してください",
+	"health.fix.enormous.fish":             "一部を ~/.config/fish/conf.d に、関数を ~/.config/fish/functions に移してください",
+	"health.fix.unused_plugin":             "シェルの起動を速くするため、プラグインの一覧から外してください",
+	"health.fix.stale_plugin":              "プラグインマネージャーで更新するか、メンテナンスされている代替を探してください",
+
+	"tab.suggestions":                   "提案",
+	"suggestions.title":                 "💡 提案",
+	"suggestions.aliases":               "⌨️  追加すると便利なエイリアス:",
+	"suggestions.alias_savings":         "%d 回実行、週に約 %.0f 打鍵の節約",
+	"suggestions.no_aliases":            "打鍵を大きく減らせるエイリアスはありません",
+	"suggestions.apply_hint":            "%[1]s: これらのエイリアスを %[2]s に追加",
+	"suggestions.reason":                "提案されたエイリアスを追加",
+	"suggestions.applied":               "%[2]s にエイリアスを %[1]d 個追加しました。元に戻すには `undo` を実行し、使うには新しいシェルを開いてください。",
+	"suggestions.apply_failed":          "エイリアスを追加できませんでした: %v",
+	"suggestions.cleanup":               "🧹 エイリアスの整理:",
+	"suggestions.unused_aliases":        "%s: 一度も使われていないエイリアスが %d 個: %s",
+	"suggestions.shadowing":             "%[1]s（%[2]s）は同名のプログラムを隠し、代わりに %[3]s を実行します",
+	"suggestions.duplicate":             "%s は %d 回定義されています: %s",
+	"suggestions.conflicting":           "%s は異なるコマンドで %d 回定義されています: %s",
+	"suggestions.alternatives":          "🦀 モダンな代替ツール:",
+	"suggestions.alternative_installed": "%[1]s を %[2]d 回実行しました。%[3]s はインストール済みです。代わりに %[4]s を使ってみましょう。",
+	"suggestions.alternative_install":   "%[1]s を %[2]d 回実行しました。より速く使いやすい代替ツール %[3]s のインストールを検討しましょう。",
+	"suggestions.recommendations":       "🔧 設定:",
+	"suggestions.few_aliases":           "%s の設定にはエイリアスがほとんどありません。よく打つコマンドに追加しましょう。",
+	"suggestions.few_plugins":           "%s ではプラグインをほとんど使っていません。自動補完候補とシンタックスハイライトから始めるのがおすすめです。",
+	"suggestions.dedupe":                "%[1]s の履歴の %.0[2]f%% が重複です。`dedupe --apply` で保存しないようにできます。",
+	"suggestions.scrub":                 "%d 件の履歴に秘密情報らしきものが含まれています。`scrub` で削除できます。",
+	"suggestions.unused_shortcuts":      "zsh のグローバルエイリアスと名前付きディレクトリのうち %d 個が一度も使われていません: %s。削除するか活用しましょう。",
+	"suggestions.direnv":                "%[3]s で %[1]s を %[2]d 回手動でエクスポートしました。そこの .envrc に書いて direnv に読み込ませましょう。",
+	"suggestions.direnv_hook":           ".envrc ファイルを使っていますが、`direnv hook` を実行する rc ファイルがないため手動でしか読み込まれません。",
+	"suggestions.security":              "危険なコマンドが %d 件見つかりました。セキュリティタブを確認してください。",
+	"suggestions.tips":                  "🚀 ワークフローのヒント:",
+	"suggestions.frequent_pattern":      "\"%s\" を %d 回入力しました。短いエイリアスや関数にすると便利です。",
+	"suggestions.workflow":              "%s を続けて %d 回実行しました。エイリアスなら一度で実行できます: %s",
+	"suggestions.recall_tool":           "コマンドの %.0f%% は以前のものの繰り返しです。atuin や fzf の Ctrl-R のような履歴検索ツールならスクロールより速く見つかります。",
+	"suggestions.typos":                 "\"%[2]s\" のつもりで \"%[1]s\" と %[3]d 回入力しました。シェルの自動修正（zsh の setopt CORRECT）や thefuck を試してみましょう。",
+	"suggestions.typo_fixes":            "🤦 打ち間違いの修正:",
+	"suggestions.typo_count":            "%d 回入力",
+
+	"tab.achievements":           "実績",
+	"achievements.title":         "🏆 実績",
+	"achievements.streaks":       "🔥 連続記録:",
+	"achievements.current":       "現在の連続記録: %d 日",
+	"achievements.longest":       "最長の連続記録: %d 日（%s から）",
+	"achievements.milestones":    "🏁 マイルストーン:",
+	"achievements.first":         "初めての %s: %s",
+	"achievements.nth":           "%s %d 回目: %s",
+	"achievements.no_milestones": "まだマイルストーンはありません",
+	"achievements.badges":        "🏅 バッジ:",
+	"achievements.earned":        "✅ %s — %s",
+	"achievements.locked":        "🔒 %s（未獲得）— %s",
+
+	"badge.night_owl":            "夜更かし",
+	"badge.night_owl.desc":       "コマンドの 5 分の 1 が深夜 0 時から 5 時",
+	"badge.early_bird":           "早起き",
+	"badge.early_bird.desc":      "コマンドの 5 分の 1 が朝 5 時から 9 時",
+	"badge.weekend_warrior":      "週末戦士",
+	"badge.weekend_warrior.desc": "コマンドの 4 分の 1 が週末",
+	"badge.pipe_wizard":          "パイプの魔術師",
+	"badge.pipe_wizard.desc":     "3 つ以上のプログラムをつなぐコマンドが 50 回",
+	"badge.marathoner":           "マラソンランナー",
+	"badge.marathoner.desc":      "3 時間以上の作業セッション",
+	"badge.streaker":             "皆勤賞",
+	"badge.streaker.desc":        "30 日連続で活動",
+	"badge.shell_hopper":         "シェル渡り鳥",
+	"badge.shell_hopper.desc":    "メインのシェルを 1 回以上乗り換えた",
+
+	"search.title":       "🔎 履歴を検索",
+	"search.placeholder": "入力して検索 (例: docker shell:zsh since:2024-01)",
+	"search.filters":     "フィルター: shell:zsh cat:development since:2024-01 until:2024-06-30",
+	"search.matches":     "%d 件のコマンドが一致",
+	"search.none":        "一致するコマンドはありません",
+	"search.help":        "↑/↓ PgUp/PgDn: スクロール • Esc: 閉じる",
+
+	"tab.chat":         "チャット",
+	"chat.title":       "💬 履歴について質問",
+	"chat.placeholder": "例: kubectl でよく使うフラグは?",
+	"chat.empty":       "Enter で質問できます。要約と質問に関係するコマンドをもとに AI が答えます。シークレットは伏せられます。",
+	"chat.thinking":    "考え中…",
+	"chat.failed":      "回答なし: %v",
+	"chat.more":        "↓ あと %d 行",
+	"chat.help":        "Enter: 質問 • PgUp/PgDn: スクロール • Esc: 終了",
+
+	"wrapped.generating": "まとめを生成しています...",
+	"wrapped.slide":      "📺 スライド %d/%d",
+	"wrapped.quotes":     "📜 ひとこと",
+
+	"wrapped.top.title":       "よく使ったコマンド",
+	"wrapped.top.description": "%d 回のコマンドのうち、最も多く使ったのは %s でした。",
+	"wrapped.top.quote":       "#%d %s — %d 回",
+
+	"wrapped.streak.title":       "最長連続記録",
+	"wrapped.streak.description": "%d 日連続でシェルを開きました（%s から）。活動日は合計 %d 日です。",
+	"wrapped.streak.quote":       "継続こそが本当の 10x スキル。",
+
+	"wrapped.busiest.title":       "最も忙しかった日",
+	"wrapped.busiest.description": "%s には %d 回コマンドを実行しました。何があったにせよ、キーボードは覚えています。",
+
+	"wrapped.typos.title":       "タイプミス大賞",
+	"wrapped.typos.description": "一番多かったタイプミスは '%s' でした。誰にでもあることです。",
+	"wrapped.typos.quote":       "%s → %s（%d 回）",
+
+	"wrapped.quiet.title":       "静かな一年",
+	"wrapped.quiet.description": "まとめを作るのに十分な履歴がありません。コマンドを実行してからまた来てください！",
+
+	"wrapped.roast.top.title":         "習慣の生き物",
+	"wrapped.roast.top.description":   "%d 個のコマンドのうち %s が %d 回。もはやツールというより性格です。",
+	"wrapped.roast.typos.title":       "キーボードはお飾り",
+	"wrapped.roast.typos.description": "'%[2]s' のつもりで '%[1]s' と打ったのが %[3]d 回。オートコレクトも匙を投げました。",
+	"wrapped.roast.late.title":        "自称・夜型",
+	"wrapped.roast.late.description":  "深夜0時から5時のコマンドが %d 個、%d 晩にわたり、最も遅いのは %s。何が壊れたにせよ、朝まで待てたはずです。",
+	"wrapped.roast.run.title":         "まだですか？",
+	"wrapped.roast.run.description":   "これを %d 回連続で実行しました。ネタバレ：出力は変わりませんでした。",
+	"wrapped.roast.quiet.title":       "いじるネタなし",
+	"wrapped.roast.quiet.description": "履歴がほとんどありません。よほど効率的か GUI 派か、答えはお互い分かっていますね。",
+
+	"wrapped.journey.title":       "シェルの移り変わり",
+	"wrapped.journey.description": "メインのシェルの変遷: %s",
+	"wrapped.journey.none":        "%s から %s へ（%s）。移すエイリアスはなく、身軽な引っ越しでした。",
+	"wrapped.journey.all":         "%s から %s へ（%s）。%d 個のエイリアスをすべて持っていきました。",
+	"wrapped.journey.some":        "%s から %s へ（%s）。%d/%d 個のエイリアスを持っていきました。",
+
+	"wrapped.forecast.title":       "これからの予測",
+	"wrapped.forecast.description": "このペースなら %[3]s までに %[2]s コマンドが %[1]d 回に達します。",
+	"wrapped.forecast.quote":       "%[1]s: 週に約 %.0[2]f 回、%[4]s までに %[3]d 回",
+
+	"wrapped.elaborate.title":       "今年のワンライナー",
+	"wrapped.elaborate.description": "最も凝ったコマンドのスコアは %d、使った機能は %s です。",
+
+	"wrapped.retry.title":       "七転び八起き",
+	"wrapped.retry.description": "失敗してはすぐにやり直したコマンド、その数 %d 回。粘り強さは美徳です。",
+
+	"wrapped.rage.title":             "宿敵",
+	"wrapped.rage.description":       "このコマンドを %d 回も連打しました。数秒おきに最大 %d 回連続。手強い相手でした。",
+	"wrapped.containers.title":       "コンテナの船長",
+	"wrapped.containers.description": "コンテナと Kubernetes のコマンドを %d 回。何度も戻ってきたイメージがひとつ。",
+
+	"wrapped.year.title":             "シェルで過ごした %d 年",
+	"wrapped.year.description":       "活動日 %[2]d 日で %[1]d 回のコマンド。月ごとに一年を振り返ります。",
+	"wrapped.year.quiet":             "%d 年の履歴はありません。",
+	"wrapped.year.outro.title":       "また %d 年に",
+	"wrapped.year.outro.description": "%d 年はこれでおしまい。来年はタイプミスが減りますように。",
+	"wrapped.month.description":      "%[2]d 日で %[1]d 回、%[3]d 種類のプログラムを使いました。一番は %[4]s の %[5]d 回です。",
+	"wrapped.month.busiest":          "一年で最も忙しかった月です。",
+	"wrapped.new.title":              "新しく覚えたツール",
+	"wrapped.new.description":        "今年は %d 個の新しいツールを覚えました。一番使ったのは %s です。",
+	"wrapped.new.quote":              "%s — %d 回",
+	"wrapped.pause":                  "一時停止",
+	"wrapped.paused":                 "⏸ 一時停止中",
+	"wrapped.year.invalid":           "--year %d は振り返れる年ではありません",
+	"wrapped.rate":                   "このスライドはどうでしたか？ %s",
+	"wrapped.rated":                  "★ このスライドの評価: %d/5",
+	"wrapped.rate_failed":            "評価を保存できませんでした: %v",
+	"wrapped.decks":                  "🗂 デッキ:",
+	"wrapped.deck.current":           "今回の実行",
+	"wrapped.deck.year":              "%s年の振り返り",
+
+	"tab.settings":         "設定",
+	"settings.title":       "⚙️  設定",
+	"settings.ai":          "AI によるまとめ",
+	"settings.roast":       "Wrapped のいじりモード",
+	"settings.on":          "オン",
+	"settings.off":         "オフ",
+	"settings.theme":       "テーマ",
+	"settings.redaction":   "AI 送信前の秘匿化",
+	"settings.shell":       "%s の履歴を分析",
+	"settings.saved_to":    "変更は %s に保存されます",
+	"settings.saved":       "保存しました。",
+	"settings.save_failed": "設定を保存できませんでした: %v",
+	"settings.last_shell":  "少なくとも 1 つのシェルを有効にしておく必要があります。",
+	"redaction.strict":     "厳格（秘密情報、IP、ホームパス）",
+	"redaction.secrets":    "秘密情報のみ",
+	"settings.period":      "期間（このセッション）",
+	"period.all":           "全期間",
+	"period.30d":           "直近 30 日",
+	"period.90d":           "直近 90 日",
+	"period.this_year":     "今年",
+	"period.last_year":     "昨年",
+	"period.since":         "%s 以降",
+	"period.until":         "%s まで",
+	"period.range":         "%s〜%s",
+
+	"simulate.none":   "シミュレーションするエイリアス候補がありません。",
+	"simulate.header": "エイリアス\t展開\t一致数\t削減キー数\t回/週\tキー/週",
+
+	"undo.none":     "元に戻す変更はありません。",
+	"undo.header":   "変更日時\tファイル\t理由",
+	"undo.restored": "%s を %s より前の状態に戻しました（%s）。",
+	"undo.removed":  "%s を削除しました（作成理由: %s）。",
+
+	"config.written": "既定の設定を %s に書き出しました。変更したい項目のコメントを外してください。",
+	"config.exists":  "%s は既に存在します。置き換えるには --force を付けてください。",
+
+	"daemon.listening": "%s でシェルフックからコマンドを受け取り %s に保存しています（Ctrl+C で停止）",
+
+	"migrate.title":       "%s から %s への移行",
+	"migrate.aliases":     "エイリアス",
+	"migrate.functions":   "関数",
+	"migrate.environment": "環境変数",
+	"migrate.port":        "移行が必要",
+	"migrate.review":      "移行が必要、変換結果を確認してください",
+	"migrate.defined":     "定義済み",
+	"migrate.same":        "--from と --to がどちらも %s です。移行するものはありません",
+	"migrate.none":        "%s の rc ファイルにエイリアス、関数、環境変数は見つかりませんでした。",
+	"migrate.summary":     "%[3]s へ移行が必要な定義は %[2]d 個中 %[1]d 個です。",
+	"migrate.starter":     "%[2]s に追記するか、そこから読み込む %[1]s の初期設定:",
+	"migrate.written":     "初期設定を %s に書き出しました。%s から読み込むか、必要な部分をコピーしてください。",
+
+	"dotfiles.none":            "エクスポートするシェルの起動ファイルが見つかりません。",
+	"dotfiles.exists":          "%s は既に存在し、空ではありません。別の --output を指定するか --force を付けてください。",
+	"dotfiles.shell":           "%s: ファイル %d 件、エイリアス %d 件、環境変数 %d 件、プラグイン %d 件",
+	"dotfiles.redacted":        "秘密情報らしき %d 行を伏せました。コミットする前に確認してください。",
+	"dotfiles.written_dir":     "dotfiles を %[1]s に書き出しました。`stow -d %[1]s -t ~ %[2]s` で元の場所にリンクできます。",
+	"dotfiles.written_archive": "dotfiles を %s に書き出しました。",
+
+	"dedupe.none":    "確認する bash や zsh の履歴がありません。fish は重複を保存しません。",
+	"dedupe.header":  "シェル\tエントリ\t重複\t割合\t連続した重複",
+	"dedupe.enabled": "%s はすでに重複を保存しません。",
+	"dedupe.clean":   "%s に重複したエントリはありません。",
+	"dedupe.suggest": "%s: %d 件のエントリが重複しています。次の設定を %s に追加するか、--apply を付けて再実行してください:",
+	"dedupe.reason":  "重複した履歴エントリを保存しない",
+	"dedupe.applied": "%s を更新しました。今後は重複が保存されません。元に戻すには `undo` を実行してください。",
+
+	"scrub.none":           "シェル履歴に秘密情報らしきものは見つかりませんでした。",
+	"scrub.found":          "%[2]s に秘密情報らしきエントリが %[1]d 件あります:",
+	"scrub.confirm":        "%[2]s からこの %[1]d 件を削除しますか？ [y/N]",
+	"scrub.yes":            "はい",
+	"scrub.skipped":        "%s は変更しませんでした。",
+	"scrub.removed":        "%[2]s から %[1]d 件を削除しました。コピーは残していないため、`undo` では元に戻せません。",
+	"scrub.running_shells": "開いたままのシェルは履歴をメモリに保持しており、終了時に書き戻すことがあります。シェルを再起動し、漏れた秘密情報は無効化してください。",
+
+	"snapshot.saved": "スナップショット %s（%d 件のコマンド）を %s に保存しました",
+
+	"diff.title":          "🔀 %s → %s",
+	"diff.commands":       "コマンド数: %d → %d",
+	"diff.commands_since": "コマンド数: %d、その後 %d 件",
+	"diff.adopted":        "🆕 使い始めたもの:",
+	"diff.abandoned":      "💤 使わなくなったもの:",
+	"diff.tech":           "🧰 技術スタック:",
+	"diff.peak":           "🕐 ピーク時間帯:",
+	"diff.proficiency":    "📊 習熟度:",
+	"diff.need_snapshots": "比較には %[1]d 件のスナップショットが必要ですが、%[2]d 件しかありません。`snapshot` を実行するか、compare 2024-Q1 2024-Q2 のように 2 つの期間を指定してください",
+
+	"report.title":           "🐚 今週のシェルハイライト",
+	"report.period":          "%s – %s",
+	"report.commands":        "⌨️ コマンド数",
+	"report.change":          "(先週比 %+.0f%%)",
+	"report.active_days":     "📅 活動日数",
+	"report.days_of_week":    "7 日中 %d 日",
+	"report.streak":          "🔥 連続記録",
+	"report.streak_days":     "%d 日",
+	"report.top":             "🏆 よく使ったツール",
+	"report.new":             "🆕 今週使い始めたもの",
+	"report.more":            "ほか %d 件",
+	"report.busiest":         "📈 最も活発な日",
+	"report.busiest_day":     "%s、%d 件",
+	"report.late_night":      "🦉 深夜",
+	"report.late_night_runs": "%d 件",
+	"report.next_skill":      "🌱 次に学ぶこと",
+	"report.footer":          "k8au-shell-analyzer で共有 · 共有されるのはプログラム名だけで、引数やパスは含まれません",
+	"report.posted":          "今週のハイライトを %s に投稿しました",
+	"report.no_webhook":      "Webhook が設定されていません。%s で report.slack_webhook または report.discord_webhook を設定してください",
+
+	"export.none":       "スナップショットがまだありません。先に `snapshot` を実行してください。",
+	"export.written":    "スナップショット %s を %s にエクスポートしました",
+	"export.unsigned":   "エクスポートは署名されていません。署名するには K8AU_SIGNING_KEY または設定ファイルの signing_key を設定してください。",
+	"import.saved":      "スナップショット %s（スキーマ %d）を %s にインポートしました",
+	"import.unverified": "警告: エクスポートは署名されていますが、署名鍵が設定されていないため署名を確認していません。",
+
+	"team.title":    "👥 %d 人のチーム",
+	"team.commands": "合計 %d コマンド",
+	"team.tools":    "🧰 共通のツール:",
+	"team.members":  "%d / %d 人",
+	"team.peak":     "チーム全体のピーク時間: %s",
+	"team.stack":    "🧱 技術スタックの分布:",
+	"team.roles":    "🎭 役割:",
+	"team.none":     "2 人以上に共通するものはありません",
+	"team.hidden":   "個人を特定できないよう、1 人だけのツール・技術・役割 %d 件を除外しました。",
+	"team.small":    "警告: メンバーが %d 人だけなので、共通の統計から個人が推測される可能性があります。",
+
+	"serve.listening":   "%s でダッシュボードを公開しています（Ctrl+C で停止）",
+	"serve.metrics":     "%s で Prometheus メトリクスを公開しています",
+	"serve.exposed":     "警告: %s は他のマシンからアクセスでき、ダッシュボードを開いた人に履歴が見えます。",
+	"serve.title":       "K8au Shell Analyzer",
+	"serve.since":       "開始",
+	"serve.until":       "終了",
+	"serve.shell":       "シェル",
+	"serve.all_shells":  "すべてのシェル",
+	"serve.apply":       "適用",
+	"serve.copy":        "リンクをコピー",
+	"serve.copied":      "コピーしました",
+	"serve.loading":     "分析中…",
+	"serve.empty":       "まだありません",
+	"serve.commands":    "コマンド",
+	"serve.total":       "件のコマンドを分析",
+	"serve.top":         "よく使うコマンド",
+	"serve.hours":       "時間帯別のコマンド",
+	"serve.week":        "1 週間の概要",
+	"serve.stack":       "技術スタック",
+	"serve.proficiency": "習熟度",
+
+	"service.wrote":         "%s を書き込みました",
+	"service.installed":     "毎日スナップショットが記録されます。",
+	"service.removed":       "%s を削除しました",
+	"service.not_installed": "毎日のスナップショットはインストールされていません。",
+}
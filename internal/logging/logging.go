@@ -0,0 +1,60 @@
+// internal/logging/logging.go
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// maxSize is how large the log grows before it is moved to k8au.log.1 at
+// the next start, replacing the previous one
+const maxSize = 5 << 20
+
+// level is shared by the handler so flags can change it after Setup
+var level = func() *slog.LevelVar {
+	v := new(slog.LevelVar)
+	v.Set(slog.LevelWarn)
+	return v
+}()
+
+// Path returns where the log is written
+func Path() string {
+	return filepath.Join(store.StateDir(), "k8au.log")
+}
+
+// Setup sends slog's default logger, and the standard log package with it,
+// to the log file. Only warnings and errors are written until SetLevel.
+// When the file cannot be opened nothing is logged, since the TUI owns the
+// terminal.
+func Setup() error {
+	var out io.Writer = io.Discard
+	defer func() {
+		slog.SetDefault(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: level})))
+	}()
+
+	if err := os.MkdirAll(store.StateDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create log directory: %v", err)
+	}
+	path := Path()
+	if info, err := os.Stat(path); err == nil && info.Size() > maxSize {
+		os.Rename(path, path+".1")
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log: %v", err)
+	}
+	out = file
+	return nil
+}
+
+// SetLevel changes which messages are written: slog.LevelInfo for
+// --verbose, slog.LevelDebug for --debug, which includes the raw AI
+// responses
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
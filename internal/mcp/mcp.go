@@ -0,0 +1,301 @@
+// internal/mcp/mcp.go
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/server"
+)
+
+// protocolVersions are the Model Context Protocol revisions spoken, newest
+// first
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+const (
+	// defaultLimit and maxLimit bound the commands a tool returns
+	defaultLimit = 10
+	maxLimit     = 100
+	// maxMessage is the longest request line accepted
+	maxMessage = 1 << 20
+)
+
+// JSON-RPC error codes
+const (
+	codeParse          = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Analyze runs, or reuses, the analysis for a filter
+type Analyze func(server.Filter) (analyzer.ShellData, error)
+
+// Server answers Model Context Protocol requests, one JSON-RPC message per
+// line, with tools over the analysis. Commands only leave through
+// search_history, which has to be asked for, and are redacted.
+type Server struct {
+	analyze Analyze
+}
+
+// New returns a server answering from analyze
+func New(analyze Analyze) *Server {
+	return &Server{analyze: analyze}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve answers the requests read from in on out until in ends
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessage)
+	encoder := json.NewEncoder(out)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			if err := encoder.Encode(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{codeParse, err.Error()}}); err != nil {
+				return fmt.Errorf("failed to write response: %v", err)
+			}
+			continue
+		}
+		// Notifications, such as notifications/initialized, get no answer
+		if len(req.ID) == 0 {
+			continue
+		}
+		result, rpcErr := s.handle(req)
+		if err := encoder.Encode(response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr}); err != nil {
+			return fmt.Errorf("failed to write response: %v", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %v", err)
+	}
+	return nil
+}
+
+func (s *Server) handle(req request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+		version := protocolVersions[0]
+		if slices.Contains(protocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "k8au-shell-analyzer", "version": buildVersion()},
+			"instructions":    "Statistics about the user's shell history on this machine. Prefer get_top_commands and get_tool_usage; only search the history when the user asks about specific commands.",
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": toolList}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{codeInvalidParams, err.Error()}
+		}
+		return s.call(params.Name, params.Arguments)
+	}
+	return nil, &rpcError{codeMethodNotFound, fmt.Sprintf("unknown method %q", req.Method)}
+}
+
+// buildVersion is the module version the binary was built from
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+// arguments are those every tool takes; the filters match serve's query
+// parameters
+type arguments struct {
+	Since string `json:"since"`
+	Until string `json:"until"`
+	Shell string `json:"shell"`
+	Limit int    `json:"limit"`
+	Query string `json:"query"`
+}
+
+// limit clamps the requested number of results
+func (a arguments) limit() int {
+	if a.Limit <= 0 {
+		return defaultLimit
+	}
+	return min(a.Limit, maxLimit)
+}
+
+// call runs a tool. A tool that fails answers with isError, so that the
+// assistant sees why, rather than with a protocol error.
+func (s *Server) call(name string, raw json.RawMessage) (interface{}, *rpcError) {
+	var args arguments
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, &rpcError{codeInvalidParams, err.Error()}
+		}
+	}
+	var run func(analyzer.ShellData, arguments) (interface{}, error)
+	switch name {
+	case "get_top_commands":
+		run = topCommands
+	case "get_tool_usage":
+		run = toolUsage
+	case "search_history":
+		run = searchHistory
+	default:
+		return nil, &rpcError{codeInvalidParams, fmt.Sprintf("unknown tool %q", name)}
+	}
+
+	data, err := s.analyze(server.Filter{Since: args.Since, Until: args.Until, Shell: args.Shell})
+	var result interface{}
+	if err == nil {
+		result, err = run(data, args)
+	}
+	if err != nil {
+		return toolResult(err.Error(), true), nil
+	}
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return toolResult(fmt.Sprintf("failed to encode the result: %v", err), true), nil
+	}
+	return toolResult(string(text), false), nil
+}
+
+func toolResult(text string, isError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func topCommands(data analyzer.ShellData, args arguments) (interface{}, error) {
+	counts := data.CommonCmds
+	if args.Shell != "" {
+		counts = data.ShellCmds[args.Shell]
+	}
+	type program struct {
+		Program string `json:"program"`
+		Runs    int    `json:"runs"`
+	}
+	result := struct {
+		Commands int       `json:"commands"`
+		Top      []program `json:"top"`
+	}{Top: []program{}}
+	for _, count := range data.CommandCounts {
+		result.Commands += count
+	}
+	for _, count := range analyzer.SortedCounts(counts, args.limit()) {
+		result.Top = append(result.Top, program{count.Command, count.Count})
+	}
+	return result, nil
+}
+
+func toolUsage(data analyzer.ShellData, _ arguments) (interface{}, error) {
+	return data.Insights.ToolUsage, nil
+}
+
+func searchHistory(data analyzer.ShellData, args arguments) (interface{}, error) {
+	if args.Query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	q := analyzer.ParseSearchQuery(args.Query)
+	if args.Shell != "" {
+		q.Shell = args.Shell
+	}
+	type match struct {
+		Command string `json:"command"`
+		Shell   string `json:"shell"`
+		Runs    int    `json:"runs"`
+		Last    string `json:"last,omitempty"`
+	}
+	matches := []match{}
+	for _, result := range analyzer.Search(data, q, args.limit()) {
+		m := match{Command: redact.String(result.Command), Shell: result.Shell, Runs: result.Runs}
+		if !result.Last.IsZero() {
+			m.Last = result.Last.Format(time.RFC3339)
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// filterProperties describe the arguments shared by every tool
+var filterProperties = map[string]interface{}{
+	"since": map[string]string{"type": "string", "description": "Start of the period: a year (2024), quarter (2024-Q1), month (2024-03), day (2024-03-15) or age (30d, 12w, 6m, 1y)"},
+	"until": map[string]string{"type": "string", "description": "End of the period, in the same forms as since"},
+	"shell": map[string]string{"type": "string", "description": "Only this shell: bash, zsh or fish"},
+}
+
+// schema returns an object schema with the filters and extra properties
+func schema(extra map[string]interface{}, required ...string) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for name, property := range filterProperties {
+		properties[name] = property
+	}
+	for name, property := range extra {
+		properties[name] = property
+	}
+	result := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+	return result
+}
+
+var limitProperty = map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxLimit, "description": fmt.Sprintf("How many to return, %d by default", defaultLimit)}
+
+var toolList = []map[string]interface{}{
+	{
+		"name":        "get_top_commands",
+		"description": "The programs the user runs most, with run counts, and the total number of commands in the history.",
+		"inputSchema": schema(map[string]interface{}{"limit": limitProperty}),
+	},
+	{
+		"name":        "get_tool_usage",
+		"description": "How the user's tools are used: editors, languages, build tools, package installs, terminal multiplexers, direnv, network hosts and the files edited.",
+		"inputSchema": schema(nil),
+	},
+	{
+		"name":        "search_history",
+		"description": "Search the shell history for distinct commands, fuzzily, with how often and when they last ran. Secrets in the commands are redacted. The query may include filters such as cat:development or since:2024-01.",
+		"inputSchema": schema(map[string]interface{}{
+			"query": map[string]string{"type": "string", "description": "Text to look for"},
+			"limit": limitProperty,
+		}, "query"),
+	},
+}
@@ -0,0 +1,64 @@
+// internal/models/background.go
+package models
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// snapshotPollInterval is how often the TUI looks for snapshots recorded by
+// a scheduled run while it is open
+const snapshotPollInterval = 30 * time.Second
+
+// snapshotCheckMsg carries the key of the newest stored snapshot
+type snapshotCheckMsg struct {
+	key string
+}
+
+// checkSnapshots reads the newest snapshot key. Errors are treated as no
+// snapshot, since the store is optional for the TUI.
+func checkSnapshots(backend string) tea.Cmd {
+	return func() tea.Msg {
+		s := store.OpenDefault(backend)
+		defer s.Close()
+		key, _ := snapshot.Latest(s)
+		return snapshotCheckMsg{key: key}
+	}
+}
+
+// pollSnapshots checks for snapshots again after snapshotPollInterval
+func pollSnapshots(backend string) tea.Cmd {
+	return tea.Tick(snapshotPollInterval, func(time.Time) tea.Msg {
+		return checkSnapshots(backend)()
+	})
+}
+
+// updateSnapshots remembers the newest snapshot seen at startup and flags
+// any later one, which means a background run finished with newer data
+func (m Model) updateSnapshots(msg snapshotCheckMsg) (tea.Model, tea.Cmd) {
+	if !m.snapshotsChecked {
+		m.snapshotsChecked = true
+		m.knownSnapshot = msg.key
+	} else if msg.key > m.knownSnapshot {
+		m.newerSnapshot = msg.key
+	}
+	return m, pollSnapshots(m.opts.Store)
+}
+
+// reload re-runs the analysis to pick up the data a background run saw
+func (m Model) reload() (tea.Model, tea.Cmd) {
+	m.knownSnapshot = m.newerSnapshot
+	m.newerSnapshot = ""
+	return m, m.analyze()
+}
+
+// newerSnapshotTime returns when the newer background snapshot was taken
+func (m Model) newerSnapshotTime() (time.Time, bool) {
+	if m.newerSnapshot == "" {
+		return time.Time{}, false
+	}
+	return snapshot.KeyTime(m.newerSnapshot)
+}
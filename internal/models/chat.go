@@ -0,0 +1,161 @@
+// internal/models/chat.go
+package models
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// chatRows is how many lines of the conversation the Chat tab shows, and
+// chatWidth how wide they are
+const (
+	chatRows  = 18
+	chatWidth = 76
+)
+
+// chatEvent is a piece of a streamed answer, or its end
+type chatEvent struct {
+	text string
+	done bool
+	err  error
+}
+
+// chatMsg carries a chatEvent to the TUI
+type chatMsg chatEvent
+
+func newChatInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = i18n.T("chat.placeholder")
+	input.Prompt = "? "
+	input.Width = 60
+	return input
+}
+
+// updateChatTab handles the keys of the Chat tab while not typing: select
+// starts typing a question, up and down scroll the conversation
+func (m Model) updateChatTab(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Select):
+		m.chatting = true
+		m.chatInput.Focus()
+		return m, textinput.Blink
+	case key.Matches(msg, m.keys.Up):
+		m.scrollChat(1)
+	case key.Matches(msg, m.keys.Down):
+		m.scrollChat(-1)
+	}
+	return m, nil
+}
+
+// updateChat feeds the keys to the question input while typing. Enter asks
+// the question unless an answer is still coming, Esc stops typing.
+func (m Model) updateChat(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.chatting = false
+		m.chatInput.Blur()
+		return m, nil
+	case tea.KeyPgUp:
+		m.scrollChat(chatRows)
+		return m, nil
+	case tea.KeyPgDown:
+		m.scrollChat(-chatRows)
+		return m, nil
+	case tea.KeyEnter:
+		question := strings.TrimSpace(m.chatInput.Value())
+		if question == "" || m.answering() {
+			return m, nil
+		}
+		m.chatInput.Reset()
+		return m, m.ask(question)
+	}
+	var cmd tea.Cmd
+	m.chatInput, cmd = m.chatInput.Update(msg)
+	return m, cmd
+}
+
+// answering reports whether an answer is still coming
+func (m Model) answering() bool {
+	return len(m.chatTurns) > 0 && !m.chatTurns[len(m.chatTurns)-1].Done
+}
+
+// ask streams the answer to question in the background. The context is
+// prepared here, redacted, from the analysis and the question; earlier
+// questions and answers are sent along so follow-ups make sense.
+func (m *Model) ask(question string) tea.Cmd {
+	var messages []gemini.ChatMessage
+	for _, turn := range m.chatTurns {
+		if turn.Err == nil {
+			messages = append(messages, gemini.ChatMessage{Role: "user", Text: turn.Question}, gemini.ChatMessage{Role: "model", Text: turn.Answer})
+		}
+	}
+	messages = append(messages, gemini.ChatMessage{Role: "user", Text: question})
+	m.chatTurns = append(m.chatTurns, render.ChatTurn{Question: question})
+	m.chatOffset = 0
+
+	events := make(chan chatEvent)
+	m.chatEvents = events
+	data := m.shellData
+	go func() {
+		context := gemini.ChatContext(data, question, clock.Now())
+		err := gemini.StreamChat(context, messages, func(text string) {
+			events <- chatEvent{text: text}
+		})
+		events <- chatEvent{done: true, err: err}
+	}()
+	return listenChat(events)
+}
+
+// listenChat waits for the next piece of the answer
+func listenChat(events <-chan chatEvent) tea.Cmd {
+	return func() tea.Msg {
+		return chatMsg(<-events)
+	}
+}
+
+// updateChatAnswer adds a piece of the answer as it arrives
+func (m Model) updateChatAnswer(msg chatMsg) (tea.Model, tea.Cmd) {
+	if len(m.chatTurns) == 0 {
+		return m, nil
+	}
+	turn := &m.chatTurns[len(m.chatTurns)-1]
+	turn.Answer += msg.text
+	if !msg.done {
+		return m, listenChat(m.chatEvents)
+	}
+	turn.Done, turn.Err = true, msg.err
+	m.fail(problemAI, "", msg.err)
+	return m, nil
+}
+
+// scrollChat moves the conversation up by delta lines, or down when delta
+// is negative, without leaving it
+func (m *Model) scrollChat(delta int) {
+	lines := len(render.ChatTranscript(m.chatTurns, chatWidth))
+	m.chatOffset = max(0, min(m.chatOffset+delta, lines-chatRows))
+}
+
+// chatView renders the Chat tab
+func (m Model) chatView() string {
+	return render.RenderChat(render.ChatTranscript(m.chatTurns, chatWidth), m.chatInput.View(), m.chatOffset, chatRows)
+}
+
+// withoutChat leaves the Chat tab out, when the AI is off
+func withoutChat(tabs []string) []string {
+	var kept []string
+	for _, id := range tabs {
+		if id != "chat" {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
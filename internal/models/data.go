@@ -0,0 +1,34 @@
+// internal/models/data.go
+package models
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// updateData picks the source the Data tab shows and turns redaction of
+// the preview on and off
+func (m Model) updateData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	sources := len(analyzer.DataSources(m.shellData, 0))
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.dataCursor > 0 {
+			m.dataCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.dataCursor < sources-1 {
+			m.dataCursor++
+		}
+	case key.Matches(msg, m.keys.Select):
+		m.dataRaw = !m.dataRaw
+	}
+	return m, nil
+}
+
+// dataToggle is the Select binding described as the redaction toggle
+func (m Model) dataToggle() key.Binding {
+	return key.NewBinding(key.WithKeys(m.keys.Select.Keys()...),
+		key.WithHelp(m.keys.Select.Help().Key, i18n.T("data.toggle")))
+}
@@ -0,0 +1,104 @@
+// internal/models/decks.go
+package models
+
+import (
+	"log/slog"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// decksMsg carries the archived Wrapped decks other than this run's
+type decksMsg struct {
+	decks []gemini.Deck
+	err   error
+}
+
+// archiveDeck saves this run's Wrapped as the deck of the month when record
+// is set, and reads back the archive in the background
+func archiveDeck(backend string, sections []gemini.Section, record bool) tea.Cmd {
+	deck := gemini.Deck{Key: gemini.MonthDeckKey(clock.Now()), Generated: clock.Now(), Sections: sections}
+	return func() tea.Msg {
+		s := store.OpenDefault(backend)
+		defer s.Close()
+
+		var msg decksMsg
+		if record {
+			msg.err = gemini.SaveDeck(s, deck)
+		}
+		decks, err := gemini.LoadDecks(s)
+		if err != nil && msg.err == nil {
+			msg.err = err
+		}
+		for _, d := range decks {
+			// This run's deck is already shown first
+			if !record || d.Key != deck.Key {
+				msg.decks = append(msg.decks, d)
+			}
+		}
+		return msg
+	}
+}
+
+// updateDecks keeps the archive for the deck picker
+func (m Model) updateDecks(msg decksMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		slog.Error("failed to archive Wrapped decks", "err", msg.err)
+	}
+	m.fail(problemArchive, "", msg.err)
+	m.decks = msg.decks
+	if m.deckCursor > len(m.decks) {
+		m.showDeck(0)
+	}
+	return m, nil
+}
+
+// updateDeckPicker picks the deck the Wrapped tab plays, this run's first
+func (m Model) updateDeckPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.deckCursor > 0 {
+			m.showDeck(m.deckCursor - 1)
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.deckCursor < len(m.decks) {
+			m.showDeck(m.deckCursor + 1)
+		}
+	}
+	// An archived deck may have animations where this run's has none
+	return m, tea.Batch(m.animate(), m.advanceSlides())
+}
+
+// showDeck switches to deck i of the picker from its first slide
+func (m *Model) showDeck(i int) {
+	m.deckCursor = i
+	m.currentSectionIndex = 0
+	m.rated = slideRatings{}
+}
+
+// slides returns the slides of the picked deck
+func (m Model) slides() []gemini.Section {
+	if m.deckCursor == 0 {
+		return m.sections
+	}
+	return m.decks[m.deckCursor-1].Sections
+}
+
+// deckPicker lists this run's deck and the archived ones
+func (m Model) deckPicker() string {
+	labels := []string{i18n.T("wrapped.deck.current")}
+	for _, deck := range m.decks {
+		if month, ok := deck.Month(); ok {
+			labels = append(labels, month.Format(i18n.T("date.month")))
+		} else {
+			labels = append(labels, i18n.T("wrapped.deck.year", deck.Key))
+		}
+	}
+	return render.RenderDeckPicker(labels, m.deckCursor,
+		m.help.ShortHelpView([]key.Binding{m.keys.Up, m.keys.Down, m.keys.NextSlide, m.keys.Rate}))
+}
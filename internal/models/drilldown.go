@@ -0,0 +1,24 @@
+// internal/models/drilldown.go
+package models
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// updateDrilldown picks the tool whose subcommands the Top Commands tab
+// breaks down
+func (m Model) updateDrilldown(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tools := len(m.shellData.Subcommands)
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.drilldownCursor > 0 {
+			m.drilldownCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.drilldownCursor < tools-1 {
+			m.drilldownCursor++
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,97 @@
+// internal/models/editor.go
+package models
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// editorFinishedMsg is sent when the user quits $EDITOR
+type editorFinishedMsg struct {
+	err error
+}
+
+// editTarget returns the file and line the active tab is about, so `e` can
+// open it
+func (m Model) editTarget() (analyzer.Location, bool) {
+	if m.loading || m.opts.NoExec || m.opts.ReadOnly {
+		return analyzer.Location{}, false
+	}
+	switch m.tabs[m.activeTab] {
+	case "overview", "suggestions":
+		return aliasesTarget(m.shellData)
+	}
+	return analyzer.Location{}, false
+}
+
+// mainShell returns the shell with the most recorded commands
+func mainShell(data analyzer.ShellData) string {
+	var shell string
+	for name, count := range data.CommandCounts {
+		if shell == "" || count > data.CommandCounts[shell] ||
+			(count == data.CommandCounts[shell] && name < shell) {
+			shell = name
+		}
+	}
+	return shell
+}
+
+// aliasesTarget points at the first alias in the rc file of the most used shell
+func aliasesTarget(data analyzer.ShellData) (analyzer.Location, bool) {
+	config := data.ShellConfigs[mainShell(data)]
+	if config.RCFile == "" {
+		return analyzer.Location{}, false
+	}
+
+	target := analyzer.Location{Path: config.RCFile, Line: 1}
+	first := 0
+	for _, loc := range config.AliasLocations {
+		if loc.Path == config.RCFile && (first == 0 || loc.Line < first) {
+			first = loc.Line
+		}
+	}
+	if first > 0 {
+		target.Line = first
+	}
+	return target, true
+}
+
+// openInEditor suspends the TUI, runs the user's editor at loc and resumes
+// once it exits
+func openInEditor(loc analyzer.Location) tea.Cmd {
+	return tea.ExecProcess(editorCommand(loc), func(err error) tea.Msg {
+		return editorFinishedMsg{err: err}
+	})
+}
+
+// editorCommand builds the command for $VISUAL or $EDITOR (default vi),
+// using the line syntax the editor understands
+func editorCommand(loc analyzer.Location) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+
+	line := strconv.Itoa(loc.Line)
+	switch filepath.Base(args[0]) {
+	case "code", "code-insiders", "codium":
+		args = append(args, "--wait", "-g", loc.Path+":"+line)
+	case "subl", "zed", "hx", "helix":
+		args = append(args, loc.Path+":"+line)
+	default:
+		// vi, vim, nvim, nano, emacs, micro and kak all accept +LINE
+		args = append(args, "+"+line, loc.Path)
+	}
+
+	return exec.Command(args[0], args[1:]...)
+}
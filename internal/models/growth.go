@@ -0,0 +1,78 @@
+// internal/models/growth.go
+package models
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// growthMsg carries a piece of the Growth advice, or its end, from the
+// request it belongs to
+type growthMsg struct {
+	event  chatEvent
+	events chan chatEvent
+}
+
+// adviseGrowth asks the AI for advice the first time the Growth tab is
+// shown after an analysis, streaming it in the background
+func (m *Model) adviseGrowth() tea.Cmd {
+	if m.loading || m.tabs[m.activeTab] != "growth" || m.growthEvents != nil || m.opts.NoAI || !gemini.HasAPIKey() {
+		return nil
+	}
+	events := make(chan chatEvent)
+	m.growthEvents = events
+	data := m.shellData
+	go func() {
+		err := gemini.StreamGrowth(data, analyzer.GrowthPlan(data), func(text string) {
+			events <- chatEvent{text: text}
+		})
+		events <- chatEvent{done: true, err: err}
+	}()
+	return listenGrowth(events)
+}
+
+// listenGrowth waits for the next piece of the advice
+func listenGrowth(events chan chatEvent) tea.Cmd {
+	return func() tea.Msg {
+		return growthMsg{<-events, events}
+	}
+}
+
+// updateGrowth adds a piece of the advice as it arrives. The pieces of a
+// request an analysis since superseded are drained and dropped.
+func (m Model) updateGrowth(msg growthMsg) (tea.Model, tea.Cmd) {
+	if msg.events != m.growthEvents {
+		if msg.event.done {
+			return m, nil
+		}
+		return m, listenGrowth(msg.events)
+	}
+	m.growthAdvice += msg.event.text
+	if !msg.event.done {
+		return m, listenGrowth(msg.events)
+	}
+	m.growthDone, m.growthErr = true, msg.event.err
+	m.fail(problemAI, "", msg.event.err)
+	return m, nil
+}
+
+// resetGrowth forgets the advice, which no longer fits new data
+func (m *Model) resetGrowth() {
+	m.growthEvents = nil
+	m.growthAdvice, m.growthDone, m.growthErr = "", false, nil
+}
+
+// growthView renders the Growth tab with the advice received so far
+func (m Model) growthView() string {
+	advice := m.growthAdvice
+	switch {
+	case m.growthErr != nil:
+		advice = i18n.T("growth.ai_failed", m.growthErr)
+	case m.growthEvents != nil && !m.growthDone && advice == "":
+		advice = i18n.T("growth.ai_thinking")
+	}
+	return render.RenderGrowth(analyzer.GrowthPlan(m.shellData), advice)
+}
@@ -0,0 +1,138 @@
+// internal/models/keys.go
+package models
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// keyMap holds every remappable binding of the TUI
+type keyMap struct {
+	NextTab   key.Binding
+	PrevTab   key.Binding
+	NextSlide key.Binding
+	PrevSlide key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Select    key.Binding
+	Rate      key.Binding
+	Search    key.Binding
+	// FilterShell, FilterCategory and FilterKind cycle the filters of the
+	// Timeline tab
+	FilterShell    key.Binding
+	FilterCategory key.Binding
+	FilterKind     key.Binding
+	Edit           key.Binding
+	Apply          key.Binding
+	Reload         key.Binding
+	Help           key.Binding
+	Quit           key.Binding
+}
+
+// bindings maps the action names used in the config file to the bindings
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"next_tab":        &k.NextTab,
+		"prev_tab":        &k.PrevTab,
+		"next_slide":      &k.NextSlide,
+		"prev_slide":      &k.PrevSlide,
+		"up":              &k.Up,
+		"down":            &k.Down,
+		"select":          &k.Select,
+		"rate":            &k.Rate,
+		"search":          &k.Search,
+		"filter_shell":    &k.FilterShell,
+		"filter_category": &k.FilterCategory,
+		"filter_kind":     &k.FilterKind,
+		"edit":            &k.Edit,
+		"apply":           &k.Apply,
+		"reload":          &k.Reload,
+		"help":            &k.Help,
+		"quit":            &k.Quit,
+	}
+}
+
+// newKeyMap returns the default bindings with the overrides from the
+// config file applied, and the override names that match no action
+func newKeyMap(overrides map[string][]string) (keyMap, []string) {
+	k := keyMap{
+		NextTab:        key.NewBinding(key.WithKeys("tab")),
+		PrevTab:        key.NewBinding(key.WithKeys("shift+tab")),
+		NextSlide:      key.NewBinding(key.WithKeys("right", "l", "n")),
+		PrevSlide:      key.NewBinding(key.WithKeys("left", "h", "p")),
+		Up:             key.NewBinding(key.WithKeys("up", "k")),
+		Down:           key.NewBinding(key.WithKeys("down", "j")),
+		Select:         key.NewBinding(key.WithKeys("enter", " ")),
+		Rate:           key.NewBinding(key.WithKeys("1", "2", "3", "4", "5")),
+		Search:         key.NewBinding(key.WithKeys("/")),
+		FilterShell:    key.NewBinding(key.WithKeys("s")),
+		FilterCategory: key.NewBinding(key.WithKeys("c")),
+		FilterKind:     key.NewBinding(key.WithKeys("t")),
+		Edit:           key.NewBinding(key.WithKeys("e")),
+		Apply:          key.NewBinding(key.WithKeys("a")),
+		Reload:         key.NewBinding(key.WithKeys("r")),
+		Help:           key.NewBinding(key.WithKeys("?")),
+		Quit:           key.NewBinding(key.WithKeys("q", "ctrl+c")),
+	}
+
+	bindings := k.bindings()
+	var unknown []string
+	for action, keys := range overrides {
+		binding, ok := bindings[action]
+		if !ok {
+			unknown = append(unknown, action)
+			continue
+		}
+		if len(keys) > 0 {
+			binding.SetKeys(keys...)
+		}
+	}
+	sort.Strings(unknown)
+
+	for action, binding := range bindings {
+		binding.SetHelp(keyNames(binding.Keys()), i18n.T("keys."+action))
+	}
+	return k, unknown
+}
+
+// keyNames joins keys for display, spelling out the space bar
+func keyNames(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		names[i] = k
+	}
+	return strings.Join(names, "/")
+}
+
+// shortHelp lists the bindings shown in the footer
+func (k keyMap) shortHelp() []key.Binding {
+	return []key.Binding{k.NextTab, k.Search, k.Help, k.Quit}
+}
+
+// fullHelp lists every binding in columns for the help overlay
+func (k keyMap) fullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.NextTab, k.PrevTab, k.NextSlide, k.PrevSlide, k.Rate},
+		{k.Up, k.Down, k.Select, k.Search},
+		{k.FilterShell, k.FilterCategory, k.FilterKind},
+		{k.Edit, k.Apply, k.Reload},
+		{k.Help, k.Quit},
+	}
+}
+
+// rating is the score a Rate key gives, its position among the keys
+func (k keyMap) rating(msg tea.KeyMsg) (int, bool) {
+	for i, name := range k.Rate.Keys() {
+		if name == msg.String() {
+			return min(i+1, 5), true
+		}
+	}
+	return 0, false
+}
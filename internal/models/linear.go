@@ -0,0 +1,164 @@
+// internal/models/linear.go
+package models
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/capability"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/logging"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/types"
+)
+
+// linearReport holds everything the tabs show, rendered as plain text
+type linearReport struct {
+	data         analyzer.ShellData
+	capabilities capability.Report
+	timeline     []types.TimelineEntry
+	sections     []gemini.Section
+	growth       string
+	trends       []snapshot.Month
+	problems     []analyzer.Problem
+}
+
+// RunLinear is the screen-reader friendly alternative to the TUI. It prints
+// the same tabs as plain linear text with headings, on the normal screen.
+// When interactive is set a numbered menu is read from in, otherwise every
+// tab is printed in order.
+func RunLinear(opts Options, in io.Reader, out io.Writer, interactive bool) error {
+	render.SetPlain(true)
+	fmt.Fprintln(out, i18n.T("linear.loading"))
+	if period := initialPeriod(opts.Analyzer); period != "all" {
+		fmt.Fprintln(out, periodLabel(period, opts.Analyzer))
+	}
+
+	data := analyzer.Analyze(opts.Analyzer)
+	sections, err := wrappedSections(data, opts.NoAI, nil)
+	problems := append(append([]analyzer.Problem{}, data.Problems...), opts.Problems...)
+	if err != nil {
+		problems = append(problems, analyzer.Problem{ID: problemAI, Err: err})
+	}
+	var growth string
+	if !opts.NoAI && gemini.HasAPIKey() {
+		var growthErr error
+		growth, growthErr = gemini.GenerateGrowth(data, analyzer.GrowthPlan(data))
+		// A failing AI is reported once, from Wrapped
+		if growthErr != nil && err == nil {
+			problems = append(problems, analyzer.Problem{ID: problemAI, Err: growthErr})
+		}
+	}
+	trends := loadTrends(opts.Store, data, opts.Record)
+	if trends.err != nil {
+		problems = append(problems, analyzer.Problem{ID: problemTrends, Err: trends.err})
+	}
+	report := linearReport{
+		data:         data,
+		capabilities: capability.Detect(data, opts.NoAI),
+		timeline:     analyzer.GenerateTimelineData(data),
+		sections:     sections,
+		growth:       growth,
+		trends:       trends.months,
+		problems:     problems,
+	}
+
+	if !interactive {
+		fmt.Fprint(out, "\n"+report.all())
+		return nil
+	}
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "\n"+linearMenu(opts.Analyzer))
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		choice := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		switch choice {
+		case "q", "quit":
+			return nil
+		case "a", "all":
+			fmt.Fprint(out, "\n"+report.all())
+			continue
+		}
+		n, err := strconv.Atoi(choice)
+		tabs := linearTabs(opts.Analyzer)
+		if err != nil || n < 1 || n > len(tabs) {
+			fmt.Fprintln(out, i18n.T("linear.invalid", choice))
+			continue
+		}
+		fmt.Fprint(out, "\n"+report.tab(tabs[n-1]))
+	}
+}
+
+// linearTabs returns the tabs shown in linear mode. Settings and Chat are
+// left out as they are interactive; edit config.yaml instead of Settings.
+func linearTabs(opts analyzer.Options) []string {
+	var tabs []string
+	for _, id := range visibleTabs(opts) {
+		if id != "settings" && id != "chat" {
+			tabs = append(tabs, id)
+		}
+	}
+	return tabs
+}
+
+// linearMenu lists the tabs by number
+func linearMenu(opts analyzer.Options) string {
+	var menu strings.Builder
+	for i, id := range linearTabs(opts) {
+		menu.WriteString(fmt.Sprintf("%d. %s\n", i+1, i18n.T("tab."+id)))
+	}
+	menu.WriteString(i18n.T("linear.prompt") + " ")
+	return menu.String()
+}
+
+func (r linearReport) all() string {
+	var content strings.Builder
+	for _, id := range linearTabs(r.data.Options) {
+		content.WriteString(r.tab(id) + "\n")
+	}
+	return content.String()
+}
+
+func (r linearReport) tab(id string) string {
+	content := render.Heading(i18n.T("tab." + id))
+	if hints := r.capabilities.Hints(id); len(hints) > 0 {
+		content += render.RenderHints(hints) + "\n"
+	}
+	switch id {
+	case "trends":
+		return content + renderTrends(r.trends) + "\n"
+	case "wrapped":
+		return content + linearWrapped(r.sections)
+	case "growth":
+		content += render.RenderGrowth(analyzer.GrowthPlan(r.data), r.growth)
+	case "diagnostics":
+		content += render.RenderProblems(r.problems, logging.Path())
+	case "data":
+		content += renderTab(id, r.data, r.timeline) + "\n" + render.RenderCapabilities(r.capabilities)
+	default:
+		content += renderTab(id, r.data, r.timeline)
+	}
+	return content + "\n"
+}
+
+// linearWrapped writes the Wrapped slides one after another
+func linearWrapped(sections []gemini.Section) string {
+	var content strings.Builder
+	for i, section := range sections {
+		content.WriteString(fmt.Sprintf("## %s: %s\n\n%s\n\n",
+			i18n.T("wrapped.slide", i+1, len(sections)), section.Title, section.Description))
+		if len(section.Quotes) > 0 {
+			content.WriteString(render.RenderQuotes(section.Quotes) + "\n")
+		}
+	}
+	return content.String()
+}
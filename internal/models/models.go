@@ -3,200 +3,839 @@ package models
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/capability"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
 	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/logging"
 	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
 	"github.com/ksauraj/k8au-shell-analyzer/internal/types"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
 )
 
+// Options configures the TUI from command-line flags
+type Options struct {
+	Analyzer analyzer.Options
+	// NoAI keeps everything on the machine and uses the local Wrapped generator
+	NoAI bool
+	// Store is the backend scheduled snapshots are read from
+	Store string
+	// Record saves each analysis as a snapshot for the Trends tab
+	Record bool
+	// Keys remaps actions to keys, e.g. {"next_tab": ["tab", "L"]}
+	Keys map[string][]string
+	// NoExec never starts other programs, such as the editor
+	NoExec bool
+	// ReadOnly never changes the analyzed home, e.g. one mounted into a
+	// container, so suggestions cannot be applied
+	ReadOnly bool
+	// Problems are the failures before the TUI started, e.g. reading the
+	// config file, shown on the Diagnostics tab
+	Problems []analyzer.Problem
+	// Watch refreshes the tabs whenever a history file changes
+	Watch bool
+	// SlideInterval is how long each Wrapped slide shows before the next,
+	// defaultSlideInterval when not positive
+	SlideInterval time.Duration
+}
+
+// defaultSlideInterval is how long a Wrapped slide shows unless configured
+const defaultSlideInterval = 10 * time.Second
+
+// Tab IDs double as message IDs, see internal/i18n
+var tabIDs = []string{"overview", "shells", "top_commands", "tech_profile", "growth", "work_patterns", "tool_usage", "projects", "git", "containers", "security", "suggestions", "config_health", "wrapped", "chat", "achievements", "timeline", "trends", "data", "diagnostics", "settings"}
+
+// visibleTabs leaves out the tabs whose data comes from a disabled module
+func visibleTabs(opts analyzer.Options) []string {
+	var tabs []string
+	for _, id := range tabIDs {
+		if id == "tech_profile" && !opts.Enabled(analyzer.ModuleProbe) {
+			continue
+		}
+		if id == "config_health" && !opts.Enabled(analyzer.ModuleConfig) {
+			continue
+		}
+		tabs = append(tabs, id)
+	}
+	return tabs
+}
+
 type Model struct {
 	viewport              viewport.Model
 	loading               bool
+	progress              loadingState
+	reports               chan analyzer.Progress
 	err                   error
+	failures              []analyzer.Problem
 	shellData             analyzer.ShellData
+	capabilities          capability.Report
 	currentView           string
 	tabs                  []string
 	activeTab             int
-	logger                *log.Logger
 	sections              []gemini.Section
+	rated                 slideRatings
+	decks                 []gemini.Deck
+	deckCursor            int
 	currentSectionIndex   int
 	currentAnimationFrame int
-	animationTicker       *time.Ticker
-	sectionSwitchTicker   *time.Ticker
 	timelineData          []types.TimelineEntry
+	timelineOffset        int
+	timelineFilter        analyzer.TimelineFilter
+	askAPIKey             bool
+	keyInput              textinput.Model
+	opts                  Options
+	settingsCursor        int
+	settingsStatus        string
+	period                string
+	drilldownCursor       int
+	dataCursor            int
+	dataRaw               bool
+	notice                string
+	searching             bool
+	searchInput           textinput.Model
+	searchResults         []analyzer.SearchResult
+	searchCursor          int
+	searchOffset          int
+	chatting              bool
+	chatInput             textinput.Model
+	chatTurns             []render.ChatTurn
+	chatEvents            chan chatEvent
+	chatOffset            int
+	growthEvents          chan chatEvent
+	growthAdvice          string
+	growthDone            bool
+	growthErr             error
+	snapshotsChecked      bool
+	knownSnapshot         string
+	newerSnapshot         string
+	trends                []snapshot.Month
+	trendsLoaded          bool
+	keys                  keyMap
+	help                  help.Model
+	showHelp              bool
+	// wrappedStream delivers the slides as the AI writes them, streaming
+	// is set until they are complete and typed counts the runes of the
+	// newest slide shown so far, for the typing effect
+	wrappedStream chan []gemini.Section
+	streaming     bool
+	typed         int
+	typing        bool
+	// animating is set while the animation frames of the slides play
+	animating bool
+	// slideTick tells the current auto-advance timer from those a key or
+	// a new deck superseded; slidesPaused stops advancing
+	slideTick    int
+	slidesPaused bool
+	// changes tells of writes to the history files with --watch, and
+	// stale of one not read yet as an analysis or refresh was running
+	changes    chan struct{}
+	refreshing bool
+	stale      bool
+	updated    time.Time
 }
 
-func InitialModel() Model {
-	logFile, err := os.OpenFile("shell_analyzer.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-	if err != nil {
-		log.Fatal(err)
+func InitialModel(opts Options) Model {
+	failures := append([]analyzer.Problem{}, opts.Problems...)
+	keys, unknown := newKeyMap(opts.Keys)
+	if len(unknown) > 0 {
+		slog.Warn("ignoring unknown key bindings in config", "actions", unknown)
+		failures = append(failures, analyzer.Problem{ID: problemKeys, Path: config.Path(),
+			Err: fmt.Errorf("unknown actions: %s", strings.Join(unknown, ", "))})
+	}
+
+	// First run without an API key: ask for one while the analysis runs
+	keyInput := textinput.New()
+	keyInput.Placeholder = i18n.T("wizard.input")
+	keyInput.EchoMode = textinput.EchoPassword
+	keyInput.Width = 48
+	keyInput.Focus()
+
+	tabs := visibleTabs(opts.Analyzer)
+	if opts.NoAI {
+		tabs = withoutChat(tabs)
 	}
-	logger := log.New(logFile, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
 
-	tabs := []string{"Overview", "Tech Profile", "Work Patterns", "Tool Usage", "Wrapped", "Timeline"}
+	reports, report := newProgress()
+	opts.Analyzer.Progress = report
 
-	animationTicker := time.NewTicker(500 * time.Millisecond)
-	sectionSwitchTicker := time.NewTicker(10 * time.Second)
+	var changes chan struct{}
+	if opts.Watch {
+		changes = watchFiles(analyzer.HistoryFiles(opts.Analyzer))
+	}
 
 	return Model{
-		viewport:            viewport.New(80, 24),
-		loading:             true,
-		currentView:         "main",
-		tabs:                tabs,
-		activeTab:           0,
-		logger:              logger,
-		animationTicker:     animationTicker,
-		sectionSwitchTicker: sectionSwitchTicker,
+		viewport:    viewport.New(80, 24),
+		loading:     true,
+		failures:    failures,
+		reports:     reports,
+		currentView: "main",
+		tabs:        tabs,
+		activeTab:   0,
+		askAPIKey:   !opts.NoAI && !gemini.HasAPIKey(),
+		keyInput:    keyInput,
+		chatInput:   newChatInput(),
+		opts:        opts,
+		period:      initialPeriod(opts.Analyzer),
+		keys:        keys,
+		help:        help.New(),
+		changes:     changes,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
-		analyzer.AnalyzeShells,
+	cmds := []tea.Cmd{
+		analyzer.AnalyzeShellsWith(m.opts.Analyzer),
+		listenProgress(m.reports),
+		checkSnapshots(m.opts.Store),
 		tea.EnterAltScreen,
-	)
+	}
+	if m.askAPIKey {
+		cmds = append(cmds, textinput.Blink)
+	}
+	if m.opts.Watch {
+		cmds = append(cmds, listenHistory(m.changes))
+	}
+	return tea.Batch(cmds...)
+}
+
+// updateKeyWizard handles input while the API key wizard is shown. Enter
+// stores the key, Esc skips it and uses the offline Wrapped generator.
+func (m Model) updateKeyWizard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.askAPIKey = false
+	case tea.KeyEnter:
+		key := strings.TrimSpace(m.keyInput.Value())
+		if key == "" {
+			return m, nil
+		}
+		if where, err := gemini.StoreAPIKey(key); err != nil {
+			slog.Warn("failed to store API key, using it for this session only", "err", err)
+			m.fail(problemAPIKey, "", err)
+		} else {
+			slog.Info("stored API key", "where", where)
+		}
+		gemini.SetAPIKey(key)
+		m.capabilities.AI = true
+		m.askAPIKey = false
+	default:
+		var cmd tea.Cmd
+		m.keyInput, cmd = m.keyInput.Update(msg)
+		return m, cmd
+	}
+
+	// The analysis may have finished while the wizard was open
+	if !m.loading {
+		return m, m.generateWrapped()
+	}
+	return m, nil
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.askAPIKey {
+		return m.updateKeyWizard(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.searching {
+		return m.updateSearch(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.chatting {
+		return m.updateChat(keyMsg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		// Any key closes the help overlay
+		if m.showHelp {
+			m.showHelp = false
+			if msg.Type == tea.KeyCtrlC {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		if m.tabs[m.activeTab] == "settings" && key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Select) {
+			return m.updateSettings(msg)
+		}
+		if m.tabs[m.activeTab] == "top_commands" && key.Matches(msg, m.keys.Up, m.keys.Down) {
+			return m.updateDrilldown(msg)
+		}
+		if m.tabs[m.activeTab] == "data" && key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Select) {
+			return m.updateData(msg)
+		}
+		if m.tabs[m.activeTab] == "wrapped" && key.Matches(msg, m.keys.Up, m.keys.Down) {
+			return m.updateDeckPicker(msg)
+		}
+		if m.tabs[m.activeTab] == "wrapped" && key.Matches(msg, m.keys.Select) {
+			m.slidesPaused = !m.slidesPaused
+			return m, m.advanceSlides()
+		}
+		if m.tabs[m.activeTab] == "timeline" && m.timelineKey(msg) {
+			return m.updateTimeline(msg)
+		}
+		if m.tabs[m.activeTab] == "chat" && key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Select) {
+			return m.updateChatTab(msg)
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
-		case "tab":
-			m.activeTab = (m.activeTab + 1) % len(m.tabs)
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = true
 			return m, nil
-		case "right", "l", "n":
-			if len(m.sections) > 0 {
-				m.currentSectionIndex = (m.currentSectionIndex + 1) % len(m.sections)
+		case key.Matches(msg, m.keys.Reload):
+			if m.newerSnapshot != "" && !m.loading {
+				return m.reload()
 			}
 			return m, nil
-		case "left", "h", "p":
-			if len(m.sections) > 0 {
+		case key.Matches(msg, m.keys.Search):
+			if !m.loading {
+				return m.openSearch()
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Edit):
+			if target, ok := m.editTarget(); ok {
+				return m, openInEditor(target)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Apply):
+			return m.applySuggestions()
+		case key.Matches(msg, m.keys.NextTab):
+			m.activeTab = (m.activeTab + 1) % len(m.tabs)
+			return m, m.adviseGrowth()
+		case key.Matches(msg, m.keys.PrevTab):
+			m.activeTab = (m.activeTab + len(m.tabs) - 1) % len(m.tabs)
+			return m, m.adviseGrowth()
+		case m.tabs[m.activeTab] == "wrapped" && key.Matches(msg, m.keys.Rate):
+			if score, ok := m.keys.rating(msg); ok && len(m.slides()) > 0 {
+				if err := m.rated.rate(m.slides(), m.currentSectionIndex, score); err != nil {
+					m.notice = i18n.T("wrapped.rate_failed", err)
+				}
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.NextSlide):
+			if slides := m.slides(); len(slides) > 0 {
+				m.currentSectionIndex = (m.currentSectionIndex + 1) % len(slides)
+			}
+			return m, m.advanceSlides()
+		case key.Matches(msg, m.keys.PrevSlide):
+			if slides := m.slides(); len(slides) > 0 {
 				m.currentSectionIndex--
 				if m.currentSectionIndex < 0 {
-					m.currentSectionIndex = len(m.sections) - 1
+					m.currentSectionIndex = len(slides) - 1
 				}
 			}
-			return m, nil
+			return m, m.advanceSlides()
 		}
 
 	case analyzer.ShellData:
 		m.loading = false
-		m.shellData = msg
-		m.timelineData = analyzer.GenerateTimelineData(msg)
+		m.setData(msg)
 
-		wrappedResp, err := gemini.GenerateWrapped(analyzer.ShellDataToString(msg))
-		if err != nil {
-			m.err = err
-			m.logger.Printf("Error generating wrapped response: %v", err)
-			return m, nil
+		// Wait for the API key wizard before generating the Wrapped view
+		var generate tea.Cmd
+		if !m.askAPIKey {
+			generate = m.generateWrapped()
 		}
 
-		// Debug log
-		m.logger.Printf("Generated %d sections", len(wrappedResp.Sections))
-
-		// Remove animation data and store sections
-		m.sections = make([]gemini.Section, len(wrappedResp.Sections))
-		for i := range wrappedResp.Sections {
-			m.sections[i] = wrappedResp.Sections[i]
-			m.sections[i].Animation = nil
+		// Commands run during the analysis were left for a refresh
+		var live tea.Cmd
+		if m.stale && !m.refreshing {
+			live = m.refresh()
 		}
 
-		m.currentSectionIndex = 0
+		return m, tea.Batch(recordTrends(m.opts.Store, msg, m.opts.Record), generate, m.adviseGrowth(), live)
+
+	case historyChangedMsg:
+		return m.updateWatch()
+
+	case liveDataMsg:
+		return m.updateLive(msg)
+
+	case progressMsg:
+		m.progress.update(analyzer.Progress(msg))
+		return m, listenProgress(m.reports)
+
+	case wrappedMsg:
+		return m.updateWrapped(msg)
 
-		// Debug log
-		m.logger.Printf("Stored %d sections, starting at index %d",
-			len(m.sections), m.currentSectionIndex)
+	case streamedMsg:
+		return m.updateStreamed(msg)
 
-		// Start the section switch ticker if we have sections
-		if len(m.sections) > 0 {
-			m.sectionSwitchTicker = time.NewTicker(10 * time.Second)
+	case animationMsg:
+		if !m.hasAnimation() {
+			m.animating = false
+			return m, nil
 		}
+		m.currentAnimationFrame++
+		return m, nextAnimation()
 
-		return m, nil
+	case typeMsg:
+		if !m.streaming {
+			m.typing = false
+			return m, nil
+		}
+		m.typed += typeRate
+		return m, nextType()
 
-	case time.Time:
-		if len(m.sections) > 0 {
-			switch msg {
-			case <-m.sectionSwitchTicker.C:
-				m.currentSectionIndex = (m.currentSectionIndex + 1) % len(m.sections)
-				return m, nil
-			}
+	case chatMsg:
+		return m.updateChatAnswer(msg)
+
+	case growthMsg:
+		return m.updateGrowth(msg)
+
+	case trendsMsg:
+		return m.updateTrends(msg)
+
+	case decksMsg:
+		return m.updateDecks(msg)
+
+	case snapshotCheckMsg:
+		return m.updateSnapshots(msg)
+
+	case editorFinishedMsg:
+		if msg.err != nil {
+			m.notice = i18n.T("edit.failed", msg.err)
+			return m, nil
+		}
+		// Pick up whatever was changed in the rc files
+		m.notice = ""
+		for shell := range m.shellData.ShellConfigs {
+			m.shellData.ShellConfigs[shell] = analyzer.AnalyzeShellConfig(shell, m.opts.Analyzer)
 		}
-		m.viewport, _ = m.viewport.Update(msg)
 		return m, nil
 
+	case slideMsg:
+		// Leave the slide being written in view until the stream ends
+		if int(msg) != m.slideTick || m.streaming {
+			return m, nil
+		}
+		if slides := m.slides(); len(slides) > 0 {
+			m.currentSectionIndex = (m.currentSectionIndex + 1) % len(slides)
+		}
+		return m, m.advanceSlides()
+
 	default:
+		if m.askAPIKey {
+			var cmd tea.Cmd
+			m.keyInput, cmd = m.keyInput.Update(msg)
+			return m, cmd
+		}
+		if m.searching {
+			var cmd tea.Cmd
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			return m, cmd
+		}
+		if m.chatting {
+			var cmd tea.Cmd
+			m.chatInput, cmd = m.chatInput.Update(msg)
+			return m, cmd
+		}
 		m.viewport, _ = m.viewport.Update(msg)
 		return m, nil
 	}
 	return m, nil
 }
 
+// setData shows the result of an analysis
+func (m *Model) setData(data analyzer.ShellData) {
+	m.shellData = data
+	m.updated = clock.Now()
+	m.capabilities = capability.Detect(data, m.opts.NoAI)
+	m.timelineData = analyzer.GenerateTimelineData(data)
+	m.timelineOffset = 0
+	if m.drilldownCursor >= len(data.Subcommands) {
+		m.drilldownCursor = 0
+	}
+	if m.dataCursor >= len(analyzer.DataSources(data, 0)) {
+		m.dataCursor = 0
+	}
+}
+
+// wrappedMsg carries the Wrapped sections generated in the background
+type wrappedMsg struct {
+	sections []gemini.Section
+	err      error
+}
+
+// streamedMsg carries the Wrapped sections written so far, from the stream
+// of one generation
+type streamedMsg struct {
+	sections []gemini.Section
+	stream   chan []gemini.Section
+}
+
+// typeMsg reveals more of the slide being written
+type typeMsg struct{}
+
+func nextType() tea.Cmd {
+	return tea.Tick(slideFrame, func(time.Time) tea.Msg { return typeMsg{} })
+}
+
+// generateWrapped asks Gemini for the Wrapped sections in the background,
+// falling back to the local generator. The loading screen stays up until
+// the first slide streams in.
+func (m *Model) generateWrapped() tea.Cmd {
+	m.progress.generating = true
+	data, noAI := m.shellData, m.opts.NoAI
+	stream := make(chan []gemini.Section, 1)
+	m.wrappedStream = stream
+	generate := func() tea.Msg {
+		sections, err := wrappedSections(data, noAI, func(sections []gemini.Section) {
+			// A newer update supersedes one the TUI has not taken yet
+			select {
+			case <-stream:
+			default:
+			}
+			stream <- sections
+		})
+		close(stream)
+		return wrappedMsg{sections, err}
+	}
+	return tea.Batch(generate, listenStream(stream))
+}
+
+// listenStream waits for more of the Wrapped sections being written
+func listenStream(stream chan []gemini.Section) tea.Cmd {
+	return func() tea.Msg {
+		sections, ok := <-stream
+		if !ok {
+			return nil
+		}
+		return streamedMsg{sections, stream}
+	}
+}
+
+// updateStreamed shows the slides written so far in place of the loading
+// screen, following the newest one while this run's deck is shown
+func (m Model) updateStreamed(msg streamedMsg) (tea.Model, tea.Cmd) {
+	// A generation started since, e.g. by a new language, replaces this one
+	if msg.stream != m.wrappedStream {
+		return m, nil
+	}
+	next := listenStream(msg.stream)
+	if len(msg.sections) == 0 {
+		return m, next
+	}
+	m.progress.generating = false
+	if !m.streaming || len(msg.sections) != len(m.sections) {
+		m.typed = 0
+	}
+	m.streaming = true
+	m.sections = msg.sections
+	if m.deckCursor == 0 {
+		m.currentSectionIndex = len(m.sections) - 1
+	}
+	cmds := []tea.Cmd{next, m.animate()}
+	if !m.typing {
+		m.typing = true
+		cmds = append(cmds, nextType())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// slideMsg advances the Wrapped slides, unless its timer was superseded
+type slideMsg int
+
+// advanceSlides restarts the timer moving to the next slide after the slide
+// interval, superseding the one running, unless the slides are paused
+func (m *Model) advanceSlides() tea.Cmd {
+	m.slideTick++
+	if m.slidesPaused || len(m.slides()) == 0 {
+		return nil
+	}
+	interval := m.opts.SlideInterval
+	if interval <= 0 {
+		interval = defaultSlideInterval
+	}
+	tick := m.slideTick
+	return tea.Tick(interval, func(time.Time) tea.Msg { return slideMsg(tick) })
+}
+
+// animationFrame is how long each frame of a slide's animation shows
+const animationFrame = 500 * time.Millisecond
+
+// animationMsg advances the animation of the Wrapped slides by one frame
+type animationMsg struct{}
+
+func nextAnimation() tea.Cmd {
+	return tea.Tick(animationFrame, func(time.Time) tea.Msg { return animationMsg{} })
+}
+
+// animate starts playing the animation frames of the slides shown, unless
+// they are already playing or there are none
+func (m *Model) animate() tea.Cmd {
+	if m.animating || !m.hasAnimation() {
+		return nil
+	}
+	m.animating = true
+	return nextAnimation()
+}
+
+// hasAnimation reports whether any slide shown has animation frames
+func (m Model) hasAnimation() bool {
+	for _, section := range m.slides() {
+		if len(section.Animation) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// updateWrapped shows the generated sections and archives them
+func (m Model) updateWrapped(msg wrappedMsg) (tea.Model, tea.Cmd) {
+	m.progress.generating = false
+	m.streaming = false
+	if msg.err != nil {
+		slog.Warn("failed to generate Wrapped with AI, using local fallback", "err", msg.err)
+	}
+	m.fail(problemAI, "", msg.err)
+	slog.Debug("generated Wrapped", "sections", len(msg.sections))
+
+	m.sections = msg.sections
+	m.showDeck(0)
+
+	// A deck of part of the history would replace the month's full one
+	return m, tea.Batch(archiveDeck(m.opts.Store, m.sections, m.opts.Record && m.period == "all"), m.animate(), m.advanceSlides())
+}
+
+// analyze re-runs the analysis behind the loading screen
+func (m *Model) analyze() tea.Cmd {
+	m.loading = true
+	m.progress = loadingState{}
+	m.resetGrowth()
+	return analyzer.AnalyzeShellsWith(m.opts.Analyzer)
+}
+
+// wrappedSections returns the Wrapped slides less the cards the user rated
+// low. When the AI request fails the local sections
+// are returned along with the error. onSections, when set, is passed the
+// slides the AI wrote so far as they stream in.
+func wrappedSections(data analyzer.ShellData, noAI bool, onSections func([]gemini.Section)) ([]gemini.Section, error) {
+	var wrappedResp gemini.WrappedResponse
+	var err error
+	if noAI {
+		wrappedResp = gemini.GenerateLocalWrapped(data)
+	} else {
+		wrappedResp, err = gemini.GenerateWrappedStream(data, onSections)
+		if err != nil {
+			wrappedResp = gemini.GenerateLocalWrapped(data)
+		}
+	}
+
+	sections := make([]gemini.Section, len(wrappedResp.Sections))
+	for i := range wrappedResp.Sections {
+		sections[i] = wrappedResp.Sections[i]
+	}
+	if journey, ok := gemini.ShellJourneySection(data.Migration); ok {
+		sections = append(sections, journey)
+	}
+	if elaborate, ok := gemini.ElaborateSection(data.Insights.WorkPatterns.Complexity); ok {
+		sections = append(sections, elaborate)
+	}
+	if retry, ok := gemini.RetrySection(data.Insights.WorkPatterns.Outcomes); ok {
+		sections = append(sections, retry)
+	}
+	if rage, ok := gemini.RageSection(data.Insights.WorkPatterns.RageRepeats); ok {
+		sections = append(sections, rage)
+	}
+	if containers, ok := gemini.ContainersSection(data.Insights.Containers); ok {
+		sections = append(sections, containers)
+	}
+	if forecast, ok := gemini.ForecastSection(analyzer.Forecasts(data, forecastCommands, clock.Now())); ok {
+		sections = append(sections, forecast)
+	}
+
+	return gemini.ApplyRatings(sections, gemini.LoadRatings()), err
+}
+
+// topCommandsShown is the length of the Top Commands leaderboards, and
+// drilldownShown that of the subcommand and flag lists per tool
+const (
+	topCommandsShown = 10
+	drilldownShown   = 8
+)
+
+// dataRecent is how many of the newest entries the Data tab shows per source
+const dataRecent = 10
+
+// forecastCommands is how many of the top programs get a forecast
+const forecastCommands = 3
+
+// renderTab renders every tab except Wrapped, which depends on the slide state
+func renderTab(id string, data analyzer.ShellData, timeline []types.TimelineEntry) string {
+	switch id {
+	case "overview":
+		return render.RenderOverview(data)
+	case "shells":
+		return render.RenderShellComparison(analyzer.CompareShells(data))
+	case "top_commands":
+		return renderTopCommands(data) + "\n" + render.RenderDrilldowns(analyzer.Drilldowns(data, drilldownShown), -1, "")
+	case "tech_profile":
+		return render.RenderTechProfile(data.Insights.TechnicalProfile)
+	case "work_patterns":
+		return render.RenderWorkPatterns(data.Insights.WorkPatterns)
+	case "tool_usage":
+		return render.RenderToolUsage(data.Insights.ToolUsage, data.Options.Enabled(analyzer.ModuleProbe))
+	case "projects":
+		return render.RenderProjects(data.Insights.Projects)
+	case "git":
+		return render.RenderGit(data.Insights.Git, data.Options.GitReflogs)
+	case "containers":
+		return render.RenderContainers(data.Insights.Containers)
+	case "security":
+		return render.RenderSecurity(data.Insights.Security)
+	case "suggestions":
+		return render.RenderSuggestions(data.Insights.Suggestions)
+	case "config_health":
+		return render.RenderConfigHealth(data.ShellConfigs)
+	case "achievements":
+		return render.RenderAchievements(data.Insights.Achievements)
+	case "timeline":
+		return render.RenderTimeline(timeline, 0, timelineRows, timelineFilters(analyzer.TimelineFilter{}), "")
+	case "data":
+		return render.RenderDataSources(analyzer.DataSources(data, dataRecent), -1, true, "")
+	}
+	return ""
+}
+
+func renderTopCommands(data analyzer.ShellData) string {
+	return render.RenderTopCommands(analyzer.TopCommands(data, topCommandsShown), analyzer.TopPrefixes(data, topCommandsShown),
+		analyzer.EntryPoints(data, topCommandsShown))
+}
+
 func (m Model) View() string {
-	if m.loading {
-		return render.RenderLoading()
+	if m.askAPIKey {
+		return render.RenderAPIKeyWizard(m.keyInput.View())
+	}
+	if m.loading || m.progress.generating {
+		return render.RenderLoading(m.progress.stages(m.opts, !m.loading))
 	}
 
 	// Header with title and version
-	header := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("86")).
-		Padding(0, 1).
-		Render("🚀 K8au Shell Analyzer v1.0.1-beta")
+	heading := i18n.T("app.title")
+	if m.period != "all" {
+		heading += " · " + periodLabel(m.period, m.opts.Analyzer)
+	}
+	header := render.RenderHeader(heading)
 
 	// Render tabs
 	tabBar := render.RenderTabs(m.tabs, m.activeTab)
 
-	// Content (existing switch case)
 	var content string
-	switch m.tabs[m.activeTab] {
-	case "Overview":
-		content = render.RenderOverview(m.shellData)
-	case "Tech Profile":
-		content = render.RenderTechProfile(m.shellData.Insights.TechnicalProfile)
-	case "Work Patterns":
-		content = render.RenderWorkPatterns(m.shellData.Insights.WorkPatterns)
-	case "Tool Usage":
-		content = render.RenderToolUsage(m.shellData.Insights.ToolUsage)
-	case "Timeline":
-		content = render.RenderTimeline(m.timelineData)
-	case "Wrapped":
-		if len(m.sections) == 0 {
+	switch tab := m.tabs[m.activeTab]; {
+	case m.searching:
+		content = m.searchView()
+	default:
+		content = renderTab(tab, m.shellData, m.timelineData)
+	case tab == "top_commands":
+		content = renderTopCommands(m.shellData) + "\n" + render.RenderDrilldowns(analyzer.Drilldowns(m.shellData, drilldownShown),
+			m.drilldownCursor, m.help.ShortHelpView([]key.Binding{m.keys.Up, m.keys.Down}))
+	case tab == "data":
+		content = render.RenderDataSources(analyzer.DataSources(m.shellData, dataRecent), m.dataCursor, !m.dataRaw,
+			m.help.ShortHelpView([]key.Binding{m.keys.Up, m.keys.Down, m.dataToggle()})) + "\n" + render.RenderCapabilities(m.capabilities)
+	case tab == "chat":
+		content = m.chatView()
+	case tab == "growth":
+		content = m.growthView()
+	case tab == "timeline":
+		content = m.timelineView()
+	case tab == "diagnostics":
+		content = render.RenderProblems(m.problems(), logging.Path())
+	case tab == "trends":
+		content = i18n.T("trends.loading")
+		if m.trendsLoaded {
+			content = renderTrends(m.trends)
+		}
+	case tab == "settings":
+		content = render.RenderSettings(m.settings(), m.settingsCursor, m.settingsStatus,
+			m.help.ShortHelpView([]key.Binding{m.keys.Up, m.keys.Down, m.keys.Select}))
+	case tab == "wrapped":
+		if slides := m.slides(); len(slides) == 0 {
 			content = lipgloss.NewStyle().
 				Width(50).
 				BorderStyle(lipgloss.RoundedBorder()).
 				Padding(1).
-				Render("Generating wrapped view...")
+				Render(i18n.T("wrapped.generating"))
 		} else {
-			currentSection := m.sections[m.currentSectionIndex]
+			currentSection := slides[m.currentSectionIndex]
+			// The slide being written is typed out as it arrives
+			if m.streaming && m.deckCursor == 0 && m.currentSectionIndex == len(slides)-1 {
+				description := []rune(currentSection.Description)
+				currentSection.Description = string(description[:min(len(description), m.typed)]) + "▌"
+			}
+			counter := i18n.T("wrapped.slide", m.currentSectionIndex+1, len(slides))
+			if m.slidesPaused {
+				counter += "  " + i18n.T("wrapped.paused")
+			}
+			heading := lipgloss.NewStyle().Bold(true).Render(currentSection.Title)
+			if animation := render.RenderAnimation(currentSection.Animation, m.currentAnimationFrame); animation != "" {
+				heading += "\n\n" + animation
+			}
 			content = lipgloss.NewStyle().
 				Width(50).
 				BorderStyle(lipgloss.RoundedBorder()).
 				Padding(1).
 				Render(fmt.Sprintf(
-					"📺 Slide %d/%d\n\n%s\n\n%s\n\n%s",
-					m.currentSectionIndex+1,
-					len(m.sections),
-					lipgloss.NewStyle().Bold(true).Render(currentSection.Title),
+					"%s\n\n%s\n\n%s\n\n%s\n\n%s",
+					counter,
+					heading,
 					lipgloss.NewStyle().Width(48).Render(currentSection.Description),
 					render.RenderQuotes(currentSection.Quotes),
+					m.rated.label(m.currentSectionIndex, m.keys.Rate),
 				))
+			if len(m.decks) > 0 {
+				content = m.deckPicker() + "\n\n" + content
+			}
 		}
 	}
+	if hints := m.capabilities.Hints(m.tabs[m.activeTab]); len(hints) > 0 && !m.searching {
+		content = render.RenderHints(hints) + "\n" + content
+	}
+	if m.showHelp {
+		content = render.RenderHelp(m.help.FullHelpView(m.keys.fullHelp()))
+	}
+
 	// Footer with controls
-	footer := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Padding(0, 1).
-		Render("↑/↓: Navigate • Tab: Switch Views • q: Quit • Left/Right: Change Slides • By Ksauraj")
+	controls := m.help.ShortHelpView(m.keys.shortHelp())
+	if m.searching {
+		controls = i18n.T("search.help")
+	} else if m.chatting {
+		controls = i18n.T("chat.help")
+	} else if target, ok := m.editTarget(); ok {
+		controls += m.help.ShortSeparator + i18n.T("edit.hint", m.keys.Edit.Help().Key, utils.DisplayPath(target.Path))
+		if m.canApplySuggestions() {
+			controls += m.help.ShortSeparator + i18n.T("suggestions.apply_hint", m.keys.Apply.Help().Key, utils.DisplayPath(target.Path))
+		}
+	}
+	footer := render.RenderFooter(controls + " • " + i18n.T("app.credit"))
+	if m.notice != "" {
+		footer = render.RenderFooter(m.notice) + "\n" + footer
+	}
+	if problems := m.problems(); len(problems) > 0 {
+		footer = render.RenderFooter(render.ProblemsIndicator(len(problems))) + "\n" + footer
+	}
+	if alert := render.BudgetAlert(m.shellData.Insights.Budgets); alert != "" {
+		footer = render.RenderFooter(alert) + "\n" + footer
+	}
+	if m.opts.Watch {
+		footer = render.RenderFooter(i18n.T("watch.live", m.updated.Local().Format("15:04:05"))) + "\n" + footer
+	}
+	if taken, ok := m.newerSnapshotTime(); ok {
+		footer = render.RenderFooter(i18n.T("background.newer", taken.Local().Format("15:04"), m.keys.Reload.Help().Key)) + "\n" + footer
+	}
 
 	// Join all components vertically
 	return lipgloss.JoinVertical(
@@ -212,7 +851,5 @@ func (m Model) View() string {
 }
 
 func (m Model) Cleanup() {
-	m.animationTicker.Stop()
-	m.sectionSwitchTicker.Stop()
 	tea.ExitAltScreen()
 }
@@ -0,0 +1,53 @@
+// internal/models/period.go
+package models
+
+import (
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// periodPresets are the ranges the Settings tab cycles through. A range
+// given with --since or --until is "custom" until another is picked.
+var periodPresets = []string{"all", "30d", "90d", "this_year", "last_year"}
+
+// presetRange returns the start and end of a preset; zero means unbounded
+func presetRange(preset string, now time.Time) (time.Time, time.Time) {
+	year := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.Local)
+	switch preset {
+	case "30d":
+		return now.AddDate(0, 0, -30), time.Time{}
+	case "90d":
+		return now.AddDate(0, 0, -90), time.Time{}
+	case "this_year":
+		return year, time.Time{}
+	case "last_year":
+		return year.AddDate(-1, 0, 0), year
+	}
+	return time.Time{}, time.Time{}
+}
+
+// initialPeriod names the range the analysis starts with
+func initialPeriod(opts analyzer.Options) string {
+	if opts.Since.IsZero() && opts.Until.IsZero() {
+		return "all"
+	}
+	return "custom"
+}
+
+// periodLabel describes the analyzed range, or "" when it is all time
+func periodLabel(preset string, opts analyzer.Options) string {
+	if preset != "custom" {
+		return i18n.T("period." + preset)
+	}
+	const layout = "2006-01-02"
+	switch {
+	case opts.Until.IsZero():
+		return i18n.T("period.since", opts.Since.Format(layout))
+	case opts.Since.IsZero():
+		// Until is exclusive, so the last day shown is the one before
+		return i18n.T("period.until", opts.Until.AddDate(0, 0, -1).Format(layout))
+	}
+	return i18n.T("period.range", opts.Since.Format(layout), opts.Until.AddDate(0, 0, -1).Format(layout))
+}
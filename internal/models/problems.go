@@ -0,0 +1,41 @@
+// internal/models/problems.go
+package models
+
+import (
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// ProblemSettings is the ID of the config file failing to load, which the
+// caller reports through Options.Problems
+const ProblemSettings = "settings"
+
+// Problem IDs of failures outside the analysis, see analyzer.Problem
+const (
+	problemKeys    = "keys"
+	problemAI      = "ai"
+	problemAPIKey  = "api_key"
+	problemArchive = "archive"
+	problemTrends  = "trends"
+)
+
+// problems lists what failed this session for the Diagnostics tab, the
+// analysis' problems first
+func (m Model) problems() []analyzer.Problem {
+	problems := append([]analyzer.Problem{}, m.shellData.Problems...)
+	return append(problems, m.failures...)
+}
+
+// fail records a failure outside the analysis, replacing the earlier one
+// of the same kind. A nil err clears it, e.g. once Gemini answers again.
+func (m *Model) fail(id, path string, err error) {
+	var failures []analyzer.Problem
+	for _, f := range m.failures {
+		if f.ID != id {
+			failures = append(failures, f)
+		}
+	}
+	if err != nil {
+		failures = append(failures, analyzer.Problem{ID: id, Path: path, Err: err})
+	}
+	m.failures = failures
+}
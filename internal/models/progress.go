@@ -0,0 +1,103 @@
+// internal/models/progress.go
+package models
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// progressMsg carries one report of the analysis
+type progressMsg analyzer.Progress
+
+// progressReports is how many reports can wait for the TUI before new
+// ones are dropped; a later report supersedes them anyway
+const progressReports = 64
+
+// newProgress returns the channel the analysis reports to and the callback
+// to set as analyzer.Options.Progress. The analysis never waits for the TUI.
+func newProgress() (chan analyzer.Progress, func(analyzer.Progress)) {
+	reports := make(chan analyzer.Progress, progressReports)
+	return reports, func(p analyzer.Progress) {
+		select {
+		case reports <- p:
+		default:
+		}
+	}
+}
+
+// listenProgress waits for the next report of the analysis. One listener is
+// always pending, so a reload is reported too.
+func listenProgress(reports <-chan analyzer.Progress) tea.Cmd {
+	return func() tea.Msg {
+		return progressMsg(<-reports)
+	}
+}
+
+// loadingState is how far the analysis got, shown on the loading screen
+type loadingState struct {
+	// files maps each shell to how much of its history file was read
+	files      map[string]analyzer.Progress
+	probe      analyzer.Progress
+	insights   bool
+	generating bool
+}
+
+// update records a report
+func (l *loadingState) update(p analyzer.Progress) {
+	switch p.Stage {
+	case analyzer.StageHistory:
+		if l.files == nil {
+			l.files = map[string]analyzer.Progress{}
+		}
+		l.files[p.Shell] = p
+	case analyzer.StageProbe:
+		l.probe = p
+	case analyzer.StageInsights:
+		l.insights = true
+	}
+}
+
+// stages lists the steps of the loading screen. analyzed is set once the
+// analysis finished, while the Wrapped slides are generated.
+func (l loadingState) stages(opts Options, analyzed bool) []render.Stage {
+	var stages []render.Stage
+	for _, shell := range analyzer.SupportedShells() {
+		file, ok := l.files[shell]
+		if !ok {
+			continue
+		}
+		stage := render.Stage{Label: i18n.T("progress.history", shell), Done: analyzed || l.insights}
+		if file.Total > 0 {
+			stage.Level = float64(file.Done) / float64(file.Total)
+			stage.Detail = fmt.Sprintf("%.0f%%", stage.Level*100)
+		}
+		stages = append(stages, stage)
+	}
+
+	if opts.Analyzer.Enabled(analyzer.ModuleProbe) {
+		stage := render.Stage{Label: i18n.T("progress.probe"), Done: analyzed}
+		if l.probe.Total > 0 {
+			stage.Level = float64(l.probe.Done) / float64(l.probe.Total)
+			stage.Detail = fmt.Sprintf("%d/%d", l.probe.Done, l.probe.Total)
+			stage.Done = stage.Done || l.probe.Done == l.probe.Total
+		}
+		stages = append(stages, stage)
+	}
+
+	if l.insights || analyzed {
+		stages = append(stages, render.Stage{Label: i18n.T("progress.insights"), Done: analyzed})
+	}
+	if l.generating {
+		label := "progress.ai"
+		if opts.NoAI || !gemini.HasAPIKey() {
+			label = "progress.local"
+		}
+		stages = append(stages, render.Stage{Label: i18n.T(label)})
+	}
+	return stages
+}
@@ -0,0 +1,92 @@
+// internal/models/search.go
+package models
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// searchRows is how many results the search list shows at once
+const searchRows = 15
+
+// openSearch shows the search bar over the active tab, listing the most
+// recent commands until something is typed
+func (m Model) openSearch() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = i18n.T("search.placeholder")
+	input.Prompt = "/ "
+	input.Width = 60
+	input.Focus()
+
+	m.searching = true
+	m.searchInput = input
+	m.runSearch()
+	return m, textinput.Blink
+}
+
+// updateSearch scrolls the results and feeds everything else to the search
+// bar, re-running the search whenever the query changes
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.searching = false
+		m.searchResults = nil
+		return m, nil
+	case tea.KeyUp:
+		m.scrollSearch(-1)
+		return m, nil
+	case tea.KeyDown:
+		m.scrollSearch(1)
+		return m, nil
+	case tea.KeyPgUp:
+		m.scrollSearch(-searchRows)
+		return m, nil
+	case tea.KeyPgDown:
+		m.scrollSearch(searchRows)
+		return m, nil
+	}
+
+	query := m.searchInput.Value()
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	if m.searchInput.Value() != query {
+		m.runSearch()
+	}
+	return m, cmd
+}
+
+// runSearch searches the parsed history for the current query and moves
+// the cursor back to the best match
+func (m *Model) runSearch() {
+	query := analyzer.ParseSearchQuery(m.searchInput.Value())
+	m.searchResults = analyzer.Search(m.shellData, query, 0)
+	m.searchCursor = 0
+	m.searchOffset = 0
+}
+
+// scrollSearch moves the cursor by delta, keeping it inside the visible window
+func (m *Model) scrollSearch(delta int) {
+	m.searchCursor += delta
+	if m.searchCursor >= len(m.searchResults) {
+		m.searchCursor = len(m.searchResults) - 1
+	}
+	if m.searchCursor < 0 {
+		m.searchCursor = 0
+	}
+	if m.searchCursor < m.searchOffset {
+		m.searchOffset = m.searchCursor
+	}
+	if m.searchCursor >= m.searchOffset+searchRows {
+		m.searchOffset = m.searchCursor - searchRows + 1
+	}
+}
+
+// searchView renders the search bar and the visible part of the results
+func (m Model) searchView() string {
+	return render.RenderSearch(m.searchInput.View(), m.searchResults, m.searchCursor, m.searchOffset, searchRows)
+}
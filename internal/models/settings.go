@@ -0,0 +1,177 @@
+// internal/models/settings.go
+package models
+
+import (
+	"log/slog"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// Rows of the Settings tab before the per-shell toggles
+const (
+	settingAI = iota
+	settingRoast
+	settingTheme
+	settingRedaction
+	settingPeriod
+	settingShells
+)
+
+// settings lists the rows of the Settings tab with their current values
+func (m Model) settings() []render.Setting {
+	ai := i18n.T("settings.on")
+	if m.opts.NoAI {
+		ai = i18n.T("settings.off")
+	}
+
+	roast := i18n.T("settings.off")
+	if gemini.Roasting() {
+		roast = i18n.T("settings.on")
+	}
+
+	settings := []render.Setting{
+		{Label: i18n.T("settings.ai"), Value: ai},
+		{Label: i18n.T("settings.roast"), Value: roast},
+		{Label: i18n.T("settings.theme"), Value: render.CurrentTheme()},
+		{Label: i18n.T("settings.redaction"), Value: i18n.T("redaction." + string(redact.CurrentLevel()))},
+		{Label: i18n.T("settings.period"), Value: periodLabel(m.period, m.opts.Analyzer)},
+	}
+	for _, shell := range analyzer.SupportedShells() {
+		value := "[ ]"
+		if m.opts.Analyzer.Includes(shell) {
+			value = "[x]"
+		}
+		settings = append(settings, render.Setting{Label: i18n.T("settings.shell", shell), Value: value})
+	}
+	return settings
+}
+
+// updateSettings moves the cursor and changes the selected setting. Every
+// change is written to the config file straight away.
+func (m Model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.settingsCursor > 0 {
+			m.settingsCursor--
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Down):
+		if m.settingsCursor < len(m.settings())-1 {
+			m.settingsCursor++
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Select):
+		return m.changeSetting(m.settingsCursor)
+	}
+	return m, nil
+}
+
+func (m Model) changeSetting(row int) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch row {
+	case settingAI:
+		m.opts.NoAI = !m.opts.NoAI
+		noAI := m.opts.NoAI
+		m.saveSettings(func(cfg *config.Config) { cfg.NoAI = noAI })
+		if !noAI && !gemini.HasAPIKey() {
+			m.askAPIKey = true
+			return m, nil
+		}
+		if !m.loading {
+			cmd = m.generateWrapped()
+		}
+
+	case settingRoast:
+		roast := !gemini.Roasting()
+		gemini.SetRoast(roast)
+		m.saveSettings(func(cfg *config.Config) { cfg.Roast = roast })
+		if !m.loading && !m.askAPIKey {
+			cmd = m.generateWrapped()
+		}
+
+	case settingTheme:
+		theme := next(render.Themes, render.CurrentTheme())
+		render.SetTheme(theme)
+		m.saveSettings(func(cfg *config.Config) { cfg.Theme = theme })
+
+	case settingRedaction:
+		levels := make([]string, len(redact.Levels))
+		for i, level := range redact.Levels {
+			levels[i] = string(level)
+		}
+		level := next(levels, string(redact.CurrentLevel()))
+		redact.SetLevel(redact.Level(level))
+		m.saveSettings(func(cfg *config.Config) { cfg.Redaction = level })
+
+	case settingPeriod:
+		// The period scopes this session only and is not saved
+		m.period = next(periodPresets, m.period)
+		m.opts.Analyzer.Since, m.opts.Analyzer.Until = presetRange(m.period, clock.Now())
+		m.settingsStatus = ""
+		cmd = m.analyze()
+
+	default:
+		shell := analyzer.SupportedShells()[row-settingShells]
+		shells, ok := toggleShell(m.opts.Analyzer, shell)
+		if !ok {
+			m.settingsStatus = i18n.T("settings.last_shell")
+			return m, nil
+		}
+		m.opts.Analyzer.Shells = shells
+		m.saveSettings(func(cfg *config.Config) { cfg.Shells = shells })
+
+		// Re-run the analysis with the new set of shells
+		cmd = m.analyze()
+	}
+
+	return m, cmd
+}
+
+// saveSettings writes a change to the config file and reports the outcome
+// below the settings
+func (m *Model) saveSettings(fn func(*config.Config)) {
+	if err := config.Update(fn); err != nil {
+		slog.Error("failed to save settings", "err", err)
+		m.settingsStatus = i18n.T("settings.save_failed", err)
+		return
+	}
+	m.settingsStatus = i18n.T("settings.saved")
+}
+
+// toggleShell returns the shells to analyze after toggling shell. Enabling
+// every shell yields nil so shells added later are analyzed too. It refuses
+// to disable the last enabled shell.
+func toggleShell(opts analyzer.Options, shell string) ([]string, bool) {
+	var shells []string
+	for _, s := range analyzer.SupportedShells() {
+		if opts.Includes(s) != (s == shell) {
+			shells = append(shells, s)
+		}
+	}
+	if len(shells) == 0 {
+		return nil, false
+	}
+	if len(shells) == len(analyzer.SupportedShells()) {
+		return nil, true
+	}
+	return shells, true
+}
+
+// next returns the value after current in values, wrapping around
+func next(values []string, current string) string {
+	for i, value := range values {
+		if value == current {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
@@ -0,0 +1,51 @@
+// internal/models/suggestions.go
+package models
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/rcfile"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
+)
+
+// canApplySuggestions reports whether `a` would add aliases on the active tab
+func (m Model) canApplySuggestions() bool {
+	suggestions := m.shellData.Insights.Suggestions
+	if m.loading || m.opts.ReadOnly || m.tabs[m.activeTab] != "suggestions" || len(suggestions.Aliases)+len(suggestions.TypoFixes) == 0 {
+		return false
+	}
+	_, ok := aliasesTarget(m.shellData)
+	return ok
+}
+
+// applySuggestions appends the proposed aliases and typo fixes to the rc
+// file of the most used shell. The file is backed up first, so `undo` reverts the change.
+func (m Model) applySuggestions() (tea.Model, tea.Cmd) {
+	if !m.canApplySuggestions() {
+		return m, nil
+	}
+	shell := mainShell(m.shellData)
+	path := m.shellData.ShellConfigs[shell].RCFile
+
+	lines := []string{"", "# Added by k8au-shell-analyzer: suggested aliases"}
+	for _, sim := range m.shellData.Insights.Suggestions.Aliases {
+		lines = append(lines, sim.Proposal.AliasLine())
+	}
+	for _, fix := range m.shellData.Insights.Suggestions.TypoFixes {
+		lines = append(lines, fix.Proposal.AliasLine())
+	}
+	_, err := rcfile.AppendLines(path, i18n.T("suggestions.reason"), lines)
+	if err != nil && !errors.Is(err, rcfile.ErrNoChange) {
+		m.notice = i18n.T("suggestions.apply_failed", err)
+		return m, nil
+	}
+
+	// The new aliases are taken now, so propose the next best ones
+	m.shellData.ShellConfigs[shell] = analyzer.AnalyzeShellConfig(shell, m.opts.Analyzer)
+	m.shellData.Insights.Suggestions = analyzer.Suggest(m.shellData)
+	m.notice = i18n.T("suggestions.applied", len(lines)-2, utils.DisplayPath(path))
+	return m, nil
+}
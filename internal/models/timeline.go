@@ -0,0 +1,96 @@
+// internal/models/timeline.go
+package models
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// timelineRows is how many commands a page of the timeline shows
+const timelineRows = 20
+
+// timelineKey reports whether msg scrolls or filters the timeline
+func (m Model) timelineKey(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyPgUp || msg.Type == tea.KeyPgDown || key.Matches(msg, m.keys.Up, m.keys.Down,
+		m.keys.NextSlide, m.keys.PrevSlide, m.keys.FilterShell, m.keys.FilterCategory, m.keys.FilterKind)
+}
+
+// updateTimeline scrolls the timeline a command or a page at a time and
+// moves each filter on to its next value, back to all after the last
+func (m Model) updateTimeline(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	filter := &m.timelineFilter
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.scrollTimeline(-1)
+	case key.Matches(msg, m.keys.Down):
+		m.scrollTimeline(1)
+	case msg.Type == tea.KeyPgUp || key.Matches(msg, m.keys.PrevSlide):
+		m.scrollTimeline(-timelineRows)
+	case msg.Type == tea.KeyPgDown || key.Matches(msg, m.keys.NextSlide):
+		m.scrollTimeline(timelineRows)
+	case key.Matches(msg, m.keys.FilterShell):
+		filter.Shell = nextFilter(analyzer.TimelineShells(m.timelineData), filter.Shell)
+		m.timelineOffset = 0
+	case key.Matches(msg, m.keys.FilterCategory):
+		filter.Category = nextFilter(analyzer.TimelineCategories(m.timelineData), filter.Category)
+		m.timelineOffset = 0
+	case key.Matches(msg, m.keys.FilterKind):
+		filter.Kind = nextFilter(analyzer.TimelineKinds, filter.Kind)
+		m.timelineOffset = 0
+	}
+	return m, nil
+}
+
+// nextFilter is the value after current in values, or all ("") after the
+// last one
+func nextFilter(values []string, current string) string {
+	i := slices.Index(values, current)
+	if i+1 < len(values) {
+		return values[i+1]
+	}
+	return ""
+}
+
+// scrollTimeline moves the first command shown by delta, no further than
+// the start of the last page
+func (m *Model) scrollTimeline(delta int) {
+	entries := len(analyzer.FilterTimeline(m.timelineData, m.timelineFilter))
+	last := max(0, entries-1) / timelineRows * timelineRows
+	m.timelineOffset = max(0, min(m.timelineOffset+delta, last))
+}
+
+// timelineView renders the page of the Timeline tab being shown
+func (m Model) timelineView() string {
+	return render.RenderTimeline(analyzer.FilterTimeline(m.timelineData, m.timelineFilter), m.timelineOffset, timelineRows,
+		timelineFilters(m.timelineFilter), m.help.ShortHelpView([]key.Binding{m.keys.Up, m.keys.Down, m.timelinePages(),
+			m.keys.FilterShell, m.keys.FilterCategory, m.keys.FilterKind}))
+}
+
+// timelinePages is the PrevSlide and NextSlide bindings, with PgUp and
+// PgDn, described as turning the timeline's pages
+func (m Model) timelinePages() key.Binding {
+	keys := append(slices.Clone(m.keys.PrevSlide.Keys()), m.keys.NextSlide.Keys()...)
+	return key.NewBinding(key.WithKeys(keys...),
+		key.WithHelp(m.keys.PrevSlide.Help().Key+" "+m.keys.NextSlide.Help().Key+" pgup/pgdown", i18n.T("timeline.pages")))
+}
+
+// timelineFilters describes the filters in effect
+func timelineFilters(filter analyzer.TimelineFilter) string {
+	all := i18n.T("timeline.all")
+	shell, category, kind := all, all, all
+	if filter.Shell != "" {
+		shell = filter.Shell
+	}
+	if filter.Category != "" {
+		category = filter.Category
+	}
+	if filter.Kind != "" {
+		kind = i18n.T("timeline.kind." + filter.Kind)
+	}
+	return i18n.T("timeline.filters", shell, category, kind)
+}
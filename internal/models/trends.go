@@ -0,0 +1,81 @@
+// internal/models/trends.go
+package models
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// trendsMsg carries the monthly trends and, when this run was recorded,
+// the key of its snapshot
+type trendsMsg struct {
+	key    string
+	months []snapshot.Month
+	err    error
+}
+
+// loadTrends saves data as a snapshot when record is set and reads back
+// every snapshot. Both go through the same store, so this run is included
+// even with the in-memory backend.
+func loadTrends(backend string, data analyzer.ShellData, record bool) trendsMsg {
+	s := store.OpenDefault(backend)
+	defer s.Close()
+
+	var msg trendsMsg
+	if record {
+		msg.key, msg.err = snapshot.Save(s, snapshot.New(data, clock.Now()))
+	}
+	snaps, err := snapshot.LoadAll(s)
+	if err != nil && msg.err == nil {
+		msg.err = err
+	}
+	msg.months = snapshot.Trends(snaps)
+	return msg
+}
+
+// recordTrends runs loadTrends in the background
+func recordTrends(backend string, data analyzer.ShellData, record bool) tea.Cmd {
+	return func() tea.Msg {
+		return loadTrends(backend, data, record)
+	}
+}
+
+// renderTrends charts the months and diffs the last two
+func renderTrends(months []snapshot.Month) string {
+	content := render.RenderTrends(months)
+	if n := len(months); n >= 2 {
+		before, after := months[n-2], months[n-1]
+		label := i18n.T("date.month")
+		content += "\n" + render.RenderDiff(snapshot.Compare(before.Last, after.Last,
+			before.Start.Format(label), after.Start.Format(label), true))
+	}
+	return content
+}
+
+// updateTrends keeps the trends and makes sure the snapshot this run saved
+// is not mistaken for one from a background run
+func (m Model) updateTrends(msg trendsMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		slog.Error("failed to record trends", "err", msg.err)
+	}
+	m.fail(problemTrends, "", msg.err)
+	m.trends = msg.months
+	m.trendsLoaded = true
+	if msg.key != "" {
+		m.snapshotsChecked = true
+		if msg.key > m.knownSnapshot {
+			m.knownSnapshot = msg.key
+		}
+		if m.newerSnapshot <= msg.key {
+			m.newerSnapshot = ""
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,170 @@
+// internal/models/watch.go
+package models
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// pollInterval is how often --watch looks at the history files when the
+// system cannot notify it of writes. Shells append a line per command, so
+// a stat is all it takes to notice one.
+const pollInterval = time.Second
+
+// fileStamp is what changes when a shell writes to its history file
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// stampFiles stamps each of files; a missing file gets the zero stamp
+func stampFiles(files map[string]string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(files))
+	for shell, path := range files {
+		if info, err := os.Stat(path); err == nil {
+			stamps[shell] = fileStamp{info.Size(), info.ModTime()}
+		} else {
+			stamps[shell] = fileStamp{}
+		}
+	}
+	return stamps
+}
+
+// historyChangedMsg tells a history file was written to
+type historyChangedMsg struct{}
+
+// liveDataMsg is the analysis with what the shells appended since, and
+// whether anything was
+type liveDataMsg struct {
+	data    analyzer.ShellData
+	changed bool
+}
+
+// watchFiles reports writes to files on the returned channel, which holds
+// one pending change so a burst of writes makes a single refresh. The
+// directories are watched rather than the files, since shells that trim
+// their history replace the file. Where the system cannot notify of
+// writes, the files are polled instead.
+func watchFiles(files map[string]string) chan struct{} {
+	changes := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	watched := make(map[string]bool, len(files))
+	for _, path := range files {
+		if err != nil {
+			break
+		}
+		watched[filepath.Clean(path)] = true
+		// A shell without a history directory has nothing to watch
+		if err = watcher.Add(filepath.Dir(path)); errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err != nil {
+		slog.Warn("failed to watch the history files, polling them instead", "err", err)
+		if watcher != nil {
+			watcher.Close()
+		}
+		go pollFiles(files, notify)
+		return changes
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if watched[filepath.Clean(event.Name)] && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					notify()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("failed to watch the history files", "err", err)
+			}
+		}
+	}()
+	return changes
+}
+
+// pollFiles calls notify whenever a stat of files differs from the last
+func pollFiles(files map[string]string, notify func()) {
+	stamps := stampFiles(files)
+	for range time.Tick(pollInterval) {
+		if current := stampFiles(files); !maps.Equal(stamps, current) {
+			stamps = current
+			notify()
+		}
+	}
+}
+
+// listenHistory waits for the next write to a history file
+func listenHistory(changes <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-changes
+		return historyChangedMsg{}
+	}
+}
+
+// updateWatch reads what was appended to the history files. A change while
+// an analysis or a refresh runs is read once it is done.
+func (m Model) updateWatch() (tea.Model, tea.Cmd) {
+	next := listenHistory(m.changes)
+	if m.loading || m.refreshing {
+		m.stale = true
+		return m, next
+	}
+	return m, tea.Batch(next, m.refresh())
+}
+
+// refresh parses only the commands appended since the shown data, see
+// analyzer.Tail. A history file that was rewritten is analyzed again,
+// from the cache where it still holds.
+func (m *Model) refresh() tea.Cmd {
+	m.refreshing, m.stale = true, false
+	data := m.shellData
+	return func() tea.Msg {
+		live, changed, err := analyzer.Tail(data)
+		if errors.Is(err, analyzer.ErrHistoryRewritten) {
+			opts := data.Options
+			opts.Full = false
+			live, changed = analyzer.Analyze(opts), true
+		}
+		return liveDataMsg{live, changed}
+	}
+}
+
+// updateLive shows a refreshed analysis. The Wrapped slides and the Trends
+// snapshots are left alone: the first run made them, and regenerating them
+// for every command would call the AI and fill the store.
+func (m Model) updateLive(msg liveDataMsg) (tea.Model, tea.Cmd) {
+	m.refreshing = false
+	// An analysis started since supersedes the refresh
+	if m.loading {
+		return m, nil
+	}
+	if msg.changed {
+		m.setData(msg.data)
+	}
+	if m.stale {
+		return m, m.refresh()
+	}
+	return m, nil
+}
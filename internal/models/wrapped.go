@@ -0,0 +1,206 @@
+// internal/models/wrapped.go
+package models
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// slideFrame is the animation step of the slideshow
+const slideFrame = 50 * time.Millisecond
+
+// A slide moves slideShift columns per frame for slideInFrames frames as
+// it comes in, then types out its description typeRate runes per frame
+const (
+	slideInFrames = 8
+	slideShift    = 3
+	typeRate      = 4
+)
+
+// slideWidth is the width of the slide box
+const slideWidth = 60
+
+// frameMsg advances the slideshow animation by one frame
+type frameMsg struct{}
+
+func nextFrame() tea.Cmd {
+	return tea.Tick(slideFrame, func(time.Time) tea.Msg { return frameMsg{} })
+}
+
+// slideshow is the full screen player of `wrapped`. Slides advance on their
+// own every interval unless paused, sliding in from the side they come from.
+type slideshow struct {
+	sections []gemini.Section
+	interval time.Duration
+	keys     keyMap
+	help     help.Model
+	width    int
+
+	current int
+	// frame counts the frames since the current slide was shown, elapsed
+	// the time it has been shown unpaused
+	frame   int
+	elapsed time.Duration
+	back    bool
+	paused  bool
+	ticking bool
+	rated   slideRatings
+	// failed is why the last rating could not be saved
+	failed error
+}
+
+// RunWrapped plays the slides full screen until the user quits. keys are
+// the overrides from the config file, as for the TUI.
+func RunWrapped(sections []gemini.Section, interval time.Duration, keys map[string][]string) error {
+	k, _ := newKeyMap(keys)
+	s := slideshow{sections: sections, interval: interval, keys: k, help: help.New(), width: 80, ticking: true, rated: slideRatings{}}
+	_, err := tea.NewProgram(s, tea.WithAltScreen()).Run()
+	return err
+}
+
+// WriteWrapped prints the slides as plain text, for --accessible and when
+// the output is not a terminal
+func WriteWrapped(out io.Writer, sections []gemini.Section) {
+	render.SetPlain(true)
+	fmt.Fprint(out, render.Heading(i18n.T("tab.wrapped"))+linearWrapped(sections))
+}
+
+func (s slideshow) Init() tea.Cmd {
+	return nextFrame()
+}
+
+func (s slideshow) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+		return s, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, s.keys.Quit):
+			return s, tea.Quit
+		case key.Matches(msg, s.keys.NextSlide):
+			s.show(s.current + 1)
+		case key.Matches(msg, s.keys.PrevSlide):
+			s.show(s.current - 1)
+		case key.Matches(msg, s.keys.Select):
+			s.paused = !s.paused
+		case key.Matches(msg, s.keys.Rate):
+			if score, ok := s.keys.rating(msg); ok {
+				s.failed = s.rated.rate(s.sections, s.current, score)
+			}
+			return s, nil
+		default:
+			return s, nil
+		}
+		if s.ticking {
+			return s, nil
+		}
+		s.ticking = true
+		return s, nextFrame()
+
+	case frameMsg:
+		s.frame++
+		if !s.paused {
+			s.elapsed += slideFrame
+		}
+		if s.elapsed >= s.interval && s.current < len(s.sections)-1 {
+			s.show(s.current + 1)
+		}
+		// Stop ticking while nothing moves, a key starts it again
+		if s.settled() && (s.paused || s.current == len(s.sections)-1) {
+			s.ticking = false
+			return s, nil
+		}
+		return s, nextFrame()
+	}
+	return s, nil
+}
+
+// show switches to slide i, if there is one, and restarts the transition
+func (s *slideshow) show(i int) {
+	if i < 0 || i >= len(s.sections) || i == s.current {
+		return
+	}
+	s.back = i < s.current
+	s.current, s.frame, s.elapsed = i, 0, 0
+}
+
+// typed is how many runes of the description are shown
+func (s slideshow) typed() int {
+	return max(0, s.frame-slideInFrames) * typeRate
+}
+
+// settled reports whether the current slide is fully in and typed out
+func (s slideshow) settled() bool {
+	return s.typed() >= len([]rune(s.sections[s.current].Description))
+}
+
+func (s slideshow) View() string {
+	section := s.sections[s.current]
+	description := []rune(section.Description)
+	content := fmt.Sprintf("%s\n\n%s\n\n%s",
+		i18n.T("wrapped.slide", s.current+1, len(s.sections)),
+		lipgloss.NewStyle().Bold(true).Render(section.Title),
+		string(description[:min(len(description), s.typed())]))
+	if s.settled() && len(section.Quotes) > 0 {
+		content += "\n\n" + render.RenderQuotes(section.Quotes)
+	}
+	box := render.RenderWrapped(lipgloss.NewStyle().Width(slideWidth).Render(content))
+
+	// Slides come in from the right going forward and from the left going back
+	centered := max(0, s.width-lipgloss.Width(box)) / 2
+	margin := centered
+	shift := max(0, slideInFrames-s.frame) * slideShift
+	if s.back {
+		margin -= shift
+	} else {
+		margin += shift
+	}
+	margin = max(0, min(margin, s.width-lipgloss.Width(box)))
+	box = lipgloss.NewStyle().MarginLeft(margin).Render(box)
+
+	pause := key.NewBinding(key.WithKeys(s.keys.Select.Keys()...),
+		key.WithHelp(s.keys.Select.Help().Key, i18n.T("wrapped.pause")))
+	controls := s.help.ShortHelpView([]key.Binding{s.keys.NextSlide, s.keys.PrevSlide, pause, s.keys.Quit})
+	progress := render.RenderSlideProgress(s.current, len(s.sections))
+	if s.paused {
+		progress += "  " + i18n.T("wrapped.paused")
+	}
+	progress += "\n" + s.rated.label(s.current, s.keys.Rate)
+	if s.failed != nil {
+		progress += "\n" + i18n.T("wrapped.rate_failed", s.failed)
+	}
+	return "\n" + box + "\n\n" + lipgloss.NewStyle().MarginLeft(centered).Render(progress) + "\n" + render.RenderFooter(controls)
+}
+
+// slideRatings are the scores given to the slides this session, by index
+type slideRatings map[int]int
+
+// rate saves a score for slide i, replacing the one given to it earlier in
+// the session
+func (r slideRatings) rate(sections []gemini.Section, i, score int) error {
+	_, again := r[i]
+	if err := gemini.RateSection(sections[i], score, again); err != nil {
+		return err
+	}
+	r[i] = score
+	return nil
+}
+
+// label shows the score given to slide i, or how to give one
+func (r slideRatings) label(i int, rate key.Binding) string {
+	if score, ok := r[i]; ok {
+		return i18n.T("wrapped.rated", score)
+	}
+	return i18n.T("wrapped.rate", rate.Help().Key)
+}
@@ -0,0 +1,262 @@
+// internal/rcfile/rcfile.go
+package rcfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// ErrNoChange is returned by Modify when the edit leaves the file as it was
+var ErrNoChange = errors.New("file is already up to date")
+
+// ErrNothingToUndo is returned by Undo when the journal is empty
+var ErrNothingToUndo = errors.New("no changes to undo")
+
+// undoBucket holds one journal entry per modification, keyed by ID
+const undoBucket = "undo"
+
+// idLayout makes IDs sort in the order the changes were made
+const idLayout = "20060102T150405.000000000"
+
+// Change is an undo journal entry for one modification of an rc file
+type Change struct {
+	ID     string    `json:"id"`
+	Path   string    `json:"path"`
+	Backup string    `json:"backup,omitempty"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+	// Created is set when the file did not exist before the change, so
+	// undoing it removes the file
+	Created bool `json:"created,omitempty"`
+}
+
+// BackupDir returns where the previous versions of modified files are kept
+func BackupDir() string {
+	return filepath.Join(store.DefaultDir(), "backups")
+}
+
+func openJournal() (store.Store, error) {
+	return store.NewJSONStore(store.DefaultDir())
+}
+
+// Modify rewrites path with the result of edit, after saving a timestamped
+// backup and recording an undo entry. Every feature that changes a user's rc
+// files must go through Modify so `undo` can restore them.
+func Modify(path, reason string, edit func(content []byte) ([]byte, error)) (Change, error) {
+	now := time.Now()
+	change := Change{
+		ID:     now.UTC().Format(idLayout),
+		Path:   path,
+		Reason: reason,
+		Time:   now,
+	}
+
+	mode := os.FileMode(0644)
+	old, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		change.Created = true
+	case err != nil:
+		return Change{}, fmt.Errorf("failed to read %s: %v", path, err)
+	default:
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+	}
+
+	content, err := edit(old)
+	if err != nil {
+		return Change{}, err
+	}
+	if !change.Created && bytes.Equal(old, content) {
+		return Change{}, ErrNoChange
+	}
+
+	if !change.Created {
+		change.Backup, err = backup(path, old, change.ID, "")
+		if err != nil {
+			return Change{}, err
+		}
+	}
+
+	journal, err := openJournal()
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to open undo journal: %v", err)
+	}
+	defer journal.Close()
+
+	entry, err := json.Marshal(change)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to encode undo entry: %v", err)
+	}
+	if err := journal.Put(undoBucket, change.ID, entry); err != nil {
+		return Change{}, fmt.Errorf("failed to record undo entry: %v", err)
+	}
+
+	if err := writeFile(path, content, mode); err != nil {
+		journal.Delete(undoBucket, change.ID)
+		return Change{}, err
+	}
+	return change, nil
+}
+
+// Rewrite replaces path with the result of edit atomically, without a
+// backup or an undo entry, for edits whose point is that the old content is
+// gone, such as removing secrets from a history file
+func Rewrite(path string, edit func(content []byte) ([]byte, error)) error {
+	old, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	mode := os.FileMode(0600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	content, err := edit(old)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(old, content) {
+		return ErrNoChange
+	}
+	return writeFile(path, content, mode)
+}
+
+// AppendLines adds lines to the end of path, creating it if needed
+func AppendLines(path, reason string, lines []string) (Change, error) {
+	return Modify(path, reason, func(content []byte) ([]byte, error) {
+		if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+			content = append(content, '\n')
+		}
+		return append(content, []byte(strings.Join(lines, "\n")+"\n")...), nil
+	})
+}
+
+// Changes returns the undo journal, oldest change first
+func Changes() ([]Change, error) {
+	journal, err := openJournal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open undo journal: %v", err)
+	}
+	defer journal.Close()
+
+	ids, err := journal.List(undoBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read undo journal: %v", err)
+	}
+
+	var changes []Change
+	for _, id := range ids {
+		raw, err := journal.Get(undoBucket, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read undo entry %s: %v", id, err)
+		}
+		var change Change
+		if err := json.Unmarshal(raw, &change); err != nil {
+			return nil, fmt.Errorf("failed to decode undo entry %s: %v", id, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// Undo restores the file touched by the most recent change to its previous
+// version. The current content is backed up first in case it was edited
+// by hand since.
+func Undo() (Change, error) {
+	changes, err := Changes()
+	if err != nil {
+		return Change{}, err
+	}
+	if len(changes) == 0 {
+		return Change{}, ErrNothingToUndo
+	}
+	change := changes[len(changes)-1]
+
+	if current, err := os.ReadFile(change.Path); err == nil {
+		if _, err := backup(change.Path, current, time.Now().UTC().Format(idLayout), ".undone"); err != nil {
+			return Change{}, err
+		}
+	}
+
+	if change.Created {
+		if err := os.Remove(change.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return Change{}, fmt.Errorf("failed to remove %s: %v", change.Path, err)
+		}
+	} else {
+		previous, err := os.ReadFile(change.Backup)
+		if err != nil {
+			return Change{}, fmt.Errorf("failed to read backup: %v", err)
+		}
+		mode := os.FileMode(0644)
+		if info, err := os.Stat(change.Path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := writeFile(change.Path, previous, mode); err != nil {
+			return Change{}, err
+		}
+	}
+
+	journal, err := openJournal()
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to open undo journal: %v", err)
+	}
+	defer journal.Close()
+	if err := journal.Delete(undoBucket, change.ID); err != nil {
+		return Change{}, fmt.Errorf("failed to update undo journal: %v", err)
+	}
+	return change, nil
+}
+
+// backup saves content as <BackupDir>/<id>-<name><suffix> with owner-only
+// permissions, since rc files often hold secrets
+func backup(path string, content []byte, id, suffix string) (string, error) {
+	if err := os.MkdirAll(BackupDir(), 0700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %v", err)
+	}
+	name := filepath.Join(BackupDir(), id+"-"+strings.TrimPrefix(filepath.Base(path), ".")+suffix)
+	if err := os.WriteFile(name, content, 0600); err != nil {
+		return "", fmt.Errorf("failed to write backup: %v", err)
+	}
+	return name, nil
+}
+
+// writeFile replaces path atomically so a crash never leaves a truncated rc file
+func writeFile(path string, content []byte, mode os.FileMode) error {
+	// Write through symlinks so dotfile managers keep tracking the file
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// internal/render/charts.go
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+)
+
+// chartHeight is the number of rows of the hourly bar chart
+const chartHeight = 6
+
+// eighths are the partial blocks used for the top of each bar
+var eighths = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// shades mark heatmap cells from no activity to the busiest slot
+var shades = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmapDays orders the heatmap rows Monday first
+var heatmapDays = []int{1, 2, 3, 4, 5, 6, 0}
+
+// renderHourlyChart draws commands per hour of day as a vertical bar chart,
+// two columns per hour, with a 00-23 axis below
+func renderHourlyChart(hourly [24]int) string {
+	max := 0
+	for _, count := range hourly {
+		if count > max {
+			max = count
+		}
+	}
+	if max == 0 {
+		return i18n.T("work.no_timestamps") + "\n"
+	}
+
+	var chart strings.Builder
+	for row := chartHeight - 1; row >= 0; row-- {
+		var line strings.Builder
+		for _, count := range hourly {
+			height := count * chartHeight * 8 / max
+			cell := height - row*8
+			switch {
+			case cell >= 8:
+				cell = 8
+			case cell < 0:
+				cell = 0
+			}
+			line.WriteString(strings.Repeat(eighths[cell], 2))
+		}
+		chart.WriteString(color.Cyan.Sprint(line.String()) + "\n")
+	}
+	for hour := 0; hour < 24; hour += 6 {
+		chart.WriteString(fmt.Sprintf("%-12s", fmt.Sprintf("%02d", hour)))
+	}
+	chart.WriteString("\n")
+
+	return chart.String()
+}
+
+// renderHeatmap draws a GitHub-style weekday × hour grid shaded by activity
+func renderHeatmap(activity [7][24]int) string {
+	max := 0
+	for _, day := range activity {
+		for _, count := range day {
+			if count > max {
+				max = count
+			}
+		}
+	}
+	if max == 0 {
+		return ""
+	}
+
+	var heatmap strings.Builder
+	for _, day := range heatmapDays {
+		heatmap.WriteString(fmt.Sprintf("%-4s", i18n.T(fmt.Sprintf("weekday.short.%d", day))))
+		for _, count := range activity[day] {
+			heatmap.WriteString(shadeCell(count, max))
+		}
+		heatmap.WriteString("\n")
+	}
+	heatmap.WriteString(fmt.Sprintf("%-4s", ""))
+	for hour := 0; hour < 24; hour += 6 {
+		heatmap.WriteString(fmt.Sprintf("%-12s", fmt.Sprintf("%02d", hour)))
+	}
+	heatmap.WriteString("\n" + i18n.T("work.legend", strings.Join(shades, " ")) + "\n")
+
+	return heatmap.String()
+}
+
+// shadeCell picks a shade for count relative to the busiest slot. Any
+// activity at all gets at least the lightest shade.
+func shadeCell(count, max int) string {
+	if count == 0 {
+		return strings.Repeat(shades[0], 2)
+	}
+	level := 1 + (count*(len(shades)-1)-1)/max
+	return color.Green.Sprint(strings.Repeat(shades[level], 2))
+}
+
+// renderActivityText describes the same data as the charts in words, for
+// plain mode where charts mean nothing to a screen reader
+func renderActivityText(hourly [24]int, activity [7][24]int) string {
+	var content strings.Builder
+	for hour, count := range hourly {
+		if count > 0 {
+			content.WriteString(i18n.T("work.hour_count", hour, count) + "\n")
+		}
+	}
+	for _, day := range heatmapDays {
+		total, busiest := 0, 0
+		for hour, count := range activity[day] {
+			total += count
+			if count > activity[day][busiest] {
+				busiest = hour
+			}
+		}
+		if total > 0 {
+			content.WriteString(i18n.T("work.day_count",
+				i18n.T(fmt.Sprintf("weekday.%d", day)), total, busiest) + "\n")
+		}
+	}
+	if content.Len() == 0 {
+		return i18n.T("work.no_timestamps") + "\n"
+	}
+	return content.String()
+}
@@ -0,0 +1,82 @@
+// internal/render/plain.go
+package render
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gookit/color"
+)
+
+// plain switches every renderer to linear text for screen readers: no
+// borders, colors, bars or decorative emoji, and markdown-style headings
+var plain bool
+
+// SetPlain enables or disables plain linear output
+func SetPlain(on bool) {
+	plain = on
+	if on {
+		color.Disable()
+	} else {
+		color.Enable = true
+	}
+}
+
+// Heading renders a top-level heading for linear output
+func Heading(text string) string {
+	return "# " + trimSymbols(text) + "\n\n"
+}
+
+// title renders the heading at the top of a tab
+func title(c color.Color, text string) string {
+	if plain {
+		return "## " + trimSymbols(text) + "\n\n"
+	}
+	return c.Sprint(text + "\n\n")
+}
+
+// frame draws the tab border, or returns the content with decorative
+// symbols stripped from each line in plain mode
+func frame(style lipgloss.Style, content string) string {
+	if !plain {
+		return style.Render(content)
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = trimSymbols(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bar draws a 20-cell proficiency bar followed by a space. Plain mode
+// leaves it out since the percentage says the same thing.
+func bar(level float64) string {
+	if plain {
+		return ""
+	}
+	cells := int(level * 20)
+	if cells < 0 {
+		cells = 0
+	}
+	if cells > 20 {
+		cells = 20
+	}
+	return strings.Repeat("█", cells) + strings.Repeat("░", 20-cells) + " "
+}
+
+// trimSymbols removes leading emoji, which screen readers announce by name
+func trimSymbols(line string) string {
+	body := strings.TrimLeftFunc(line, unicode.IsSpace)
+	stripped := strings.TrimLeftFunc(body, isDecoration)
+	if stripped == body {
+		return line
+	}
+	indent := line[:len(line)-len(body)]
+	return indent + strings.TrimLeftFunc(stripped, unicode.IsSpace)
+}
+
+// isDecoration matches emoji along with their variation selectors and joiners
+func isDecoration(r rune) bool {
+	return unicode.Is(unicode.So, r) || r == '\ufe0f' || r == '\u200d'
+}
@@ -3,25 +3,73 @@ package render
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gookit/color"
 	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/capability"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
 	"github.com/ksauraj/k8au-shell-analyzer/internal/types"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/utils"
 )
 
 type WrappedResponse struct {
 	Text string
 }
 
-// RenderLoading renders the loading screen
-func RenderLoading() string {
-	return lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("86")).
-		Render("Analyzing your shell history... 🔍")
+// Stage is a step of the analysis shown on the loading screen. Level is how
+// far it got from 0 to 1 and Detail says so in numbers, both unset when the
+// step cannot tell.
+type Stage struct {
+	Label  string
+	Level  float64
+	Detail string
+	Done   bool
+}
+
+// RenderLoading renders the loading screen with the steps done so far
+func RenderLoading(stages []Stage) string {
+	var content strings.Builder
+	content.WriteString(foreground(lipgloss.NewStyle().Bold(true), activeTheme.title).
+		Render(i18n.T("app.loading")))
+	content.WriteString("\n")
+	for _, stage := range stages {
+		mark := "•"
+		if stage.Done {
+			mark = "✓"
+		}
+		line := "\n" + mark + " " + stage.Label
+		if !stage.Done && stage.Detail != "" {
+			line += "  " + bar(stage.Level) + stage.Detail
+		}
+		content.WriteString(line)
+	}
+	return content.String()
+}
+
+// RenderAPIKeyWizard renders the first-run prompt asking for a Gemini API key
+func RenderAPIKeyWizard(input string) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1).
+		Width(60)
+
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("wizard.title")))
+	content.WriteString(i18n.T("wizard.body") + "\n\n")
+	content.WriteString(input + "\n\n")
+	content.WriteString(i18n.T("wizard.storage", config.Path()) + "\n\n")
+	content.WriteString(color.Gray.Sprint(i18n.T("wizard.help")))
+
+	return frame(style, content.String())
 }
 
 // RenderTabs renders the tab bar
@@ -32,14 +80,16 @@ func RenderTabs(tabs []string, active int) string {
 		style := lipgloss.NewStyle().
 			Padding(0, 2)
 
-		if i == active {
+		if i == active && activeTheme.monochrome {
+			style = style.Bold(true).Reverse(true)
+		} else if i == active {
 			style = style.
 				Bold(true).
-				Background(lipgloss.Color("4")).
-				Foreground(lipgloss.Color("15"))
+				Background(activeTheme.activeTab).
+				Foreground(activeTheme.activeText)
 		}
 
-		tabsDisplay.WriteString(style.Render(tab))
+		tabsDisplay.WriteString(style.Render(i18n.T("tab." + tab)))
 	}
 
 	return tabsDisplay.String()
@@ -51,56 +101,205 @@ func RenderOverview(data analyzer.ShellData) string {
 		Padding(1)
 
 	var content strings.Builder
-	content.WriteString(color.Green.Sprintf("📊 Shell Usage Overview\n\n"))
+	content.WriteString(title(color.Green, i18n.T("overview.title")))
 
-	for shell, history := range data.Histories {
-		content.WriteString(fmt.Sprintf("Shell: %s\n", color.Cyan.Sprint(shell)))
-		content.WriteString(fmt.Sprintf("Commands: %d\n", len(history)))
+	for _, shell := range analyzer.SortedKeys(data.Histories) {
+		content.WriteString(i18n.T("overview.shell", color.Cyan.Sprint(shell)) + "\n")
+		content.WriteString(i18n.T("overview.commands", data.CommandCounts[shell]) + "\n")
 
 		// Add shell configuration information
 		if config, exists := data.ShellConfigs[shell]; exists {
-			content.WriteString("\nConfiguration:\n")
-			content.WriteString(fmt.Sprintf("• Aliases: %d\n", len(config.Aliases)))
-			content.WriteString(fmt.Sprintf("• Plugins: %d\n", len(config.Plugins)))
-			content.WriteString(fmt.Sprintf("• Environment Variables: %d\n", len(config.Environment)))
+			readConfig := data.Options.Enabled(analyzer.ModuleConfig)
+			content.WriteString("\n" + i18n.T("overview.configuration") + "\n")
+			if readConfig {
+				content.WriteString("• " + i18n.T("overview.aliases", len(config.Aliases)) + "\n")
+			}
+			if data.Options.Enabled(analyzer.ModulePlugins) {
+				content.WriteString("• " + i18n.T("overview.plugins", len(config.Plugins)) + "\n")
+			}
+			if readConfig {
+				content.WriteString("• " + i18n.T("overview.env", len(config.Environment)) + "\n")
+			}
+			if len(config.GlobalAliases) > 0 {
+				content.WriteString("• " + i18n.T("overview.global_aliases", len(config.GlobalAliases)) + "\n")
+			}
+			if len(config.NamedDirs) > 0 {
+				content.WriteString("• " + i18n.T("overview.named_dirs", len(config.NamedDirs)) + "\n")
+			}
+			if len(config.Abbreviations) > 0 {
+				content.WriteString("• " + i18n.T("overview.abbreviations", len(config.Abbreviations)) + "\n")
+			}
 
 			// List up to 3 plugins
 			if len(config.Plugins) > 0 {
-				content.WriteString("\nInstalled Plugins:\n")
+				content.WriteString("\n" + i18n.T("overview.plugin_list") + "\n")
 				for i, plugin := range config.Plugins {
 					if i >= 3 { // Show only the first 3 plugins
 						break
 					}
-					content.WriteString(fmt.Sprintf("• %s (from %s)\n",
+					content.WriteString("• " + i18n.T("overview.plugin_from",
 						color.Yellow.Sprint(plugin.Name),
-						plugin.Source))
+						plugin.Source) + "\n")
 				}
 				if len(config.Plugins) > 3 {
-					content.WriteString(fmt.Sprintf("• And %d more...\n", len(config.Plugins)-3))
+					content.WriteString("• " + i18n.T("overview.more", len(config.Plugins)-3) + "\n")
 				}
 			}
 
 			// List some aliases if any
-			if len(config.Aliases) > 0 {
-				content.WriteString("\nSome Aliases:\n")
+			if len(config.Aliases) > len(config.Abbreviations) {
+				content.WriteString("\n" + i18n.T("overview.alias_list") + "\n")
 				count := 0
-				for alias, command := range config.Aliases {
+				for _, alias := range analyzer.SortedKeys(config.Aliases) {
 					if count >= 5 { // Show only first 5 aliases
 						break
 					}
+					if config.Abbreviations[alias] { // Listed with their uses below
+						continue
+					}
 					content.WriteString(fmt.Sprintf("• %s → %s\n",
 						color.Yellow.Sprint(alias),
-						command))
+						config.Aliases[alias]))
 					count++
 				}
 			}
+
+			content.WriteString(renderShortcuts(data, shell))
 		}
 		content.WriteString("\n")
 	}
 
-	return style.Render(content.String())
+	content.WriteString(renderShellJourney(data.Migration, data.Options.Enabled(analyzer.ModuleConfig)))
+	content.WriteString(renderBudgets(data.Insights.Budgets))
+
+	return frame(style, content.String())
+}
+
+// renderBudgets lists the configured budgets with their current share
+func renderBudgets(statuses []analyzer.BudgetStatus) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+	var content strings.Builder
+	content.WriteString("\n" + i18n.T("budgets.title") + "\n")
+	for _, status := range statuses {
+		// No bar in plain mode, so no space before the share either
+		line := strings.TrimLeft(fmt.Sprintf("%s %5.1f%% / %.0f%%  %s", bar(status.Share/100), status.Share, status.Budget.Max, status.Budget.Name), " ")
+		if status.Exceeded {
+			line = color.Red.Sprint(line + "  " + i18n.T("budgets.over"))
+		} else {
+			line = color.Green.Sprint(line + "  " + i18n.T("budgets.within"))
+		}
+		content.WriteString("• " + line + "\n")
+	}
+	return content.String()
+}
+
+// BudgetAlert is the status bar line for the budgets over their limit, or
+// "" when all are within
+func BudgetAlert(statuses []analyzer.BudgetStatus) string {
+	exceeded := analyzer.ExceededBudgets(statuses)
+	if len(exceeded) == 0 {
+		return ""
+	}
+	worst := exceeded[0]
+	alert := i18n.T("budgets.alert", worst.Budget.Name, worst.Share, worst.Budget.Max)
+	if len(exceeded) > 1 {
+		alert += " " + i18n.T("budgets.alert_more", len(exceeded)-1)
+	}
+	return alert
+}
+
+// shortcutList is a heading and the usage listed under it
+type shortcutList struct {
+	heading string
+	usage   []analyzer.ShortcutUsage
+}
+
+// renderShortcuts lists the most used zsh global aliases and named
+// directories, or fish abbreviations, with their uses
+func renderShortcuts(data analyzer.ShellData, shell string) string {
+	var lists []shortcutList
+	switch shell {
+	case "zsh":
+		globals, dirs := analyzer.ZshShortcuts(data)
+		lists = []shortcutList{{"overview.global_alias_list", globals}, {"overview.named_dir_list", dirs}}
+	case "fish":
+		lists = []shortcutList{{"overview.abbreviation_list", analyzer.FishAbbreviations(data)}}
+	}
+
+	var content strings.Builder
+	for _, list := range lists {
+		if len(list.usage) == 0 {
+			continue
+		}
+		content.WriteString("\n" + i18n.T(list.heading) + "\n")
+		for i, usage := range list.usage {
+			if i >= 5 {
+				content.WriteString("• " + i18n.T("overview.more", len(list.usage)-5) + "\n")
+				break
+			}
+			content.WriteString("• " + i18n.T("overview.shortcut", color.Yellow.Sprint(usage.Name), usage.Expansion, usage.Uses) + "\n")
+		}
+	}
+	return content.String()
+}
+
+// renderShellJourney renders the dominant-shell periods and, when the rc
+// files were read, the aliases that did or did not survive each switch
+func renderShellJourney(migration analyzer.ShellMigration, aliases bool) string {
+	if len(migration.Switches) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("journey.title")))
+
+	for _, period := range migration.Periods {
+		content.WriteString("• " + i18n.T("journey.period",
+			color.Cyan.Sprint(period.Shell),
+			period.Start.Format(i18n.T("date.month")),
+			period.End.Format(i18n.T("date.month")),
+			period.Commands) + "\n")
+	}
+
+	for _, sw := range migration.Switches {
+		content.WriteString("\n" + i18n.T("journey.switch",
+			color.Yellow.Sprint(sw.From),
+			color.Yellow.Sprint(sw.To),
+			sw.At.Format(i18n.T("date.month"))) + "\n")
+		if !aliases {
+			continue
+		}
+		total := len(sw.CarriedAliases) + len(sw.MissingAliases)
+		if total == 0 {
+			content.WriteString("• " + i18n.T("journey.no_aliases") + "\n")
+			continue
+		}
+		content.WriteString("• " + i18n.T("journey.carried", len(sw.CarriedAliases), total) + "\n")
+		if len(sw.MissingAliases) > 0 {
+			missing := sw.MissingAliases
+			if len(missing) > 5 {
+				missing = missing[:5]
+			}
+			content.WriteString("• " + i18n.T("journey.left", strings.Join(missing, ", ")) + "\n")
+		}
+	}
+
+	return content.String()
+}
+
+// SkillName returns the display name of a skill
+func SkillName(skill analyzer.Skill) string {
+	if skill.Language {
+		return strings.Title(skill.Name)
+	}
+	return i18n.T("skill." + skill.Name)
 }
 
+// proficiencyShown caps the proficiency list
+const proficiencyShown = 10
+
 // RenderTechProfile renders the tech profile tab
 func RenderTechProfile(profile analyzer.TechProfile) string {
 	style := lipgloss.NewStyle().
@@ -108,71 +307,129 @@ func RenderTechProfile(profile analyzer.TechProfile) string {
 		Padding(1)
 
 	var content strings.Builder
-	content.WriteString(color.Green.Sprintf("💻 Technical Profile\n\n"))
+	content.WriteString(title(color.Green, i18n.T("tech.title")))
 
 	// Primary Role
-	if profile.PrimaryRole != "" {
-		content.WriteString(fmt.Sprintf("🎯 Primary Role: %s\n\n",
-			color.Cyan.Sprint(profile.PrimaryRole)))
+	if skill := profile.PrimarySkill; skill.Name != "" && !skill.Language {
+		content.WriteString(i18n.T("tech.role", color.Cyan.Sprint(i18n.T("role."+skill.Name))) + "\n\n")
+	} else if profile.PrimaryLanguage != "" {
+		content.WriteString(i18n.T("tech.role",
+			color.Cyan.Sprint(i18n.T("persona.developer", strings.Title(profile.PrimaryLanguage)))) + "\n\n")
 	} else {
-		content.WriteString("🎯 Primary Role: Not enough data\n\n")
+		content.WriteString(i18n.T("tech.role", i18n.T("tech.role_none")) + "\n\n")
 	}
 
 	// Tech Stack
-	content.WriteString("💻 Tech Stack:\n")
+	content.WriteString(i18n.T("tech.stack") + "\n")
 	if len(profile.TechStack) > 0 {
 		for _, tech := range profile.TechStack {
 			content.WriteString(fmt.Sprintf("• %s\n", tech))
 		}
 	} else {
-		content.WriteString("No tech stack data available\n")
+		content.WriteString(i18n.T("tech.stack_none") + "\n")
+	}
+	content.WriteString("\n")
+
+	// Prompt Setup
+	content.WriteString(i18n.T("tech.prompt") + "\n")
+	for _, prompt := range profile.Prompts {
+		framework := prompt.Framework
+		if prompt.Theme != "" {
+			framework = i18n.T("tech.prompt_theme", prompt.Framework, prompt.Theme)
+		}
+		line := "• " + prompt.Shell + ": " + color.Cyan.Sprint(framework)
+		if prompt.Config != "" {
+			line += "  " + color.Gray.Sprint(utils.DisplayPath(prompt.Config))
+		}
+		content.WriteString(line + "\n")
+	}
+	if len(profile.Prompts) == 0 {
+		content.WriteString(i18n.T("tech.prompt_none") + "\n")
+	}
+	if len(profile.PromptsInstalled) > 0 {
+		content.WriteString(color.Gray.Sprint(i18n.T("tech.prompt_installed", strings.Join(profile.PromptsInstalled, ", "))) + "\n")
 	}
 	content.WriteString("\n")
 
 	// Secondary Skills
-	content.WriteString("🛠️  Secondary Skills:\n")
+	content.WriteString(i18n.T("tech.skills") + "\n")
 	if len(profile.SecondarySkills) > 0 {
 		for _, skill := range profile.SecondarySkills {
-			content.WriteString(fmt.Sprintf("• %s\n", skill))
+			content.WriteString("• " + i18n.T("tech.skill", SkillName(skill), skill.Confidence*100, skill.Runs) + "\n")
 		}
 	} else {
-		content.WriteString("No secondary skills data available\n")
+		content.WriteString(i18n.T("tech.skills_none") + "\n")
 	}
 	content.WriteString("\n")
 
 	// Proficiency Levels
-	content.WriteString("📊 Proficiency Levels:\n")
+	content.WriteString(i18n.T("tech.proficiency") + "\n")
 	if len(profile.Proficiency) > 0 {
-		// Sort proficiencies for consistent display
-		var items []struct {
-			Name  string
-			Level float64
-		}
-		for tech, level := range profile.Proficiency {
-			items = append(items, struct {
-				Name  string
-				Level float64
-			}{tech, level})
-		}
-		// Sort by proficiency level in descending order
-		sort.Slice(items, func(i, j int) bool {
-			return items[i].Level > items[j].Level
+		content.WriteString(i18n.T("tech.proficiency_about") + "\n")
+		names := analyzer.SortedKeys(profile.Proficiency)
+		// Highest score first, then by name
+		sort.SliceStable(names, func(i, j int) bool {
+			return profile.Proficiency[names[i]] > profile.Proficiency[names[j]]
 		})
-
-		for _, item := range items {
-			bars := int(item.Level * 20)
-			if bars < 0 {
-				bars = 0
-			}
-			barStr := strings.Repeat("█", bars) + strings.Repeat("░", 20-bars)
-			content.WriteString(fmt.Sprintf("%-15s %s %.1f%%\n",
-				item.Name, barStr, item.Level*100))
+		for _, name := range names[:min(len(names), proficiencyShown)] {
+			score := profile.Proficiency[name]
+			content.WriteString(fmt.Sprintf("%-15s %s%3.0f  %s\n",
+				name, bar(score/100), score, i18n.T("tech.level."+analyzer.ProficiencyLevel(score))))
 		}
 	} else {
-		content.WriteString("No proficiency data available\n")
+		content.WriteString(i18n.T("tech.proficiency_no") + "\n")
+	}
+
+	return frame(style, content.String())
+}
+
+// growthWidth is the width the AI advice on the Growth tab wraps at
+const growthWidth = 72
+
+// RenderGrowth renders the Growth tab: the skills and tools to learn next,
+// a practice idea for each skill and the AI's advice, when there is any
+func RenderGrowth(growth analyzer.Growth, advice string) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("growth.title")))
+
+	if len(growth.Skills) == 0 {
+		content.WriteString(i18n.T("growth.none") + "\n")
+	} else {
+		var skills []string
+		for _, skill := range growth.Skills {
+			skills = append(skills, SkillName(skill))
+		}
+		content.WriteString(i18n.T("growth.from", color.Cyan.Sprint(strings.Join(skills, ", "))) + "\n\n")
+
+		content.WriteString(i18n.T("growth.next") + "\n")
+		for _, next := range growth.Next {
+			content.WriteString("• " + i18n.T("growth.next_skill", color.Cyan.Sprint(SkillName(next.Skill)), SkillName(next.After)) + "\n")
+			content.WriteString("  " + color.Gray.Sprint(i18n.T("growth.practice."+next.Skill.Name)) + "\n")
+		}
+		if len(growth.Next) == 0 {
+			content.WriteString(i18n.T("growth.next_none") + "\n")
+		}
+		content.WriteString("\n")
+
+		content.WriteString(i18n.T("growth.tools") + "\n")
+		for _, tool := range growth.Tools {
+			content.WriteString("• " + i18n.T("growth.tool", color.Cyan.Sprint(tool.Program), SkillName(tool.For)) + "\n")
+		}
+		if len(growth.Tools) == 0 {
+			content.WriteString(i18n.T("growth.tools_none") + "\n")
+		}
+	}
+
+	if advice != "" {
+		content.WriteString("\n" + i18n.T("growth.ai") + "\n")
+		content.WriteString(lipgloss.NewStyle().Width(growthWidth).Render(strings.TrimSpace(advice)) + "\n")
 	}
 
-	return style.Render(content.String())
+	return frame(style, content.String())
 }
 
 // RenderWorkPatterns renders the work patterns tab
@@ -182,124 +439,1750 @@ func RenderWorkPatterns(patterns analyzer.WorkPatterns) string {
 		Padding(1)
 
 	var content strings.Builder
-	content.WriteString(color.Yellow.Sprintf("⏰ Work Patterns\n\n"))
+	content.WriteString(title(color.Yellow, i18n.T("work.title")))
 
 	// Daily Activity
-	content.WriteString("📅 Daily Activity:\n")
-	for _, hour := range patterns.PeakHours {
-		content.WriteString(fmt.Sprintf("Peak hour: %02d:00\n", hour))
+	hourly := analyzer.HourlyActivity(patterns)
+	content.WriteString(i18n.T("work.daily") + "\n")
+	if plain {
+		content.WriteString(renderActivityText(hourly, patterns.Activity))
+	} else {
+		content.WriteString(renderHourlyChart(hourly))
+	}
+	if len(patterns.PeakHours) > 0 {
+		var peaks []string
+		for _, hour := range patterns.PeakHours {
+			peaks = append(peaks, fmt.Sprintf("%02d:00", hour))
+		}
+		content.WriteString(i18n.T("work.peak_hours", strings.Join(peaks, ", ")) + "\n")
+	}
+	content.WriteString("\n")
+
+	// Weekly heatmap
+	if heatmap := renderHeatmap(patterns.Activity); heatmap != "" && !plain {
+		content.WriteString(i18n.T("work.weekly") + "\n")
+		content.WriteString(heatmap + "\n")
+	}
+
+	// Sessions
+	content.WriteString(i18n.T("work.sessions") + "\n")
+	if sessions := patterns.Sessions; sessions.Count > 0 {
+		content.WriteString(i18n.T("work.session_count", sessions.Count, int(analyzer.SessionGap.Minutes())) + "\n")
+		content.WriteString(i18n.T("work.session_average", formatDuration(sessions.AverageLength)) + "\n")
+		content.WriteString(i18n.T("work.session_longest", formatDuration(sessions.Longest),
+			sessions.LongestStart.Format("2006-01-02 15:04")) + "\n")
+		content.WriteString(i18n.T("work.session_commands", sessions.CommandsPerSession) + "\n")
+	} else {
+		content.WriteString(i18n.T("work.no_timestamps") + "\n")
 	}
 	content.WriteString("\n")
 
+	// Weekdays and weekends
+	if workLife := patterns.WorkLife; workLife.Total() > 0 {
+		content.WriteString(i18n.T("work.week") + "\n")
+		content.WriteString(fmt.Sprintf("%-20s %s%.1f%%\n", i18n.T("work.weekend_share"), bar(workLife.WeekendShare()), workLife.WeekendShare()*100))
+		content.WriteString(i18n.T("work.weekdays", workLife.WeekdayCommands, workLife.WeekdayDays) + "\n")
+		content.WriteString(i18n.T("work.weekends", workLife.WeekendCommands, workLife.WeekendDays) + "\n")
+		content.WriteString(i18n.T("work.day_span", analyzer.ClockTime(workLife.AverageStart), analyzer.ClockTime(workLife.AverageEnd)) + "\n")
+		if workLife.LateNight > 0 {
+			content.WriteString(i18n.T("work.late_night", workLife.LateNight, workLife.LateNights, workLife.LateNightCoding) + "\n")
+			content.WriteString(i18n.T("work.latest", workLife.Latest.Format("15:04"), workLife.Latest.AddDate(0, 0, -1).Format("2006-01-02")) + "\n")
+		}
+		balance, reasons := workLife.Balance()
+		var why []string
+		for _, reason := range reasons {
+			why = append(why, i18n.T("work.balance."+reason))
+		}
+		if len(why) > 0 {
+			content.WriteString(i18n.T("work.balance", i18n.T("work.balance."+balance)) + " (" + strings.Join(why, ", ") + ")\n")
+		} else {
+			content.WriteString(i18n.T("work.balance", i18n.T("work.balance."+balance)) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
+	// History reuse
+	if reuse := patterns.Reuse; reuse.Entries > 0 {
+		content.WriteString(i18n.T("work.reuse") + "\n")
+		content.WriteString(fmt.Sprintf("%-20s %s%.1f%%\n", i18n.T("work.reuse_rate"), bar(reuse.Rate()), reuse.Rate()*100))
+		content.WriteString(i18n.T("work.reuse_breakdown", reuse.Repeats, reuse.Expansions, reuse.Edits) + "\n")
+		if len(reuse.Tools) > 0 {
+			content.WriteString(i18n.T("work.reuse_tools", strings.Join(reuse.Tools, ", ")) + "\n")
+		} else {
+			content.WriteString(i18n.T("work.reuse_no_tools") + "\n")
+		}
+		content.WriteString("\n")
+	}
+
+	// Exploration time
+	if exploration := patterns.Exploration; exploration.Launches > 0 {
+		content.WriteString(i18n.T("work.exploration") + "\n")
+		content.WriteString(i18n.T("work.exploration_total", formatDuration(exploration.Time), exploration.Launches) + "\n")
+		for _, repl := range exploration.REPLs {
+			content.WriteString(fmt.Sprintf("• %-12s %s\n", repl.Name, i18n.T("work.exploration_repl", formatDuration(repl.Time), repl.Launches)))
+		}
+		content.WriteString("\n")
+	}
+
+	// Failures and durations
+	if outcomes := patterns.Outcomes; outcomes.Exited > 0 || outcomes.Timed > 0 {
+		content.WriteString(i18n.T("work.outcomes") + "\n")
+		content.WriteString(i18n.T("work.outcomes_counted", outcomes.Exited, outcomes.Timed) + "\n")
+		if len(outcomes.Failures) > 0 {
+			content.WriteString(i18n.T("work.failures") + "\n")
+			for _, f := range outcomes.Failures {
+				content.WriteString(fmt.Sprintf("• %-12s %s%s\n", f.Program, bar(f.Rate()), i18n.T("work.failure", f.Rate()*100, f.Runs)))
+			}
+		}
+		if len(outcomes.Slowest) > 0 {
+			content.WriteString(i18n.T("work.slowest") + "\n")
+			for _, slow := range outcomes.Slowest {
+				content.WriteString("• " + color.Cyan.Sprint(redact.String(slow.Command)) + "\n")
+				content.WriteString("  " + i18n.T("work.slow", formatRuntime(slow.Average), formatRuntime(slow.Longest), slow.Runs) + "\n")
+			}
+		}
+		if retried := outcomes.Retried; retried.Retries > 0 {
+			content.WriteString(i18n.T("work.retried", retried.Retries) + "\n")
+			content.WriteString("  " + color.Cyan.Sprint(redact.String(retried.Command)) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
+	// Rage repeats
+	if len(patterns.RageRepeats) > 0 {
+		content.WriteString(i18n.T("work.rage") + "\n")
+		content.WriteString(i18n.T("work.rage_about", int(analyzer.RageGap.Seconds())) + "\n")
+		for _, rage := range patterns.RageRepeats {
+			content.WriteString("• " + color.Cyan.Sprint(redact.String(rage.Command)) + "\n")
+			content.WriteString("  " + i18n.T("work.rage_bursts", rage.Bursts, rage.Longest) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
+	// Command complexity
+	complexity := patterns.Complexity
+	total := 0
+	for _, count := range complexity.Levels {
+		total += count
+	}
+	if total > 0 {
+		content.WriteString(i18n.T("work.complexity") + "\n")
+		for i, level := range analyzer.ComplexityLevels {
+			share := float64(complexity.Levels[i]) / float64(total)
+			content.WriteString(fmt.Sprintf("%-20s %s%.1f%%\n", i18n.T("complexity."+level), bar(share), share*100))
+		}
+		var features []string
+		for _, feature := range analyzer.ComplexityFeatures {
+			if count := complexity.Features[feature]; count > 0 {
+				features = append(features, fmt.Sprintf("%s %d", i18n.T("complexity."+feature), count))
+			}
+		}
+		if len(features) > 0 {
+			content.WriteString(i18n.T("work.complexity_features", strings.Join(features, " · ")) + "\n")
+		}
+		if complexity.LongestPipeline > 1 {
+			content.WriteString(i18n.T("work.complexity_pipeline", complexity.LongestPipeline) + "\n")
+		}
+		if complexity.MostElaborate != "" {
+			content.WriteString(i18n.T("work.complexity_top", complexity.Score) + "\n")
+			content.WriteString("  " + color.Cyan.Sprint(redact.String(complexity.MostElaborate)) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
 	// Productivity Metrics
-	content.WriteString("📈 Productivity Metrics:\n")
-	for metric, value := range patterns.Productivity {
-		bars := int(value * 20)
-		barStr := strings.Repeat("█", bars) + strings.Repeat("░", 20-bars)
-		content.WriteString(fmt.Sprintf("%-20s %s %.1f%%\n", metric, barStr, value*100))
+	content.WriteString(i18n.T("work.productivity") + "\n")
+	for _, metric := range analyzer.SortedKeys(patterns.Productivity) {
+		value := patterns.Productivity[metric]
+		content.WriteString(fmt.Sprintf("%-20s %s%.1f%%\n", i18n.T("metric."+metric), bar(value), value*100))
 	}
 	content.WriteString("\n")
 
 	// Common Workflows
-	content.WriteString("🔄 Common Workflows:\n")
+	content.WriteString(i18n.T("work.workflows") + "\n")
+	if len(patterns.CommonWorkflows) == 0 {
+		content.WriteString(i18n.T("work.workflows_none") + "\n")
+	}
 	for _, workflow := range patterns.CommonWorkflows {
-		content.WriteString(fmt.Sprintf("• %s\n", workflow))
+		content.WriteString("• " + color.Cyan.Sprint(strings.Join(workflow.Steps, " → ")) + " " + i18n.T("work.workflow_runs", workflow.Count) + "\n")
+		if workflow.Alias.Name != "" {
+			content.WriteString("  " + i18n.T("work.workflow_alias", redact.String(workflow.Alias.AliasLine())) + "\n")
+		}
 	}
 
-	return style.Render(content.String())
+	return frame(style, content.String())
 }
 
-func RenderToolUsage(usage analyzer.ToolUsage) string {
+// RenderToolUsage renders the Tool Usage tab. Languages are only known when
+// installed tools were probed.
+func RenderToolUsage(usage analyzer.ToolUsage, languages bool) string {
 	style := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		Padding(1)
 
 	var content strings.Builder
-	content.WriteString(color.Magenta.Sprintf("🔧 Tool Usage Statistics\n\n"))
+	content.WriteString(title(color.Magenta, i18n.T("tools.title")))
 
 	// Editors Section
-	content.WriteString("📝 Editors:\n")
+	content.WriteString(i18n.T("tools.editors") + "\n")
 	if len(usage.Editors) > 0 {
-		for editor, count := range usage.Editors {
-			content.WriteString(fmt.Sprintf("• %s: %d uses\n", editor, count))
+		for _, editor := range byCount(usage.Editors) {
+			content.WriteString("• " + i18n.T("tools.uses", editor, usage.Editors[editor]) + "\n")
 		}
 	} else {
-		content.WriteString("No editor usage data available\n")
+		content.WriteString(i18n.T("tools.editors_none") + "\n")
 	}
 	content.WriteString("\n")
 
+	// Languages edited against languages run
+	edits := usage.Edits
+	if languages := edits.Languages(); len(languages) > 0 {
+		content.WriteString(i18n.T("tools.edited") + "\n")
+		for _, lang := range languages {
+			switch {
+			case edits.Run[lang] == 0:
+				content.WriteString("• " + i18n.T("tools.edited_only", lang, edits.Edited[lang]) + "\n")
+			case edits.Edited[lang] == 0:
+				content.WriteString("• " + color.Gray.Sprint(i18n.T("tools.run_only", lang, edits.Run[lang])) + "\n")
+			default:
+				content.WriteString("• " + i18n.T("tools.edited_run", lang, edits.Edited[lang], edits.Run[lang]) + "\n")
+			}
+		}
+		if len(edits.Files) > 0 {
+			var files []string
+			for _, file := range edits.Files {
+				files = append(files, fmt.Sprintf("%s %d", redact.String(file.Command), file.Count))
+			}
+			content.WriteString(i18n.T("tools.edited_files", strings.Join(files, " · ")) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
 	// Languages Section
-	content.WriteString("💻 Programming Languages:\n")
-	if len(usage.Languages) > 0 {
-		for lang, count := range usage.Languages {
-			content.WriteString(fmt.Sprintf("• %s: %d uses\n", lang, count))
+	if languages {
+		content.WriteString(i18n.T("tools.languages") + "\n")
+		if len(usage.Languages) > 0 {
+			for _, lang := range byCount(usage.Languages) {
+				content.WriteString("• " + i18n.T("tools.uses", lang, usage.Languages[lang]) + "\n")
+			}
+		} else {
+			content.WriteString(i18n.T("tools.languages_none") + "\n")
 		}
-	} else {
-		content.WriteString("No language usage data available\n")
+		content.WriteString("\n")
 	}
-	content.WriteString("\n")
 
 	// Build Tools Section
-	content.WriteString("🛠️  Build Tools:\n")
+	content.WriteString(i18n.T("tools.build") + "\n")
 	if len(usage.BuildTools) > 0 {
-		for tool, count := range usage.BuildTools {
-			content.WriteString(fmt.Sprintf("• %s: %d uses\n", tool, count))
+		for _, tool := range byCount(usage.BuildTools) {
+			content.WriteString("• " + i18n.T("tools.uses", tool, usage.BuildTools[tool]) + "\n")
 		}
 	} else {
-		content.WriteString("No build tool usage data available\n")
+		content.WriteString(i18n.T("tools.build_none") + "\n")
+	}
+	content.WriteString("\n")
+
+	// Multiplexer Section
+	mux := usage.Multiplexers
+	content.WriteString(i18n.T("tools.mux") + "\n")
+	for _, program := range analyzer.SortedCounts(mux.Commands, 0) {
+		content.WriteString("• " + i18n.T("tools.mux_program", program.Command, program.Count) + "\n")
+	}
+	if mux.Total() == 0 && mux.Config == "" {
+		content.WriteString(i18n.T("tools.mux_none") + "\n")
+	}
+	if len(mux.TmuxCommands) > 0 {
+		var commands []string
+		for _, command := range analyzer.SortedCounts(mux.TmuxCommands, muxShown) {
+			commands = append(commands, fmt.Sprintf("%s %d", command.Command, command.Count))
+		}
+		content.WriteString(i18n.T("tools.mux_commands", strings.Join(commands, " · ")) + "\n")
+	}
+	if len(mux.Sessions) > 0 {
+		var sessions []string
+		for _, session := range mux.Sessions {
+			sessions = append(sessions, fmt.Sprintf("%s %d", redact.String(session.Command), session.Count))
+		}
+		content.WriteString(i18n.T("tools.mux_sessions", strings.Join(sessions, " · ")) + "\n")
+	}
+	if mux.Config != "" {
+		content.WriteString("\n" + i18n.T("tools.mux_config", utils.DisplayPath(mux.Config), mux.Prefix, len(mux.Bindings)) + "\n")
+		for i, binding := range mux.Bindings {
+			if i == muxShown {
+				content.WriteString("  " + i18n.T("overview.more", len(mux.Bindings)-muxShown) + "\n")
+				break
+			}
+			key := binding.Key
+			if binding.Root {
+				key = i18n.T("tools.mux_root", key)
+			}
+			content.WriteString("• " + color.Cyan.Sprint(key) + " → " + redact.String(binding.Command) + "\n")
+		}
+		if len(mux.Plugins) > 0 {
+			content.WriteString(i18n.T("tools.mux_plugins") + "\n")
+			for _, plugin := range mux.Plugins {
+				line := "• " + plugin.Name
+				if plugin.Version != "" {
+					line += " " + color.Cyan.Sprint("("+plugin.Version+")")
+				}
+				if plugin.Stale(clock.Now()) {
+					line += "  " + color.Yellow.Sprint(i18n.T("data.plugin_stale", plugin.LastUpdated.Format(i18n.T("date.long"))))
+				}
+				content.WriteString(line + "\n")
+			}
+		}
+	}
+	content.WriteString("\n")
+
+	// Per-project environments
+	content.WriteString(i18n.T("tools.direnv") + "\n")
+	for _, project := range usage.Direnv.Projects {
+		content.WriteString("• " + i18n.T("tools.direnv_project", project.Dir, project.Commands) + "\n")
+	}
+	if len(usage.Direnv.Projects) == 0 {
+		content.WriteString(i18n.T("tools.direnv_none") + "\n")
+	}
+	for _, sequence := range usage.Direnv.ExportSequences {
+		content.WriteString("• " + i18n.T("tools.direnv_exports", strings.Join(sequence.Variables, ", "), sequence.Count, sequence.Dir) + "\n")
 	}
+	content.WriteString("\n")
+
+	// Network Section
+	network := usage.Network
+	content.WriteString(i18n.T("tools.network") + "\n")
+	for i, host := range network.Hosts {
+		if i == networkShown {
+			content.WriteString("  " + i18n.T("overview.more", len(network.Hosts)-networkShown) + "\n")
+			break
+		}
+		line := "• " + i18n.T("tools.network_host", host.Host, host.Count, strings.Join(host.Tools, ", "))
+		if !host.Last.IsZero() {
+			line += color.Gray.Sprint(" — " + i18n.T("projects.last", host.Last.Format(i18n.T("date.long"))))
+		}
+		content.WriteString(line + "\n")
+	}
+	if len(network.Hosts) == 0 {
+		content.WriteString(i18n.T("tools.network_none") + "\n")
+	}
+	content.WriteString("\n" + i18n.T("tools.domains") + "\n")
+	for i, domain := range network.Domains {
+		if i == networkShown {
+			content.WriteString("  " + i18n.T("overview.more", len(network.Domains)-networkShown) + "\n")
+			break
+		}
+		content.WriteString("• " + i18n.T("tools.domain", domain.Command, domain.Count) + "\n")
+	}
+	if len(network.Domains) == 0 {
+		content.WriteString(i18n.T("tools.domains_none") + "\n")
+	}
+	content.WriteString("\n")
+
+	// Package Section
+	packages := usage.Packages
+	content.WriteString(i18n.T("tools.packages") + "\n")
+	if packages.Distinct == 0 {
+		content.WriteString(i18n.T("tools.packages_none") + "\n")
+		return frame(style, content.String())
+	}
+	for _, pkg := range packages.Packages {
+		content.WriteString("• " + i18n.T("tools.package", pkg.Name, pkg.Manager, pkg.Count) + "\n")
+	}
+	if len(packages.New) > 0 {
+		content.WriteString("\n" + i18n.T("tools.packages_new") + "\n")
+		for _, pkg := range packages.New {
+			content.WriteString("• " + i18n.T("tools.package_new", pkg.Name, pkg.Manager, pkg.First.Format(i18n.T("date.long"))) + "\n")
+		}
+	}
+	content.WriteString("\n" + i18n.T("tools.hoarder", packages.HoarderScore(), i18n.T("tools.hoarding."+packages.Hoarding())) + "\n")
+	content.WriteString(color.Gray.Sprint(i18n.T("tools.hoarder_about", packages.Distinct, float64(packages.Distinct)/packages.Months)) + "\n")
 
-	return style.Render(content.String())
+	return frame(style, content.String())
 }
 
-func RenderWrapped(content string) string {
+// networkShown caps the hosts and domains listed in the Tool Usage tab
+const networkShown = 10
+
+// muxShown caps the tmux commands and key bindings listed in the Tool Usage
+// tab
+const muxShown = 8
+
+// RenderTopCommands renders the leaderboard of programs and command
+// prefixes, and the project entry points grouped by directory
+func RenderTopCommands(commands, prefixes []analyzer.TopCommand, entryPoints []analyzer.EntryPoint) string {
 	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.RoundedBorder()).
 		Padding(1)
-	return style.Render(content)
-}
 
-// removeMarkdownPlaceholders removes markdown placeholders from the text
-func removeMarkdownPlaceholders(text string) string {
-	// Remove (Text animation: ...) placeholders
-	for strings.Contains(text, "(Text animation:") {
-		start := strings.Index(text, "(Text animation:")
-		end := strings.Index(text[start:], ")") + start + 1
-		text = text[:start] + text[end:]
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("top.title")))
+
+	if len(commands) == 0 {
+		content.WriteString(i18n.T("top.none") + "\n")
+		return frame(style, content.String())
 	}
 
-	// Remove **bold** markdown
-	text = strings.ReplaceAll(text, "**", "")
+	for i, cmd := range commands {
+		content.WriteString(fmt.Sprintf("%2d. %s %s%5.1f%%  %s\n",
+			i+1, color.Cyan.Sprintf("%-16s", cmd.Command), bar(cmd.Share), cmd.Share*100,
+			i18n.T("top.runs", cmd.Count)))
+		if len(cmd.PerShell) > 1 {
+			content.WriteString("    " + color.Gray.Sprint(shellBreakdown(cmd.PerShell)) + "\n")
+		}
+	}
 
-	// Remove *italic* markdown
-	text = strings.ReplaceAll(text, "*", "")
+	if len(prefixes) > 0 {
+		content.WriteString("\n" + i18n.T("top.prefixes") + "\n")
+		for i, prefix := range prefixes {
+			content.WriteString(fmt.Sprintf("%2d. %-24s %5.1f%%  %s\n",
+				i+1, prefix.Command, prefix.Share*100, i18n.T("top.runs", prefix.Count)))
+		}
+	}
 
-	return text
+	if len(entryPoints) > 0 {
+		content.WriteString("\n" + i18n.T("top.entry_points") + "\n")
+		// Grouped by project, busiest project first
+		var dirs []string
+		byDir := make(map[string][]analyzer.EntryPoint)
+		for _, point := range entryPoints {
+			if byDir[point.Dir] == nil {
+				dirs = append(dirs, point.Dir)
+			}
+			byDir[point.Dir] = append(byDir[point.Dir], point)
+		}
+		for _, dir := range dirs {
+			content.WriteString(color.Yellow.Sprint(dir) + "\n")
+			for _, point := range byDir[dir] {
+				content.WriteString(fmt.Sprintf("    %-28s %s\n", point.Command(), i18n.T("top.runs", point.Count)))
+			}
+		}
+	}
+
+	return frame(style, content.String())
 }
 
-func RenderTimeline(entries []types.TimelineEntry) string {
+// RenderDrilldowns renders the subcommand breakdown of tools. With selected
+// at or above zero only that tool is shown, under a picker naming the others
+// and the help line; otherwise every tool is listed.
+func RenderDrilldowns(tools []analyzer.ToolDrilldown, selected int, help string) string {
 	style := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		Padding(1)
 
 	var content strings.Builder
-	content.WriteString(color.Green.Sprintf("⏳ Interesting Commands Timeline\n\n"))
+	content.WriteString(title(color.Magenta, i18n.T("drilldown.title")))
 
-	for _, entry := range entries {
-		content.WriteString(fmt.Sprintf("📅 %s - %s (%s)\n",
-			entry.Timestamp.Format("2006-01-02 15:04:05"),
-			color.Cyan.Sprint(entry.Command),
-			color.Yellow.Sprint(entry.Shell)))
+	if len(tools) == 0 {
+		content.WriteString(i18n.T("drilldown.none") + "\n")
+		return frame(style, content.String())
 	}
 
-	return style.Render(content.String())
+	shown := tools
+	if selected >= 0 && selected < len(tools) {
+		names := make([]string, len(tools))
+		for i, tool := range tools {
+			names[i] = tool.Tool
+			if i == selected {
+				names[i] = color.Cyan.Sprintf("[%s]", tool.Tool)
+			}
+		}
+		content.WriteString(strings.Join(names, " · ") + "\n\n")
+		shown = tools[selected : selected+1]
+	}
+
+	for i, tool := range shown {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(color.Yellow.Sprint(tool.Tool) + " — " + i18n.T("top.runs", tool.Runs) + "\n")
+		for _, sub := range tool.Subcommands {
+			line := fmt.Sprintf("  %-20s %s", sub.Name, i18n.T("top.runs", sub.Count))
+			if len(sub.Flags) > 0 {
+				flags := make([]string, len(sub.Flags))
+				for j, flag := range sub.Flags {
+					flags[j] = fmt.Sprintf("%s %d", flag.Command, flag.Count)
+				}
+				line += "  " + color.Gray.Sprint(strings.Join(flags, " · "))
+			}
+			content.WriteString(line + "\n")
+		}
+	}
+
+	if help != "" {
+		content.WriteString("\n" + help)
+	}
+	return frame(style, content.String())
+}
+
+// shellBreakdown lists per-shell counts, busiest shell first
+func shellBreakdown(perShell map[string]int) string {
+	shells := byCount(perShell)
+	parts := make([]string, len(shells))
+	for i, shell := range shells {
+		parts[i] = fmt.Sprintf("%s %d", shell, perShell[shell])
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatDuration shows d in hours and minutes, e.g. "1h 05m" or "12m"
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// formatRuntime formats how long a command ran, which unlike sessions is
+// often under a minute
+func formatRuntime(d time.Duration) string {
+	switch {
+	case d < 10*time.Second:
+		return d.Round(100 * time.Millisecond).String()
+	case d < time.Hour:
+		return d.Round(time.Second).String()
+	}
+	return formatDuration(d)
+}
+
+// byCount returns the keys of counts, highest count first and ties by name
+func byCount(counts map[string]int) []string {
+	keys := analyzer.SortedKeys(counts)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return counts[keys[i]] > counts[keys[j]]
+	})
+	return keys
+}
+
+// Setting is one row of the Settings tab
+type Setting struct {
+	Label string
+	Value string
+}
+
+// RenderSettings renders the Settings tab with the selected row highlighted
+// and the keys that change it
+func RenderSettings(settings []Setting, selected int, status, help string) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Cyan, i18n.T("settings.title")))
+
+	for i, setting := range settings {
+		cursor := "  "
+		value := setting.Value
+		if i == selected {
+			cursor = "> "
+			value = color.Cyan.Sprint(value)
+		}
+		content.WriteString(fmt.Sprintf("%s%-28s %s\n", cursor, setting.Label, value))
+	}
+
+	content.WriteString("\n" + i18n.T("settings.saved_to", config.Path()) + "\n")
+	if status != "" {
+		content.WriteString(color.Yellow.Sprint(status) + "\n")
+	}
+	content.WriteString("\n" + help)
+
+	return frame(style, content.String())
+}
+
+// RenderSearch renders the search bar above a window of rows results
+// starting at offset, with the result under the cursor highlighted
+func RenderSearch(input string, results []analyzer.SearchResult, cursor, offset, rows int) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Cyan, i18n.T("search.title")))
+	content.WriteString(input + "\n")
+	content.WriteString(color.Gray.Sprint(i18n.T("search.filters")) + "\n\n")
+
+	if len(results) == 0 {
+		content.WriteString(i18n.T("search.none") + "\n")
+		return frame(style, content.String())
+	}
+
+	end := offset + rows
+	if end > len(results) {
+		end = len(results)
+	}
+	for i := offset; i < end; i++ {
+		result := results[i]
+		when := i18n.T("timeline.unknown")
+		if !result.Last.IsZero() {
+			when = result.Last.Format("2006-01-02 15:04")
+		}
+		marker := "  "
+		command := result.Command
+		if i == cursor {
+			marker = "> "
+			command = color.Cyan.Sprint(command)
+		}
+		content.WriteString(fmt.Sprintf("%s%-16s %-5s %-10s %s\n",
+			marker, when, result.Shell, i18n.T("top.runs", result.Runs), command))
+	}
+
+	content.WriteString("\n" + color.Gray.Sprint(i18n.T("search.matches", len(results))) + "\n")
+	return frame(style, content.String())
+}
+
+// ChatTurn is a question asked on the Chat tab and its answer so far
+type ChatTurn struct {
+	Question string
+	Answer   string
+	// Done is set once the answer is complete, Err when it failed
+	Done bool
+	Err  error
+}
+
+// ChatTranscript lays out the conversation as lines of at most width
+// columns, oldest first
+func ChatTranscript(turns []ChatTurn, width int) []string {
+	wrap := lipgloss.NewStyle().Width(width)
+	var lines []string
+	for _, turn := range turns {
+		lines = append(lines, strings.Split(wrap.Render(color.Cyan.Sprint("> "+turn.Question)), "\n")...)
+		answer := turn.Answer
+		switch {
+		case turn.Err != nil:
+			answer += "\n" + color.Red.Sprint(i18n.T("chat.failed", turn.Err))
+		case !turn.Done && answer == "":
+			answer = color.Gray.Sprint(i18n.T("chat.thinking"))
+		case !turn.Done:
+			answer += "▌"
+		}
+		lines = append(lines, strings.Split(wrap.Render(strings.TrimSpace(answer)), "\n")...)
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// RenderChat renders rows lines of the transcript, offset lines up from the
+// latest, above the question input
+func RenderChat(transcript []string, input string, offset, rows int) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Cyan, i18n.T("chat.title")))
+	if len(transcript) == 0 {
+		content.WriteString(color.Gray.Sprint(i18n.T("chat.empty")) + "\n\n")
+	}
+	end := len(transcript) - offset
+	for _, line := range transcript[max(0, end-rows):end] {
+		content.WriteString(line + "\n")
+	}
+	if offset > 0 {
+		content.WriteString(color.Gray.Sprint(i18n.T("chat.more", offset)) + "\n")
+	}
+	content.WriteString(input + "\n")
+	return frame(style, content.String())
+}
+
+// RenderHelp renders the help overlay around the key binding columns
+func RenderHelp(bindings string) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Cyan, i18n.T("help.title")))
+	content.WriteString(bindings + "\n\n")
+	content.WriteString(color.Gray.Sprint(i18n.T("help.close", config.Path())))
+
+	return frame(style, content.String())
+}
+
+func RenderWrapped(content string) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(1)
+	return style.Render(content)
+}
+
+// RenderSlideProgress draws one dot per slide, filling those up to current
+func RenderSlideProgress(current, total int) string {
+	dots := make([]string, total)
+	for i := range dots {
+		if i <= current {
+			dots[i] = foreground(lipgloss.NewStyle(), activeTheme.title).Render("●")
+		} else {
+			dots[i] = foreground(lipgloss.NewStyle(), activeTheme.muted).Render("○")
+		}
+	}
+	return strings.Join(dots, " ")
+}
+
+// RenderDeckPicker names the Wrapped decks to play, the selected one in
+// brackets, above the help line
+func RenderDeckPicker(labels []string, selected int, help string) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label
+		if i == selected {
+			names[i] = color.Cyan.Sprintf("[%s]", label)
+		}
+	}
+	return i18n.T("wrapped.decks") + " " + strings.Join(names, " · ") + "\n" + help
+}
+
+// removeMarkdownPlaceholders removes markdown placeholders from the text
+func removeMarkdownPlaceholders(text string) string {
+	// Remove (Text animation: ...) placeholders
+	for strings.Contains(text, "(Text animation:") {
+		start := strings.Index(text, "(Text animation:")
+		end := strings.Index(text[start:], ")") + start + 1
+		text = text[:start] + text[end:]
+	}
+
+	// Remove **bold** markdown
+	text = strings.ReplaceAll(text, "**", "")
+
+	// Remove *italic* markdown
+	text = strings.ReplaceAll(text, "*", "")
+
+	return text
+}
+
+// projectsShown caps the projects listed in the Projects tab
+const projectsShown = 10
+
+// RenderProjects renders the Projects tab, busiest project first
+func RenderProjects(usage analyzer.ProjectUsage) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Yellow, i18n.T("projects.title")))
+
+	if len(usage.Projects) == 0 {
+		content.WriteString(i18n.T("projects.none") + "\n")
+		return frame(style, content.String())
+	}
+
+	inside := usage.Commands - usage.Elsewhere
+	content.WriteString(i18n.T("projects.summary", inside, usage.Commands, len(usage.Projects)) + "\n\n")
+
+	for i, project := range usage.Projects {
+		if i == projectsShown {
+			content.WriteString(i18n.T("overview.more", len(usage.Projects)-projectsShown) + "\n")
+			break
+		}
+		share := float64(project.Commands) / float64(usage.Commands)
+		content.WriteString(fmt.Sprintf("%2d. %s %s  %s%5.1f%%  %s\n",
+			i+1, color.Cyan.Sprint(project.Dir), color.Gray.Sprintf("[%s]", i18n.T("projects.kind."+project.Kind)),
+			bar(share), share*100, i18n.T("top.runs", project.Commands)))
+
+		var details []string
+		if project.Time > 0 {
+			details = append(details, i18n.T("projects.time", formatDuration(project.Time)))
+		}
+		if !project.Last.IsZero() {
+			details = append(details, i18n.T("projects.last", project.Last.Format(i18n.T("date.long"))))
+		}
+		var programs []string
+		for _, program := range project.Programs {
+			programs = append(programs, fmt.Sprintf("%s %d", program.Command, program.Count))
+		}
+		if len(programs) > 0 {
+			details = append(details, strings.Join(programs, " · "))
+		}
+		if len(details) > 0 {
+			content.WriteString("    " + strings.Join(details, " — ") + "\n")
+		}
+	}
+
+	content.WriteString("\n" + i18n.T("projects.elsewhere", usage.Elsewhere) + "\n")
+	return frame(style, content.String())
+}
+
+// gitRatios are the subcommands shown per commit on the Git tab
+var gitRatios = []string{"push", "pull", "fetch", "rebase", "merge"}
+
+// RenderGit renders the Git tab. reflogs is whether the reflogs were read.
+func RenderGit(usage analyzer.GitUsage, reflogs bool) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("git.title")))
+
+	if usage.Commands == 0 {
+		content.WriteString(i18n.T("git.none") + "\n")
+		return frame(style, content.String())
+	}
+	content.WriteString(i18n.T("git.summary", usage.Commands, usage.Subcommands["commit"]) + "\n\n")
+
+	// Subcommands per commit
+	if usage.Subcommands["commit"] > 0 {
+		content.WriteString(i18n.T("git.ratios") + "\n")
+		for _, subcommand := range gitRatios {
+			content.WriteString(fmt.Sprintf("%-10s %s\n", subcommand, i18n.T("git.per_commit", usage.Ratio(subcommand))))
+		}
+		content.WriteString("\n")
+	}
+
+	// Merge or rebase
+	if preference := usage.Preference(); preference != "" {
+		content.WriteString(i18n.T("git.prefers."+preference, usage.Merges(), usage.Rebases()) + "\n")
+	} else if usage.Merges() > 0 {
+		content.WriteString(i18n.T("git.prefers.none", usage.Merges(), usage.Rebases()) + "\n")
+	}
+
+	// Force pushes
+	if usage.ForcePushes > 0 || usage.LeasePushes > 0 {
+		content.WriteString(i18n.T("git.force", usage.ForcePushes, usage.LeasePushes) + "\n")
+		if usage.ForcePushes > usage.LeasePushes {
+			content.WriteString("  " + color.Yellow.Sprint(i18n.T("git.force_tip")) + "\n")
+		}
+	}
+
+	// Stash habits
+	if len(usage.Stash) > 0 {
+		var actions []string
+		for _, action := range analyzer.SortedCounts(usage.Stash, 0) {
+			actions = append(actions, fmt.Sprintf("%s %d", action.Command, action.Count))
+		}
+		content.WriteString(i18n.T("git.stash", strings.Join(actions, " · ")) + "\n")
+		if left := usage.Stash["push"] + usage.Stash["save"] - usage.Stash["pop"] - usage.Stash["apply"] - usage.Stash["drop"]; left >= gitStashesLeft {
+			content.WriteString("  " + color.Yellow.Sprint(i18n.T("git.stash_left", left)) + "\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Branch names
+	if len(usage.Branches) > 0 {
+		content.WriteString(i18n.T("git.branches") + "\n")
+		for _, branch := range usage.Branches {
+			content.WriteString(fmt.Sprintf("• %-30s %s\n", redact.String(branch.Command), i18n.T("top.runs", branch.Count)))
+		}
+		content.WriteString("\n")
+	}
+
+	// Reflogs
+	content.WriteString(i18n.T("git.reflogs") + "\n")
+	switch {
+	case !reflogs:
+		content.WriteString(i18n.T("git.reflogs_off") + "\n")
+	case len(usage.Reflogs) == 0:
+		content.WriteString(i18n.T("git.reflogs_none") + "\n")
+	}
+	for _, reflog := range usage.Reflogs {
+		content.WriteString("• " + color.Cyan.Sprint(reflog.Dir) + "\n")
+		content.WriteString("  " + i18n.T("git.reflog", reflog.Commits, reflog.Amends, reflog.Rebases, reflog.Merges, reflog.Checkouts))
+		if !reflog.Last.IsZero() {
+			content.WriteString(" — " + i18n.T("projects.last", reflog.Last.Format(i18n.T("date.long"))))
+		}
+		content.WriteString("\n")
+	}
+
+	return frame(style, content.String())
+}
+
+// gitStashesLeft is how many more stashes than pops and applies suggest
+// forgotten work
+const gitStashesLeft = 3
+
+// RenderContainers renders the Containers tab: docker images and compose
+// use, kubectl verbs and namespaces, helm and context switches
+func RenderContainers(usage analyzer.ContainerUsage) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Blue, i18n.T("containers.title")))
+
+	if usage.Total() == 0 {
+		content.WriteString(i18n.T("containers.none") + "\n")
+		return frame(style, content.String())
+	}
+
+	// Docker
+	if docker := usage.DockerCommands + usage.ComposeCommands; docker > 0 {
+		content.WriteString(i18n.T("containers.docker", docker, usage.ComposeCommands, usage.ComposeShare()*100) + "\n")
+		if len(usage.Images) > 0 {
+			content.WriteString(i18n.T("containers.images") + "\n")
+			for _, image := range usage.Images {
+				content.WriteString(fmt.Sprintf("• %-30s %s\n", redact.String(image.Command), i18n.T("top.runs", image.Count)))
+			}
+		}
+		content.WriteString("\n")
+	}
+
+	// Kubernetes
+	if usage.KubectlCommands > 0 {
+		content.WriteString(i18n.T("containers.kubectl", usage.KubectlCommands) + "\n")
+		for _, verb := range analyzer.SortedCounts(usage.KubectlVerbs, containerVerbsShown) {
+			content.WriteString(fmt.Sprintf("• %-30s %s\n", verb.Command, i18n.T("top.runs", verb.Count)))
+		}
+		content.WriteString("\n")
+	}
+	if usage.HelmCommands > 0 {
+		var subcommands []string
+		for _, subcommand := range analyzer.SortedCounts(usage.Helm, containerVerbsShown) {
+			subcommands = append(subcommands, fmt.Sprintf("%s %d", subcommand.Command, subcommand.Count))
+		}
+		content.WriteString(i18n.T("containers.helm", usage.HelmCommands, strings.Join(subcommands, " · ")) + "\n\n")
+	}
+	if len(usage.Namespaces) > 0 {
+		content.WriteString(i18n.T("containers.namespaces") + "\n")
+		for _, namespace := range usage.Namespaces {
+			content.WriteString(fmt.Sprintf("• %-30s %s\n", redact.String(namespace.Command), i18n.T("top.runs", namespace.Count)))
+		}
+		content.WriteString("\n")
+	}
+
+	// Contexts
+	if usage.ContextSwitches > 0 {
+		var contexts []string
+		for _, context := range usage.Contexts {
+			contexts = append(contexts, fmt.Sprintf("%s %d", redact.String(context.Command), context.Count))
+		}
+		content.WriteString(i18n.T("containers.contexts", usage.ContextSwitches, strings.Join(contexts, " · ")) + "\n")
+	}
+
+	return frame(style, content.String())
+}
+
+// containerVerbsShown caps the kubectl verbs and helm subcommands
+const containerVerbsShown = 8
+
+// RenderConfigHealth renders the Config Health tab: what the linter found
+// in each shell's startup files and how to fix it
+func RenderConfigHealth(configs map[string]analyzer.ShellConfig) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("health.title")))
+
+	found := 0
+	for _, shell := range analyzer.SortedKeys(configs) {
+		issues := configs[shell].Issues
+		if len(issues) == 0 {
+			continue
+		}
+		found += len(issues)
+		content.WriteString(color.Cyan.Sprint(shell) + "\n")
+		for _, issue := range issues {
+			place := utils.DisplayPath(issue.Path)
+			if issue.Line > 0 {
+				place = fmt.Sprintf("%s:%d", place, issue.Line)
+			}
+			var message, fix string
+			switch issue.Kind {
+			case analyzer.IssueDuplicatePath:
+				message = i18n.T("health.duplicate_path", issue.Subject, fmt.Sprintf("%s:%d", utils.DisplayPath(issue.Previous.Path), issue.Previous.Line))
+				fix = i18n.T("health.fix.duplicate_path")
+			case analyzer.IssueInteractiveGuard:
+				message = i18n.T("health.interactive_guard", issue.Subject)
+				fix = i18n.T("health.fix.interactive_guard." + shell)
+			case analyzer.IssueDeprecated:
+				message = i18n.T("health.deprecated", i18n.T("health.syntax."+issue.Subject))
+				fix = i18n.T("health.fix.deprecated." + issue.Subject)
+			case analyzer.IssueEnormous:
+				message = i18n.T("health.enormous", issue.Subject)
+				fix = i18n.T("health.fix.enormous." + shell)
+			case analyzer.IssueUnusedPlugin:
+				message = i18n.T("health.unused_plugin", issue.Subject)
+				fix = i18n.T("health.fix.unused_plugin")
+			case analyzer.IssueStalePlugin:
+				message = i18n.T("health.stale_plugin", issue.Subject, issue.Since.Format(i18n.T("date.long")))
+				fix = i18n.T("health.fix.stale_plugin")
+			}
+			content.WriteString("• " + color.Gray.Sprint(place) + " " + message + "\n")
+			content.WriteString("  → " + color.Green.Sprint(fix) + "\n")
+		}
+		content.WriteString("\n")
+	}
+	switch {
+	case len(configs) == 0:
+		content.WriteString(i18n.T("health.no_configs") + "\n")
+	case found == 0:
+		content.WriteString(i18n.T("health.none") + "\n")
+	}
+
+	return frame(style, content.String())
+}
+
+// RenderShellComparison renders the Shells tab: a column per shell and the
+// shell that gets the most use
+func RenderShellComparison(c analyzer.ShellComparison) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Blue, i18n.T("compare.title")))
+
+	if len(c.Shells) == 0 {
+		content.WriteString(i18n.T("compare.none") + "\n")
+		return frame(style, content.String())
+	}
+
+	total := 0
+	for _, shell := range c.Shells {
+		total += shell.Commands
+	}
+	row := func(label string, cell func(analyzer.ShellSummary) string) {
+		line := fmt.Sprintf("%-20s", label)
+		for _, shell := range c.Shells {
+			text := []rune(cell(shell))
+			if len(text) > 17 {
+				text = append(text[:16], '…')
+			}
+			line += fmt.Sprintf("%-18s", string(text))
+		}
+		content.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	header := fmt.Sprintf("%-20s", "")
+	for _, shell := range c.Shells {
+		header += color.Cyan.Sprintf("%-18s", shell.Shell)
+	}
+	content.WriteString(strings.TrimRight(header, " ") + "\n")
+	row(i18n.T("compare.commands"), func(s analyzer.ShellSummary) string {
+		return fmt.Sprintf("%d (%.0f%%)", s.Commands, share(s.Commands, total))
+	})
+	if c.ByRecent {
+		row(i18n.T("compare.recent"), func(s analyzer.ShellSummary) string { return fmt.Sprint(s.Recent) })
+	}
+	row(i18n.T("compare.last"), func(s analyzer.ShellSummary) string {
+		if s.Last.IsZero() {
+			return "-"
+		}
+		return s.Last.Format("2006-01-02")
+	})
+	for i := 0; i < 3; i++ {
+		label := ""
+		if i == 0 {
+			label = i18n.T("compare.top")
+		}
+		row(label, func(s analyzer.ShellSummary) string {
+			if i >= len(s.Top) {
+				return ""
+			}
+			return fmt.Sprintf("%s %d", s.Top[i].Command, s.Top[i].Count)
+		})
+	}
+	row(i18n.T("compare.aliases"), func(s analyzer.ShellSummary) string { return fmt.Sprint(s.Aliases) })
+	row(i18n.T("compare.plugins"), func(s analyzer.ShellSummary) string { return fmt.Sprint(s.Plugins) })
+	row(i18n.T("compare.config"), func(s analyzer.ShellSummary) string {
+		return i18n.T("compare.config_size", s.ConfigFiles, s.ConfigLines)
+	})
+
+	content.WriteString("\n")
+	switch {
+	case len(c.Shells) == 1:
+		content.WriteString(i18n.T("compare.only", c.Recommended) + "\n")
+	case c.ByRecent:
+		content.WriteString(color.Green.Sprint(i18n.T("compare.recommended_recent", c.Recommended, int(analyzer.RecentWindow.Hours()/24))) + "\n")
+	default:
+		content.WriteString(color.Green.Sprint(i18n.T("compare.recommended_total", c.Recommended)) + "\n")
+	}
+	return frame(style, content.String())
+}
+
+// share is count as a percentage of total
+func share(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total) * 100
+}
+
+// trendsShown is how many recent months the Trends tab charts
+const trendsShown = 12
+
+// RenderTrends charts how the command volume, tech stack and productivity
+// metrics changed from month to month
+func RenderTrends(months []snapshot.Month) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Blue, i18n.T("trends.title")))
+
+	if len(months) == 0 {
+		content.WriteString(i18n.T("trends.none") + "\n")
+		return frame(style, content.String())
+	}
+	if len(months) > trendsShown {
+		months = months[len(months)-trendsShown:]
+	}
+	label := func(month snapshot.Month) string {
+		return fmt.Sprintf("%-10s", month.Start.Format(i18n.T("date.month")))
+	}
+
+	// Command Volume
+	content.WriteString(i18n.T("trends.volume") + "\n")
+	maxAdded := 0
+	for _, month := range months[1:] {
+		maxAdded = max(maxAdded, month.Added)
+	}
+	for i, month := range months {
+		line := label(month) + " "
+		switch {
+		case i == 0:
+			line += bar(0) + i18n.T("trends.total", month.Commands)
+		case month.Added < 0:
+			line += bar(0) + i18n.T("trends.trimmed", month.Commands)
+		default:
+			line += bar(float64(month.Added)/float64(max(maxAdded, 1))) + fmt.Sprintf("+%d  ", month.Added) + i18n.T("trends.total", month.Commands)
+		}
+		content.WriteString(line + "\n")
+	}
+	content.WriteString("\n")
+
+	// Tech Stack
+	content.WriteString(i18n.T("trends.stack") + "\n")
+	for i, month := range months {
+		switch {
+		case i == 0:
+			stack := strings.Join(month.TechStack, ", ")
+			if stack == "" {
+				stack = "-"
+			}
+			content.WriteString(label(month) + " " + stack + "\n")
+		case len(month.NewTech)+len(month.DroppedTech) == 0:
+			content.WriteString(label(month) + " " + i18n.T("trends.unchanged") + "\n")
+		default:
+			var changes []string
+			for _, tech := range month.NewTech {
+				changes = append(changes, color.Green.Sprint("+"+tech))
+			}
+			for _, tech := range month.DroppedTech {
+				changes = append(changes, color.Red.Sprint("-"+tech))
+			}
+			content.WriteString(label(month) + " " + strings.Join(changes, " ") + "\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Productivity
+	content.WriteString(i18n.T("trends.productivity") + "\n")
+	metrics := make(map[string]bool)
+	for _, month := range months {
+		for metric := range month.Productivity {
+			metrics[metric] = true
+		}
+	}
+	for _, metric := range analyzer.SortedKeys(metrics) {
+		content.WriteString(i18n.T("metric."+metric) + "\n")
+		for _, month := range months {
+			value, ok := month.Productivity[metric]
+			if !ok {
+				continue
+			}
+			content.WriteString(fmt.Sprintf("  %s %s%.1f%%\n", label(month), bar(value), value*100))
+		}
+	}
+	content.WriteString(i18n.T("trends.per_session") + "\n")
+	maxPerSession := 0.0
+	for _, month := range months {
+		maxPerSession = max(maxPerSession, month.CommandsPerSession)
+	}
+	for _, month := range months {
+		level := 0.0
+		if maxPerSession > 0 {
+			level = month.CommandsPerSession / maxPerSession
+		}
+		content.WriteString(fmt.Sprintf("  %s %s%.1f\n", label(month), bar(level), month.CommandsPerSession))
+	}
+
+	return frame(style, content.String())
+}
+
+// diffShown caps the programs and proficiencies listed per part of a diff
+const diffShown = 10
+
+// RenderDiff shows what changed between two snapshots or periods: added
+// items with +, removed ones with - and changed ones with ~
+func RenderDiff(d snapshot.Diff) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Magenta, i18n.T("diff.title", d.Before, d.After)))
+	if d.Cumulative {
+		content.WriteString(i18n.T("diff.commands_since", d.BeforeCommands, d.AfterCommands))
+	} else {
+		content.WriteString(i18n.T("diff.commands", d.BeforeCommands, d.AfterCommands))
+	}
+	if !d.Cumulative && d.BeforeCommands > 0 {
+		content.WriteString(fmt.Sprintf(" (%+.0f%%)", share(d.AfterCommands-d.BeforeCommands, d.BeforeCommands)))
+	}
+	content.WriteString("\n\n")
+
+	programs := func(heading, sign string, c color.Color, counts []analyzer.CommandCount) {
+		if len(counts) == 0 {
+			return
+		}
+		content.WriteString(heading + "\n")
+		for i, count := range counts {
+			if i == diffShown {
+				content.WriteString("  " + i18n.T("overview.more", len(counts)-diffShown) + "\n")
+				break
+			}
+			content.WriteString(c.Sprintf("%s %-20s", sign, count.Command) + fmt.Sprintf(" %d\n", count.Count))
+		}
+		content.WriteString("\n")
+	}
+	programs(i18n.T("diff.adopted"), "+", color.Green, d.Adopted)
+	programs(i18n.T("diff.abandoned"), "-", color.Red, d.Abandoned)
+
+	if len(d.NewTech)+len(d.DroppedTech) > 0 {
+		content.WriteString(i18n.T("diff.tech") + "\n")
+		for _, tech := range d.NewTech {
+			content.WriteString(color.Green.Sprint("+ "+tech) + "\n")
+		}
+		for _, tech := range d.DroppedTech {
+			content.WriteString(color.Red.Sprint("- "+tech) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
+	hours := func(peak []int) string {
+		var names []string
+		for _, hour := range peak {
+			names = append(names, fmt.Sprintf("%d:00", hour))
+		}
+		if len(names) == 0 {
+			return "-"
+		}
+		return strings.Join(names, ", ")
+	}
+	content.WriteString(i18n.T("diff.peak") + "\n")
+	if before, after := hours(d.BeforePeak), hours(d.AfterPeak); before == after {
+		content.WriteString("  " + before + "\n\n")
+	} else {
+		content.WriteString(color.Yellow.Sprintf("~ %s → %s", before, after) + "\n\n")
+	}
+
+	if len(d.Proficiency) > 0 {
+		content.WriteString(i18n.T("diff.proficiency") + "\n")
+		for i, change := range d.Proficiency {
+			if i == diffShown {
+				content.WriteString("  " + i18n.T("overview.more", len(d.Proficiency)-diffShown) + "\n")
+				break
+			}
+			c := color.Green
+			if change.After < change.Before {
+				c = color.Red
+			}
+			content.WriteString(c.Sprintf("~ %-20s %3.0f → %3.0f", change.Name, change.Before, change.After) + "\n")
+		}
+	}
+
+	return frame(style, strings.TrimRight(content.String(), "\n")+"\n")
+}
+
+// RenderTeam renders the statistics of a team combined by `aggregate`
+func RenderTeam(team snapshot.Team) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Magenta, i18n.T("team.title", team.Members)))
+	content.WriteString(i18n.T("team.commands", team.Commands) + "\n\n")
+
+	members := func(item snapshot.SharedItem) string {
+		return fmt.Sprintf("%s %s", bar(float64(item.Members)/float64(team.Members)), i18n.T("team.members", item.Members, team.Members))
+	}
+	content.WriteString(i18n.T("team.tools") + "\n")
+	for _, tool := range team.Tools {
+		content.WriteString(fmt.Sprintf("%s %s  %s\n", color.Cyan.Sprintf("%-16s", tool.Name), members(tool), color.Gray.Sprint(i18n.T("top.runs", tool.Count))))
+	}
+	if len(team.Tools) == 0 {
+		content.WriteString(i18n.T("team.none") + "\n")
+	}
+	content.WriteString("\n")
+
+	content.WriteString(i18n.T("work.daily") + "\n")
+	if plain {
+		content.WriteString(renderActivityText(team.Hourly, [7][24]int{}))
+	} else {
+		content.WriteString(renderHourlyChart(team.Hourly))
+	}
+	if len(team.PeakHours) > 0 {
+		var peaks []string
+		for _, hour := range team.PeakHours {
+			peaks = append(peaks, fmt.Sprintf("%02d:00", hour))
+		}
+		content.WriteString(i18n.T("team.peak", strings.Join(peaks, ", ")) + "\n")
+	}
+	content.WriteString("\n")
+
+	content.WriteString(i18n.T("team.stack") + "\n")
+	for _, tech := range team.Stack {
+		content.WriteString(fmt.Sprintf("%-16s %s\n", tech.Name, members(tech)))
+	}
+	if len(team.Stack) == 0 {
+		content.WriteString(i18n.T("team.none") + "\n")
+	}
+	content.WriteString("\n")
+
+	content.WriteString(i18n.T("team.roles") + "\n")
+	for _, role := range team.Roles {
+		name := i18n.T("role." + role.Name)
+		if role.Language {
+			name = i18n.T("persona.developer", strings.Title(role.Name))
+		}
+		content.WriteString(fmt.Sprintf("%-24s %s\n", name, members(role)))
+	}
+	if len(team.Roles) == 0 {
+		content.WriteString(i18n.T("team.none") + "\n")
+	}
+	if team.Hidden > 0 {
+		content.WriteString("\n" + color.Gray.Sprint(i18n.T("team.hidden", team.Hidden)) + "\n")
+	}
+
+	return frame(style, content.String())
+}
+
+// dataShown caps the aliases and variables listed per source in the Data tab
+const dataShown = 15
+
+// RenderCapabilities renders what the environment supports, below the
+// sources on the Data tab
+func RenderCapabilities(r capability.Report) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Cyan, i18n.T("capability.title")))
+
+	or := func(value string) string {
+		if value == "" {
+			return i18n.T("capability.none")
+		}
+		return value
+	}
+	var timed []string
+	for _, shell := range r.Histories {
+		if !slices.Contains(r.Untimed, shell) {
+			timed = append(timed, shell)
+		}
+	}
+	ai := "capability.ai.missing"
+	switch {
+	case r.AIDisabled:
+		ai = "capability.ai.disabled"
+	case r.AI:
+		ai = "capability.ai.configured"
+	}
+	content.WriteString(i18n.T("capability.histories", or(strings.Join(r.Histories, ", "))) + "\n")
+	content.WriteString(i18n.T("capability.timestamps", or(strings.Join(timed, ", "))) + "\n")
+	content.WriteString(i18n.T("capability.ai", i18n.T(ai)) + "\n")
+	content.WriteString(i18n.T("capability.clipboard", or(r.Clipboard)) + "\n")
+	content.WriteString(i18n.T("capability.images", or(r.Images)) + "\n")
+	return frame(style, content.String())
+}
+
+// RenderHints lists what to enable to unlock more of a tab, shown above it
+func RenderHints(hints []string) string {
+	var content strings.Builder
+	for _, hint := range hints {
+		if plain {
+			content.WriteString(hint + "\n")
+			continue
+		}
+		content.WriteString(color.Yellow.Sprint("💡 "+hint) + "\n")
+	}
+	return content.String()
+}
+
+// RenderProblems renders the Diagnostics tab: what could not be read or
+// reached, why, and how to fix it, then where the details are logged
+func RenderProblems(problems []analyzer.Problem, logPath string) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Red, i18n.T("problems.title")))
+
+	if len(problems) == 0 {
+		content.WriteString(i18n.T("problems.none") + "\n")
+	}
+	for i, p := range problems {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		what := i18n.T("problem." + p.ID)
+		if p.Path != "" {
+			what = i18n.T("problem."+p.ID+".path", utils.DisplayPath(p.Path))
+		}
+		content.WriteString(color.Red.Sprint("⚠️  "+what) + "\n")
+		if p.Err != nil {
+			content.WriteString("  " + i18n.T("problems.why", p.Err) + "\n")
+		}
+		content.WriteString("  " + i18n.T("problems.fix", i18n.T("problem."+p.ID+".fix")) + "\n")
+	}
+	content.WriteString("\n" + i18n.T("problems.log", utils.DisplayPath(logPath)) + "\n")
+
+	return frame(style, content.String())
+}
+
+// ProblemsIndicator is the footer line pointing to the Diagnostics tab
+func ProblemsIndicator(n int) string {
+	return color.Red.Sprint(i18n.T("problems.indicator", n, i18n.T("tab.diagnostics")))
+}
+
+// RenderDataSources renders the Data tab: what was parsed from each source,
+// with redaction applied as it would be for the AI or shown raw. With
+// selected < 0 every source is listed, otherwise a picker and that source.
+func RenderDataSources(sources []analyzer.DataSource, selected int, redacted bool, help string) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Cyan, i18n.T("data.title")))
+
+	show := func(s string) string { return s }
+	if redacted {
+		show = redact.String
+		content.WriteString(color.Green.Sprint(i18n.T("data.redacted", redact.CurrentLevel())) + "\n\n")
+	} else {
+		content.WriteString(color.Yellow.Sprint(i18n.T("data.raw")) + "\n\n")
+	}
+
+	if len(sources) == 0 {
+		content.WriteString(i18n.T("data.none") + "\n")
+		return frame(style, content.String())
+	}
+
+	shown := sources
+	if selected >= 0 && selected < len(sources) {
+		names := make([]string, len(sources))
+		for i, source := range sources {
+			names[i] = source.Name
+			if i == selected {
+				names[i] = color.Cyan.Sprintf("[%s]", source.Name)
+			}
+		}
+		content.WriteString(strings.Join(names, " · ") + "\n\n")
+		shown = sources[selected : selected+1]
+	}
+
+	for i, source := range shown {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		heading := color.Yellow.Sprint(source.Name)
+		if source.Path != "" {
+			heading += " — " + show(source.Path)
+		}
+		content.WriteString(heading + "\n")
+		content.WriteString("  " + i18n.T("data.entries", source.Entries) + "\n")
+
+		if len(source.Recent) > 0 {
+			content.WriteString("\n  " + i18n.T("data.recent", len(source.Recent)) + "\n")
+			for _, entry := range source.Recent {
+				when := i18n.T("timeline.unknown")
+				if !entry.Timestamp.IsZero() {
+					when = entry.Timestamp.Format("2006-01-02 15:04")
+				}
+				line := fmt.Sprintf("    %-16s %s", when, show(entry.Command))
+				if entry.Dir != "" {
+					line += "  " + color.Gray.Sprint(show(entry.Dir))
+				}
+				content.WriteString(line + "\n")
+			}
+		}
+
+		if len(source.Aliases) > 0 {
+			content.WriteString("\n  " + i18n.T("data.aliases", len(source.Aliases)) + "\n")
+			for j, alias := range source.Aliases {
+				if j == dataShown {
+					content.WriteString("    " + i18n.T("overview.more", len(source.Aliases)-dataShown) + "\n")
+					break
+				}
+				content.WriteString(fmt.Sprintf("    %s = %s  %s\n", alias.Name, show(alias.Value),
+					color.Gray.Sprintf("%s:%d", show(alias.Path), alias.Line)))
+			}
+		}
+
+		if len(source.Plugins) > 0 {
+			content.WriteString("\n  " + i18n.T("data.plugins", len(source.Plugins)) + "\n")
+			for _, plugin := range source.Plugins {
+				line := "    " + plugin.Name
+				if details := strings.TrimSpace(plugin.Manager + " " + plugin.Version); details != "" {
+					line += " " + color.Cyan.Sprint("("+details+")")
+				}
+				if updated := plugin.LastUpdated; plugin.Stale(clock.Now()) {
+					line += "  " + color.Yellow.Sprint(i18n.T("data.plugin_stale", updated.Format(i18n.T("date.long"))))
+				} else if !updated.IsZero() {
+					line += "  " + i18n.T("data.plugin_updated", updated.Format(i18n.T("date.long")))
+				}
+				if plugin.Source != "" {
+					line += "  " + color.Gray.Sprint(show(plugin.Source))
+				}
+				content.WriteString(line + "\n")
+			}
+		}
+
+		if len(source.Environment) > 0 {
+			content.WriteString("\n  " + i18n.T("data.environment", len(source.Environment)) + "\n")
+			for j, name := range analyzer.SortedKeys(source.Environment) {
+				if j == dataShown {
+					content.WriteString("    " + i18n.T("overview.more", len(source.Environment)-dataShown) + "\n")
+					break
+				}
+				// Through show as one assignment, so secrets are caught by name
+				content.WriteString("    " + show(name+"="+source.Environment[name]) + "\n")
+			}
+		}
+	}
+
+	if help != "" {
+		content.WriteString("\n" + help)
+	}
+	return frame(style, content.String())
+}
+
+// RenderSecurity renders the Security tab. Examples are redacted, since
+// risky commands often carry tokens in URLs or headers.
+func RenderSecurity(findings []analyzer.SecurityFinding) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Red, i18n.T("security.title")))
+
+	if len(findings) == 0 {
+		content.WriteString(i18n.T("security.none") + "\n")
+		return frame(style, content.String())
+	}
+
+	for i, f := range findings {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(i18n.T("security.finding", color.Red.Sprint(i18n.T("security."+f.ID)), f.Count) + "\n")
+		content.WriteString("  " + i18n.T("security."+f.ID+".warning") + "\n")
+		example := redact.String(f.Example)
+		if f.Last.IsZero() {
+			content.WriteString("  " + i18n.T("security.example", example) + "\n")
+		} else {
+			content.WriteString("  " + i18n.T("security.example_at", example, f.Last.Format(i18n.T("date.long"))) + "\n")
+		}
+	}
+
+	return frame(style, content.String())
+}
+
+// RenderSuggestions renders the Suggestions tab: alias lines ready to paste,
+// then setup recommendations and workflow tips
+func RenderSuggestions(s analyzer.Suggestions) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("suggestions.title")))
+
+	// Aliases
+	content.WriteString(i18n.T("suggestions.aliases") + "\n")
+	for _, sim := range s.Aliases {
+		content.WriteString(color.Cyan.Sprint(sim.Proposal.AliasLine()) + "\n")
+		content.WriteString("  " + i18n.T("suggestions.alias_savings", sim.Matches, sim.KeystrokesPerWeek) + "\n")
+	}
+	if len(s.Aliases) == 0 {
+		content.WriteString(i18n.T("suggestions.no_aliases") + "\n")
+	}
+	if len(s.TypoFixes) > 0 {
+		content.WriteString("\n" + i18n.T("suggestions.typo_fixes") + "\n")
+		for _, fix := range s.TypoFixes {
+			content.WriteString(color.Cyan.Sprint(fix.Proposal.AliasLine()) + "\n")
+			content.WriteString("  " + i18n.T("suggestions.typo_count", fix.Count) + "\n")
+		}
+	}
+	content.WriteString(renderAliasCleanup(s.Cleanup))
+
+	if len(s.Alternatives) > 0 {
+		content.WriteString("\n" + i18n.T("suggestions.alternatives") + "\n")
+		for _, alt := range s.Alternatives {
+			classic, modern := color.Cyan.Sprint(alt.Classic), color.Green.Sprint(alt.Modern)
+			if alt.Installed {
+				content.WriteString("• " + i18n.T("suggestions.alternative_installed", classic, alt.Runs, modern, alt.Binary) + "\n")
+			} else {
+				content.WriteString("• " + i18n.T("suggestions.alternative_install", classic, alt.Runs, modern) + "\n")
+			}
+		}
+	}
+
+	for _, section := range []struct {
+		heading string
+		items   []analyzer.Suggestion
+	}{
+		{"suggestions.recommendations", s.Recommendations},
+		{"suggestions.tips", s.Tips},
+	} {
+		if len(section.items) == 0 {
+			continue
+		}
+		content.WriteString("\n" + i18n.T(section.heading) + "\n")
+		for _, item := range section.items {
+			content.WriteString("• " + i18n.T("suggestions."+item.ID, item.Args...) + "\n")
+		}
+	}
+
+	return frame(style, content.String())
+}
+
+// renderAliasCleanup lists unused, shadowing and duplicate aliases
+func renderAliasCleanup(audit analyzer.AliasAudit) string {
+	if audit.Empty() {
+		return ""
+	}
+	var content strings.Builder
+	content.WriteString("\n" + i18n.T("suggestions.cleanup") + "\n")
+	for _, unused := range audit.Unused {
+		content.WriteString("• " + i18n.T("suggestions.unused_aliases", unused.Shell, len(unused.Names), strings.Join(unused.Names, ", ")) + "\n")
+	}
+	for _, shadow := range audit.Shadowing {
+		content.WriteString("• " + i18n.T("suggestions.shadowing", color.Yellow.Sprint(shadow.Name), shadow.Shell, shadow.Expansion) + "\n")
+	}
+	for _, dup := range audit.Duplicates {
+		var places []string
+		for _, def := range dup.Definitions {
+			places = append(places, fmt.Sprintf("%s:%d", utils.DisplayPath(def.Path), def.Line))
+		}
+		id := "suggestions.duplicate"
+		if dup.Conflicting {
+			id = "suggestions.conflicting"
+		}
+		content.WriteString("• " + i18n.T(id, color.Yellow.Sprint(dup.Name), len(dup.Definitions), strings.Join(places, ", ")) + "\n")
+	}
+	return content.String()
+}
+
+// RenderAchievements renders the Achievements tab
+func RenderAchievements(a analyzer.Achievements) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Yellow, i18n.T("achievements.title")))
+
+	// Streaks
+	content.WriteString(i18n.T("achievements.streaks") + "\n")
+	if a.LongestStreak > 0 {
+		content.WriteString(i18n.T("achievements.current", a.CurrentStreak) + "\n")
+		content.WriteString(i18n.T("achievements.longest", a.LongestStreak, a.StreakStart.Format(i18n.T("date.long"))) + "\n")
+	} else {
+		content.WriteString(i18n.T("work.no_timestamps") + "\n")
+	}
+	content.WriteString("\n")
+
+	// Milestones
+	content.WriteString(i18n.T("achievements.milestones") + "\n")
+	for _, m := range a.Milestones {
+		reached := m.Reached.Format(i18n.T("date.long"))
+		if m.Count == 1 {
+			content.WriteString("• " + i18n.T("achievements.first", color.Cyan.Sprint(m.Command), reached) + "\n")
+		} else {
+			content.WriteString("• " + i18n.T("achievements.nth", color.Cyan.Sprint(m.Command), m.Count, reached) + "\n")
+		}
+	}
+	if len(a.Milestones) == 0 {
+		content.WriteString(i18n.T("achievements.no_milestones") + "\n")
+	}
+	content.WriteString("\n")
+
+	// Badges, earned first
+	content.WriteString(i18n.T("achievements.badges") + "\n")
+	for _, earned := range []bool{true, false} {
+		for _, badge := range a.Badges {
+			if badge.Earned != earned {
+				continue
+			}
+			name, desc := i18n.T("badge."+badge.ID), i18n.T("badge."+badge.ID+".desc")
+			if earned {
+				content.WriteString(i18n.T("achievements.earned", color.Yellow.Sprint(name), desc) + "\n")
+			} else {
+				content.WriteString(i18n.T("achievements.locked", name, desc) + "\n")
+			}
+		}
+	}
+
+	return frame(style, content.String())
+}
+
+// RenderTimeline shows rows entries of the timeline from offset, under a
+// heading for each day, after the filters in effect and before the page
+// and footer
+func RenderTimeline(entries []types.TimelineEntry, offset, rows int, filters, footer string) string {
+	style := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		Padding(1)
+
+	var content strings.Builder
+	content.WriteString(title(color.Green, i18n.T("timeline.title")))
+	content.WriteString(color.Gray.Sprint(filters) + "\n")
+
+	if len(entries) == 0 {
+		content.WriteString("\n" + i18n.T("timeline.none") + "\n")
+	}
+
+	end := min(offset+rows, len(entries))
+	day := ""
+	for i := offset; i < end; i++ {
+		entry := entries[i]
+		heading, when := i18n.T("timeline.undated"), ""
+		if !entry.Timestamp.IsZero() {
+			heading, when = entry.Timestamp.Format(i18n.T("date.day")), entry.Timestamp.Format("15:04:05")
+		}
+		if i == offset || heading != day {
+			day = heading
+			content.WriteString("\n📅 " + color.Green.Sprint(heading) + "\n")
+		}
+		content.WriteString(fmt.Sprintf("  %-8s %s (%s)\n",
+			when,
+			color.Cyan.Sprint(entry.Command),
+			color.Yellow.Sprint(entry.Shell)))
+	}
+
+	if len(entries) > 0 {
+		pages := (len(entries) + rows - 1) / rows
+		content.WriteString("\n" + color.Gray.Sprint(i18n.T("timeline.page", offset/rows+1, pages, len(entries))) + "\n")
+	}
+	if footer != "" {
+		content.WriteString(footer + "\n")
+	}
+	return frame(style, content.String())
+}
+
+// RenderAnimation shows frame i of a Wrapped section's text animation,
+// wrapping around, or nothing when it has no frames
+func RenderAnimation(frames []string, i int) string {
+	if len(frames) == 0 {
+		return ""
+	}
+	return color.Magenta.Sprint(frames[i%len(frames)])
 }
 
 func RenderQuotes(quotes []string) string {
 	var content strings.Builder
 
 	// Add a header for the quotes section
-	content.WriteString(color.Green.Sprintf("📜 Quotes\n\n"))
+	content.WriteString(title(color.Green, i18n.T("wrapped.quotes")))
 
 	// Render each quote
 	for _, quote := range quotes {
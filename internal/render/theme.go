@@ -0,0 +1,66 @@
+// internal/render/theme.go
+package render
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gookit/color"
+)
+
+// theme holds the colors of the TUI chrome
+type theme struct {
+	title      lipgloss.Color
+	activeTab  lipgloss.Color
+	activeText lipgloss.Color
+	muted      lipgloss.Color
+	// monochrome drops all colors and marks the active tab in reverse video
+	monochrome bool
+}
+
+// Themes lists the available theme names, default first
+var Themes = []string{"default", "light", "mono"}
+
+var themes = map[string]theme{
+	"default": {title: "86", activeTab: "4", activeText: "15", muted: "241"},
+	"light":   {title: "25", activeTab: "31", activeText: "231", muted: "246"},
+	"mono":    {monochrome: true},
+}
+
+var (
+	activeTheme = themes["default"]
+	themeName   = "default"
+)
+
+// SetTheme switches the TUI colors. Unknown names use the default theme.
+func SetTheme(name string) {
+	if _, ok := themes[name]; !ok {
+		name = "default"
+	}
+	activeTheme = themes[name]
+	themeName = name
+	if !plain {
+		color.Enable = !activeTheme.monochrome
+	}
+}
+
+// CurrentTheme returns the name of the active theme
+func CurrentTheme() string {
+	return themeName
+}
+
+// foreground applies c unless the theme is monochrome
+func foreground(style lipgloss.Style, c lipgloss.Color) lipgloss.Style {
+	if activeTheme.monochrome {
+		return style
+	}
+	return style.Foreground(c)
+}
+
+// RenderHeader renders the title bar
+func RenderHeader(text string) string {
+	return foreground(lipgloss.NewStyle().Bold(true).Padding(0, 1), activeTheme.title).Render(text)
+}
+
+// RenderFooter renders the key help line
+func RenderFooter(text string) string {
+	return foreground(lipgloss.NewStyle().Padding(0, 1), activeTheme.muted).Render(text)
+}
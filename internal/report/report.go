@@ -0,0 +1,167 @@
+// internal/report/report.go
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/render"
+)
+
+// Formats a weekly report can be written in
+const (
+	FormatText    = "text"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+// newShown caps the new tools listed
+const newShown = 5
+
+// discordColor is the accent of the Discord embed
+const discordColor = 0x5865F2
+
+// field is one highlight, e.g. "🏆 Top tools" and "git 120 · make 40"
+type field struct {
+	name, value string
+}
+
+// fields condenses the highlights into the lines every format shows,
+// leaving out the ones with nothing to say
+func fields(w analyzer.WeeklyHighlights) []field {
+	commands := fmt.Sprint(w.Commands)
+	if w.PreviousCommands > 0 {
+		change := float64(w.Commands-w.PreviousCommands) / float64(w.PreviousCommands) * 100
+		commands += " " + i18n.T("report.change", change)
+	}
+	result := []field{
+		{i18n.T("report.commands"), commands},
+		{i18n.T("report.active_days"), i18n.T("report.days_of_week", w.ActiveDays)},
+	}
+	if w.Streak > 0 {
+		result = append(result, field{i18n.T("report.streak"), i18n.T("report.streak_days", w.Streak)})
+	}
+	if len(w.Top) > 0 {
+		var top []string
+		for _, program := range w.Top {
+			top = append(top, fmt.Sprintf("%s %d", program.Command, program.Count))
+		}
+		result = append(result, field{i18n.T("report.top"), strings.Join(top, " · ")})
+	}
+	if len(w.NewTools) > 0 {
+		tools := w.NewTools
+		if len(tools) > newShown {
+			tools = tools[:newShown]
+		}
+		value := strings.Join(tools, ", ")
+		if len(w.NewTools) > newShown {
+			value += " " + i18n.T("report.more", len(w.NewTools)-newShown)
+		}
+		result = append(result, field{i18n.T("report.new"), value})
+	}
+	if w.BusiestDayRuns > 0 {
+		result = append(result, field{i18n.T("report.busiest"),
+			i18n.T("report.busiest_day", w.BusiestDay.Format(i18n.T("date.day")), w.BusiestDayRuns)})
+	}
+	if w.LateNight > 0 {
+		result = append(result, field{i18n.T("report.late_night"), i18n.T("report.late_night_runs", w.LateNight)})
+	}
+	if w.NextSkill.Name != "" {
+		result = append(result, field{i18n.T("report.next_skill"), render.SkillName(w.NextSkill)})
+	}
+	return result
+}
+
+// period names the week covered
+func period(w analyzer.WeeklyHighlights) string {
+	layout := i18n.T("date.long")
+	return i18n.T("report.period", w.Start.Format(layout), w.End.AddDate(0, 0, -1).Format(layout))
+}
+
+// Text writes the highlights for the terminal
+func Text(w analyzer.WeeklyHighlights) string {
+	var content strings.Builder
+	content.WriteString(i18n.T("report.title") + "\n" + period(w) + "\n\n")
+	for _, f := range fields(w) {
+		content.WriteString(fmt.Sprintf("%s: %s\n", f.name, f.value))
+	}
+	content.WriteString("\n" + i18n.T("report.footer") + "\n")
+	return content.String()
+}
+
+type slackText struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+// slackMaxFields is the most fields a Slack section block takes
+const slackMaxFields = 10
+
+// Slack builds a Slack incoming webhook payload using Block Kit
+func Slack(w analyzer.WeeklyHighlights) ([]byte, error) {
+	var section []slackText
+	for _, f := range fields(w) {
+		if len(section) < slackMaxFields {
+			section = append(section, slackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", f.name, f.value)})
+		}
+	}
+	payload := struct {
+		Text   string       `json:"text"`
+		Blocks []slackBlock `json:"blocks"`
+	}{
+		// Shown in notifications, where blocks are not
+		Text: i18n.T("report.title") + " · " + period(w),
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: i18n.T("report.title"), Emoji: true}},
+			{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: period(w)}}},
+			{Type: "section", Fields: section},
+			{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: i18n.T("report.footer")}}},
+		},
+	}
+	return json.Marshal(payload)
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+	Footer      struct {
+		Text string `json:"text"`
+	} `json:"footer"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Discord builds a Discord webhook payload with a single embed
+func Discord(w analyzer.WeeklyHighlights) ([]byte, error) {
+	embed := discordEmbed{
+		Title:       i18n.T("report.title"),
+		Description: period(w),
+		Color:       discordColor,
+		Timestamp:   w.End.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	for _, f := range fields(w) {
+		embed.Fields = append(embed.Fields, discordField{Name: f.name, Value: f.value, Inline: true})
+	}
+	embed.Footer.Text = i18n.T("report.footer")
+	return json.Marshal(struct {
+		Embeds []discordEmbed `json:"embeds"`
+	}{[]discordEmbed{embed}})
+}
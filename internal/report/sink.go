@@ -0,0 +1,97 @@
+// internal/report/sink.go
+package report
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/config"
+)
+
+// Bucket is the store bucket recording which weeks were posted
+const Bucket = "reports"
+
+// postTimeout bounds a webhook request
+const postTimeout = 15 * time.Second
+
+// Configured reports whether any webhook is set
+func Configured(cfg config.Report) bool {
+	return cfg.SlackWebhook != "" || cfg.DiscordWebhook != ""
+}
+
+// Due reports whether a scheduled run at now should post, i.e. whether it
+// is the configured weekday, Monday by default
+func Due(cfg config.Report, now time.Time) (bool, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.Weekday))
+	if name == "" {
+		return now.Weekday() == time.Monday, nil
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.ToLower(day.String()) == name {
+			return now.Weekday() == day, nil
+		}
+	}
+	return false, fmt.Errorf("invalid report weekday %q, expected e.g. monday", cfg.Weekday)
+}
+
+// Send posts the highlights to every configured webhook and returns the
+// formats that were delivered
+func Send(cfg config.Report, w analyzer.WeeklyHighlights) ([]string, error) {
+	var sent []string
+	var errs []error
+	for _, hook := range []struct {
+		format string
+		url    string
+		build  func(analyzer.WeeklyHighlights) ([]byte, error)
+	}{
+		{FormatSlack, cfg.SlackWebhook, Slack},
+		{FormatDiscord, cfg.DiscordWebhook, Discord},
+	} {
+		if hook.url == "" {
+			continue
+		}
+		payload, err := hook.build(w)
+		if err == nil {
+			err = Post(hook.url, payload)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to post to %s: %v", hook.format, err))
+			continue
+		}
+		sent = append(sent, hook.format)
+	}
+	return sent, errors.Join(errs...)
+}
+
+// Post sends a JSON payload to a webhook
+func Post(webhook string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return errors.New("invalid webhook URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: postTimeout}
+	resp, err := client.Do(req)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// The URL holds the webhook's secret, so it is left out
+		return urlErr.Err
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
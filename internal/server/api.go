@@ -0,0 +1,153 @@
+// internal/server/api.go
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/gemini"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/redact"
+)
+
+// apiTopCommands caps the programs listed by /api/v1/overview, and
+// apiTimeline the commands of /api/v1/timeline
+const (
+	apiTopCommands = 10
+	apiTimeline    = 100
+)
+
+// Response is what every /api/v1 endpoint serves: its data with the filter
+// it was computed for, so that fields can be added without breaking
+// dashboards reading data
+type Response struct {
+	Filter Filter      `json:"filter"`
+	Data   interface{} `json:"data"`
+}
+
+// Overview is the data of /api/v1/overview
+type Overview struct {
+	Commands        int                     `json:"commands"`
+	Shells          []ShellOverview         `json:"shells"`
+	TopCommands     []analyzer.CommandCount `json:"top_commands"`
+	PrimaryRole     string                  `json:"primary_role,omitempty"`
+	PrimaryLanguage string                  `json:"primary_language,omitempty"`
+	TechStack       []string                `json:"tech_stack"`
+	PeakHours       []int                   `json:"peak_hours"`
+}
+
+// ShellOverview is one shell in the overview
+type ShellOverview struct {
+	Shell       string `json:"shell"`
+	Commands    int    `json:"commands"`
+	Aliases     int    `json:"aliases"`
+	Plugins     int    `json:"plugins"`
+	Environment int    `json:"environment"`
+	Prompt      string `json:"prompt,omitempty"`
+}
+
+// TimelineEntry is one command of /api/v1/timeline, with secrets redacted
+type TimelineEntry struct {
+	Timestamp  string   `json:"timestamp,omitempty"`
+	Command    string   `json:"command"`
+	Shell      string   `json:"shell"`
+	Categories []string `json:"categories"`
+	Kind       string   `json:"kind"`
+}
+
+// Wrapped is the data of /api/v1/wrapped: the year in review and the
+// slides `wrapped` plays for it
+type Wrapped struct {
+	Year     int                   `json:"year"`
+	Review   analyzer.YearInReview `json:"review"`
+	Sections []gemini.Section      `json:"sections"`
+}
+
+// handleAPI serves an /api/v1 endpoint, computing its data with fn from
+// the analysis for the filter
+func (s *Server) handleAPI(fn func(analyzer.ShellData) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := filterOf(r)
+		data, err := s.Analyze(filter)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, Response{Filter: filter, Data: fn(data)})
+	}
+}
+
+func overview(data analyzer.ShellData) interface{} {
+	profile := data.Insights.TechnicalProfile
+	result := Overview{
+		TopCommands:     analyzer.SortedCounts(data.CommonCmds, apiTopCommands),
+		PrimaryRole:     profile.PrimaryRole,
+		PrimaryLanguage: profile.PrimaryLanguage,
+		TechStack:       profile.TechStack,
+		PeakHours:       data.Insights.WorkPatterns.PeakHours,
+	}
+	for _, shell := range analyzer.SortedKeys(data.Histories) {
+		config := data.ShellConfigs[shell]
+		result.Commands += data.CommandCounts[shell]
+		result.Shells = append(result.Shells, ShellOverview{
+			Shell:       shell,
+			Commands:    data.CommandCounts[shell],
+			Aliases:     len(config.Aliases),
+			Plugins:     len(config.Plugins),
+			Environment: len(config.Environment),
+			Prompt:      config.Prompt.Framework,
+		})
+	}
+	return result
+}
+
+func toolUsage(data analyzer.ShellData) interface{} {
+	return data.Insights.ToolUsage
+}
+
+func timeline(data analyzer.ShellData) interface{} {
+	entries := []TimelineEntry{}
+	commands := analyzer.GenerateTimelineData(data)
+	for _, entry := range commands[:min(len(commands), apiTimeline)] {
+		item := TimelineEntry{Command: redact.String(entry.Command), Shell: entry.Shell, Categories: entry.Categories, Kind: entry.Kind}
+		if !entry.Timestamp.IsZero() {
+			item.Timestamp = entry.Timestamp.Format(time.RFC3339)
+		}
+		entries = append(entries, item)
+	}
+	return entries
+}
+
+// handleWrapped serves /api/v1/wrapped for the year in the query string,
+// this year by default. since and until are ignored, the year is the
+// period.
+func (s *Server) handleWrapped(w http.ResponseWriter, r *http.Request) {
+	now := clock.Now()
+	year := now.Year()
+	if value := r.URL.Query().Get("year"); value != "" {
+		var err error
+		if year, err = strconv.Atoi(value); err != nil || year < 1970 || year > now.Year() {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", value))
+			return
+		}
+	}
+
+	filter := Filter{Since: strconv.Itoa(year), Until: strconv.Itoa(year), Shell: filterOf(r).Shell}
+	data, err := s.Analyze(filter)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// The years before only tell which tools are new
+	before, err := s.Analyze(Filter{Until: strconv.Itoa(year - 1), Shell: filter.Shell})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	review := analyzer.ReviewYear(data, year, before.CommonCmds)
+	sections := gemini.ApplyRatings(gemini.GenerateYearWrapped(data, review).Sections, gemini.LoadRatings())
+	writeJSON(w, Response{Filter: filter, Data: Wrapped{Year: year, Review: review, Sections: sections}})
+}
@@ -0,0 +1,97 @@
+// internal/server/metrics.go
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// metricsTools caps the programs exported by k8au_tool_usage, which keeps
+// the number of series bounded however varied the history
+const metricsTools = 50
+
+// metricsUncategorized labels the commands in no category
+const metricsUncategorized = "other"
+
+// labelValue escapes a label value as the text format expects
+var labelValue = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// MetricsHandler returns the Prometheus exposition of the whole history,
+// for scraping. Every scrape reuses the analysis for cacheTTL.
+func (s *Server) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := s.Analyze(Filter{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, data)
+	})
+}
+
+// writeMetrics writes data in the Prometheus text format
+func writeMetrics(w io.Writer, data analyzer.ShellData) {
+	// Categories are only known for the sample of recent commands, which
+	// can shrink as well as grow between scrapes, so this is no counter
+	metric(w, "k8au_commands", "gauge", "Recent commands by shell and category; a command in several categories counts in each.")
+	for _, shell := range analyzer.SortedKeys(data.Histories) {
+		categories := make(map[string]int)
+		for _, entry := range data.Histories[shell] {
+			if len(entry.Categories) == 0 {
+				categories[metricsUncategorized]++
+			}
+			for _, category := range entry.Categories {
+				categories[category]++
+			}
+		}
+		for _, category := range analyzer.SortedKeys(categories) {
+			sample(w, "k8au_commands", categories[category], "shell", shell, "category", category)
+		}
+	}
+
+	metric(w, "k8au_unique_commands", "gauge", "Distinct programs run, by shell.")
+	for _, shell := range analyzer.SortedKeys(data.ShellCmds) {
+		sample(w, "k8au_unique_commands", len(data.ShellCmds[shell]), "shell", shell)
+	}
+
+	metric(w, "k8au_peak_hour", "gauge", "Hour of the day, 0 to 23, with the most commands; absent without timestamps.")
+	if peaks := data.Insights.WorkPatterns.PeakHours; len(peaks) > 0 {
+		sample(w, "k8au_peak_hour", peaks[0])
+	}
+
+	// A program leaving the top list drops its series, so this is no counter
+	// either
+	metric(w, "k8au_tool_usage", "gauge", fmt.Sprintf("Runs of the %d most run programs.", metricsTools))
+	tools := analyzer.SortedCounts(data.CommonCmds, metricsTools)
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Command < tools[j].Command })
+	for _, tool := range tools {
+		sample(w, "k8au_tool_usage", tool.Count, "tool", tool.Command)
+	}
+}
+
+// metric writes the HELP and TYPE lines of a metric
+func metric(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sample writes one value of a metric; labels alternate names and values
+func sample(w io.Writer, name string, value int, labels ...string) {
+	var pairs []string
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+`="`+labelValue.Replace(labels[i+1])+`"`)
+	}
+	if len(pairs) > 0 {
+		name += "{" + strings.Join(pairs, ",") + "}"
+	}
+	fmt.Fprintf(w, "%s %d\n", name, value)
+}
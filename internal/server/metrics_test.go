@@ -0,0 +1,34 @@
+// internal/server/metrics_test.go
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// writeMetrics exports values computed afresh on every scrape, which must
+// not be declared counters
+func TestMetricsAreGauges(t *testing.T) {
+	data := analyzer.ShellData{
+		Histories:  map[string][]analyzer.CommandEntry{"zsh": {{Command: "git status", Categories: []string{"git"}}, {Command: "ls"}}},
+		ShellCmds:  map[string]map[string]int{"zsh": {"git": 1, "ls": 1}},
+		CommonCmds: map[string]int{"git": 1, "ls": 1},
+	}
+	var out strings.Builder
+	writeMetrics(&out, data)
+
+	if strings.Contains(out.String(), "counter") {
+		t.Errorf("a metric is declared a counter:\n%s", out.String())
+	}
+	for _, want := range []string{
+		`k8au_commands{shell="zsh",category="git"} 1`,
+		`k8au_commands{shell="zsh",category="other"} 1`,
+		`k8au_tool_usage{tool="git"} 1`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %s in:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// internal/server/server.go
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/i18n"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/snapshot"
+)
+
+//go:embed dashboard.html
+var dashboardHTML string
+
+// cacheTTL is how long an analysis is served before the history is read
+// again, so new commands show up on reload
+const cacheTTL = time.Minute
+
+// maxCached is how many analyses are kept, each with the whole history:
+// enough for a dashboard switching between a few filters
+const maxCached = 4
+
+// Period turns the since and until filters into the range to analyze,
+// as the --since and --until flags do
+type Period func(since, until string) (time.Time, time.Time, error)
+
+// Server renders the analysis as a web dashboard backed by JSON endpoints
+type Server struct {
+	opts   analyzer.Options
+	period Period
+	page   *template.Template
+
+	// analyzing serializes the analyses, which share caches on disk
+	analyzing sync.Mutex
+	mu        sync.Mutex
+	cache     map[cacheKey]cached
+}
+
+// Filter narrows the analysis served, from the query string
+type Filter struct {
+	Since string `json:"since,omitempty"`
+	Until string `json:"until,omitempty"`
+	Shell string `json:"shell,omitempty"`
+}
+
+// cacheKey is a filter resolved to the period and shell analyzed, so that
+// e.g. since=2024 and since=2024-01-01 share an analysis. The period is
+// rounded to cacheTTL, which an age such as since=30d moves by the second.
+type cacheKey struct {
+	since, until time.Time
+	shell        string
+}
+
+type cached struct {
+	data  analyzer.ShellData
+	taken time.Time
+	used  time.Time
+}
+
+// Report is what /data.json serves: the analysis as a snapshot, which
+// leaves out the raw history, with the filter it was run with
+type Report struct {
+	Filter Filter `json:"filter"`
+	// Shells are the shells that can be filtered on
+	Shells []string `json:"shells"`
+	snapshot.Snapshot
+}
+
+// New returns a server analyzing with opts
+func New(opts analyzer.Options, period Period) (*Server, error) {
+	page, err := template.New("dashboard").Funcs(template.FuncMap{"t": i18n.T}).Parse(dashboardHTML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the dashboard: %v", err)
+	}
+	return &Server{opts: opts, period: period, page: page, cache: make(map[cacheKey]cached)}, nil
+}
+
+// Handler returns the routes of the dashboard and of the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleDashboard)
+	mux.HandleFunc("/data.json", s.handleData)
+	mux.HandleFunc("/api/v1/overview", s.handleAPI(overview))
+	mux.HandleFunc("/api/v1/toolusage", s.handleAPI(toolUsage))
+	mux.HandleFunc("/api/v1/timeline", s.handleAPI(timeline))
+	mux.HandleFunc("/api/v1/wrapped", s.handleWrapped)
+	return mux
+}
+
+// Analyze runs the analysis for the filter, or returns the one run less
+// than cacheTTL ago
+func (s *Server) Analyze(filter Filter) (analyzer.ShellData, error) {
+	opts := s.opts
+	var err error
+	if opts.Since, opts.Until, err = s.period(filter.Since, filter.Until); err != nil {
+		return analyzer.ShellData{}, err
+	}
+	if filter.Shell != "" {
+		if !slices.Contains(analyzer.SupportedShells(), filter.Shell) || !opts.Includes(filter.Shell) {
+			return analyzer.ShellData{}, fmt.Errorf("unknown shell %q", filter.Shell)
+		}
+		opts.Shells = []string{filter.Shell}
+	}
+	key := cacheKey{since: opts.Since.Truncate(cacheTTL), until: opts.Until.Truncate(cacheTTL), shell: filter.Shell}
+
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	if ok && clock.Now().Sub(entry.taken) < cacheTTL {
+		entry.used = clock.Now()
+		s.cache[key] = entry
+		s.mu.Unlock()
+		return entry.data, nil
+	}
+	s.mu.Unlock()
+
+	s.analyzing.Lock()
+	data := analyzer.Analyze(opts)
+	s.analyzing.Unlock()
+
+	s.mu.Lock()
+	s.store(key, data)
+	s.mu.Unlock()
+	return data, nil
+}
+
+// store caches an analysis, dropping the expired ones and then, past
+// maxCached, the least recently used. s.mu must be held.
+func (s *Server) store(key cacheKey, data analyzer.ShellData) {
+	now := clock.Now()
+	for k, entry := range s.cache {
+		if now.Sub(entry.taken) >= cacheTTL {
+			delete(s.cache, k)
+		}
+	}
+	delete(s.cache, key)
+	for len(s.cache) >= maxCached {
+		var oldest cacheKey
+		found := false
+		for k, entry := range s.cache {
+			if !found || entry.used.Before(s.cache[oldest].used) {
+				oldest, found = k, true
+			}
+		}
+		delete(s.cache, oldest)
+	}
+	s.cache[key] = cached{data: data, taken: now, used: now}
+}
+
+// filterOf reads the filter from the query string
+func filterOf(r *http.Request) Filter {
+	query := r.URL.Query()
+	return Filter{Since: query.Get("since"), Until: query.Get("until"), Shell: query.Get("shell")}
+}
+
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.page.Execute(w, struct{ Lang string }{i18n.Language()}); err != nil {
+		slog.Error("failed to render the dashboard", "err", err)
+	}
+}
+
+func (s *Server) handleData(w http.ResponseWriter, r *http.Request) {
+	filter := filterOf(r)
+	data, err := s.Analyze(filter)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var shells []string
+	for _, shell := range analyzer.SupportedShells() {
+		if s.opts.Includes(shell) {
+			shells = append(shells, shell)
+		}
+	}
+	writeJSON(w, Report{Filter: filter, Shells: shells, Snapshot: snapshot.New(data, clock.Now())})
+}
+
+// writeJSON writes v as the response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write response", "err", err)
+	}
+}
+
+// writeError writes err as a JSON error with the status
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
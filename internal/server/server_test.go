@@ -0,0 +1,59 @@
+// internal/server/server_test.go
+package server
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/clock"
+)
+
+// period resolves the filters as the serve command does
+func period(since, until string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if since != "" {
+		if from, _, err = analyzer.ParsePeriod(since, clock.Now()); err != nil {
+			return from, to, err
+		}
+	}
+	if until != "" {
+		if _, to, err = analyzer.ParsePeriod(until, clock.Now()); err != nil {
+			return from, to, err
+		}
+	}
+	return from, to, nil
+}
+
+func TestAnalyzeCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	s, err := New(analyzer.Options{Disabled: []string{analyzer.ModuleProbe, analyzer.ModuleConfig, analyzer.ModulePlugins}}, period)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Spellings of the same period share an analysis
+	for _, since := range []string{"2024", "2024-01", "2024-01-01"} {
+		if _, err := s.Analyze(Filter{Since: since}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(s.cache) != 1 {
+		t.Errorf("cached %d analyses for one period, want 1", len(s.cache))
+	}
+
+	for year := 2010; year < 2020; year++ {
+		if _, err := s.Analyze(Filter{Since: strconv.Itoa(year)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(s.cache) > maxCached {
+		t.Errorf("cached %d analyses, want at most %d", len(s.cache), maxCached)
+	}
+	if _, ok := s.cache[cacheKey{since: time.Date(2019, 1, 1, 0, 0, 0, 0, time.Local)}]; !ok {
+		t.Error("the most recent analysis was evicted")
+	}
+}
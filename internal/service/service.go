@@ -0,0 +1,199 @@
+// internal/service/service.go
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+const (
+	// unitName names the systemd service and timer
+	unitName = "k8au-shell-analyzer-snapshot"
+	// launchdLabel names the launchd job and its plist
+	launchdLabel = "com.ksauraj.k8au-shell-analyzer.snapshot"
+)
+
+// ErrUnsupported is returned on systems without systemd or launchd
+var ErrUnsupported = errors.New("scheduled snapshots need systemd (Linux) or launchd (macOS)")
+
+// File is a service definition written by Install
+type File struct {
+	Path    string
+	Content string
+}
+
+// Files returns the definitions that run `exe snapshot` once a day on goos
+func Files(goos, exe string) ([]File, error) {
+	switch goos {
+	case "linux":
+		dir := systemdDir()
+		return []File{
+			{Path: filepath.Join(dir, unitName+".service"), Content: systemdService(exe)},
+			{Path: filepath.Join(dir, unitName+".timer"), Content: systemdTimer},
+		}, nil
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find home directory: %v", err)
+		}
+		path := filepath.Join(home, "Library", "LaunchAgents", launchdLabel+".plist")
+		return []File{{Path: path, Content: launchdPlist(exe)}}, nil
+	}
+	return nil, ErrUnsupported
+}
+
+// Install writes the service definitions for this system and enables them.
+// The files are returned even when enabling fails, so the caller can say
+// where they are.
+func Install(exe string) ([]File, error) {
+	files, err := Files(runtime.GOOS, exe)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %v", filepath.Dir(f.Path), err)
+		}
+		if err := os.WriteFile(f.Path, []byte(f.Content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %v", f.Path, err)
+		}
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		if err := run("systemctl", "--user", "daemon-reload"); err != nil {
+			return files, err
+		}
+		return files, run("systemctl", "--user", "enable", "--now", unitName+".timer")
+	case "darwin":
+		// Reloading picks up a changed executable path
+		run("launchctl", "unload", files[0].Path)
+		return files, run("launchctl", "load", "-w", files[0].Path)
+	}
+	return files, nil
+}
+
+// Uninstall disables the scheduled snapshot and removes its definitions
+func Uninstall() ([]File, error) {
+	files, err := Files(runtime.GOOS, "")
+	if err != nil {
+		return nil, err
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		run("systemctl", "--user", "disable", "--now", unitName+".timer")
+	case "darwin":
+		run("launchctl", "unload", "-w", files[0].Path)
+	}
+
+	var removed []File
+	for _, f := range files {
+		err := os.Remove(f.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %v", f.Path, err)
+		}
+		removed = append(removed, f)
+	}
+
+	if runtime.GOOS == "linux" {
+		run("systemctl", "--user", "daemon-reload")
+	}
+	return removed, nil
+}
+
+// run executes a service manager command, including its output in the error
+func run(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to run %s %s: %v: %s", name, strings.Join(args, " "), err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// systemdDir returns $XDG_CONFIG_HOME/systemd/user, defaulting to
+// ~/.config/systemd/user
+func systemdDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "systemd", "user")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "systemd", "user")
+}
+
+func systemdService(exe string) string {
+	return fmt.Sprintf(`[Unit]
+Description=Record a K8au Shell Analyzer snapshot
+
+[Service]
+Type=oneshot
+ExecStart="%s" snapshot
+Nice=10
+`, exe)
+}
+
+// systemdTimer runs the service daily, catching up on boot when the
+// machine was off at the scheduled time
+const systemdTimer = `[Unit]
+Description=Record a K8au Shell Analyzer snapshot every day
+
+[Timer]
+OnCalendar=daily
+Persistent=true
+RandomizedDelaySec=15min
+
+[Install]
+WantedBy=timers.target
+`
+
+// launchdPlist runs `exe snapshot` every day at noon. launchd runs missed
+// calendar jobs when the machine wakes up.
+func launchdPlist(exe string) string {
+	logPath := filepath.Join(store.DefaultDir(), "snapshot.log")
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>%s</string>
+		<string>snapshot</string>
+	</array>
+	<key>StartCalendarInterval</key>
+	<dict>
+		<key>Hour</key>
+		<integer>12</integer>
+		<key>Minute</key>
+		<integer>0</integer>
+	</dict>
+	<key>ProcessType</key>
+	<string>Background</string>
+	<key>StandardOutPath</key>
+	<string>%s</string>
+	<key>StandardErrorPath</key>
+	<string>%s</string>
+</dict>
+</plist>
+`, launchdLabel, escape(exe), escape(logPath), escape(logPath))
+}
+
+// escape makes s safe to embed in the plist
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,124 @@
+// internal/snapshot/aggregate.go
+package snapshot
+
+import (
+	"sort"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// Team combines the snapshots of several people into statistics that name
+// none of them
+type Team struct {
+	Members  int `json:"members"`
+	Commands int `json:"commands"`
+	// Tools are the programs run by the most members, then most often
+	Tools []SharedItem `json:"tools"`
+	// Hourly counts the timestamped commands of everyone by hour, and
+	// PeakHours are the three busiest
+	Hourly    [24]int `json:"hourly"`
+	PeakHours []int   `json:"peak_hours"`
+	// Stack counts the members with each language or tool in their tech
+	// stack, and Roles those with each primary skill
+	Stack []SharedItem `json:"stack"`
+	Roles []SharedItem `json:"roles"`
+	// Hidden counts the tools, stack entries and roles of a single member,
+	// left out so that no one can be picked out by them
+	Hidden int `json:"hidden"`
+}
+
+// SharedItem is a tool, technology or role and how many members have it
+type SharedItem struct {
+	Name    string `json:"name"`
+	Members int    `json:"members"`
+	// Count is the number of runs, for tools
+	Count int `json:"count,omitempty"`
+	// Language marks a role named after a language, e.g. Go Developer
+	Language bool `json:"language,omitempty"`
+}
+
+const (
+	// teamMinMembers is how many members must share a tool, technology or
+	// role for it to be listed
+	teamMinMembers = 2
+	// teamMinRuns is how often a member must run a program for it to count
+	// as one of their tools
+	teamMinRuns = 3
+	// teamToolsShown caps the tools listed
+	teamToolsShown = 15
+)
+
+// Aggregate combines the snapshots, one per member
+func Aggregate(snaps []Snapshot) Team {
+	team := Team{Members: len(snaps)}
+	tools := make(map[string]*SharedItem)
+	stack := make(map[string]*SharedItem)
+	roles := make(map[string]*SharedItem)
+	add := func(items map[string]*SharedItem, name string, count int) {
+		if items[name] == nil {
+			items[name] = &SharedItem{Name: name}
+		}
+		items[name].Members++
+		items[name].Count += count
+	}
+
+	var activity [7][24]int
+	for _, snap := range snaps {
+		team.Commands += total(snap.CommandCounts)
+		for program, count := range snap.CommonCmds {
+			if count >= teamMinRuns {
+				add(tools, program, count)
+			}
+		}
+		for _, tech := range snap.TechProfile.TechStack {
+			add(stack, tech, 0)
+		}
+		if skill := snap.TechProfile.PrimarySkill; skill.Name != "" && !skill.Language {
+			add(roles, skill.Name, 0)
+		} else if language := snap.TechProfile.PrimaryLanguage; language != "" {
+			add(roles, language, 0)
+			roles[language].Language = true
+		}
+		for day := range activity {
+			for hour := range activity[day] {
+				activity[day][hour] += snap.WorkPatterns.Activity[day][hour]
+			}
+		}
+	}
+	team.Hourly = analyzer.HourlyActivity(analyzer.WorkPatterns{Activity: activity})
+	team.PeakHours = analyzer.PeakHours(team.Hourly)
+
+	var hidden int
+	team.Tools, hidden = shared(tools, team.Members)
+	team.Hidden += hidden
+	team.Tools = team.Tools[:min(len(team.Tools), teamToolsShown)]
+	team.Stack, hidden = shared(stack, team.Members)
+	team.Hidden += hidden
+	team.Roles, hidden = shared(roles, team.Members)
+	team.Hidden += hidden
+	return team
+}
+
+// shared lists the items held by at least teamMinMembers, or by anyone
+// when the team is smaller, most members first, and counts those left out
+func shared(items map[string]*SharedItem, members int) ([]SharedItem, int) {
+	var list []SharedItem
+	hidden := 0
+	for _, item := range items {
+		if item.Members < min(teamMinMembers, members) {
+			hidden++
+			continue
+		}
+		list = append(list, *item)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Members != list[j].Members {
+			return list[i].Members > list[j].Members
+		}
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list, hidden
+}
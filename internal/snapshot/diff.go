@@ -0,0 +1,117 @@
+// internal/snapshot/diff.go
+package snapshot
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// diffMinRuns is how often a program has to run on one side to count as
+// adopted or abandoned, so one-off commands are left out
+const diffMinRuns = 3
+
+// proficiencyChange is the smallest change in proficiency worth listing,
+// in points of the score out of 100
+const proficiencyChange = 1.0
+
+// Diff compares the usage of two snapshots or periods
+type Diff struct {
+	Before, After string
+	// BeforeCommands and AfterCommands count the commands on each side.
+	// When Cumulative, the After side is what was run since Before.
+	BeforeCommands, AfterCommands int
+	Cumulative                    bool
+	// Adopted are the programs run on the After side but not the Before
+	// side, Abandoned the other way round, each most run first
+	Adopted     []analyzer.CommandCount
+	Abandoned   []analyzer.CommandCount
+	NewTech     []string
+	DroppedTech []string
+	// BeforePeak and AfterPeak are the three busiest hours of each side
+	BeforePeak, AfterPeak []int
+	// Proficiency lists the changes in proficiency, largest first
+	Proficiency []ProficiencyChange
+}
+
+// ProficiencyChange is how the proficiency in a language or tool moved
+type ProficiencyChange struct {
+	Name          string
+	Before, After float64
+}
+
+// Compare diffs before against after, labelled as given. Stored snapshots
+// each cover the whole history up to when they were taken; with cumulative
+// set, the After side is what was added in between, so a program counts
+// as abandoned when it was not run again.
+func Compare(before, after Snapshot, beforeLabel, afterLabel string, cumulative bool) Diff {
+	afterCmds := after.CommonCmds
+	afterActivity := after.WorkPatterns.Activity
+	if cumulative {
+		afterCmds = make(map[string]int)
+		for program, count := range after.CommonCmds {
+			if added := count - before.CommonCmds[program]; added > 0 {
+				afterCmds[program] = added
+			}
+		}
+		for day := range afterActivity {
+			for hour := range afterActivity[day] {
+				afterActivity[day][hour] = max(afterActivity[day][hour]-before.WorkPatterns.Activity[day][hour], 0)
+			}
+		}
+	}
+
+	diff := Diff{
+		Before:         beforeLabel,
+		After:          afterLabel,
+		BeforeCommands: total(before.CommonCmds),
+		AfterCommands:  total(afterCmds),
+		Cumulative:     cumulative,
+		Adopted:        onlyIn(afterCmds, before.CommonCmds),
+		Abandoned:      onlyIn(before.CommonCmds, afterCmds),
+		NewTech:        missing(after.TechProfile.TechStack, before.TechProfile.TechStack),
+		DroppedTech:    missing(before.TechProfile.TechStack, after.TechProfile.TechStack),
+		BeforePeak:     analyzer.PeakHours(analyzer.HourlyActivity(before.WorkPatterns)),
+		AfterPeak:      analyzer.PeakHours(analyzer.HourlyActivity(analyzer.WorkPatterns{Activity: afterActivity})),
+	}
+
+	names := make(map[string]bool)
+	for name := range before.TechProfile.Proficiency {
+		names[name] = true
+	}
+	for name := range after.TechProfile.Proficiency {
+		names[name] = true
+	}
+	for _, name := range analyzer.SortedKeys(names) {
+		change := ProficiencyChange{Name: name, Before: before.TechProfile.Proficiency[name], After: after.TechProfile.Proficiency[name]}
+		if math.Abs(change.After-change.Before) >= proficiencyChange {
+			diff.Proficiency = append(diff.Proficiency, change)
+		}
+	}
+	sort.SliceStable(diff.Proficiency, func(i, j int) bool {
+		a, b := diff.Proficiency[i], diff.Proficiency[j]
+		return math.Abs(a.After-a.Before) > math.Abs(b.After-b.Before)
+	})
+	return diff
+}
+
+// onlyIn returns the programs run at least diffMinRuns times in a and
+// never in b
+func onlyIn(a, b map[string]int) []analyzer.CommandCount {
+	only := make(map[string]int)
+	for program, count := range a {
+		if count >= diffMinRuns && b[program] == 0 {
+			only[program] = count
+		}
+	}
+	return analyzer.SortedCounts(only, 0)
+}
+
+func total(counts map[string]int) int {
+	sum := 0
+	for _, count := range counts {
+		sum += count
+	}
+	return sum
+}
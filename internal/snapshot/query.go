@@ -0,0 +1,164 @@
+// internal/snapshot/query.go
+package snapshot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// Groups a query can break the commands of a snapshot down by
+const (
+	GroupTool     = "tool"
+	GroupCategory = "category"
+	GroupShell    = "shell"
+)
+
+// Buckets a query can thin the snapshots to, keeping the newest of each
+const (
+	PerDay   = "day"
+	PerMonth = "month"
+)
+
+// uncategorized groups the programs without a category
+const uncategorized = "other"
+
+// Query slices the stored snapshots, so dashboards can chart them without
+// exporting every snapshot
+type Query struct {
+	// Since and Until limit when the snapshots were taken; zero is open
+	Since, Until time.Time
+	// GroupBy is GroupTool, GroupCategory or GroupShell
+	GroupBy string
+	// Per is PerDay or PerMonth to keep one snapshot per period, or ""
+	// for all of them
+	Per string
+	// Only keeps these groups, e.g. {"git", "docker"}; empty keeps all
+	Only []string
+	// Top keeps the largest groups of each snapshot; 0 keeps all
+	Top int
+}
+
+// Row is the commands of one group in one snapshot
+type Row struct {
+	Snapshot string    `json:"snapshot"`
+	Taken    time.Time `json:"taken"`
+	// Period is the day or month the snapshot stands for, with Per set
+	Period   string `json:"period,omitempty"`
+	Group    string `json:"group"`
+	Commands int    `json:"commands"`
+	// Share is the percentage of the snapshot's commands
+	Share float64 `json:"share"`
+}
+
+// Validate checks the group and bucket names
+func (q Query) Validate() error {
+	switch q.GroupBy {
+	case GroupTool, GroupCategory, GroupShell:
+	default:
+		return fmt.Errorf("unknown group %q, expected %s, %s or %s", q.GroupBy, GroupTool, GroupCategory, GroupShell)
+	}
+	switch q.Per {
+	case "", PerDay, PerMonth:
+	default:
+		return fmt.Errorf("unknown period %q, expected %s or %s", q.Per, PerDay, PerMonth)
+	}
+	return nil
+}
+
+// Run answers q from the snapshots in s, oldest first and largest group
+// first within a snapshot. Snapshots outside the range are not decoded.
+func Run(s store.Store, q Query) ([]Row, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	keys, err := s.List(Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %v", err)
+	}
+
+	// Keys sort chronologically, so the last key of a period is its newest
+	var selected []string
+	periods := make(map[string]string)
+	for _, key := range keys {
+		taken, ok := KeyTime(key)
+		if !ok || taken.Before(q.Since) || (!q.Until.IsZero() && !taken.Before(q.Until)) {
+			continue
+		}
+		period := q.period(taken)
+		if n := len(selected); n > 0 && period != "" && periods[selected[n-1]] == period {
+			selected = selected[:n-1]
+		}
+		selected = append(selected, key)
+		periods[key] = period
+	}
+
+	only := make(map[string]bool)
+	for _, group := range q.Only {
+		only[group] = true
+	}
+	var rows []Row
+	for _, key := range selected {
+		snap, err := Load(s, key)
+		if err != nil {
+			return nil, err
+		}
+		counts := q.groups(snap)
+		total := 0
+		for _, count := range snap.CommandCounts {
+			total += count
+		}
+		var groups []analyzer.CommandCount
+		for _, group := range analyzer.SortedCounts(counts, 0) {
+			if len(only) == 0 || only[group.Command] {
+				groups = append(groups, group)
+			}
+		}
+		if q.Top > 0 && len(groups) > q.Top {
+			groups = groups[:q.Top]
+		}
+		for _, group := range groups {
+			row := Row{Snapshot: key, Taken: snap.Taken, Period: periods[key], Group: group.Command, Commands: group.Count}
+			if total > 0 {
+				row.Share = float64(group.Count) / float64(total) * 100
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// period names the bucket a snapshot taken at taken falls in
+func (q Query) period(taken time.Time) string {
+	switch q.Per {
+	case PerDay:
+		return taken.Local().Format("2006-01-02")
+	case PerMonth:
+		return taken.Local().Format("2006-01")
+	}
+	return ""
+}
+
+// groups breaks the commands of snap down by q.GroupBy. A program in more
+// than one category counts towards each.
+func (q Query) groups(snap Snapshot) map[string]int {
+	switch q.GroupBy {
+	case GroupShell:
+		return snap.CommandCounts
+	case GroupCategory:
+		counts := make(map[string]int)
+		for program, count := range snap.CommonCmds {
+			categories := analyzer.CategorizeCommand(program)
+			if len(categories) == 0 {
+				categories = []string{uncategorized}
+			}
+			for _, category := range categories {
+				counts[category] += count
+			}
+		}
+		return counts
+	}
+	return snap.CommonCmds
+}
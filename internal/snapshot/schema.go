@@ -0,0 +1,149 @@
+// internal/snapshot/schema.go
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+)
+
+// SchemaVersion is the version of the snapshot layout written by this
+// release. Bump it, and add a migration, whenever a field is renamed,
+// removed or changes meaning.
+//
+//	1: snapshots written before the schema field existed
+//	2: adds schema; work patterns always carry the complexity levels
+//	3: proficiency is a score out of 100 instead of a share of commands
+const SchemaVersion = 3
+
+// document is a snapshot decoded generically, so migrations can rename and
+// reshape fields the Snapshot type no longer has
+type document map[string]interface{}
+
+// migrations[i] upgrades a document from schema i+1 to i+2
+var migrations = []func(document) error{
+	migrateV1,
+	migrateV2,
+}
+
+// Decode parses a snapshot written with any schema up to SchemaVersion
+// and migrates it to the current one
+func Decode(data []byte) (Snapshot, error) {
+	doc := make(document)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep counts as written rather than rounding them through float64
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to decode snapshot: %v", err)
+	}
+
+	version, err := doc.schema()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if version > SchemaVersion {
+		return Snapshot{}, fmt.Errorf("snapshot schema %d is newer than this release supports (%d), please upgrade", version, SchemaVersion)
+	}
+	for ; version < SchemaVersion; version++ {
+		if err := migrations[version-1](doc); err != nil {
+			return Snapshot{}, fmt.Errorf("failed to migrate snapshot from schema %d: %v", version, err)
+		}
+		doc["schema"] = version + 1
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to encode migrated snapshot: %v", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(migrated, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to decode snapshot: %v", err)
+	}
+	return snap, nil
+}
+
+// schema returns the version the document was written with; snapshots
+// from before the field existed are version 1
+func (doc document) schema() (int, error) {
+	value, ok := doc["schema"]
+	if !ok {
+		return 1, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid snapshot schema %v", value)
+	}
+	version, err := number.Int64()
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid snapshot schema %v", value)
+	}
+	return int(version), nil
+}
+
+// object returns the object under key, creating it when missing
+func (doc document) object(key string) (document, error) {
+	switch value := doc[key].(type) {
+	case nil:
+		child := make(document)
+		doc[key] = child
+		return child, nil
+	case map[string]interface{}:
+		return document(value), nil
+	case document:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("%s is not an object", key)
+	}
+}
+
+// migrateV1 fills in the complexity levels, which the first snapshots
+// predate; the work patterns view indexes them by level
+func migrateV1(doc document) error {
+	patterns, err := doc.object("work_patterns")
+	if err != nil {
+		return err
+	}
+	complexity, err := patterns.object("Complexity")
+	if err != nil {
+		return err
+	}
+	if levels, ok := complexity["Levels"].([]interface{}); !ok || len(levels) != len(analyzer.ComplexityLevels) {
+		complexity["Levels"] = make([]int, len(analyzer.ComplexityLevels))
+	}
+	return nil
+}
+
+// migrateV2 turns the proficiency shares of all commands into scores out
+// of 100, each the share of the uses of all languages and tools listed
+func migrateV2(doc document) error {
+	profile, err := doc.object("tech_profile")
+	if err != nil {
+		return err
+	}
+	proficiency, err := profile.object("Proficiency")
+	if err != nil {
+		return err
+	}
+	shares := make(map[string]float64, len(proficiency))
+	total := 0.0
+	for name, value := range proficiency {
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("proficiency of %s is not a number", name)
+		}
+		share, err := number.Float64()
+		if err != nil {
+			return fmt.Errorf("proficiency of %s is not a number", name)
+		}
+		shares[name] = share
+		total += share
+	}
+	for name, share := range shares {
+		if total > 0 {
+			proficiency[name] = share / total * 100
+		}
+	}
+	return nil
+}
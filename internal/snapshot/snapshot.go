@@ -0,0 +1,97 @@
+// internal/snapshot/snapshot.go
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/analyzer"
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// Bucket is the store bucket snapshots are kept in
+const Bucket = "snapshots"
+
+// keyLayout sorts snapshot keys chronologically
+const keyLayout = "20060102T150405Z"
+
+// Snapshot is the part of an analysis worth keeping to chart trends; the
+// raw history is left out
+type Snapshot struct {
+	// Schema is the SchemaVersion the snapshot was written with
+	Schema        int                   `json:"schema"`
+	Taken         time.Time             `json:"taken"`
+	CommandCounts map[string]int        `json:"command_counts"`
+	CommonCmds    map[string]int        `json:"common_cmds"`
+	TechProfile   analyzer.TechProfile  `json:"tech_profile"`
+	WorkPatterns  analyzer.WorkPatterns `json:"work_patterns"`
+	ToolUsage     analyzer.ToolUsage    `json:"tool_usage"`
+	Achievements  analyzer.Achievements `json:"achievements"`
+}
+
+// New summarizes data as a snapshot taken at taken
+func New(data analyzer.ShellData, taken time.Time) Snapshot {
+	return Snapshot{
+		Schema:        SchemaVersion,
+		Taken:         taken.UTC(),
+		CommandCounts: data.CommandCounts,
+		CommonCmds:    data.CommonCmds,
+		TechProfile:   data.Insights.TechnicalProfile,
+		WorkPatterns:  data.Insights.WorkPatterns,
+		ToolUsage:     data.Insights.ToolUsage,
+		Achievements:  data.Insights.Achievements,
+	}
+}
+
+// Save stores snap and returns its key. Of two runs saving in the same
+// second, the snapshot taken last is kept.
+func Save(s store.Store, snap Snapshot) (string, error) {
+	value, err := json.Marshal(snap)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+	key := snap.Taken.UTC().Format(keyLayout)
+	err = s.Update(Bucket, key, func(old []byte) ([]byte, error) {
+		if saved, err := Decode(old); err == nil && saved.Taken.After(snap.Taken) {
+			return old, nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save snapshot: %v", err)
+	}
+	return key, nil
+}
+
+// Load reads the snapshot saved under key, migrating it from the schema
+// it was written with
+func Load(s store.Store, key string) (Snapshot, error) {
+	value, err := s.Get(Bucket, key)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to read snapshot %s: %v", key, err)
+	}
+	snap, err := Decode(value)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to read snapshot %s: %v", key, err)
+	}
+	return snap, nil
+}
+
+// Latest returns the key of the newest snapshot, or "" when there is none
+func Latest(s store.Store) (string, error) {
+	keys, err := s.List(Bucket)
+	if err != nil {
+		return "", fmt.Errorf("failed to list snapshots: %v", err)
+	}
+	if len(keys) == 0 {
+		return "", nil
+	}
+	return keys[len(keys)-1], nil
+}
+
+// KeyTime returns when the snapshot saved under key was taken
+func KeyTime(key string) (time.Time, bool) {
+	t, err := time.Parse(keyLayout, key)
+	return t, err == nil
+}
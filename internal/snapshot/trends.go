@@ -0,0 +1,97 @@
+// internal/snapshot/trends.go
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ksauraj/k8au-shell-analyzer/internal/store"
+)
+
+// Month is the newest snapshot of a calendar month, compared with the
+// month before it
+type Month struct {
+	Start     time.Time
+	Snapshots int
+	// Commands is the size of the histories at the end of the month, and
+	// Added the change since the previous month. Added is negative when a
+	// history was trimmed and is not set for the first month.
+	Commands int
+	Added    int
+	// TechStack is as detected that month; NewTech and DroppedTech are the
+	// differences from the previous month
+	TechStack   []string
+	NewTech     []string
+	DroppedTech []string
+	// Productivity holds the work pattern metrics, e.g. command_variety
+	Productivity       map[string]float64
+	CommandsPerSession float64
+	// Last is the snapshot the month is summarized from
+	Last Snapshot
+}
+
+// LoadAll reads every stored snapshot, oldest first. Snapshots that cannot
+// be read, e.g. ones from a newer release, are skipped.
+func LoadAll(s store.Store) ([]Snapshot, error) {
+	keys, err := s.List(Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %v", err)
+	}
+	var snaps []Snapshot
+	for _, key := range keys {
+		if snap, err := Load(s, key); err == nil {
+			snaps = append(snaps, snap)
+		}
+	}
+	return snaps, nil
+}
+
+// Trends groups snapshots by the month they were taken in, in local time,
+// oldest month first
+func Trends(snaps []Snapshot) []Month {
+	snaps = append([]Snapshot(nil), snaps...)
+	sort.SliceStable(snaps, func(i, j int) bool { return snaps[i].Taken.Before(snaps[j].Taken) })
+
+	var months []Month
+	for _, snap := range snaps {
+		taken := snap.Taken.Local()
+		start := time.Date(taken.Year(), taken.Month(), 1, 0, 0, 0, 0, time.Local)
+		if len(months) == 0 || !months[len(months)-1].Start.Equal(start) {
+			months = append(months, Month{Start: start})
+		}
+		month := &months[len(months)-1]
+		month.Snapshots++
+		month.Last = snap
+		month.Commands = 0
+		for _, count := range snap.CommandCounts {
+			month.Commands += count
+		}
+		month.TechStack = snap.TechProfile.TechStack
+		month.Productivity = snap.WorkPatterns.Productivity
+		month.CommandsPerSession = snap.WorkPatterns.Sessions.CommandsPerSession
+	}
+
+	for i := 1; i < len(months); i++ {
+		previous, month := months[i-1], &months[i]
+		month.Added = month.Commands - previous.Commands
+		month.NewTech = missing(month.TechStack, previous.TechStack)
+		month.DroppedTech = missing(previous.TechStack, month.TechStack)
+	}
+	return months
+}
+
+// missing returns the items of a that are not in b
+func missing(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, item := range b {
+		in[item] = true
+	}
+	var result []string
+	for _, item := range a {
+		if !in[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
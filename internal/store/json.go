@@ -0,0 +1,148 @@
+// internal/store/json.go
+package store
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// JSONStore keeps one file per key under dir/<bucket>/. It needs no CGO
+// and works anywhere the directory is writable. A lock file in dir keeps
+// concurrent runs from interleaving their reads and writes.
+type JSONStore struct {
+	dir  string
+	lock fileLock
+}
+
+// NewJSONStore creates the store directory if needed
+func NewJSONStore(dir string) (*JSONStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %v", err)
+	}
+	return &JSONStore{dir: dir, lock: newFileLock(dir)}, nil
+}
+
+func (s *JSONStore) path(bucket, key string) string {
+	return filepath.Join(s.dir, url.PathEscape(bucket), url.PathEscape(key)+".json")
+}
+
+func (s *JSONStore) Get(bucket, key string) ([]byte, error) {
+	unlock, err := s.lock.acquire(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	data, err := os.ReadFile(s.path(bucket, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Put writes the value to a temporary file and renames it into place so a
+// crash never leaves a half-written entry behind
+func (s *JSONStore) Put(bucket, key string, value []byte) error {
+	unlock, err := s.lock.acquire(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.write(bucket, key, value)
+}
+
+// Update holds the exclusive lock from reading the old value until the new
+// one is in place
+func (s *JSONStore) Update(bucket, key string, fn func(old []byte) ([]byte, error)) error {
+	unlock, err := s.lock.acquire(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	old, err := os.ReadFile(s.path(bucket, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	value, err := fn(old)
+	if err != nil {
+		return err
+	}
+	return s.write(bucket, key, value)
+}
+
+// write puts value in place; the caller holds the exclusive lock
+func (s *JSONStore) write(bucket, key string, value []byte) error {
+	path := s.path(bucket, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create bucket: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %v", err)
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write value: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to close temp file: %v", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *JSONStore) Delete(bucket, key string) error {
+	unlock, err := s.lock.acquire(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = os.Remove(s.path(bucket, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List returns the keys of a bucket in sorted order
+func (s *JSONStore) List(bucket string) ([]string, error) {
+	unlock, err := s.lock.acquire(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	files, err := os.ReadDir(filepath.Join(s.dir, url.PathEscape(bucket)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+		if key, err := url.PathUnescape(name); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *JSONStore) Close() error {
+	return nil
+}
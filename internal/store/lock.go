@@ -0,0 +1,37 @@
+// internal/store/lock.go
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// lockName is the lock file kept next to each store's data
+const lockName = ".lock"
+
+// fileLock serializes access to a store between processes, e.g. a scheduled
+// snapshot and the TUI. Readers share the lock; writers hold it alone.
+type fileLock struct {
+	path string
+}
+
+func newFileLock(dir string) fileLock {
+	return fileLock{path: filepath.Join(dir, lockName)}
+}
+
+// acquire blocks until the lock is held and returns the function releasing it
+func (l fileLock) acquire(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %v", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock store: %v", err)
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+// internal/store/lock_other.go
+package store
+
+import "os"
+
+// Platforms without file locking (js, wasip1, plan9) run a single process
+
+func lockFile(f *os.File, exclusive bool) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+// internal/store/lock_windows.go
+package store
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// The whole file is locked, whatever its length
+const lockBytes = ^uint32(0)
+
+func lockFile(f *os.File, exclusive bool) error {
+	var flags uint32
+	if exclusive {
+		flags = windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+	return windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, lockBytes, lockBytes, new(windows.Overlapped))
+}
+
+func unlockFile(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, lockBytes, lockBytes, new(windows.Overlapped))
+}
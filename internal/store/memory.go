@@ -0,0 +1,78 @@
+// internal/store/memory.go
+package store
+
+import (
+	"sort"
+	"sync"
+)
+
+// MemoryStore keeps everything in memory for the lifetime of the process.
+// It is the last-resort fallback when nothing on disk is writable.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]map[string][]byte)}
+}
+
+func (s *MemoryStore) Get(bucket, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.buckets[bucket][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (s *MemoryStore) Put(bucket, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.buckets[bucket] == nil {
+		s.buckets[bucket] = make(map[string][]byte)
+	}
+	s.buckets[bucket][key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *MemoryStore) Update(bucket, key string, fn func(old []byte) ([]byte, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var old []byte
+	if value, ok := s.buckets[bucket][key]; ok {
+		old = append([]byte(nil), value...)
+	}
+	value, err := fn(old)
+	if err != nil {
+		return err
+	}
+	if s.buckets[bucket] == nil {
+		s.buckets[bucket] = make(map[string][]byte)
+	}
+	s.buckets[bucket][key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *MemoryStore) Delete(bucket, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.buckets[bucket], key)
+	return nil
+}
+
+func (s *MemoryStore) List(bucket string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.buckets[bucket]))
+	for key := range s.buckets[bucket] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
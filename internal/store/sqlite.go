@@ -0,0 +1,152 @@
+// internal/store/sqlite.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// sqliteDriver is the database/sql driver name registered by the build-tagged
+// driver files. It is empty when the binary was built without SQLite support.
+var sqliteDriver string
+
+// ErrNoSQLite is returned when the binary was built without SQLite support
+var ErrNoSQLite = errors.New("sqlite support not compiled in, rebuild with -tags sqlite")
+
+// OpenSQLiteReadOnly opens an existing database, such as another tool's
+// history, without ever writing to it
+func OpenSQLiteReadOnly(path string) (*sql.DB, error) {
+	if sqliteDriver == "" {
+		return nil, ErrNoSQLite
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open(sqliteDriver, "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	return db, nil
+}
+
+// SQLiteStore keeps all buckets in a single key/value table
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (or creates) the database at path
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	if sqliteDriver == "" {
+		return nil, ErrNoSQLite
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %v", err)
+	}
+
+	db, err := sql.Open(sqliteDriver, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite store: %v", err)
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS kv (
+		bucket TEXT NOT NULL,
+		key    TEXT NOT NULL,
+		value  BLOB NOT NULL,
+		PRIMARY KEY (bucket, key)
+	)`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize sqlite store: %v", err)
+	}
+	// SQLite locks the database itself; wait for another run's write to
+	// finish instead of failing with SQLITE_BUSY. The pragma is per
+	// connection, so keep a single one.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`PRAGMA busy_timeout = 10000`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize sqlite store: %v", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Get(bucket, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRow(`SELECT value FROM kv WHERE bucket = ? AND key = ?`, bucket, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (s *SQLiteStore) Put(bucket, key string, value []byte) error {
+	_, err := s.db.Exec(`INSERT INTO kv (bucket, key, value) VALUES (?, ?, ?)
+		ON CONFLICT (bucket, key) DO UPDATE SET value = excluded.value`, bucket, key, value)
+	return err
+}
+
+// Update reads and writes in one IMMEDIATE transaction, which takes the
+// database's write lock before the read
+func (s *SQLiteStore) Update(bucket, key string, fn func(old []byte) ([]byte, error)) (err error) {
+	ctx := context.Background()
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `BEGIN IMMEDIATE`); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			conn.ExecContext(ctx, `ROLLBACK`)
+		}
+	}()
+
+	var old []byte
+	err = conn.QueryRowContext(ctx, `SELECT value FROM kv WHERE bucket = ? AND key = ?`, bucket, key).Scan(&old)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	value, err := fn(old)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, `INSERT INTO kv (bucket, key, value) VALUES (?, ?, ?)
+		ON CONFLICT (bucket, key) DO UPDATE SET value = excluded.value`, bucket, key, value)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, `COMMIT`)
+	return err
+}
+
+func (s *SQLiteStore) Delete(bucket, key string) error {
+	_, err := s.db.Exec(`DELETE FROM kv WHERE bucket = ? AND key = ?`, bucket, key)
+	return err
+}
+
+func (s *SQLiteStore) List(bucket string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT key FROM kv WHERE bucket = ? ORDER BY key`, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
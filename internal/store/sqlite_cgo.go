@@ -0,0 +1,10 @@
+//go:build sqlite && cgo
+
+// internal/store/sqlite_cgo.go
+package store
+
+import _ "github.com/mattn/go-sqlite3"
+
+func init() {
+	sqliteDriver = "sqlite3"
+}
@@ -0,0 +1,12 @@
+//go:build sqlite && !cgo
+
+// internal/store/sqlite_purego.go
+package store
+
+// modernc.org/sqlite is a transpiled, pure-Go SQLite used when cross-compiling
+// with CGO_ENABLED=0 so the sqlite tag never requires a C toolchain
+import _ "modernc.org/sqlite"
+
+func init() {
+	sqliteDriver = "sqlite"
+}
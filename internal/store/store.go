@@ -0,0 +1,129 @@
+// internal/store/store.go
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNotFound is returned by Get when a key does not exist
+var ErrNotFound = errors.New("store: key not found")
+
+// Store persists snapshots and cached results between runs. Values are
+// opaque bytes grouped into buckets (e.g. "snapshots", "cache").
+type Store interface {
+	Get(bucket, key string) ([]byte, error)
+	Put(bucket, key string, value []byte) error
+	// Update replaces a value with what fn returns for the current one
+	// (nil when the key does not exist), keeping other runs from writing
+	// the key in between. An error from fn leaves the value as it was.
+	Update(bucket, key string, fn func(old []byte) ([]byte, error)) error
+	Delete(bucket, key string) error
+	List(bucket string) ([]string, error)
+	Close() error
+}
+
+// Backend names accepted by Open
+const (
+	BackendJSON   = "json"
+	BackendSQLite = "sqlite"
+	BackendMemory = "memory"
+)
+
+// Open returns a store of the given backend rooted at dir
+func Open(backend, dir string) (Store, error) {
+	switch backend {
+	case BackendJSON, "":
+		return NewJSONStore(dir)
+	case BackendSQLite:
+		return NewSQLiteStore(filepath.Join(dir, "store.db"))
+	case BackendMemory:
+		return sharedMemoryStore(dir), nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", backend)
+	}
+}
+
+var (
+	memoryMu     sync.Mutex
+	memoryStores = make(map[string]*MemoryStore)
+)
+
+// sharedMemoryStore returns the process's in-memory store for dir, so what
+// one caller saves is there for the next one that opens it
+func sharedMemoryStore(dir string) *MemoryStore {
+	memoryMu.Lock()
+	defer memoryMu.Unlock()
+	s, ok := memoryStores[dir]
+	if !ok {
+		s = NewMemoryStore()
+		memoryStores[dir] = s
+	}
+	return s
+}
+
+// OpenDefault opens the preferred backend in the default data directory,
+// falling back to flat JSON files and finally to an in-memory store so that
+// constrained environments (no CGO, read-only home) still work
+func OpenDefault(backend string) Store {
+	return openOrFallback(backend, DefaultDir())
+}
+
+// OpenCache is OpenDefault for results that can be computed again, kept in
+// CacheDir
+func OpenCache(backend string) Store {
+	return openOrFallback(backend, CacheDir())
+}
+
+// openOrFallback opens backend in dir, or the first fallback that works
+func openOrFallback(backend, dir string) Store {
+	if s, err := Open(backend, dir); err == nil {
+		return s
+	}
+	if s, err := NewJSONStore(dir); err == nil {
+		return s
+	}
+	return sharedMemoryStore(dir)
+}
+
+// DefaultDir returns $XDG_DATA_HOME/k8au-shell-analyzer, defaulting to
+// ~/.local/share/k8au-shell-analyzer
+func DefaultDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "k8au-shell-analyzer")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "k8au-shell-analyzer")
+	}
+	return filepath.Join(home, ".local", "share", "k8au-shell-analyzer")
+}
+
+// StateDir returns $XDG_STATE_HOME/k8au-shell-analyzer, defaulting to
+// ~/.local/state/k8au-shell-analyzer, for the log
+func StateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "k8au-shell-analyzer")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "k8au-shell-analyzer-state")
+	}
+	return filepath.Join(home, ".local", "state", "k8au-shell-analyzer")
+}
+
+// CacheDir returns $XDG_CACHE_HOME/k8au-shell-analyzer, defaulting to
+// ~/.cache/k8au-shell-analyzer. Everything in it can be safely deleted.
+func CacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "k8au-shell-analyzer")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "k8au-shell-analyzer-cache")
+	}
+	return filepath.Join(home, ".cache", "k8au-shell-analyzer")
+}
@@ -0,0 +1,76 @@
+// internal/store/store_test.go
+package store
+
+import (
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// testUpdate has runs, each with its own store on dir, increment a counter
+// at the same time and checks that no increment is lost
+func testUpdate(t *testing.T, open func() (Store, error)) {
+	const runs, increments = 4, 25
+
+	var wg sync.WaitGroup
+	errs := make(chan error, runs)
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := open()
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer s.Close()
+			for j := 0; j < increments; j++ {
+				err := s.Update("counters", "n", func(old []byte) ([]byte, error) {
+					n, _ := strconv.Atoi(string(old))
+					return []byte(strconv.Itoa(n + 1)), nil
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	s, err := open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	value, err := s.Get("counters", "n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(value); got != strconv.Itoa(runs*increments) {
+		t.Errorf("counter is %s after %d increments", got, runs*increments)
+	}
+}
+
+func TestJSONUpdate(t *testing.T) {
+	dir := t.TempDir()
+	testUpdate(t, func() (Store, error) { return NewJSONStore(dir) })
+}
+
+func TestMemoryUpdate(t *testing.T) {
+	s := NewMemoryStore()
+	testUpdate(t, func() (Store, error) { return s, nil })
+}
+
+func TestSQLiteUpdate(t *testing.T) {
+	if sqliteDriver == "" {
+		t.Skip(ErrNoSQLite)
+	}
+	path := filepath.Join(t.TempDir(), "store.db")
+	testUpdate(t, func() (Store, error) { return NewSQLiteStore(path) })
+}
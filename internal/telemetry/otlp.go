@@ -0,0 +1,256 @@
+// internal/telemetry/otlp.go
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// The OTLP/HTTP JSON encoding: ids are hex, 64-bit integers are strings
+
+type attribute struct {
+	Key   string         `json:"key"`
+	Value attributeValue `json:"value"`
+}
+
+type attributeValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func stringAttribute(key, value string) attribute {
+	return attribute{Key: key, Value: attributeValue{StringValue: &value}}
+}
+
+// newAttribute converts value, falling back to its printed form for
+// types OTLP has no scalar for
+func newAttribute(key string, value interface{}) attribute {
+	switch v := value.(type) {
+	case string:
+		return stringAttribute(key, v)
+	case bool:
+		return attribute{Key: key, Value: attributeValue{BoolValue: &v}}
+	case int:
+		s := strconv.Itoa(v)
+		return attribute{Key: key, Value: attributeValue{IntValue: &s}}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return attribute{Key: key, Value: attributeValue{IntValue: &s}}
+	case float64:
+		return attribute{Key: key, Value: attributeValue{DoubleValue: &v}}
+	}
+	return stringAttribute(key, fmt.Sprint(value))
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type spanJSON struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []attribute `json:"attributes,omitempty"`
+	Status            status      `json:"status"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// Span kind and status codes from the OTLP protos
+const (
+	spanKindInternal = 1
+	statusOK         = 1
+	statusError      = 2
+	// cumulative aggregation temporality
+	cumulative = 2
+)
+
+func nanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func tracesPayload() interface{} {
+	encoded := make([]spanJSON, len(spans))
+	for i, s := range spans {
+		encoded[i] = spanJSON{
+			TraceID:           traceID,
+			SpanID:            s.id,
+			ParentSpanID:      s.parent,
+			Name:              s.name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: nanos(s.start),
+			EndTimeUnixNano:   nanos(s.end),
+			Attributes:        s.attributes,
+			Status:            status{Code: statusOK},
+		}
+		if s.err != nil {
+			encoded[i].Status = status{Code: statusError, Message: s.err.Error()}
+		}
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{"attributes": resource},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": scope{Name: serviceName},
+				"spans": encoded,
+			}},
+		}},
+	}
+}
+
+// sum is a monotonic counter
+type sum struct {
+	name       string
+	attributes map[string]string
+	value      int64
+}
+
+// stageBounds are the histogram buckets of k8au.stage.duration in seconds
+var stageBounds = []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30}
+
+type histogram struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+func newHistogram() *histogram {
+	return &histogram{buckets: make([]uint64, len(stageBounds)+1)}
+}
+
+func (h *histogram) record(value float64) {
+	h.count++
+	h.sum += value
+	i := sort.SearchFloat64s(stageBounds, value)
+	h.buckets[i]++
+}
+
+func metricsPayload(now time.Time) interface{} {
+	var encoded []interface{}
+
+	// Counters with the same name become data points of one metric
+	byName := make(map[string][]interface{})
+	var names []string
+	var keys []string
+	for key := range sums {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := sums[key]
+		var attributes []attribute
+		for _, k := range sortedKeys(s.attributes) {
+			attributes = append(attributes, stringAttribute(k, s.attributes[k]))
+		}
+		if byName[s.name] == nil {
+			names = append(names, s.name)
+		}
+		byName[s.name] = append(byName[s.name], map[string]interface{}{
+			"attributes":        attributes,
+			"startTimeUnixNano": nanos(started),
+			"timeUnixNano":      nanos(now),
+			"asInt":             strconv.FormatInt(s.value, 10),
+		})
+	}
+	for _, name := range names {
+		encoded = append(encoded, map[string]interface{}{
+			"name": name,
+			"sum": map[string]interface{}{
+				"dataPoints":             byName[name],
+				"aggregationTemporality": cumulative,
+				"isMonotonic":            true,
+			},
+		})
+	}
+
+	if len(stages) > 0 {
+		var points []interface{}
+		for _, stage := range sortedKeys(stages) {
+			h := stages[stage]
+			buckets := make([]string, len(h.buckets))
+			for i, count := range h.buckets {
+				buckets[i] = strconv.FormatUint(count, 10)
+			}
+			points = append(points, map[string]interface{}{
+				"attributes":        []attribute{stringAttribute("stage", stage)},
+				"startTimeUnixNano": nanos(started),
+				"timeUnixNano":      nanos(now),
+				"count":             strconv.FormatUint(h.count, 10),
+				"sum":               h.sum,
+				"bucketCounts":      buckets,
+				"explicitBounds":    stageBounds,
+			})
+		}
+		encoded = append(encoded, map[string]interface{}{
+			"name":        "k8au.stage.duration",
+			"description": "Duration of the analyzer's pipeline stages",
+			"unit":        "s",
+			"histogram": map[string]interface{}{
+				"dataPoints":             points,
+				"aggregationTemporality": cumulative,
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"resourceMetrics": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{"attributes": resource},
+			"scopeMetrics": []interface{}{map[string]interface{}{
+				"scope":   scope{Name: serviceName},
+				"metrics": encoded,
+			}},
+		}},
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// post sends one OTLP/HTTP JSON request
+func post(exp *exporter, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, exp.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range exp.headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s responded %s", exp.endpoint, resp.Status)
+	}
+	return nil
+}
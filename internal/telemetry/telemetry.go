@@ -0,0 +1,263 @@
+// internal/telemetry/telemetry.go
+package telemetry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// serviceName is reported when OTEL_SERVICE_NAME is not set
+const serviceName = "k8au-shell-analyzer"
+
+// exporter holds where and how one signal is sent
+type exporter struct {
+	endpoint string
+	headers  map[string]string
+}
+
+var (
+	mu       sync.Mutex
+	traces   *exporter
+	metrics  *exporter
+	timeout  = 10 * time.Second
+	resource []attribute
+	traceID  string
+	started  = time.Now()
+	spans    []*Span
+	sums     = make(map[string]*sum)
+	stages   = make(map[string]*histogram)
+)
+
+// Setup reads the standard OTEL_ environment variables. Telemetry stays off
+// unless an OTLP endpoint is configured, and only the http/json protocol
+// is spoken. A misconfigured signal is left off and reported in the error.
+func Setup() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	traces, metrics = nil, nil
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return nil
+	}
+	if ms, err := strconv.Atoi(os.Getenv("OTEL_EXPORTER_OTLP_TIMEOUT")); err == nil && ms > 0 {
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	var errs []string
+	var err error
+	if traces, err = signalExporter("TRACES", "/v1/traces"); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if metrics, err = signalExporter("METRICS", "/v1/metrics"); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if traces == nil && metrics == nil {
+		return joinErrors(errs)
+	}
+
+	name := os.Getenv("OTEL_SERVICE_NAME")
+	resource = nil
+	for _, pair := range strings.Split(os.Getenv("OTEL_RESOURCE_ATTRIBUTES"), ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value, _ = url.QueryUnescape(strings.TrimSpace(value))
+		if key == "service.name" {
+			if name == "" {
+				name = value
+			}
+			continue
+		}
+		resource = append(resource, stringAttribute(key, value))
+	}
+	if name == "" {
+		name = serviceName
+	}
+	resource = append(resource,
+		stringAttribute("service.name", name),
+		stringAttribute("telemetry.sdk.language", "go"))
+	traceID = randomID(16)
+	return joinErrors(errs)
+}
+
+// signalExporter reads the settings of one signal, e.g. "TRACES". The
+// signal specific variables win over the shared OTEL_EXPORTER_OTLP_ ones.
+func signalExporter(signal, path string) (*exporter, error) {
+	switch kind := os.Getenv("OTEL_" + signal + "_EXPORTER"); kind {
+	case "", "otlp":
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_%s_EXPORTER %q, expected otlp or none", signal, kind)
+	}
+
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_ENDPOINT")
+	if endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			return nil, nil
+		}
+		endpoint = strings.TrimRight(base, "/") + path
+	}
+	if protocol != "" && protocol != "http/json" {
+		return nil, fmt.Errorf("unsupported OTLP protocol %q for %s, only http/json is supported", protocol, strings.ToLower(signal))
+	}
+
+	headers := parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+	for key, value := range parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_HEADERS")) {
+		headers[key] = value
+	}
+	return &exporter{endpoint: endpoint, headers: headers}, nil
+}
+
+// parseHeaders reads a list of key=value pairs with URL encoded values
+func parseHeaders(list string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(list, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		value, _ = url.QueryUnescape(strings.TrimSpace(value))
+		headers[strings.TrimSpace(key)] = value
+	}
+	return headers
+}
+
+func joinErrors(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("telemetry: %s", strings.Join(errs, "; "))
+}
+
+// Enabled reports whether anything is exported
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return traces != nil || metrics != nil
+}
+
+// Span times one pipeline stage. Every span of a run shares one trace.
+// A nil Span, as returned while telemetry is off, ignores every call.
+type Span struct {
+	name       string
+	id         string
+	parent     string
+	start, end time.Time
+	attributes []attribute
+	err        error
+}
+
+// Start begins a top-level span, or returns nil when telemetry is off
+func Start(name string) *Span {
+	return startSpan(name, "")
+}
+
+// Child begins a span nested under s
+func (s *Span) Child(name string) *Span {
+	if s == nil {
+		return nil
+	}
+	return startSpan(name, s.id)
+}
+
+func startSpan(name, parent string) *Span {
+	if !Enabled() {
+		return nil
+	}
+	return &Span{name: name, id: randomID(8), parent: parent, start: time.Now()}
+}
+
+// SetAttribute records a string, bool, int or float64 value on the span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.attributes = append(s.attributes, newAttribute(key, value))
+}
+
+// End finishes the span, marking it failed when err is not nil, and records
+// its duration in the k8au.stage.duration histogram
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	s.err = err
+
+	mu.Lock()
+	defer mu.Unlock()
+	if traces != nil {
+		spans = append(spans, s)
+	}
+	if metrics != nil {
+		h := stages[s.name]
+		if h == nil {
+			h = newHistogram()
+			stages[s.name] = h
+		}
+		h.record(s.end.Sub(s.start).Seconds())
+	}
+}
+
+// Count adds value to the counter name for the given attributes, e.g.
+// the commands parsed per shell
+func Count(name string, value int64, attributes map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if metrics == nil {
+		return
+	}
+	key := name
+	for _, k := range sortedKeys(attributes) {
+		key += "\x00" + k + "=" + attributes[k]
+	}
+	if sums[key] == nil {
+		sums[key] = &sum{name: name, attributes: attributes}
+	}
+	sums[key].value += value
+}
+
+// Shutdown sends everything recorded so far. It is safe to call when
+// telemetry is off.
+func Shutdown() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var errs []string
+	if traces != nil && len(spans) > 0 {
+		if err := post(traces, tracesPayload()); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to export traces: %v", err))
+		}
+		spans = nil
+	}
+	if metrics != nil && (len(sums) > 0 || len(stages) > 0) {
+		if err := post(metrics, metricsPayload(time.Now())); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to export metrics: %v", err))
+		}
+	}
+	return joinErrors(errs)
+}
+
+func randomID(bytes int) string {
+	id := make([]byte, bytes)
+	if _, err := rand.Read(id); err != nil {
+		// Unique enough to tell runs apart
+		return fmt.Sprintf("%0*x", bytes*2, time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
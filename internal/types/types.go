@@ -8,4 +8,8 @@ type TimelineEntry struct {
 	Timestamp time.Time
 	Command   string
 	Shell     string
+	// Categories are those of the command, and Kind why it is in the
+	// timeline (see analyzer.TimelineKinds)
+	Categories []string
+	Kind       string
 }
@@ -7,14 +7,44 @@ import (
 	"strings"
 )
 
+// home overrides the home directory being analyzed, see SetHome
+var home string
+
+// SetHome analyzes the home directory at dir instead of the user's own, e.g.
+// one mounted into a container. Paths written by the analyzer itself, such
+// as the config and the snapshots, stay under the real home.
+func SetHome(dir string) {
+	home = filepath.Clean(dir)
+}
+
+// HomeDir returns the home directory being analyzed
+func HomeDir() (string, error) {
+	if home != "" {
+		return home, nil
+	}
+	return os.UserHomeDir()
+}
+
 // ExpandPath expands the tilde (~) in a path to the user's home directory
 func ExpandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
+		dir, err := HomeDir()
 		if err != nil {
 			return path
 		}
-		return filepath.Join(home, path[2:])
+		return filepath.Join(dir, path[2:])
+	}
+	return path
+}
+
+// DisplayPath shortens paths under the home directory to ~/...
+func DisplayPath(path string) string {
+	dir, err := HomeDir()
+	if err != nil || dir == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
 	}
 	return path
 }